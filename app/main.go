@@ -38,6 +38,10 @@ func main() {
 
 	hostName := flag.String("host", "sqs", "the name of the host")
 
+	replayPath := flag.String("replay", emptyValuePlaceholder, "recorded ingest log to replay instead of ingesting from the node")
+
+	replaySpeed := flag.Float64("replay-speed", 1, "multiplier of the recorded pace to replay at, 0 replays without delay")
+
 	// Parse the command-line arguments
 	flag.Parse()
 
@@ -61,6 +65,16 @@ func main() {
 		log.Fatalf("error unmarshalling config: %v", err)
 	}
 
+	// Replay flags override the config.
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "replay":
+			config.GRPCIngester.ReplayPath = *replayPath
+		case "replay-speed":
+			config.GRPCIngester.ReplaySpeed = *replaySpeed
+		}
+	})
+
 	// Validate config
 	if err := config.Validate(); err != nil {
 		fmt.Println("Error validating config:", err)
@@ -108,9 +122,12 @@ func main() {
 		panic(err)
 	}
 
-	// If fails, it means that the node is not reachable
-	if _, err := chainClient.GetLatestHeight(ctx); err != nil {
-		panic(err)
+	// If fails, it means that the node is not reachable.
+	// The node is not required when replaying a recorded ingest log.
+	if config.GRPCIngester.ReplayPath == emptyValuePlaceholder {
+		if _, err := chainClient.GetLatestHeight(ctx); err != nil {
+			panic(err)
+		}
 	}
 
 	encCfg := app.MakeEncodingConfig()
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 
@@ -20,38 +21,41 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	querygrpcdelivery "github.com/osmosis-labs/sqs/delivery/grpc"
 	ingestrpcdelivry "github.com/osmosis-labs/sqs/ingest/delivery/grpc"
-	ingestusecase "github.com/osmosis-labs/sqs/ingest/usecase"
+	"github.com/osmosis-labs/sqs/ingest/recorder"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/arbdetector"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/eventpublisher"
 	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/orderbookfiller"
-	orderbookrepository "github.com/osmosis-labs/sqs/orderbook/repository"
-	orderbookusecase "github.com/osmosis-labs/sqs/orderbook/usecase"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/remotehost"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/webhook"
 	"github.com/osmosis-labs/sqs/sqsutil/datafetchers"
 
-	chaininforepo "github.com/osmosis-labs/sqs/chaininfo/repository"
+	arbdetectorhttpdelivery "github.com/osmosis-labs/sqs/arbdetector/delivery/http"
+	chaininfoclient "github.com/osmosis-labs/sqs/chaininfo/client"
 	chaininfousecase "github.com/osmosis-labs/sqs/chaininfo/usecase"
+	"github.com/osmosis-labs/sqs/embedded"
 	passthroughHttpDelivery "github.com/osmosis-labs/sqs/passthrough/delivery/http"
 	passthroughUseCase "github.com/osmosis-labs/sqs/passthrough/usecase"
 	poolsHttpDelivery "github.com/osmosis-labs/sqs/pools/delivery/http"
-	poolsUseCase "github.com/osmosis-labs/sqs/pools/usecase"
-	routerrepo "github.com/osmosis-labs/sqs/router/repository"
-	routerWorker "github.com/osmosis-labs/sqs/router/usecase/worker"
 	tokenshttpdelivery "github.com/osmosis-labs/sqs/tokens/delivery/http"
 	tokensusecase "github.com/osmosis-labs/sqs/tokens/usecase"
 	"github.com/osmosis-labs/sqs/tokens/usecase/pricing"
-	pricingWorker "github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
 
 	"github.com/osmosis-labs/sqs/domain"
-	"github.com/osmosis-labs/sqs/domain/cache"
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
 	"github.com/osmosis-labs/sqs/domain/keyring"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	orderbookgrpcclientdomain "github.com/osmosis-labs/sqs/domain/orderbook/grpcclient"
 	orderbookplugindomain "github.com/osmosis-labs/sqs/domain/orderbook/plugin"
 	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/middleware"
 
 	routerHttpDelivery "github.com/osmosis-labs/sqs/router/delivery/http"
-	routerUseCase "github.com/osmosis-labs/sqs/router/usecase"
 
 	systemhttpdelivery "github.com/osmosis-labs/sqs/system/delivery/http"
 )
@@ -71,6 +75,13 @@ type sideCarQueryServer struct {
 	e             *echo.Echo
 	sqsAddress    string
 	logger        log.Logger
+
+	// grpcIngestServer is the GRPC ingest server if configured, nil otherwise.
+	grpcIngestServer *grpc.Server
+	// blockRecorder records the ingested blocks if configured, nil otherwise.
+	blockRecorder *recorder.Recorder
+	// eventSink is the sink of the block event publisher plugin if enabled, nil otherwise.
+	eventSink eventpublisherdomain.Sink
 }
 
 // GetTokensUseCase implements SideCarQueryServer.
@@ -84,7 +95,25 @@ func (sqs *sideCarQueryServer) GetLogger() log.Logger {
 }
 
 // Shutdown implements SideCarQueryServer.
+// The ingest server is stopped prior to closing the block recorder and the event sink
+// so that no block is recorded and no event is published after they are closed.
 func (sqs *sideCarQueryServer) Shutdown(ctx context.Context) error {
+	if sqs.grpcIngestServer != nil {
+		sqs.grpcIngestServer.GracefulStop()
+	}
+
+	if sqs.blockRecorder != nil {
+		if err := sqs.blockRecorder.Close(); err != nil {
+			sqs.logger.Error("failed to close block recorder", zap.Error(err))
+		}
+	}
+
+	if sqs.eventSink != nil {
+		if err := sqs.eventSink.Close(); err != nil {
+			sqs.logger.Error("failed to close event sink", zap.Error(err))
+		}
+	}
+
 	return sqs.e.Shutdown(ctx)
 }
 
@@ -108,81 +137,85 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 	e.Use(middleware.InstrumentMiddleware)
 	e.Use(otelecho.Middleware("sqs"), middleware.TraceWithParamsMiddleware())
 
-	routerRepository := routerrepo.New(logger)
-
 	// Compute token metadata from chain denom.
 	tokenMetadataByChainDenom, _, err := tokensusecase.GetTokensFromChainRegistry(config.ChainRegistryAssetsFileURL)
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialized tokens usecase
-	// TODO: Make the max number of tokens configurable
-	tokensUseCase := tokensusecase.NewTokensUsecase(
-		tokenMetadataByChainDenom,
-		config.UpdateAssetsHeightInterval,
-		logger,
-	)
+	// The node is not required when replaying a recorded ingest log.
+	isReplaying := config.GRPCIngester.Enabled && config.GRPCIngester.ReplayPath != ""
 
-	// Initialize chain registry HTTP fetcher
-	chainRegistryHTTPFetcher := tokensusecase.NewChainRegistryHTTPFetcher(
-		config.ChainRegistryAssetsFileURL,
-		tokensusecase.GetTokensFromChainRegistry,
-		tokensUseCase.LoadTokens,
-	)
-
-	tokensUseCase.SetTokenRegistryLoader(chainRegistryHTTPFetcher)
-
-	// Check the status of the grpc gateway
-	if err := checkGRPCGatewayStatus(config.ChainGRPCGatewayEndpoint); err != nil {
-		return nil, err
+	// Check the status of the grpc gateway.
+	// It only serves the passthrough and orderbook queries, so SQS starts without it,
+	// e.g. against the chain simulator.
+	if !isReplaying {
+		if err := checkGRPCGatewayStatus(config.ChainGRPCGatewayEndpoint); err != nil {
+			logger.Warn("grpc gateway is unreachable, passthrough and orderbook queries fail until it is", zap.String("endpoint", config.ChainGRPCGatewayEndpoint), zap.Error(err))
+		}
 	}
 
-	// Initialize pools repository, usecase and HTTP handler
-	poolsUseCase, err := poolsUseCase.NewPoolsUsecase(config.Pools, config.ChainGRPCGatewayEndpoint, routerRepository, tokensUseCase.GetChainScalingFactorByDenomMut, logger)
+	// Initialize passthrough grpc client
+	passthroughGRPCClient, err := passthroughdomain.NewPassthroughGRPCClient(config.ChainGRPCGatewayEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize candidate route searcher
-	candidateRouteSearcher := routerUseCase.NewCandidateRouteFinder(routerRepository, logger)
+	wasmQueryClient := wasmtypes.NewQueryClient(passthroughGRPCClient.GetChainGRPCClient())
+	orderBookAPIClient := orderbookgrpcclientdomain.New(wasmQueryClient)
 
-	// Initialize router repository, usecase
-	routerUsecase := routerUseCase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, poolsUseCase.GetCosmWasmPoolConfig(), logger, cache.New(), cache.New())
+	// Create a Numia HTTP client
+	passthroughConfig := config.Passthrough
+	numiaHTTPClient := passthroughdomain.NewNumiaHTTPClient(passthroughConfig.NumiaURL)
 
-	// Initialize system handler
-	chainInfoRepository := chaininforepo.New()
-	chainInfoUseCase := chaininfousecase.NewChainInfoUsecase(chainInfoRepository)
+	// Iniitialize data fetcher for pool APRs
+	fetchPoolAPRsCallback := datafetchers.GetFetchPoolAPRsFromNumiaCb(numiaHTTPClient, logger)
+	var aprFetcher datafetchers.MapFetcher[uint64, passthroughdomain.PoolAPR] = datafetchers.NewMapFetcher(fetchPoolAPRsCallback, time.Minute*time.Duration(passthroughConfig.APRFetchIntervalMinutes))
 
-	cosmWasmPoolConfig := poolsUseCase.GetCosmWasmPoolConfig()
+	// Initialize data fetcher for pool fees
+	timeseriesHTTPClient := passthroughdomain.NewTimeSeriesHTTPClient(passthroughConfig.TimeseriesURL)
+	fetchPoolFeesCallback := datafetchers.GetFetchPoolPoolFeesFromTimeseries(timeseriesHTTPClient, logger)
+	poolFeesFetcher := datafetchers.NewMapFetcher(fetchPoolFeesCallback, time.Minute*time.Duration(passthroughConfig.PoolFeesFetchIntervalMinutes))
 
-	// Initialize chain pricing strategy
-	pricingSimpleRouterUsecase := routerUseCase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, cosmWasmPoolConfig, logger, cache.New(), cache.New())
-	chainPricingSource, err := pricing.NewPricingStrategy(*config.Pricing, tokensUseCase, pricingSimpleRouterUsecase)
+	// Initialize the routing core from the chain registry token metadata.
+	// The pools and taker fees are ingested from the node.
+	sqsRouter, err := embedded.New(config, appCodec, embedded.State{Tokens: tokenMetadataByChainDenom}, logger,
+		embedded.WithOrderBookClient(orderBookAPIClient),
+		embedded.WithPoolDataFetchers(aprFetcher, poolFeesFetcher),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the default quote denom
-	defaultQuoteDenom, err := tokensUseCase.GetChainDenom(config.Pricing.DefaultQuoteHumanDenom)
-	if err != nil {
-		return nil, err
-	}
+	routerRepository := sqsRouter.GetRouterRepository()
+	routerUsecase := sqsRouter.GetRouterUsecase()
+	pricingSimpleRouterUsecase := sqsRouter.GetPricingRouterUsecase()
+	poolsUseCase := sqsRouter.GetPoolsUsecase()
+	tokensUseCase := sqsRouter.GetTokensUsecase()
+	chainInfoUseCase := sqsRouter.GetChainInfoUsecase()
+	orderBookUseCase := sqsRouter.GetOrderBookUsecase()
+	defaultQuoteDenom := sqsRouter.GetDefaultQuoteDenom()
 
-	// Get liquidity pricer
-	liquidityPricer := pricingWorker.NewLiquidityPricer(defaultQuoteDenom, tokensUseCase.GetChainScalingFactorByDenomMut)
+	// Initialize chain registry HTTP fetcher
+	chainRegistryHTTPFetcher := tokensusecase.NewChainRegistryHTTPFetcher(
+		config.ChainRegistryAssetsFileURL,
+		tokensusecase.GetTokensFromChainRegistry,
+		tokensUseCase.LoadTokens,
+	)
 
-	// Initialize passthrough grpc client
-	passthroughGRPCClient, err := passthroughdomain.NewPassthroughGRPCClient(config.ChainGRPCGatewayEndpoint)
-	if err != nil {
-		return nil, err
+	tokensUseCase.SetTokenRegistryLoader(chainRegistryHTTPFetcher)
+
+	// Pin the state snapshot of the latest ingested block to each request
+	// and report the height metadata of the response.
+	stateSnapshotHolder := sqsRouter.GetStateSnapshotHolder()
+	e.Use(middleware.StateSnapshotMiddleware(stateSnapshotHolder), middleware.HeightMetadataMiddleware(chainInfoUseCase))
+
+	if poolCircuitBreaker := sqsRouter.GetPoolCircuitBreaker(); poolCircuitBreaker != nil {
+		routerHttpDelivery.NewPoolCircuitBreakerHandler(e, poolCircuitBreaker)
 	}
 
 	// Initialize passthrough query use case
-	passthroughUseCase := passthroughUseCase.NewPassThroughUsecase(passthroughGRPCClient, poolsUseCase, tokensUseCase, liquidityPricer, defaultQuoteDenom, logger)
-	if err != nil {
-		return nil, err
-	}
+	passthroughUseCase := passthroughUseCase.NewPassThroughUsecase(passthroughGRPCClient, poolsUseCase, tokensUseCase, sqsRouter.GetLiquidityPricer(), defaultQuoteDenom, logger)
 
 	// Use the same config to initialize coingecko pricing strategy
 	coingeckPricingConfig := *config.Pricing
@@ -193,77 +226,64 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 	}
 
 	// Register pricing strategy on the tokens use case.
-	tokensUseCase.RegisterPricingStrategy(domain.ChainPricingSourceType, chainPricingSource)
 	tokensUseCase.RegisterPricingStrategy(domain.CoinGeckoPricingSourceType, coingeckoPricingSource)
 
-	wasmQueryClient := wasmtypes.NewQueryClient(passthroughGRPCClient.GetChainGRPCClient())
-	orderBookAPIClient := orderbookgrpcclientdomain.New(wasmQueryClient)
-	orderBookRepository := orderbookrepository.New()
-	orderBookUseCase := orderbookusecase.New(orderBookRepository, orderBookAPIClient, poolsUseCase, tokensUseCase, logger)
+	// Detect chain halts, ingest stalls and node lag, applying the degraded mode action to quotes.
+	// The monitor is the only poller of the node status, also feeding the readiness probe.
+	chainClient, err := chaininfoclient.NewClient(config.ChainID, config.ChainTendermintRPCEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	chainStatusMonitor := chaininfousecase.NewChainStatusMonitor(*config.DegradedMode, chainClient, chainInfoUseCase, logger)
+	// When replaying, the node is not polled and the chain status stays healthy.
+	if !isReplaying {
+		go chainStatusMonitor.Start(context.Background())
+	}
 
 	// HTTP handlers
 	poolsHttpDelivery.NewPoolsHandler(e, poolsUseCase)
 	passthroughHttpDelivery.NewPassthroughHandler(e, passthroughUseCase, orderBookUseCase)
-	systemhttpdelivery.NewSystemHandler(e, config, logger, chainInfoUseCase)
+	systemhttpdelivery.NewSystemHandler(e, config, logger, chainInfoUseCase, routerUsecase, chainStatusMonitor)
 	if err := tokenshttpdelivery.NewTokensHandler(e, *config.Pricing, tokensUseCase, pricingSimpleRouterUsecase, logger); err != nil {
 		return nil, err
 	}
-	routerHttpDelivery.NewRouterHandler(e, routerUsecase, tokensUseCase, logger)
-
-	// Create a Numia HTTP client
-	passthroughConfig := config.Passthrough
-	numiaHTTPClient := passthroughdomain.NewNumiaHTTPClient(passthroughConfig.NumiaURL)
+	routerHttpDelivery.NewRouterHandler(e, routerUsecase, tokensUseCase, logger, middleware.DegradedModeMiddleware(chainStatusMonitor, *config.DegradedMode))
 
-	// Iniitialize data fetcher for pool APRs
-	fetchPoolAPRsCallback := datafetchers.GetFetchPoolAPRsFromNumiaCb(numiaHTTPClient, logger)
-	var aprFetcher datafetchers.MapFetcher[uint64, passthroughdomain.PoolAPR] = datafetchers.NewMapFetcher(fetchPoolAPRsCallback, time.Minute*time.Duration(passthroughConfig.APRFetchIntervalMinutes))
-	// Register the APR fetcher with the passthrough use case
-	poolsUseCase.RegisterAPRFetcher(aprFetcher)
+	// Start grpc query server if enabled
+	if grpcQueryConfig := config.GRPCQuery; grpcQueryConfig.Enabled {
+		grpcQueryHandler, err := querygrpcdelivery.NewQueryGRPCHandler(routerUsecase, poolsUseCase, tokensUseCase, passthroughUseCase, chainInfoUseCase, chainStatusMonitor, stateSnapshotHolder, *config.Pricing, *config.DegradedMode, *grpcQueryConfig, logger)
+		if err != nil {
+			return nil, err
+		}
 
-	// Initialize data fetcher for pool fees
-	timeseriesHTTPClient := passthroughdomain.NewTimeSeriesHTTPClient(passthroughConfig.TimeseriesURL)
-	fetchPoolFeesCallback := datafetchers.GetFetchPoolPoolFeesFromTimeseries(timeseriesHTTPClient, logger)
-	poolFeesFetcher := datafetchers.NewMapFetcher(fetchPoolFeesCallback, time.Minute*time.Duration(passthroughConfig.PoolFeesFetchIntervalMinutes))
+		go func() {
+			logger.Info("Starting grpc query server")
 
-	// Register the pool fees fetcher with the passthrough use case
-	poolsUseCase.RegisterPoolFeesFetcher(poolFeesFetcher)
+			lis, err := net.Listen("tcp", grpcQueryConfig.ServerAddress)
+			if err != nil {
+				panic(err)
+			}
+			if err := grpcQueryHandler.Serve(lis); err != nil {
+				panic(err)
+			}
+		}()
+	}
 
 	// Start grpc ingest server if enabled
 	grpcIngesterConfig := config.GRPCIngester
+	var (
+		grpcIngestHandler *grpc.Server
+		blockRecorder     *recorder.Recorder
+		eventSink         eventpublisherdomain.Sink
+	)
 	if grpcIngesterConfig.Enabled {
-		quotePriceUpdateWorker := pricingWorker.New(tokensUseCase, defaultQuoteDenom, config.Pricing.WorkerMinPoolLiquidityCap, logger)
-
-		poolLiquidityComputeWorker := pricingWorker.NewPoolLiquidityWorker(tokensUseCase, poolsUseCase, liquidityPricer, logger)
-
-		candidateRouteSearchDataWorker := routerWorker.NewCandidateRouteSearchDataWorker(poolsUseCase, routerRepository, config.Router.PreferredPoolIDs, cosmWasmPoolConfig, logger)
-
-		// Register chain info use case (healthcheck) as a listener to the candidate route search data worker.
-		candidateRouteSearchDataWorker.RegisterListener(chainInfoUseCase)
-
-		// chain info use case acts as the healthcheck. It receives updates from the pricing worker.
-		// It then passes the healthcheck as long as updates are received at the appropriate intervals.
-		quotePriceUpdateWorker.RegisterListener(chainInfoUseCase)
-
-		// pool liquidity compute worker listens to the quote price update worker.
-		quotePriceUpdateWorker.RegisterListener(poolLiquidityComputeWorker)
-
-		// Initialize ingest handler and usecase
-		ingestUseCase, err := ingestusecase.NewIngestUsecase(
-			poolsUseCase,
-			routerUsecase,
-			pricingSimpleRouterUsecase,
-			tokensUseCase,
-			chainInfoUseCase,
-			appCodec,
-			quotePriceUpdateWorker,
-			candidateRouteSearchDataWorker,
-			orderBookUseCase,
-			logger,
-		)
+		ingestUseCase := sqsRouter.GetIngestUsecase()
 
-		if err != nil {
-			return nil, err
-		}
+		// Out-of-process plugin host and its configuration, if enabled.
+		var (
+			remotePluginHost       remoteplugindomain.PluginHost
+			remotePluginHostConfig *domain.RemotePluginHostConfig
+		)
 
 		// Iterate over the plugin configurations and register the enabled plugins.
 		for _, plugin := range grpcIngesterConfig.Plugins {
@@ -279,6 +299,49 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 
 					logger.Info("Using keyring with address", zap.Stringer("address", keyring.GetAddress()))
 					currentPlugin = orderbookfiller.New(poolsUseCase, routerUsecase, tokensUseCase, passthroughGRPCClient, orderBookAPIClient, keyring, defaultQuoteDenom, logger)
+				} else if plugin.GetName() == arbdetectordomain.ArbDetectorPluginName {
+					arbDetectorConfig, ok := plugin.(*domain.ArbDetectorPluginConfig)
+					if !ok {
+						return nil, fmt.Errorf("invalid %s plugin config type: %T", plugin.GetName(), plugin)
+					}
+
+					arbDetectorPlugin := arbdetector.New(poolsUseCase, routerUsecase, tokensUseCase, *arbDetectorConfig, defaultQuoteDenom, logger)
+					arbdetectorhttpdelivery.NewArbDetectorHandler(e, arbDetectorPlugin)
+
+					currentPlugin = arbDetectorPlugin
+				} else if plugin.GetName() == remoteplugindomain.RemotePluginHostName {
+					var ok bool
+					remotePluginHostConfig, ok = plugin.(*domain.RemotePluginHostConfig)
+					if !ok {
+						return nil, fmt.Errorf("invalid %s plugin config type: %T", plugin.GetName(), plugin)
+					}
+
+					pluginHost := remotehost.New(poolsUseCase, appCodec, *remotePluginHostConfig, logger)
+					remotePluginHost = pluginHost
+
+					currentPlugin = pluginHost
+				} else if plugin.GetName() == webhookdomain.WebhookPluginName {
+					webhookConfig, ok := plugin.(*domain.WebhookPluginConfig)
+					if !ok {
+						return nil, fmt.Errorf("invalid %s plugin config type: %T", plugin.GetName(), plugin)
+					}
+
+					currentPlugin, err = webhook.New(poolsUseCase, tokensUseCase, routerRepository, *webhookConfig, defaultQuoteDenom, logger)
+					if err != nil {
+						return nil, err
+					}
+				} else if plugin.GetName() == eventpublisherdomain.EventPublisherPluginName {
+					eventPublisherConfig, ok := plugin.(*domain.EventPublisherPluginConfig)
+					if !ok {
+						return nil, fmt.Errorf("invalid %s plugin config type: %T", plugin.GetName(), plugin)
+					}
+
+					eventSink, err = eventpublisher.NewSink(*eventPublisherConfig)
+					if err != nil {
+						return nil, err
+					}
+
+					currentPlugin = eventpublisher.New(poolsUseCase, tokensUseCase, routerRepository, eventSink, *eventPublisherConfig, defaultQuoteDenom, logger)
 				}
 
 				// Register the plugin with the ingest use case
@@ -286,25 +349,70 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 			}
 		}
 
-		// Register chain info use case as a listener to the pool liquidity compute worker (healthcheck).
-		poolLiquidityComputeWorker.RegisterListener(chainInfoUseCase)
+		if grpcIngesterConfig.RecordPath != "" {
+			blockRecorder, err = recorder.NewRecorder(grpcIngesterConfig.RecordPath)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-		grpcIngestHandler, err := ingestrpcdelivry.NewIngestGRPCHandler(ingestUseCase, *grpcIngesterConfig, logger)
+		grpcIngestHandler, err = ingestrpcdelivry.NewIngestGRPCHandler(ingestUseCase, blockRecorder, *grpcIngesterConfig, logger)
 		if err != nil {
 			panic(err)
 		}
 
-		go func() {
-			logger.Info("Starting grpc ingest server")
-
-			lis, err := net.Listen("tcp", grpcIngesterConfig.ServerAddress)
+		if remotePluginHost != nil {
+			grpcPluginHostHandler, err := ingestrpcdelivry.NewPluginHostGRPCHandler(remotePluginHost, routerUsecase, tokensUseCase, *grpcIngesterConfig, *remotePluginHostConfig, logger)
 			if err != nil {
 				panic(err)
 			}
-			if err := grpcIngestHandler.Serve(lis); err != nil {
-				panic(err)
+
+			go func() {
+				logger.Info("Starting grpc plugin host server")
+
+				lis, err := net.Listen("tcp", remotePluginHostConfig.ServerAddress)
+				if err != nil {
+					panic(err)
+				}
+				if err := grpcPluginHostHandler.Serve(lis); err != nil {
+					panic(err)
+				}
+			}()
+		}
+
+		// When replaying, the recorded blocks are ingested instead of the ones pushed by the node.
+		if grpcIngesterConfig.ReplayPath != "" {
+			replayReader, err := recorder.NewReader(grpcIngesterConfig.ReplayPath)
+			if err != nil {
+				return nil, err
 			}
-		}()
+
+			go func() {
+				defer replayReader.Close()
+
+				logger.Info("Starting ingest replay", zap.String("path", grpcIngesterConfig.ReplayPath), zap.Float64("speed", grpcIngesterConfig.ReplaySpeed))
+
+				replayed, err := recorder.Replay(context.Background(), replayReader, ingestUseCase, grpcIngesterConfig.ReplaySpeed, logger)
+				if err != nil {
+					logger.Error("ingest replay failed", zap.Int("replayed", replayed), zap.Error(err))
+					return
+				}
+
+				logger.Info("Ingest replay completed", zap.Int("replayed", replayed))
+			}()
+		} else {
+			go func() {
+				logger.Info("Starting grpc ingest server")
+
+				lis, err := net.Listen("tcp", grpcIngesterConfig.ServerAddress)
+				if err != nil {
+					panic(err)
+				}
+				if err := grpcIngestHandler.Serve(lis); err != nil {
+					panic(err)
+				}
+			}()
+		}
 	}
 
 	go func() {
@@ -320,6 +428,10 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 		logger:        logger,
 		e:             e,
 		sqsAddress:    config.ServerAddress,
+
+		grpcIngestServer: grpcIngestHandler,
+		blockRecorder:    blockRecorder,
+		eventSink:        eventSink,
 	}, nil
 }
 
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v25/app"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/ingest/simulator"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// testAssetList is the chain registry asset list served to SQS, containing the default quote denom.
+const testAssetList = `{
+	"chainName": "osmosis",
+	"assets": [
+		{"name": "Osmosis", "coinMinimalDenom": "uosmo", "symbol": "OSMO", "decimals": 6},
+		{"name": "USD Coin", "coinMinimalDenom": "uusdc", "symbol": "USDC", "decimals": 6}
+	]
+}`
+
+// Tests that SQS starts against the chain simulator alone, without an Osmosis node or gRPC gateway,
+// and serves quotes over the simulated blocks once healthy.
+func TestNewSideCarQueryServer_ChainSimulator(t *testing.T) {
+	encodingConfig := app.MakeEncodingConfig()
+
+	balancerPool, err := balancer.NewBalancerPool(
+		1,
+		balancer.PoolParams{SwapFee: osmomath.MustNewDecFromStr("0.002"), ExitFee: osmomath.ZeroDec()},
+		[]balancer.PoolAsset{
+			{Token: sdk.NewCoin("uosmo", osmomath.NewInt(10_000_000_000)), Weight: osmomath.NewInt(1)},
+			{Token: sdk.NewCoin("uusdc", osmomath.NewInt(5_000_000_000)), Weight: osmomath.NewInt(1)},
+		},
+		"",
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	pools := []sqsdomain.PoolI{
+		&sqsdomain.PoolWrapper{
+			ChainModel: &balancerPool,
+			SQSModel: sqsdomain.SQSPool{
+				PoolLiquidityCap: osmomath.NewInt(10_000_000),
+				PoolDenoms:       []string{"uosmo", "uusdc"},
+				Balances:         balancerPool.GetTotalPoolLiquidity(sdk.Context{}),
+				SpreadFactor:     balancerPool.GetSpreadFactor(sdk.Context{}),
+			},
+		},
+	}
+
+	simulatorConfig := simulator.DefaultConfig
+	simulatorConfig.BlockTime = 50 * time.Millisecond
+	sim, err := simulator.New(pools, sqsdomain.TakerFeeMap{}, encodingConfig.Marshaler, simulatorConfig, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	// The simulator stands in for the Tendermint RPC status endpoint of the node.
+	statusServer := httptest.NewServer(sim.StatusHandler())
+	defer statusServer.Close()
+
+	chainRegistryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testAssetList))
+	}))
+	defer chainRegistryServer.Close()
+
+	config := domain.DefaultConfig
+	config.ChainTendermintRPCEndpoint = statusServer.URL
+	config.ChainRegistryAssetsFileURL = chainRegistryServer.URL
+	// No gRPC gateway is listening at this address.
+	config.ChainGRPCGatewayEndpoint = freeAddress(t)
+
+	grpcIngesterConfig := *config.GRPCIngester
+	grpcIngesterConfig.ServerAddress = freeAddress(t)
+	config.GRPCIngester = &grpcIngesterConfig
+
+	// The flight recorder traces the whole process, so it is not run in tests.
+	flightRecordConfig := *config.FlightRecord
+	flightRecordConfig.Enabled = false
+	config.FlightRecord = &flightRecordConfig
+
+	degradedModeConfig := *config.DegradedMode
+	degradedModeConfig.CheckIntervalMs = 50
+	config.DegradedMode = &degradedModeConfig
+
+	server, err := NewSideCarQueryServer(encodingConfig.Marshaler, config, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	sqs, ok := server.(*sideCarQueryServer)
+	require.True(t, ok)
+	defer func() {
+		require.NoError(t, sqs.Shutdown(context.Background()))
+	}()
+
+	conn, err := grpc.NewClient(grpcIngesterConfig.ServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Blocks pushed before the ingest server listens fail and are pushed in full at the next block.
+	go func() {
+		_ = sim.Run(ctx, prototypes.NewSQSIngesterClient(conn), 0)
+	}()
+
+	get := func(target string) int {
+		rec := httptest.NewRecorder()
+		sqs.e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Code
+	}
+
+	require.Eventually(t, func() bool {
+		return get("/healthcheck") == http.StatusOK
+	}, 10*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, http.StatusOK, get("/router/quote?tokenIn=1000000uosmo&tokenOutDenom=uusdc"))
+}
+
+// freeAddress returns a local address that is free to listen at.
+func freeAddress(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	return lis.Addr().String()
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+)
+
+// ArbDetectorHandler is the http handler for the cyclic arbitrage detector
+type ArbDetectorHandler struct {
+	ArbDetector arbdetectordomain.CyclicArbDetector
+}
+
+const resourcePrefix = "/arb"
+
+func formatArbResource(resource string) string {
+	return resourcePrefix + resource
+}
+
+// NewArbDetectorHandler will initialize the arb/ resources endpoint
+func NewArbDetectorHandler(e *echo.Echo, arbDetector arbdetectordomain.CyclicArbDetector) {
+	handler := &ArbDetectorHandler{
+		ArbDetector: arbDetector,
+	}
+
+	e.GET(formatArbResource("/opportunities"), handler.GetOpportunities)
+}
+
+// @Summary Returns cyclic arbitrage opportunities detected at the latest processed block.
+// @Description The opportunities are sorted by profit valued in the default quote denom in descending order.
+// Each opportunity starts and ends in the same denom and is sized to the amount in maximizing the profit.
+//
+// @Produce  json
+// @Success 200  {object}  arbdetectordomain.CyclicArbOpportunities  "Cyclic arbitrage opportunities"
+// @Param  denom  query  string  false  "Only return opportunities starting and ending in the given chain denom"
+// @Router /arb/opportunities [get]
+func (a *ArbDetectorHandler) GetOpportunities(c echo.Context) error {
+	opportunities := a.ArbDetector.GetOpportunities()
+
+	denom := c.QueryParam("denom")
+	if denom == "" {
+		return c.JSON(http.StatusOK, opportunities)
+	}
+
+	filtered := make([]arbdetectordomain.CyclicArbOpportunity, 0, len(opportunities.Opportunities))
+	for _, opportunity := range opportunities.Opportunities {
+		if opportunity.DenomIn == denom {
+			filtered = append(filtered, opportunity)
+		}
+	}
+
+	return c.JSON(http.StatusOK, arbdetectordomain.CyclicArbOpportunities{
+		Height:        opportunities.Height,
+		Opportunities: filtered,
+	})
+}
@@ -12,10 +12,13 @@ import (
 	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/stableswap"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+
+	"github.com/osmosis-labs/sqs/domain"
 )
 
 type Client interface {
 	GetLatestHeight(ctx context.Context) (uint64, error)
+	domain.NodeStatusGetter
 }
 
 type chainClient struct {
@@ -50,3 +53,17 @@ func (c chainClient) GetLatestHeight(ctx context.Context) (uint64, error) {
 
 	return uint64(latestBlockHeight), nil
 }
+
+// GetNodeStatus implements domain.NodeStatusGetter.
+func (c chainClient) GetNodeStatus(ctx context.Context) (domain.NodeStatus, error) {
+	statusResult, err := c.rpcClient.Status(ctx)
+	if err != nil {
+		return domain.NodeStatus{}, err
+	}
+
+	return domain.NodeStatus{
+		LatestHeight:    uint64(statusResult.SyncInfo.LatestBlockHeight),
+		LatestBlockTime: statusResult.SyncInfo.LatestBlockTime,
+		CatchingUp:      statusResult.SyncInfo.CatchingUp,
+	}, nil
+}
@@ -24,6 +24,12 @@ type chainInfoUseCase struct {
 	lastIngestedHeight  uint64
 	lastSeenUpdatedTime time.Time
 
+	// latestHeightStoredTime is the time the latest height was stored at.
+	latestHeightStoredMx   sync.RWMutex
+	latestHeightStoredTime time.Time
+	// observedBlockTime is the moving average of the interval between stored heights.
+	observedBlockTime time.Duration
+
 	priceUpdateHeightMx      sync.RWMutex
 	latestPricesUpdateHeight uint64
 
@@ -44,6 +50,9 @@ const (
 	updateHeightThreshold = 50
 	initialUpdateHeight   = 0
 
+	// observedBlockTimeSmoothing is the weight of the latest block interval in the observed block time moving average.
+	observedBlockTimeSmoothing = 0.2
+
 	poolLiquidityPricingUpdateName     = "pool liquidity"
 	pricingUpdateName                  = "pricing"
 	candidateRouteSearchDataUpdateName = "candidate route search data"
@@ -97,7 +106,66 @@ func (p *chainInfoUseCase) GetLatestHeight() (uint64, error) {
 
 // StoreLatestHeight implements mvc.ChainInfoUsecase.
 func (p *chainInfoUseCase) StoreLatestHeight(height uint64) {
+	p.latestHeightStoredMx.Lock()
+	defer p.latestHeightStoredMx.Unlock()
+
+	previousHeight := p.chainInfoRepository.GetLatestHeight()
+	previousHeightStoredTime := p.latestHeightStoredTime
+
 	p.chainInfoRepository.StoreLatestHeight(height)
+	p.latestHeightStoredTime = time.Now()
+
+	// Track the observed block time, averaging the interval over the blocks ingested at once.
+	if !previousHeightStoredTime.IsZero() && height > previousHeight {
+		blockTime := p.latestHeightStoredTime.Sub(previousHeightStoredTime) / time.Duration(height-previousHeight)
+
+		if p.observedBlockTime == 0 {
+			p.observedBlockTime = blockTime
+		} else {
+			p.observedBlockTime += time.Duration(observedBlockTimeSmoothing * float64(blockTime-p.observedBlockTime))
+		}
+
+		domain.SQSChainObservedBlockTimeGauge.Set(float64(p.observedBlockTime.Milliseconds()))
+	}
+}
+
+// GetObservedBlockTime implements mvc.ChainInfoUsecase.
+func (p *chainInfoUseCase) GetObservedBlockTime() time.Duration {
+	p.latestHeightStoredMx.RLock()
+	defer p.latestHeightStoredMx.RUnlock()
+
+	return p.observedBlockTime
+}
+
+// GetHeightMetadata implements mvc.ChainInfoUsecase.
+// Unlike GetLatestHeight, it does not validate the latest height for staleness
+// so that it can be called on every request without affecting the healthcheck.
+func (p *chainInfoUseCase) GetHeightMetadata(ctx context.Context) domain.HeightMetadata {
+	p.latestHeightStoredMx.RLock()
+	ingestHeight := p.chainInfoRepository.GetLatestHeight()
+	latestHeightStoredTime := p.latestHeightStoredTime
+	p.latestHeightStoredMx.RUnlock()
+
+	p.priceUpdateHeightMx.RLock()
+	pricingHeight := p.latestPricesUpdateHeight
+	p.priceUpdateHeightMx.RUnlock()
+
+	snapshotHeight, snapshotCreatedAt := ingestHeight, latestHeightStoredTime
+	if snapshot, ok := domain.GetStateSnapshot(ctx); ok {
+		snapshotHeight, snapshotCreatedAt = snapshot.GetHeight(), snapshot.GetCreatedAt()
+	}
+
+	snapshotAgeMs := int64(0)
+	if !snapshotCreatedAt.IsZero() {
+		snapshotAgeMs = time.Since(snapshotCreatedAt).Milliseconds()
+	}
+
+	return domain.HeightMetadata{
+		IngestHeight:   ingestHeight,
+		PricingHeight:  pricingHeight,
+		SnapshotHeight: snapshotHeight,
+		SnapshotAgeMs:  snapshotAgeMs,
+	}
 }
 
 // OnPricingUpdate implements domain.PricingUpdateListener.
@@ -119,7 +187,7 @@ func (p *chainInfoUseCase) OnPoolLiquidityCompute(ctx context.Context, height ui
 }
 
 // OnSearchDataUpdate implements domain.CandidateRouteSearchDataUpdateListener.
-func (p *chainInfoUseCase) OnSearchDataUpdate(ctx context.Context, height uint64) error {
+func (p *chainInfoUseCase) OnSearchDataUpdate(ctx context.Context, height uint64, blockPoolMetaData domain.BlockPoolMetadata) error {
 	p.candidateRouteSearchDataUpdateHeightMx.Lock()
 	defer p.candidateRouteSearchDataUpdateHeightMx.Unlock()
 	p.latestCandidateRouteSearchDataUpdateHeight = height
@@ -164,8 +232,9 @@ func validateUpdate(currentUpdateHeight uint64, latestIngestedHeight uint64, upd
 		return fmt.Errorf("healthcheck has not received initial %s updates", updateName)
 	}
 
-	// Check that the pool liquidity updates have been occurring
-	if currentUpdateHeight < latestIngestedHeight-updateHeightThreshold {
+	// Check that the pool liquidity updates have been occurring.
+	// Heights below the threshold, e.g. of the chain simulator, are not subtracted from to avoid underflow.
+	if latestIngestedHeight > updateHeightThreshold && currentUpdateHeight < latestIngestedHeight-updateHeightThreshold {
 		return fmt.Errorf("latest %s update height is less than the latest ingested height", updateName)
 	}
 
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+)
+
+// chainStatusMonitor periodically detects whether the chain is halted, ingest is stalled
+// or the node is lagging from the node status and the latest ingested height.
+// It logs and emits metrics on every chain status transition.
+// It is the only poller of the node status: the readiness probe reads the latest report instead of
+// fetching the node status itself.
+type chainStatusMonitor struct {
+	config           domain.DegradedModeConfig
+	nodeStatusGetter domain.NodeStatusGetter
+	chainInfoUsecase mvc.ChainInfoUsecase
+	logger           log.Logger
+
+	mx     sync.RWMutex
+	report domain.ChainStatusReport
+}
+
+var _ domain.ChainStatusGetter = &chainStatusMonitor{}
+
+// allChainStatuses are all the chain statuses, used to reset the chain status gauge on transitions.
+var allChainStatuses = []domain.ChainStatus{
+	domain.ChainStatusHealthy,
+	domain.ChainStatusNodeLag,
+	domain.ChainStatusChainHalt,
+	domain.ChainStatusIngestStall,
+}
+
+// NewChainStatusMonitor returns a new chain status monitor.
+// The chain status is healthy until the first check. Call Start to run the checks.
+func NewChainStatusMonitor(config domain.DegradedModeConfig, nodeStatusGetter domain.NodeStatusGetter, chainInfoUsecase mvc.ChainInfoUsecase, logger log.Logger) *chainStatusMonitor {
+	domain.SQSChainStatusGauge.WithLabelValues(string(domain.ChainStatusHealthy)).Set(1)
+
+	return &chainStatusMonitor{
+		config:           config,
+		nodeStatusGetter: nodeStatusGetter,
+		chainInfoUsecase: chainInfoUsecase,
+		logger:           logger,
+
+		report: domain.ChainStatusReport{
+			Status:              domain.ChainStatusHealthy,
+			ExpectedBlockTimeMs: int64(config.ExpectedBlockTimeMs),
+		},
+	}
+}
+
+// Start checks the chain status immediately and then at the configured interval until the context is done.
+// CONTRACT: called once.
+func (m *chainStatusMonitor) Start(ctx context.Context) {
+	m.check(ctx)
+
+	ticker := time.NewTicker(time.Duration(m.config.CheckIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// GetChainStatus implements domain.ChainStatusGetter.
+func (m *chainStatusMonitor) GetChainStatus() domain.ChainStatusReport {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	return m.report
+}
+
+// check detects the chain status and updates the report, logging and emitting metrics on transition.
+func (m *chainStatusMonitor) check(ctx context.Context) {
+	nodeStatusCtx, cancel := context.WithTimeout(ctx, time.Duration(m.config.CheckIntervalMs)*time.Millisecond)
+	defer cancel()
+
+	nodeStatus, nodeStatusErr := m.nodeStatusGetter.GetNodeStatus(nodeStatusCtx)
+
+	m.update(m.observe(nodeStatus, nodeStatusErr, time.Now()))
+}
+
+// observe returns the chain status observation at the given time from the given node status
+// and the latest ingested height.
+func (m *chainStatusMonitor) observe(nodeStatus domain.NodeStatus, nodeStatusErr error, now time.Time) domain.ChainStatusObservation {
+	// Use the latest ingested state rather than any pinned snapshot.
+	heightMetadata := m.chainInfoUsecase.GetHeightMetadata(context.Background())
+
+	observation := domain.ChainStatusObservation{
+		Now:           now,
+		NodeStatus:    nodeStatus,
+		NodeStatusErr: nodeStatusErr,
+		IngestHeight:  heightMetadata.IngestHeight,
+	}
+
+	if heightMetadata.IngestHeight > 0 {
+		observation.IngestTime = now.Add(-time.Duration(heightMetadata.SnapshotAgeMs) * time.Millisecond)
+	}
+
+	return observation
+}
+
+// update detects the chain status from the given observation and updates the report.
+// Logs and emits metrics on transition.
+func (m *chainStatusMonitor) update(observation domain.ChainStatusObservation) {
+	status, message := domain.DetectChainStatus(observation, m.config)
+
+	report := domain.ChainStatusReport{
+		Status:              status,
+		Message:             message,
+		ExpectedBlockTimeMs: int64(m.config.ExpectedBlockTimeMs),
+		ObservedBlockTimeMs: m.chainInfoUsecase.GetObservedBlockTime().Milliseconds(),
+		NodeHeight:          observation.NodeStatus.LatestHeight,
+		IngestHeight:        observation.IngestHeight,
+		NodeCatchingUp:      observation.NodeStatus.CatchingUp,
+	}
+
+	if observation.NodeStatusErr != nil {
+		report.NodeError = observation.NodeStatusErr.Error()
+	}
+
+	m.mx.Lock()
+	previousStatus := m.report.Status
+	m.report = report
+	m.mx.Unlock()
+
+	if previousStatus == status {
+		return
+	}
+
+	domain.SQSChainStatusTransitionsCounter.WithLabelValues(string(previousStatus), string(status)).Inc()
+	for _, chainStatus := range allChainStatuses {
+		isCurrent := 0.0
+		if chainStatus == status {
+			isCurrent = 1
+		}
+		domain.SQSChainStatusGauge.WithLabelValues(string(chainStatus)).Set(isCurrent)
+	}
+
+	fields := []zap.Field{
+		zap.String("from", string(previousStatus)),
+		zap.String("to", string(status)),
+		zap.String("message", message),
+		zap.Uint64("node_height", report.NodeHeight),
+		zap.Uint64("ingest_height", report.IngestHeight),
+		zap.Int64("expected_block_time_ms", report.ExpectedBlockTimeMs),
+		zap.Int64("observed_block_time_ms", report.ObservedBlockTimeMs),
+	}
+
+	if status == domain.ChainStatusHealthy {
+		m.logger.Info("chain status recovered", fields...)
+	} else {
+		m.logger.Warn("chain status degraded", fields...)
+	}
+}
@@ -0,0 +1,235 @@
+// Package client provides a typed Go client for the sidecar query server HTTP API.
+//
+// The responses are decoded into the domain and sqsdomain types where they can be,
+// and into the types of this package otherwise (e.g. quotes, whose domain type is an interface).
+// Requests failing with a transient error are retried.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 2
+	defaultRetryBackoff = 200 * time.Millisecond
+
+	// maxRetryAfter caps the delay requested by the server via the Retry-After header.
+	maxRetryAfter = 10 * time.Second
+)
+
+// Client is a client of the sidecar query server HTTP API.
+// It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// Option configures the client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for the requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithMaxRetries sets the max number of times a request failing with a transient error is retried.
+// Zero disables retries.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry. The delay is doubled on each subsequent retry.
+func WithRetryBackoff(retryBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.retryBackoff = retryBackoff
+	}
+}
+
+// New returns a client of the sidecar query server at the given base URL, e.g. https://sqs.osmosis.zone.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is returned when the server responds with a non-successful status code.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by the server.
+	Message string
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("sqs request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the given error is a response error with the 404 Not Found status code.
+func IsNotFound(err error) bool {
+	var responseErr *Error
+	return errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound
+}
+
+// get makes a GET request to the given endpoint with the given query and decodes the response body into T.
+func get[T any](ctx context.Context, c *Client, endpoint string, query url.Values) (T, error) {
+	var result T
+	if err := c.get(ctx, endpoint, query, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// get makes a GET request to the given endpoint with the given query and decodes the response body into result.
+// Retries the request on transient errors, waiting for the backoff or the delay requested by the server.
+func (c *Client) get(ctx context.Context, endpoint string, query url.Values, result any) error {
+	requestURL := c.baseURL + endpoint
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.doGet(ctx, requestURL, result)
+		if err == nil || attempt >= c.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		delay := backoff
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		backoff *= 2
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// doGet makes a single GET request to the given URL and decodes the response body into result.
+// Returns the delay requested by the server via the Retry-After header, if any.
+func (c *Client) doGet(ctx context.Context, requestURL string, result any) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return parseRetryAfter(resp.Header.Get("Retry-After")), newError(resp.StatusCode, body)
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+
+	return 0, nil
+}
+
+// newError returns the response error with the message of the given response body.
+// Falls back to the raw body if it is not a JSON error response.
+func newError(statusCode int, body []byte) *Error {
+	var responseErr domain.ResponseError
+	if err := json.Unmarshal(body, &responseErr); err != nil || responseErr.Message == "" {
+		responseErr.Message = strings.TrimSpace(string(body))
+	}
+
+	return &Error{StatusCode: statusCode, Message: responseErr.Message}
+}
+
+// isRetryable returns true if the request failed with a transient error: a network or transport error,
+// including a response body cut short, 429 Too Many Requests or a 502, 503 or 504 gateway error.
+// Context cancellation and errors decoding the response are not retryable.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var responseErr *Error
+	if errors.As(err, &responseErr) {
+		switch responseErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var (
+		urlErr *url.Error
+		netErr net.Error
+	)
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses the Retry-After header in seconds, capped at maxRetryAfter.
+// Returns zero if the header is absent or invalid.
+func parseRetryAfter(retryAfter string) time.Duration {
+	seconds, err := strconv.Atoi(retryAfter)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	delay := time.Duration(seconds) * time.Second
+	if delay > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return delay
+}
+
+// formatDenoms formats the given denoms as the comma-separated query parameter value.
+func formatDenoms(denoms []string) string {
+	return strings.Join(denoms, ",")
+}
+
+// formatIDs formats the given IDs as the comma-separated query parameter value.
+func formatIDs(ids []uint64) string {
+	idStrs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrs = append(idStrs, strconv.FormatUint(id, 10))
+	}
+	return strings.Join(idStrs, ",")
+}
@@ -0,0 +1,347 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	"github.com/osmosis-labs/sqs/client"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	poolshttpdelivery "github.com/osmosis-labs/sqs/pools/delivery/http"
+	routerhttpdelivery "github.com/osmosis-labs/sqs/router/delivery/http"
+	"github.com/osmosis-labs/sqs/router/types"
+	routerusecase "github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/route"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	tokenshttpdelivery "github.com/osmosis-labs/sqs/tokens/delivery/http"
+)
+
+const (
+	uosmo = "uosmo"
+	uusdc = "ibc/usdc"
+)
+
+var (
+	tokens = map[string]domain.Token{
+		uosmo: {Name: "Osmosis", HumanDenom: "osmo", CoinMinimalDenom: uosmo, Precision: 6},
+		uusdc: {Name: "USD Coin", HumanDenom: "usdc", CoinMinimalDenom: uusdc, Precision: 6},
+	}
+
+	tokensUsecase = &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			for chainDenom, token := range tokens {
+				if token.HumanDenom == humanDenom {
+					return chainDenom, nil
+				}
+			}
+			return "", errors.New("unknown human denom")
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			_, ok := tokens[chainDenom]
+			return ok
+		},
+		GetMetadataByChainDenomFunc: func(denom string) (domain.Token, error) {
+			token, ok := tokens[denom]
+			if !ok {
+				return domain.Token{}, errors.New("unknown chain denom")
+			}
+			return token, nil
+		},
+		IsValidPricingSourceFunc: func(pricingSource int) bool {
+			return pricingSource == int(domain.ChainPricingSourceType)
+		},
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			prices := domain.PricesResult{}
+			for _, baseDenom := range baseDenoms {
+				prices[baseDenom] = map[string]osmomath.BigDec{quoteDenoms[0]: osmomath.MustNewBigDecFromStr("0.5")}
+			}
+			return prices, nil
+		},
+	}
+)
+
+// newTestPool returns a pool swapping 1:1 into the given token out denom.
+func newTestPool(tokenOutDenom string) *mocks.MockRoutablePool {
+	return &mocks.MockRoutablePool{
+		ID:            1,
+		PoolType:      poolmanagertypes.CosmWasm,
+		TokenOutDenom: tokenOutDenom,
+		TakerFee:      osmomath.ZeroDec(),
+		SpreadFactor:  osmomath.ZeroDec(),
+	}
+}
+
+// newTestQuote returns the quote of swapping the given token in over a single 1:1 pool.
+func newTestQuote(tokenIn sdk.Coin, tokenOutDenom string) *routerusecase.QuoteExactAmountIn {
+	return &routerusecase.QuoteExactAmountIn{
+		AmountIn:  tokenIn,
+		AmountOut: tokenIn.Amount,
+		Route: []domain.SplitRoute{
+			&routerusecase.RouteWithOutAmount{
+				RouteImpl: route.RouteImpl{Pools: []domain.RoutablePool{newTestPool(tokenOutDenom)}},
+				InAmount:  tokenIn.Amount,
+				OutAmount: tokenIn.Amount,
+			},
+		},
+		PriceImpact: osmomath.ZeroDec(),
+	}
+}
+
+// newTestServer starts a server with the real HTTP handlers backed by mock usecases.
+func newTestServer(t *testing.T) *client.Client {
+	routerUsecase := &mocks.RouterUsecaseMock{
+		GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+			return newTestQuote(tokenIn, tokenOutDenom), nil
+		},
+		GetOptimalQuoteInGivenOutFunc: func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+			return routerusecase.NewQuoteExactAmountOut(newTestQuote(tokenOut, tokenInDenom)), nil
+		},
+		GetCandidateRoutesFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error) {
+			return sqsdomain.CandidateRoutes{
+				Routes:        []sqsdomain.CandidateRoute{{Pools: []sqsdomain.CandidatePool{{ID: 1, TokenOutDenom: tokenOutDenom}}}},
+				UniquePoolIDs: map[uint64]struct{}{1: {}},
+			}, nil
+		},
+	}
+
+	poolsUsecase := &mocks.PoolsUsecaseMock{
+		GetPoolsFunc: func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error) {
+			pool := newTestPool(uusdc)
+			pool.Balances = sdk.NewCoins(sdk.NewCoin(uosmo, osmomath.NewInt(100)), sdk.NewCoin(uusdc, osmomath.NewInt(50)))
+			pool.PoolLiquidityCap = osmomath.NewInt(150)
+			return []sqsdomain.PoolI{pool}, nil
+		},
+		GetTickModelMapFunc: func(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error) {
+			return map[uint64]*sqsdomain.TickModel{}, nil
+		},
+	}
+
+	e := echo.New()
+	routerhttpdelivery.NewRouterHandler(e, routerUsecase, tokensUsecase, &log.NoOpLogger{})
+	poolshttpdelivery.NewPoolsHandler(e, poolsUsecase)
+	err := tokenshttpdelivery.NewTokensHandler(e, domain.PricingConfig{DefaultQuoteHumanDenom: "usdc"}, tokensUsecase, routerUsecase, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return client.New(server.URL)
+}
+
+// TestQuote tests that the quotes of both swap methods are decoded with the denoms of both amounts.
+func TestQuote(t *testing.T) {
+	c := newTestServer(t)
+
+	tests := []struct {
+		name string
+		req  types.GetQuoteRequest
+
+		expectedAmountIn  sdk.Coin
+		expectedAmountOut sdk.Coin
+		expectedErr       bool
+	}{
+		{
+			name: "exact amount in",
+			req:  types.GetQuoteRequest{TokenIn: &sdk.Coin{Denom: uosmo, Amount: osmomath.NewInt(1000)}, TokenOutDenom: uusdc},
+
+			expectedAmountIn:  sdk.NewCoin(uosmo, osmomath.NewInt(1000)),
+			expectedAmountOut: sdk.NewCoin(uusdc, osmomath.NewInt(1000)),
+		},
+		{
+			name: "exact amount in with human denoms",
+			req:  types.GetQuoteRequest{TokenIn: &sdk.Coin{Denom: "osmo", Amount: osmomath.NewInt(1000)}, TokenOutDenom: "usdc", HumanDenoms: true},
+
+			// The human denom amount is scaled by the token precision.
+			expectedAmountIn:  sdk.NewCoin(uosmo, osmomath.NewInt(1_000_000_000)),
+			expectedAmountOut: sdk.NewCoin(uusdc, osmomath.NewInt(1_000_000_000)),
+		},
+		{
+			name: "exact amount out",
+			req:  types.GetQuoteRequest{TokenOut: &sdk.Coin{Denom: uusdc, Amount: osmomath.NewInt(1000)}, TokenInDenom: uosmo},
+
+			expectedAmountIn:  sdk.NewCoin(uosmo, osmomath.NewInt(1000)),
+			expectedAmountOut: sdk.NewCoin(uusdc, osmomath.NewInt(1000)),
+		},
+		{
+			name: "invalid request is not sent",
+			req:  types.GetQuoteRequest{TokenIn: &sdk.Coin{Denom: uosmo, Amount: osmomath.NewInt(1000)}, TokenOutDenom: uosmo},
+
+			expectedErr: true,
+		},
+		{
+			name: "server rejects unknown denom",
+			req:  types.GetQuoteRequest{TokenIn: &sdk.Coin{Denom: "uatom", Amount: osmomath.NewInt(1000)}, TokenOutDenom: uusdc},
+
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quote, err := c.Quote(context.Background(), tc.req)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedAmountIn, quote.AmountIn)
+			require.Equal(t, tc.expectedAmountOut, quote.AmountOut)
+			require.Len(t, quote.Route, 1)
+			require.Len(t, quote.Route[0].Pools, 1)
+			require.Equal(t, uint64(1), quote.Route[0].Pools[0].ID)
+			require.Equal(t, osmomath.ZeroDec(), quote.EffectiveFee)
+		})
+	}
+}
+
+// TestRoutesAndPools tests decoding the candidate routes, pools and ticks.
+func TestRoutesAndPools(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+
+	routes, err := c.Routes(ctx, "osmo", "usdc", true, types.CandidateRouteDenomConstraintsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []sqsdomain.CandidateRoute{{Pools: []sqsdomain.CandidatePool{{ID: 1, TokenOutDenom: uusdc}}}}, routes.Routes)
+	require.Equal(t, map[uint64]struct{}{1: {}}, routes.UniquePoolIDs)
+
+	pools, err := c.Pools(ctx, client.PoolsRequest{PoolIDs: []uint64{1}})
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	require.Equal(t, poolmanagertypes.CosmWasm, pools[0].Type)
+	require.Equal(t, "50ibc/usdc,100uosmo", pools[0].Balances.String())
+	require.Equal(t, osmomath.NewInt(150), pools[0].LiquidityCap)
+
+	_, err = c.Ticks(ctx, 1)
+	require.True(t, client.IsNotFound(err))
+}
+
+// TestTokens tests decoding the token metadata and prices.
+func TestTokens(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+
+	metadata, err := c.TokenMetadata(ctx, "osmo", uusdc)
+	require.NoError(t, err)
+	require.Equal(t, map[string]domain.Token{uosmo: tokens[uosmo], uusdc: tokens[uusdc]}, metadata)
+
+	prices, err := c.Prices(ctx, domain.ChainPricingSourceType, true, "osmo")
+	require.NoError(t, err)
+	require.Equal(t, osmomath.MustNewBigDecFromStr("0.5"), prices.GetPriceForDenom(uosmo, uusdc))
+
+	_, err = c.Prices(ctx, domain.CoinGeckoPricingSourceType, false, uosmo)
+	var responseErr *client.Error
+	require.ErrorAs(t, err, &responseErr)
+	require.Equal(t, http.StatusBadRequest, responseErr.StatusCode)
+}
+
+// TestRetries tests that transient errors are retried and other errors are not.
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int32
+		statusCode int
+
+		expectedRequests int32
+		expectedErr      bool
+	}{
+		{
+			name:       "transient error is retried",
+			failures:   2,
+			statusCode: http.StatusServiceUnavailable,
+
+			expectedRequests: 3,
+		},
+		{
+			name:       "transient error exceeds max retries",
+			failures:   3,
+			statusCode: http.StatusBadGateway,
+
+			expectedRequests: 3,
+			expectedErr:      true,
+		},
+		{
+			name:       "bad request is not retried",
+			failures:   1,
+			statusCode: http.StatusBadRequest,
+
+			expectedRequests: 1,
+			expectedErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tc.failures {
+					w.WriteHeader(tc.statusCode)
+					_, _ = w.Write([]byte(`{"message":"failure"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			c := client.New(server.URL, client.WithMaxRetries(2), client.WithRetryBackoff(time.Millisecond))
+
+			_, err := c.QuarantinedPools(context.Background())
+			if tc.expectedErr {
+				var responseErr *client.Error
+				require.ErrorAs(t, err, &responseErr)
+				require.Equal(t, tc.statusCode, responseErr.StatusCode)
+				require.Equal(t, "failure", responseErr.Message)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+// Tests that a response that fails to decode is returned without retrying.
+func TestRetries_DecodeError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"unexpected":"object"}`))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithMaxRetries(2), client.WithRetryBackoff(time.Millisecond))
+
+	_, err := c.QuarantinedPools(context.Background())
+	require.ErrorContains(t, err, "failed to unmarshal")
+	require.Equal(t, int32(1), requests.Load())
+}
+
+// Tests that a network error is retried.
+func TestRetries_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	c := client.New(serverURL, client.WithMaxRetries(2), client.WithRetryBackoff(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := c.QuarantinedPools(context.Background())
+
+	var urlErr *url.Error
+	require.ErrorAs(t, err, &urlErr)
+	// Two retries with backoffs of 50ms and 100ms.
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
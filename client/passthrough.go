@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+	orderbooktypes "github.com/osmosis-labs/sqs/orderbook/types"
+)
+
+// PortfolioAssets returns the portfolio assets of the given address by category.
+func (c *Client) PortfolioAssets(ctx context.Context, address string) (passthroughdomain.PortfolioAssetsResult, error) {
+	return get[passthroughdomain.PortfolioAssetsResult](ctx, c, "/passthrough/portfolio-assets/"+url.PathEscape(address), nil)
+}
+
+// ActiveOrders returns the active orderbook orders of the given address.
+func (c *Client) ActiveOrders(ctx context.Context, address string) (orderbooktypes.GetActiveOrdersResponse, error) {
+	query := url.Values{}
+	query.Set("userOsmoAddress", address)
+
+	return get[orderbooktypes.GetActiveOrdersResponse](ctx, c, "/passthrough/active-orders", query)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// PoolsRequest is the request of the pools endpoint.
+type PoolsRequest struct {
+	// PoolIDs are the IDs of the pools to return. All pools if empty.
+	PoolIDs []uint64
+	// MinLiquidityCap is the min liquidity capitalization of the pools to return.
+	MinLiquidityCap uint64
+	// WithMarketIncentives includes the market incentives data of the pools if true.
+	WithMarketIncentives bool
+}
+
+// Pools returns the pools matching the given request.
+func (c *Client) Pools(ctx context.Context, req PoolsRequest) ([]Pool, error) {
+	query := url.Values{}
+	if len(req.PoolIDs) > 0 {
+		query.Set("IDs", formatIDs(req.PoolIDs))
+	}
+	if req.MinLiquidityCap > 0 {
+		query.Set("min_liquidity_cap", strconv.FormatUint(req.MinLiquidityCap, 10))
+	}
+	setBool(query, "with_market_incentives", req.WithMarketIncentives)
+
+	return get[[]Pool](ctx, c, "/pools", query)
+}
+
+// Ticks returns the tick model of the given concentrated liquidity pool.
+func (c *Client) Ticks(ctx context.Context, poolID uint64) (sqsdomain.TickModel, error) {
+	return get[sqsdomain.TickModel](ctx, c, "/pools/ticks/"+strconv.FormatUint(poolID, 10), nil)
+}
+
+// CanonicalOrderbook returns the canonical orderbook pool of the given base and quote denoms.
+func (c *Client) CanonicalOrderbook(ctx context.Context, base, quote string) (domain.CanonicalOrderBooksResult, error) {
+	query := url.Values{}
+	query.Set("base", base)
+	query.Set("quote", quote)
+
+	return get[domain.CanonicalOrderBooksResult](ctx, c, "/pools/canonical-orderbook", query)
+}
+
+// CanonicalOrderbooks returns the canonical orderbook pools of all base and quote denoms.
+func (c *Client) CanonicalOrderbooks(ctx context.Context) ([]domain.CanonicalOrderBooksResult, error) {
+	return get[[]domain.CanonicalOrderBooksResult](ctx, c, "/pools/canonical-orderbooks", nil)
+}
+
+// QuarantinedPools returns the pools quarantined from routing.
+func (c *Client) QuarantinedPools(ctx context.Context) ([]domain.QuarantinedPool, error) {
+	return get[[]domain.QuarantinedPool](ctx, c, "/pools/quarantined", nil)
+}
+
+// AlloyTransmuterCapacities returns the rate limiter capacities of the alloyed transmuter pools.
+func (c *Client) AlloyTransmuterCapacities(ctx context.Context) ([]domain.AlloyTransmuterCapacity, error) {
+	return get[[]domain.AlloyTransmuterCapacity](ctx, c, "/pools/alloyed-transmuter-capacity", nil)
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/router/types"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// Quote returns the optimal quote for the exact amount in or exact amount out swap method.
+// For the exact amount in swap method, TokenIn and TokenOutDenom must be set.
+// For the exact amount out swap method, TokenOut and TokenInDenom must be set.
+// The request is validated the same way as by the server before it is sent,
+// except for the time budget, whose maximum is configured by the server.
+func (c *Client) Quote(ctx context.Context, req types.GetQuoteRequest) (Quote, error) {
+	if err := req.Validate(math.MaxUint64); err != nil {
+		return Quote{}, err
+	}
+
+	query := url.Values{}
+	if req.SwapMethod() == domain.TokenSwapMethodExactIn {
+		query.Set("tokenIn", req.TokenIn.String())
+		query.Set("tokenOutDenom", req.TokenOutDenom)
+	} else {
+		query.Set("tokenOut", req.TokenOut.String())
+		query.Set("tokenInDenom", req.TokenInDenom)
+	}
+	setBool(query, "singleRoute", req.SingleRoute)
+	setBool(query, "humanDenoms", req.HumanDenoms)
+	setBool(query, "applyExponents", req.ApplyExponents)
+	if req.TimeBudgetMs > 0 {
+		query.Set("timeBudgetMs", strconv.FormatUint(req.TimeBudgetMs, 10))
+	}
+	setCandidateRouteDenomConstraints(query, req.CandidateRouteDenomConstraintsRequest)
+
+	quote, err := get[Quote](ctx, c, "/router/quote", query)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	fillQuoteDenoms(&quote, req.SwapMethod())
+
+	return quote, nil
+}
+
+// CustomDirectQuote returns the quote over the given pools without searching for routes.
+// For the exact amount in swap method, TokenIn and one TokenOutDenom per pool must be set.
+// For the exact amount out swap method, TokenOut and one TokenInDenom per pool must be set.
+// The request is validated the same way as by the server before it is sent.
+func (c *Client) CustomDirectQuote(ctx context.Context, req types.GetDirectCustomQuoteRequest) (Quote, error) {
+	if err := req.Validate(); err != nil {
+		return Quote{}, err
+	}
+
+	query := url.Values{}
+	if req.SwapMethod() == domain.TokenSwapMethodExactIn {
+		query.Set("tokenIn", req.TokenIn.String())
+		query.Set("tokenOutDenom", formatDenoms(req.TokenOutDenom))
+	} else {
+		query.Set("tokenOut", req.TokenOut.String())
+		query.Set("tokenInDenom", formatDenoms(req.TokenInDenom))
+	}
+	query.Set("poolID", formatIDs(req.PoolID))
+	setBool(query, "humanDenoms", req.HumanDenoms)
+	setBool(query, "applyExponents", req.ApplyExponents)
+
+	quote, err := get[Quote](ctx, c, "/router/custom-direct-quote", query)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	fillQuoteDenoms(&quote, req.SwapMethod())
+
+	return quote, nil
+}
+
+// Routes returns the candidate routes from the token in denom to the token out denom.
+// If humanDenoms is true, the denoms are translated from human to chain denoms by the server.
+func (c *Client) Routes(ctx context.Context, tokenInDenom, tokenOutDenom string, humanDenoms bool, constraints types.CandidateRouteDenomConstraintsRequest) (sqsdomain.CandidateRoutes, error) {
+	query := url.Values{}
+	query.Set("tokenIn", tokenInDenom)
+	query.Set("tokenOutDenom", tokenOutDenom)
+	setBool(query, "humanDenoms", humanDenoms)
+	setCandidateRouteDenomConstraints(query, constraints)
+
+	return get[sqsdomain.CandidateRoutes](ctx, c, "/router/routes", query)
+}
+
+// CachedRoutes returns the cached candidate routes from the token in chain denom to the token out chain denom
+// without computing them if absent.
+func (c *Client) CachedRoutes(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, error) {
+	query := url.Values{}
+	query.Set("tokenIn", tokenInDenom)
+	query.Set("tokenOutDenom", tokenOutDenom)
+
+	return get[sqsdomain.CandidateRoutes](ctx, c, "/router/cached-routes", query)
+}
+
+// SpotPriceForPool returns the spot price of the base asset in terms of the quote asset in the given pool.
+func (c *Client) SpotPriceForPool(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
+	query := url.Values{}
+	query.Set("quoteAsset", quoteAsset)
+	query.Set("baseAsset", baseAsset)
+
+	return get[osmomath.BigDec](ctx, c, "/router/spot-price-pool/"+strconv.FormatUint(poolID, 10), query)
+}
+
+// TakerFee returns the taker fees of the denom pairs of the given pool.
+func (c *Client) TakerFee(ctx context.Context, poolID uint64) ([]sqsdomain.TakerFeeForPair, error) {
+	return get[[]sqsdomain.TakerFeeForPair](ctx, c, "/router/taker-fee-pool/"+strconv.FormatUint(poolID, 10), nil)
+}
+
+// PoolCircuitBreakers returns the circuit breaker states of the pools with recent quote failures.
+func (c *Client) PoolCircuitBreakers(ctx context.Context) ([]domain.PoolCircuitBreakerState, error) {
+	return get[[]domain.PoolCircuitBreakerState](ctx, c, "/router/pool-circuit-breakers", nil)
+}
+
+// fillQuoteDenoms fills in the denom of the quote amount formatted as an integer from the last pool of the route.
+// For the exact amount in swap method, it is the token out denom of the last pool.
+// For the exact amount out swap method, the route is ordered from the token out, so it is the token in denom of the last pool.
+func fillQuoteDenoms(quote *Quote, swapMethod domain.TokenSwapMethod) {
+	if len(quote.Route) == 0 || len(quote.Route[0].Pools) == 0 {
+		return
+	}
+
+	lastPool := quote.Route[0].Pools[len(quote.Route[0].Pools)-1]
+	if swapMethod == domain.TokenSwapMethodExactIn && quote.AmountOut.Denom == "" {
+		quote.AmountOut.Denom = lastPool.TokenOutDenom
+	} else if swapMethod == domain.TokenSwapMethodExactOut && quote.AmountIn.Denom == "" {
+		quote.AmountIn.Denom = lastPool.TokenInDenom
+	}
+}
+
+// setCandidateRouteDenomConstraints sets the query parameters of the given candidate route denom constraints.
+func setCandidateRouteDenomConstraints(query url.Values, constraints types.CandidateRouteDenomConstraintsRequest) {
+	if len(constraints.AllowedIntermediateDenoms) > 0 {
+		query.Set("allowedIntermediateDenoms", formatDenoms(constraints.AllowedIntermediateDenoms))
+	}
+	if len(constraints.ForbiddenIntermediateDenoms) > 0 {
+		query.Set("forbiddenIntermediateDenoms", formatDenoms(constraints.ForbiddenIntermediateDenoms))
+	}
+	if constraints.MustIncludeDenom != "" {
+		query.Set("mustIncludeDenom", constraints.MustIncludeDenom)
+	}
+	setBool(query, "excludeUnlistedTokens", constraints.ExcludeUnlistedTokens)
+}
+
+// setBool sets the given boolean query parameter if it is true.
+func setBool(query url.Values, param string, value bool) {
+	if value {
+		query.Set(param, "true")
+	}
+}
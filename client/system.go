@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/osmosis-labs/sqs/domain"
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+)
+
+// Readiness returns the readiness report of the server.
+// If the server is not ready, the report is returned along with the error.
+// The request is not retried since not being ready is the expected outcome of a probe.
+func (c *Client) Readiness(ctx context.Context) (domain.ReadinessReport, error) {
+	var report domain.ReadinessReport
+	_, err := c.doGet(ctx, c.baseURL+"/health/ready", &report)
+
+	// The not ready response carries the report rather than an error message.
+	var responseErr *Error
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusServiceUnavailable {
+		_ = json.Unmarshal([]byte(responseErr.Message), &report)
+	}
+
+	return report, err
+}
+
+// ArbOpportunities returns the cyclic arbitrage opportunities detected at the latest processed block.
+// If denom is set, only the opportunities starting and ending in the given chain denom are returned.
+func (c *Client) ArbOpportunities(ctx context.Context, denom string) (arbdetectordomain.CyclicArbOpportunities, error) {
+	query := url.Values{}
+	if denom != "" {
+		query.Set("denom", denom)
+	}
+
+	return get[arbdetectordomain.CyclicArbOpportunities](ctx, c, "/arb/opportunities", query)
+}
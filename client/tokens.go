@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// TokenMetadata returns the metadata of the given human or chain denoms by chain denom.
+// Returns the metadata of all tokens if no denoms are given.
+func (c *Client) TokenMetadata(ctx context.Context, denoms ...string) (map[string]domain.Token, error) {
+	query := url.Values{}
+	if len(denoms) > 0 {
+		query.Set("denoms", formatDenoms(denoms))
+	}
+
+	return get[map[string]domain.Token](ctx, c, "/tokens/metadata", query)
+}
+
+// PoolDenomMetadata returns the pool denom metadata of the given denoms by chain denom.
+// If humanDenoms is true, the denoms are translated from human to chain denoms by the server.
+// Returns the metadata of all denoms if no denoms are given.
+func (c *Client) PoolDenomMetadata(ctx context.Context, humanDenoms bool, denoms ...string) (domain.PoolDenomMetaDataMap, error) {
+	query := url.Values{}
+	if len(denoms) > 0 {
+		query.Set("denoms", formatDenoms(denoms))
+	}
+	setBool(query, "humanDenoms", humanDenoms)
+
+	return get[domain.PoolDenomMetaDataMap](ctx, c, "/tokens/pool-metadata", query)
+}
+
+// Prices returns the prices of the given base denoms in terms of the default quote denom
+// of the given pricing source, by base chain denom and quote chain denom.
+// If humanDenoms is true, the base denoms are translated from human to chain denoms by the server.
+func (c *Client) Prices(ctx context.Context, pricingSource domain.PricingSourceType, humanDenoms bool, baseDenoms ...string) (domain.PricesResult, error) {
+	query := url.Values{}
+	query.Set("base", formatDenoms(baseDenoms))
+	query.Set("pricingSource", strconv.Itoa(int(pricingSource)))
+	setBool(query, "humanDenoms", humanDenoms)
+
+	return get[domain.PricesResult](ctx, c, "/tokens/prices", query)
+}
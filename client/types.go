@@ -0,0 +1,127 @@
+package client
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+)
+
+// Quote is the response of the quote and custom direct quote endpoints.
+type Quote struct {
+	// AmountIn is the token swapped in.
+	AmountIn sdk.Coin
+	// AmountOut is the token swapped out.
+	AmountOut sdk.Coin
+	// Route is the split route of the quote.
+	Route []SplitRoute
+	// EffectiveFee is the effective fee of the quote.
+	EffectiveFee osmomath.Dec
+	// PriceImpact is the price impact of the quote.
+	PriceImpact osmomath.Dec
+	// InBaseOutQuoteSpotPrice is the spot price of the token in in terms of the token out.
+	InBaseOutQuoteSpotPrice osmomath.Dec
+	// IsPartial is true if the quote is the best found before the quote time budget was exhausted.
+	IsPartial bool
+}
+
+// quoteJSON is the JSON representation of the quote.
+// The exact amount in quote formats the amount out as an integer and the exact amount out quote
+// formats the amount in as an integer. The missing denom is filled in from the request.
+type quoteJSON struct {
+	AmountIn                json.RawMessage `json:"amount_in"`
+	AmountOut               json.RawMessage `json:"amount_out"`
+	Route                   []SplitRoute    `json:"route"`
+	EffectiveFee            osmomath.Dec    `json:"effective_fee"`
+	PriceImpact             osmomath.Dec    `json:"price_impact"`
+	InBaseOutQuoteSpotPrice osmomath.Dec    `json:"in_base_out_quote_spot_price"`
+	IsPartial               bool            `json:"is_partial,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	var quote quoteJSON
+	if err := json.Unmarshal(data, &quote); err != nil {
+		return err
+	}
+
+	amountIn, err := unmarshalCoinOrAmount(quote.AmountIn)
+	if err != nil {
+		return err
+	}
+
+	amountOut, err := unmarshalCoinOrAmount(quote.AmountOut)
+	if err != nil {
+		return err
+	}
+
+	*q = Quote{
+		AmountIn:                amountIn,
+		AmountOut:               amountOut,
+		Route:                   quote.Route,
+		EffectiveFee:            quote.EffectiveFee,
+		PriceImpact:             quote.PriceImpact,
+		InBaseOutQuoteSpotPrice: quote.InBaseOutQuoteSpotPrice,
+		IsPartial:               quote.IsPartial,
+	}
+
+	return nil
+}
+
+// unmarshalCoinOrAmount unmarshals either a coin or an integer amount, leaving the denom empty.
+func unmarshalCoinOrAmount(data json.RawMessage) (sdk.Coin, error) {
+	var amount osmomath.Int
+	if err := json.Unmarshal(data, &amount); err == nil {
+		return sdk.Coin{Amount: amount}, nil
+	}
+
+	var coin sdk.Coin
+	if err := json.Unmarshal(data, &coin); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return coin, nil
+}
+
+// SplitRoute is a single route of a quote with the amounts swapped over it.
+type SplitRoute struct {
+	// Pools are the pools of the route.
+	Pools []RoutePool `json:"pools"`
+	// HasGeneralizedCosmWasmPool is true if the route contains a generalized cosmwasm pool.
+	HasGeneralizedCosmWasmPool bool `json:"has-cw-pool"`
+	// InAmount is the amount swapped in over the route.
+	InAmount osmomath.Int `json:"in_amount"`
+	// OutAmount is the amount swapped out over the route.
+	OutAmount osmomath.Int `json:"out_amount"`
+}
+
+// RoutePool is a pool of a quote route.
+type RoutePool struct {
+	ID            uint64                    `json:"id"`
+	Type          poolmanagertypes.PoolType `json:"type"`
+	Balances      sdk.Coins                 `json:"balances"`
+	SpreadFactor  osmomath.Dec              `json:"spread_factor"`
+	TokenOutDenom string                    `json:"token_out_denom,omitempty"`
+	TokenInDenom  string                    `json:"token_in_denom,omitempty"`
+	TakerFee      osmomath.Dec              `json:"taker_fee"`
+	CodeID        uint64                    `json:"code_id,omitempty"`
+}
+
+// Pool is the response of the pools endpoint.
+// Mirrors domain.PoolResponse with the chain model left encoded since its domain type is an interface,
+// and the APR and fees data left encoded since their domain types decode the upstream data format.
+type Pool struct {
+	// ChainModel is the JSON-encoded chain model of the pool.
+	ChainModel        json.RawMessage           `json:"chain_model"`
+	Balances          sdk.Coins                 `json:"balances"`
+	Type              poolmanagertypes.PoolType `json:"type"`
+	SpreadFactor      osmomath.Dec              `json:"spread_factor"`
+	LiquidityCap      osmomath.Int              `json:"liquidity_cap"`
+	LiquidityCapError string                    `json:"liquidity_cap_error"`
+
+	// APRData is the JSON-encoded APR data of the pool, if requested with market incentives.
+	APRData json.RawMessage `json:"apr_data,omitempty"`
+	// FeesData is the JSON-encoded fees data of the pool, if requested with market incentives.
+	FeesData json.RawMessage `json:"fees_data,omitempty"`
+}
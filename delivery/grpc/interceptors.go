@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// quoteMethods are the methods that the degraded mode action is applied to.
+var quoteMethods = map[string]struct{}{
+	prototypes.SQSQuery_Quote_FullMethodName:             {},
+	prototypes.SQSQuery_CustomDirectQuote_FullMethodName: {},
+}
+
+// stateSnapshotInterceptor pins the latest state snapshot to the request context so that the request
+// observes the state of a single block for its lifetime. No-op until the first block is ingested.
+func stateSnapshotInterceptor(stateSnapshotHolder *domain.StateSnapshotHolder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if snapshot := stateSnapshotHolder.Load(); snapshot != nil {
+			ctx = domain.ContextWithStateSnapshot(ctx, snapshot)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// heightMetadataInterceptor attaches the height metadata of the response as header metadata,
+// mirroring the HTTP height metadata middleware.
+// If the min height request metadata is set and the response would be served from an older height, it fails the request
+// with FailedPrecondition, or with Unavailable if no block has been ingested yet.
+// Must be chained after the state snapshot interceptor so that the metadata reflects the pinned snapshot.
+func heightMetadataInterceptor(chainInfoUsecase mvc.ChainInfoUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		heightMetadata := chainInfoUsecase.GetHeightMetadata(ctx)
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(
+			domain.StateSnapshotHeightHeader, strconv.FormatUint(heightMetadata.SnapshotHeight, 10),
+			domain.IngestHeightHeader, strconv.FormatUint(heightMetadata.IngestHeight, 10),
+			domain.PricingHeightHeader, strconv.FormatUint(heightMetadata.PricingHeight, 10),
+			domain.SnapshotAgeHeader, strconv.FormatInt(heightMetadata.SnapshotAgeMs, 10),
+		)); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		minHeights := metadata.ValueFromIncomingContext(ctx, domain.MinHeightMetadataKey)
+		if len(minHeights) == 0 {
+			return handler(ctx, req)
+		}
+
+		minHeight, err := strconv.ParseUint(minHeights[0], 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid min height: "+err.Error())
+		}
+
+		if heightMetadata.SnapshotHeight < minHeight {
+			err := domain.MinHeightNotReachedError{MinHeight: minHeight, Height: heightMetadata.SnapshotHeight}
+
+			if heightMetadata.SnapshotHeight == 0 {
+				return nil, status.Error(codes.Unavailable, err.Error())
+			}
+
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// degradedModeInterceptor applies the configured degraded mode action to the quote methods
+// while the detected chain status is not healthy, mirroring the HTTP degraded mode middleware:
+// - "warn" adds a warning header.
+// - "widen-slippage" adds a warning header and widens the reported price impact by the configured factor.
+// - "refuse" fails the request with Unavailable.
+func degradedModeInterceptor(chainStatusGetter domain.ChainStatusGetter, config domain.DegradedModeConfig) grpc.UnaryServerInterceptor {
+	slippageWideningFactor := osmomath.MustNewDecFromStr(strconv.FormatFloat(config.SlippageWideningFactor, 'f', -1, 64))
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := quoteMethods[info.FullMethod]; !ok || config.Action == domain.DegradedModeActionNone {
+			return handler(ctx, req)
+		}
+
+		chainStatus := chainStatusGetter.GetChainStatus()
+		if chainStatus.Status == domain.ChainStatusHealthy {
+			return handler(ctx, req)
+		}
+
+		domain.SQSDegradedModeQuotesCounter.WithLabelValues(config.Action, string(chainStatus.Status)).Inc()
+
+		warning := fmt.Sprintf("%s: %s", chainStatus.Status, chainStatus.Message)
+
+		switch config.Action {
+		case domain.DegradedModeActionRefuse:
+			return nil, status.Error(codes.Unavailable, "quotes are unavailable in degraded mode, "+warning)
+		case domain.DegradedModeActionWidenSlippage:
+			ctx = domain.ContextWithSlippageWideningFactor(ctx, slippageWideningFactor)
+		}
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(domain.DegradedModeWarningHeader, warning)); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,511 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/types"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// QueryGRPCHandler serves the gRPC query API.
+// It mirrors the HTTP endpoints and is backed by the same usecases.
+type QueryGRPCHandler struct {
+	logger log.Logger
+
+	routerUsecase      mvc.RouterUsecase
+	poolsUsecase       mvc.PoolsUsecase
+	tokensUsecase      mvc.TokensUsecase
+	passthroughUsecase mvc.PassthroughUsecase
+
+	defaultQuoteChainDenom string
+
+	prototypes.UnimplementedSQSQueryServer
+}
+
+var _ prototypes.SQSQueryServer = &QueryGRPCHandler{}
+
+var oneDec = osmomath.OneDec()
+
+// NewQueryGRPCHandler will initialize the gRPC query server.
+// Same as the HTTP requests, every request observes the state snapshot pinned from the given holder
+// and reports its height metadata, and the degraded mode action is applied to the quotes.
+// Server reflection is registered so that the API can be discovered by generic clients.
+func NewQueryGRPCHandler(routerUsecase mvc.RouterUsecase, poolsUsecase mvc.PoolsUsecase, tokensUsecase mvc.TokensUsecase, passthroughUsecase mvc.PassthroughUsecase, chainInfoUsecase mvc.ChainInfoUsecase, chainStatusGetter domain.ChainStatusGetter, stateSnapshotHolder *domain.StateSnapshotHolder, pricingConfig domain.PricingConfig, degradedModeConfig domain.DegradedModeConfig, grpcQueryConfig domain.GRPCQueryConfig, logger log.Logger) (*grpc.Server, error) {
+	defaultQuoteChainDenom, err := tokensUsecase.GetChainDenom(pricingConfig.DefaultQuoteHumanDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	queryHandler := &QueryGRPCHandler{
+		logger:             logger,
+		routerUsecase:      routerUsecase,
+		poolsUsecase:       poolsUsecase,
+		tokensUsecase:      tokensUsecase,
+		passthroughUsecase: passthroughUsecase,
+
+		defaultQuoteChainDenom: defaultQuoteChainDenom,
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(grpcQueryConfig.MaxReceiveMsgSizeBytes),
+		grpc.ConnectionTimeout(time.Second*time.Duration(grpcQueryConfig.ServerConnectionTimeoutSeconds)),
+		grpc.ChainUnaryInterceptor(
+			stateSnapshotInterceptor(stateSnapshotHolder),
+			heightMetadataInterceptor(chainInfoUsecase),
+			degradedModeInterceptor(chainStatusGetter, degradedModeConfig),
+		),
+	)
+	prototypes.RegisterSQSQueryServer(grpcServer, queryHandler)
+	reflection.Register(grpcServer)
+
+	return grpcServer, nil
+}
+
+// Quote implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Quote(ctx context.Context, req *prototypes.QueryQuoteRequest) (*prototypes.QueryQuoteReply, error) {
+	var quoteReq types.GetQuoteRequest
+	if err := quoteReq.UnmarshalGRPCRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := quoteReq.Validate(uint64(h.routerUsecase.GetConfig().MaxQuoteTimeBudgetMs)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		tokenIn       *sdk.Coin
+		tokenOutDenom string
+	)
+
+	if quoteReq.SwapMethod() == domain.TokenSwapMethodExactIn {
+		tokenIn, tokenOutDenom = quoteReq.TokenIn, quoteReq.TokenOutDenom
+	} else {
+		tokenIn, tokenOutDenom = quoteReq.TokenOut, quoteReq.TokenInDenom
+	}
+
+	chainDenoms, err := mvc.ValidateChainDenoms(h.tokensUsecase, append([]string{tokenIn.Denom, tokenOutDenom}, quoteReq.Denoms()...), quoteReq.HumanDenoms)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Update coins token in denom it case it was translated from human to chain.
+	tokenIn.Denom = chainDenoms[0]
+	tokenOutDenom = chainDenoms[1]
+
+	var routerOpts []domain.RouterOption
+	if quoteReq.SingleRoute {
+		routerOpts = append(routerOpts, domain.WithMaxSplitRoutes(domain.DisableSplitRoutes))
+	}
+
+	if denomConstraints := quoteReq.ToDomain(chainDenoms[2:]); !denomConstraints.IsEmpty() {
+		routerOpts = append(routerOpts, domain.WithCandidateRouteDenomConstraints(denomConstraints))
+	}
+
+	if quoteReq.TimeBudgetMs > 0 {
+		routerOpts = append(routerOpts, domain.WithQuoteTimeBudget(time.Duration(quoteReq.TimeBudgetMs)*time.Millisecond))
+	}
+
+	var quote domain.Quote
+	if quoteReq.SwapMethod() == domain.TokenSwapMethodExactIn {
+		quote, err = h.routerUsecase.GetOptimalQuote(ctx, *tokenIn, tokenOutDenom, routerOpts...)
+	} else {
+		quote, err = h.routerUsecase.GetOptimalQuoteInGivenOut(ctx, *tokenIn, tokenOutDenom, routerOpts...)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return h.prepareQuoteReply(ctx, quote, quoteReq.ApplyExponents, tokenIn.Denom, tokenOutDenom)
+}
+
+// CustomDirectQuote implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) CustomDirectQuote(ctx context.Context, req *prototypes.QueryCustomDirectQuoteRequest) (*prototypes.QueryQuoteReply, error) {
+	var quoteReq types.GetDirectCustomQuoteRequest
+	if err := quoteReq.UnmarshalGRPCRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := quoteReq.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		tokenIn       *sdk.Coin
+		tokenOutDenom []string
+	)
+
+	if quoteReq.SwapMethod() == domain.TokenSwapMethodExactIn {
+		tokenIn, tokenOutDenom = quoteReq.TokenIn, quoteReq.TokenOutDenom
+	} else {
+		tokenIn, tokenOutDenom = quoteReq.TokenOut, quoteReq.TokenInDenom
+	}
+
+	chainDenoms, err := mvc.ValidateChainDenoms(h.tokensUsecase, append([]string{tokenIn.Denom}, tokenOutDenom...), quoteReq.HumanDenoms)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Update coins token in denom it case it was translated from human to chain.
+	tokenIn.Denom = chainDenoms[0]
+	tokenOutDenom = chainDenoms[1:]
+
+	var quote domain.Quote
+	if quoteReq.SwapMethod() == domain.TokenSwapMethodExactIn {
+		quote, err = h.routerUsecase.GetCustomDirectQuoteMultiPool(ctx, *tokenIn, tokenOutDenom, quoteReq.PoolID)
+	} else {
+		quote, err = h.routerUsecase.GetCustomDirectQuoteMultiPoolInGivenOut(ctx, *tokenIn, tokenOutDenom, quoteReq.PoolID)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return h.prepareQuoteReply(ctx, quote, quoteReq.ApplyExponents, tokenIn.Denom, tokenOutDenom[len(tokenOutDenom)-1])
+}
+
+// Routes implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Routes(ctx context.Context, req *prototypes.QueryRoutesRequest) (*prototypes.QueryRoutesReply, error) {
+	if req.TokenInDenom == "" {
+		return nil, status.Error(codes.InvalidArgument, types.ErrTokenInDenomNotSpecified.Error())
+	}
+
+	if req.TokenOutDenom == "" {
+		return nil, status.Error(codes.InvalidArgument, types.ErrTokenOutDenomNotSpecified.Error())
+	}
+
+	var denomConstraintsReq types.CandidateRouteDenomConstraintsRequest
+	denomConstraintsReq.UnmarshalGRPCRequest(req.AllowedIntermediateDenoms, req.ForbiddenIntermediateDenoms, req.MustIncludeDenom, req.ExcludeUnlistedTokens)
+
+	chainDenoms, err := mvc.ValidateChainDenoms(h.tokensUsecase, append([]string{req.TokenInDenom, req.TokenOutDenom}, denomConstraintsReq.Denoms()...), req.HumanDenoms)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var routerOpts []domain.RouterOption
+	if denomConstraints := denomConstraintsReq.ToDomain(chainDenoms[2:]); !denomConstraints.IsEmpty() {
+		routerOpts = append(routerOpts, domain.WithCandidateRouteDenomConstraints(denomConstraints))
+	}
+
+	candidateRoutes, err := h.routerUsecase.GetCandidateRoutes(ctx, sdk.NewCoin(chainDenoms[0], osmomath.OneInt()), chainDenoms[1], routerOpts...)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	routes := make([]*prototypes.QueryCandidateRoute, 0, len(candidateRoutes.Routes))
+	for _, candidateRoute := range candidateRoutes.Routes {
+		pools := make([]*prototypes.QueryCandidatePool, 0, len(candidateRoute.Pools))
+		for _, pool := range candidateRoute.Pools {
+			pools = append(pools, &prototypes.QueryCandidatePool{
+				Id:            pool.ID,
+				TokenOutDenom: pool.TokenOutDenom,
+			})
+		}
+
+		routes = append(routes, &prototypes.QueryCandidateRoute{
+			Pools:                     pools,
+			IsCanonicalOrderbookRoute: candidateRoute.IsCanonicalOrderboolRoute,
+		})
+	}
+
+	return &prototypes.QueryRoutesReply{Routes: routes}, nil
+}
+
+// Pools implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Pools(ctx context.Context, req *prototypes.QueryPoolsRequest) (*prototypes.QueryPoolsReply, error) {
+	filters := []domain.PoolsOption{
+		domain.WithMinPoolsLiquidityCap(req.MinLiquidityCap),
+		domain.WithMarketIncentives(req.WithMarketIncentives),
+	}
+
+	// Only add pool ID filter if it is not empty.
+	if len(req.PoolIds) > 0 {
+		filters = append(filters, domain.WithPoolIDFilter(req.PoolIds))
+	}
+
+	pools, err := h.poolsUsecase.GetPools(filters...)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := make([]*prototypes.QueryPool, 0, len(pools))
+	for _, pool := range pools {
+		poolResponse := domain.NewPoolResponse(pool)
+
+		chainModel, err := json.Marshal(poolResponse.ChainModel)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		result = append(result, &prototypes.QueryPool{
+			Id:                pool.GetId(),
+			Type:              int32(poolResponse.Type),
+			ChainModel:        chainModel,
+			Balances:          poolResponse.Balances.String(),
+			SpreadFactor:      poolResponse.SpreadFactor.String(),
+			LiquidityCap:      poolResponse.LiquidityCap.String(),
+			LiquidityCapError: poolResponse.LiquidityCapError,
+		})
+	}
+
+	return &prototypes.QueryPoolsReply{Pools: result}, nil
+}
+
+// Ticks implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Ticks(ctx context.Context, req *prototypes.QueryTicksRequest) (*prototypes.QueryTicksReply, error) {
+	tickModels, err := h.poolsUsecase.GetTickModelMap([]uint64{req.PoolId})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	tickModel, ok := tickModels[req.PoolId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "tick model not found for given pool")
+	}
+
+	ticks := make([]*prototypes.QueryTickRange, 0, len(tickModel.Ticks))
+	for _, tick := range tickModel.Ticks {
+		ticks = append(ticks, &prototypes.QueryTickRange{
+			LowerTick:       tick.LowerTick,
+			UpperTick:       tick.UpperTick,
+			LiquidityAmount: tick.LiquidityAmount.String(),
+		})
+	}
+
+	return &prototypes.QueryTicksReply{
+		Ticks:            ticks,
+		CurrentTickIndex: tickModel.CurrentTickIndex,
+		HasNoLiquidity:   tickModel.HasNoLiquidity,
+	}, nil
+}
+
+// TokenMetadata implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) TokenMetadata(ctx context.Context, req *prototypes.QueryTokenMetadataRequest) (*prototypes.QueryTokenMetadataReply, error) {
+	var tokenMetadata map[string]domain.Token
+	if len(req.Denoms) == 0 {
+		var err error
+		tokenMetadata, err = h.tokensUsecase.GetFullTokenMetadata()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else {
+		tokenMetadata = make(map[string]domain.Token, len(req.Denoms))
+		for _, denom := range req.Denoms {
+			if err := sdk.ValidateDenom(denom); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+
+			chainDenom, token, err := h.getTokenMetadata(denom)
+			if err != nil {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+
+			tokenMetadata[chainDenom] = token
+		}
+	}
+
+	tokens := make([]*prototypes.QueryToken, 0, len(tokenMetadata))
+	for chainDenom, token := range tokenMetadata {
+		tokens = append(tokens, &prototypes.QueryToken{
+			ChainDenom:  chainDenom,
+			HumanDenom:  token.HumanDenom,
+			Name:        token.Name,
+			Precision:   int32(token.Precision),
+			IsUnlisted:  token.IsUnlisted,
+			CoingeckoId: token.CoingeckoID,
+		})
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ChainDenom < tokens[j].ChainDenom
+	})
+
+	return &prototypes.QueryTokenMetadataReply{Tokens: tokens}, nil
+}
+
+// Prices implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Prices(ctx context.Context, req *prototypes.QueryPricesRequest) (*prototypes.QueryPricesReply, error) {
+	if len(req.BaseDenoms) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "base denoms must be non-empty")
+	}
+
+	if !h.tokensUsecase.IsValidPricingSource(int(req.PricingSource)) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid pricing source: %d", req.PricingSource))
+	}
+	pricingSourceType := domain.PricingSourceType(req.PricingSource)
+
+	// Coingecko pricing source is quoted in its configured quote currency.
+	var quoteDenom string
+	if pricingSourceType == domain.ChainPricingSourceType {
+		quoteDenom = h.defaultQuoteChainDenom
+	}
+
+	baseDenoms, err := mvc.ValidateChainDenoms(h.tokensUsecase, req.BaseDenoms, req.HumanDenoms)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prices, err := h.tokensUsecase.GetPrices(ctx, baseDenoms, []string{quoteDenom}, pricingSourceType)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	result := make([]*prototypes.QueryPrice, 0, len(baseDenoms))
+	for _, baseDenom := range baseDenoms {
+		result = append(result, &prototypes.QueryPrice{
+			BaseDenom:  baseDenom,
+			QuoteDenom: quoteDenom,
+			Price:      prices.GetPriceForDenom(baseDenom, quoteDenom).String(),
+		})
+	}
+
+	return &prototypes.QueryPricesReply{Prices: result}, nil
+}
+
+// Portfolio implements types.SQSQueryServer.
+func (h *QueryGRPCHandler) Portfolio(ctx context.Context, req *prototypes.QueryPortfolioRequest) (*prototypes.QueryPortfolioReply, error) {
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid address: cannot be empty")
+	}
+
+	portfolioAssets, err := h.passthroughUsecase.GetPortfolioAssets(ctx, req.Address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	categories := make([]*prototypes.QueryPortfolioCategory, 0, len(portfolioAssets.Categories))
+	for name, category := range portfolioAssets.Categories {
+		accountCoins := make([]*prototypes.QueryAccountCoin, 0, len(category.AccountCoinsResult))
+		for _, accountCoin := range category.AccountCoinsResult {
+			accountCoins = append(accountCoins, &prototypes.QueryAccountCoin{
+				Coin:     accountCoin.Coin.String(),
+				CapValue: accountCoin.CapitalizationValue.String(),
+			})
+		}
+
+		categories = append(categories, &prototypes.QueryPortfolioCategory{
+			Name:           name,
+			Capitalization: category.Capitalization.String(),
+			AccountCoins:   accountCoins,
+			IsBestEffort:   category.IsBestEffort,
+		})
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	return &prototypes.QueryPortfolioReply{Categories: categories}, nil
+}
+
+// getTokenMetadata returns the chain denom and metadata of the given chain or human denom.
+func (h *QueryGRPCHandler) getTokenMetadata(denom string) (string, domain.Token, error) {
+	tokenMetadata, err := h.tokensUsecase.GetMetadataByChainDenom(denom)
+	if err == nil {
+		return denom, tokenMetadata, nil
+	}
+
+	// If we fail to get metadata by chain denom, assume we are given a human denom and try to translate it.
+	chainDenom, err := h.tokensUsecase.GetChainDenom(denom)
+	if err != nil {
+		return "", domain.Token{}, err
+	}
+
+	tokenMetadata, err = h.tokensUsecase.GetMetadataByChainDenom(chainDenom)
+	if err != nil {
+		return "", domain.Token{}, err
+	}
+
+	return chainDenom, tokenMetadata, nil
+}
+
+// prepareQuoteReply prepares the quote for output and converts it to the reply.
+func (h *QueryGRPCHandler) prepareQuoteReply(ctx context.Context, quote domain.Quote, applyExponents bool, tokenInDenom, tokenOutDenom string) (*prototypes.QueryQuoteReply, error) {
+	scalingFactor := oneDec
+	if applyExponents {
+		var err error
+		scalingFactor, err = h.tokensUsecase.GetSpotPriceScalingFactorByDenom(tokenOutDenom, tokenInDenom)
+		if err != nil {
+			// Note that we do not fail the quote if scaling factor fetching fails.
+			// Instead, we simply set it to zero to invalidate spot price downstream.
+			scalingFactor = osmomath.ZeroDec()
+		}
+	}
+
+	if _, _, err := quote.PrepareResult(ctx, scalingFactor, h.logger); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	routes := make([]*prototypes.QueryQuoteRoute, 0, len(quote.GetRoute()))
+	for _, route := range quote.GetRoute() {
+		pools := make([]*prototypes.QueryQuotePool, 0, len(route.GetPools()))
+		for _, pool := range route.GetPools() {
+			pools = append(pools, &prototypes.QueryQuotePool{
+				Id:            pool.GetId(),
+				Type:          int32(pool.GetType()),
+				TokenInDenom:  pool.GetTokenInDenom(),
+				TokenOutDenom: pool.GetTokenOutDenom(),
+				SpreadFactor:  pool.GetSpreadFactor().String(),
+				TakerFee:      pool.GetTakerFee().String(),
+			})
+		}
+
+		routes = append(routes, &prototypes.QueryQuoteRoute{
+			Pools:     pools,
+			InAmount:  route.GetAmountIn().String(),
+			OutAmount: route.GetAmountOut().String(),
+		})
+	}
+
+	return &prototypes.QueryQuoteReply{
+		AmountIn:                quote.GetAmountIn().String(),
+		AmountOut:               quote.GetAmountOut().String(),
+		Routes:                  routes,
+		EffectiveFee:            quote.GetEffectiveFee().String(),
+		PriceImpact:             quote.GetPriceImpact().String(),
+		InBaseOutQuoteSpotPrice: quote.GetInBaseOutQuoteSpotPrice().String(),
+		IsPartial:               quote.GetIsPartial(),
+	}, nil
+}
+
+// toStatusError converts the given usecase error to a gRPC status error.
+// Both domain.ErrNotFound and the typed not found errors are converted to NotFound.
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case isNotFoundError(err):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// isNotFoundError returns true if the given error is domain.ErrNotFound or one of the typed not found errors.
+func isNotFoundError(err error) bool {
+	var (
+		poolNotFoundErr           domain.PoolNotFoundError
+		takerFeeNotFoundErr       domain.TakerFeeNotFoundForDenomPairError
+		denomLiquidityNotFoundErr domain.DenomPoolLiquidityDataNotFoundError
+	)
+
+	return errors.Is(err, domain.ErrNotFound) ||
+		errors.As(err, &poolNotFoundErr) ||
+		errors.As(err, &takerFeeNotFoundErr) ||
+		errors.As(err, &denomLiquidityNotFoundErr)
+}
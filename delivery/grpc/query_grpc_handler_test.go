@@ -0,0 +1,482 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	querygrpc "github.com/osmosis-labs/sqs/delivery/grpc"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+const (
+	uosmo = "uosmo"
+	uusdc = "ibc/usdc"
+)
+
+// staticChainStatusGetter is a chain status getter returning a fixed chain status.
+type staticChainStatusGetter domain.ChainStatusReport
+
+// GetChainStatus implements domain.ChainStatusGetter.
+func (g staticChainStatusGetter) GetChainStatus() domain.ChainStatusReport {
+	return domain.ChainStatusReport(g)
+}
+
+// newQueryClient starts the gRPC query server over an in-memory listener with a healthy chain
+// and returns a client connected to it.
+func newQueryClient(t *testing.T, routerUsecase *mocks.RouterUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) prototypes.SQSQueryClient {
+	return newQueryClientWithChainStatus(t, routerUsecase, tokensUsecase, &mocks.ChainInfoUsecaseMock{}, domain.ChainStatusReport{Status: domain.ChainStatusHealthy}, *domain.DefaultConfig.DegradedMode)
+}
+
+// newQueryClientWithChainStatus starts the gRPC query server over an in-memory listener with the given
+// chain info usecase, chain status and degraded mode config, and returns a client connected to it.
+func newQueryClientWithChainStatus(t *testing.T, routerUsecase *mocks.RouterUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock, chainInfoUsecase *mocks.ChainInfoUsecaseMock, chainStatus domain.ChainStatusReport, degradedModeConfig domain.DegradedModeConfig) prototypes.SQSQueryClient {
+	grpcServer, err := querygrpc.NewQueryGRPCHandler(routerUsecase, &mocks.PoolsUsecaseMock{}, tokensUsecase, nil, chainInfoUsecase, staticChainStatusGetter(chainStatus), domain.NewStateSnapshotHolder(), domain.PricingConfig{DefaultQuoteHumanDenom: "usdc"}, degradedModeConfig, *domain.DefaultConfig.GRPCQuery, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return prototypes.NewSQSQueryClient(conn)
+}
+
+// TestQuote tests that the gRPC quote shares validation with the HTTP request
+// and converts the quote computed by the router usecase.
+func TestQuote(t *testing.T) {
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			if humanDenom == "usdc" {
+				return uusdc, nil
+			}
+			return "", errors.New("unknown human denom")
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return chainDenom == uosmo || chainDenom == uusdc
+		},
+	}
+
+	quote := &mocks.QuoteMock{
+		GetAmountInFunc:                func() sdk.Coin { return sdk.NewCoin(uosmo, osmomath.NewInt(1000)) },
+		GetAmountOutFunc:               func() osmomath.Int { return osmomath.NewInt(500) },
+		GetRouteFunc:                   func() []domain.SplitRoute { return nil },
+		GetEffectiveFeeFunc:            func() osmomath.Dec { return osmomath.MustNewDecFromStr("0.002") },
+		GetPriceImpactFunc:             func() osmomath.Dec { return osmomath.MustNewDecFromStr("-0.01") },
+		GetInBaseOutQuoteSpotPriceFunc: func() osmomath.Dec { return osmomath.MustNewDecFromStr("0.5") },
+		GetIsPartialFunc:               func() bool { return true },
+		PrepareResultFunc: func(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
+			return nil, osmomath.Dec{}, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *prototypes.QueryQuoteRequest
+		routerErr error
+
+		expectedCode  codes.Code
+		expectedReply *prototypes.QueryQuoteReply
+	}{
+		{
+			name: "exact amount in",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+
+			expectedCode: codes.OK,
+			expectedReply: &prototypes.QueryQuoteReply{
+				AmountIn:                "1000uosmo",
+				AmountOut:               "500",
+				Routes:                  []*prototypes.QueryQuoteRoute{},
+				EffectiveFee:            "0.002000000000000000",
+				PriceImpact:             "-0.010000000000000000",
+				InBaseOutQuoteSpotPrice: "0.500000000000000000",
+				IsPartial:               true,
+			},
+		},
+		{
+			name: "human denoms",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: "usdc", HumanDenoms: true},
+
+			expectedCode: codes.OK,
+		},
+		{
+			name: "invalid token in",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "uosmo", TokenOutDenom: uusdc},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "both swap methods",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc, TokenOut: "1000ibc/usdc", TokenInDenom: uosmo},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "time budget",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc, TimeBudgetMs: 1000},
+
+			expectedCode: codes.OK,
+		},
+		{
+			name: "time budget above max",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc, TimeBudgetMs: math.MaxUint64},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid chain denom",
+			req:  &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: "uatom"},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:      "pool not found",
+			req:       &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+			routerErr: fmt.Errorf("failed to get route: %w", domain.PoolNotFoundError{PoolID: 1}),
+
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:      "taker fee not found",
+			req:       &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+			routerErr: domain.TakerFeeNotFoundForDenomPairError{Denom0: uosmo, Denom1: uusdc},
+
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:      "internal error",
+			req:       &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+			routerErr: errors.New("internal error"),
+
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			routerUsecase := &mocks.RouterUsecaseMock{
+				GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+					require.Equal(t, uosmo, tokenIn.Denom)
+					require.Equal(t, uusdc, tokenOutDenom)
+					if tc.routerErr != nil {
+						return nil, tc.routerErr
+					}
+					return quote, nil
+				},
+				GetConfigFunc: func() domain.RouterConfig {
+					return domain.RouterConfig{MaxQuoteTimeBudgetMs: 1000}
+				},
+			}
+
+			client := newQueryClient(t, routerUsecase, tokensUsecase)
+
+			reply, err := client.Quote(context.Background(), tc.req)
+			require.Equal(t, tc.expectedCode, status.Code(err))
+
+			if tc.expectedReply != nil {
+				require.Equal(t, tc.expectedReply.AmountIn, reply.AmountIn)
+				require.Equal(t, tc.expectedReply.AmountOut, reply.AmountOut)
+				require.Len(t, reply.Routes, len(tc.expectedReply.Routes))
+				require.Equal(t, tc.expectedReply.EffectiveFee, reply.EffectiveFee)
+				require.Equal(t, tc.expectedReply.PriceImpact, reply.PriceImpact)
+				require.Equal(t, tc.expectedReply.InBaseOutQuoteSpotPrice, reply.InBaseOutQuoteSpotPrice)
+				require.Equal(t, tc.expectedReply.IsPartial, reply.IsPartial)
+			}
+		})
+	}
+}
+
+// TestHeightMetadataInterceptor tests that the height metadata is reported as header metadata
+// and that requests are failed if the min height request metadata is not reached.
+func TestHeightMetadataInterceptor(t *testing.T) {
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return uusdc, nil
+		},
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return map[string]domain.Token{}, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		snapshotHeight uint64
+		minHeight      string
+
+		expectedCode codes.Code
+	}{
+		{
+			name:           "no min height",
+			snapshotHeight: 10,
+
+			expectedCode: codes.OK,
+		},
+		{
+			name:           "min height reached",
+			snapshotHeight: 10,
+			minHeight:      "10",
+
+			expectedCode: codes.OK,
+		},
+		{
+			name:           "min height not reached",
+			snapshotHeight: 10,
+			minHeight:      "11",
+
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:      "no block ingested yet",
+			minHeight: "1",
+
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:           "invalid min height",
+			snapshotHeight: 10,
+			minHeight:      "abc",
+
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			chainInfoUsecase := &mocks.ChainInfoUsecaseMock{
+				GetHeightMetadataFunc: func(ctx context.Context) domain.HeightMetadata {
+					return domain.HeightMetadata{
+						IngestHeight:   tc.snapshotHeight + 1,
+						PricingHeight:  tc.snapshotHeight,
+						SnapshotHeight: tc.snapshotHeight,
+						SnapshotAgeMs:  100,
+					}
+				},
+			}
+
+			client := newQueryClientWithChainStatus(t, &mocks.RouterUsecaseMock{}, tokensUsecase, chainInfoUsecase, domain.ChainStatusReport{Status: domain.ChainStatusHealthy}, *domain.DefaultConfig.DegradedMode)
+
+			ctx := context.Background()
+			if tc.minHeight != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, domain.MinHeightMetadataKey, tc.minHeight)
+			}
+
+			var header metadata.MD
+			_, err := client.TokenMetadata(ctx, &prototypes.QueryTokenMetadataRequest{}, grpc.Header(&header))
+			require.Equal(t, tc.expectedCode, status.Code(err))
+
+			// The height metadata is reported even if the request fails.
+			require.Equal(t, []string{strconv.FormatUint(tc.snapshotHeight, 10)}, header.Get(domain.StateSnapshotHeightHeader))
+			require.Equal(t, []string{strconv.FormatUint(tc.snapshotHeight+1, 10)}, header.Get(domain.IngestHeightHeader))
+			require.Equal(t, []string{strconv.FormatUint(tc.snapshotHeight, 10)}, header.Get(domain.PricingHeightHeader))
+			require.Equal(t, []string{"100"}, header.Get(domain.SnapshotAgeHeader))
+		})
+	}
+}
+
+// TestDegradedModeInterceptor tests that the degraded mode action is applied to the quote methods only
+// while the chain status is not healthy.
+func TestDegradedModeInterceptor(t *testing.T) {
+	chainHalt := domain.ChainStatusReport{Status: domain.ChainStatusChainHalt, Message: "no block produced"}
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return uusdc, nil
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return chainDenom == uosmo || chainDenom == uusdc
+		},
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return map[string]domain.Token{}, nil
+		},
+	}
+
+	quote := &mocks.QuoteMock{
+		GetAmountInFunc:                func() sdk.Coin { return sdk.NewCoin(uosmo, osmomath.NewInt(1000)) },
+		GetAmountOutFunc:               func() osmomath.Int { return osmomath.NewInt(500) },
+		GetRouteFunc:                   func() []domain.SplitRoute { return nil },
+		GetEffectiveFeeFunc:            func() osmomath.Dec { return osmomath.ZeroDec() },
+		GetPriceImpactFunc:             func() osmomath.Dec { return osmomath.ZeroDec() },
+		GetInBaseOutQuoteSpotPriceFunc: func() osmomath.Dec { return osmomath.OneDec() },
+		GetIsPartialFunc:               func() bool { return false },
+		PrepareResultFunc: func(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
+			return nil, osmomath.Dec{}, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		action      string
+		chainStatus domain.ChainStatusReport
+
+		expectedCode                   codes.Code
+		expectedWarning                string
+		expectedSlippageWideningFactor string
+	}{
+		{
+			name:        "healthy",
+			action:      domain.DegradedModeActionRefuse,
+			chainStatus: domain.ChainStatusReport{Status: domain.ChainStatusHealthy},
+
+			expectedCode: codes.OK,
+		},
+		{
+			name:        "none",
+			action:      domain.DegradedModeActionNone,
+			chainStatus: chainHalt,
+
+			expectedCode: codes.OK,
+		},
+		{
+			name:        "warn",
+			action:      domain.DegradedModeActionWarn,
+			chainStatus: chainHalt,
+
+			expectedCode:    codes.OK,
+			expectedWarning: "chain_halt: no block produced",
+		},
+		{
+			name:        "widen slippage",
+			action:      domain.DegradedModeActionWidenSlippage,
+			chainStatus: chainHalt,
+
+			expectedCode:                   codes.OK,
+			expectedWarning:                "chain_halt: no block produced",
+			expectedSlippageWideningFactor: "2.000000000000000000",
+		},
+		{
+			name:        "refuse",
+			action:      domain.DegradedModeActionRefuse,
+			chainStatus: chainHalt,
+
+			expectedCode: codes.Unavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var actualSlippageWideningFactor string
+			routerUsecase := &mocks.RouterUsecaseMock{
+				GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+					if factor, ok := domain.GetSlippageWideningFactor(ctx); ok {
+						actualSlippageWideningFactor = factor.String()
+					}
+					return quote, nil
+				},
+			}
+
+			degradedModeConfig := *domain.DefaultConfig.DegradedMode
+			degradedModeConfig.Action = tc.action
+
+			client := newQueryClientWithChainStatus(t, routerUsecase, tokensUsecase, &mocks.ChainInfoUsecaseMock{}, tc.chainStatus, degradedModeConfig)
+
+			var header metadata.MD
+			_, err := client.Quote(context.Background(), &prototypes.QueryQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc}, grpc.Header(&header))
+			require.Equal(t, tc.expectedCode, status.Code(err))
+			require.Equal(t, tc.expectedSlippageWideningFactor, actualSlippageWideningFactor)
+
+			if tc.expectedWarning != "" {
+				require.Equal(t, []string{tc.expectedWarning}, header.Get(domain.DegradedModeWarningHeader))
+			} else {
+				require.Empty(t, header.Get(domain.DegradedModeWarningHeader))
+			}
+
+			// Non-quote methods are served regardless of the chain status.
+			_, err = client.TokenMetadata(context.Background(), &prototypes.QueryTokenMetadataRequest{})
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestTokenMetadata tests that the token metadata is returned by chain or human denom, sorted by chain denom.
+func TestTokenMetadata(t *testing.T) {
+	tokens := map[string]domain.Token{
+		uosmo: {Name: "Osmosis", HumanDenom: "osmo", Precision: 6},
+		uusdc: {Name: "USD Coin", HumanDenom: "usdc", Precision: 6},
+	}
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			for chainDenom, token := range tokens {
+				if token.HumanDenom == humanDenom {
+					return chainDenom, nil
+				}
+			}
+			return "", errors.New("unknown human denom")
+		},
+		GetMetadataByChainDenomFunc: func(denom string) (domain.Token, error) {
+			token, ok := tokens[denom]
+			if !ok {
+				return domain.Token{}, errors.New("unknown chain denom")
+			}
+			return token, nil
+		},
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return tokens, nil
+		},
+	}
+
+	client := newQueryClient(t, &mocks.RouterUsecaseMock{}, tokensUsecase)
+
+	reply, err := client.TokenMetadata(context.Background(), &prototypes.QueryTokenMetadataRequest{})
+	require.NoError(t, err)
+	require.Len(t, reply.Tokens, 2)
+	require.Equal(t, uusdc, reply.Tokens[0].ChainDenom)
+	require.Equal(t, uosmo, reply.Tokens[1].ChainDenom)
+
+	reply, err = client.TokenMetadata(context.Background(), &prototypes.QueryTokenMetadataRequest{Denoms: []string{"osmo"}})
+	require.NoError(t, err)
+	require.Len(t, reply.Tokens, 1)
+	require.Equal(t, uosmo, reply.Tokens[0].ChainDenom)
+	require.Equal(t, "Osmosis", reply.Tokens[0].Name)
+
+	_, err = client.TokenMetadata(context.Background(), &prototypes.QueryTokenMetadataRequest{Denoms: []string{"atom"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestServerReflection tests that the server reflection is registered next to the query service.
+func TestServerReflection(t *testing.T) {
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return uusdc, nil
+		},
+	}
+
+	grpcServer, err := querygrpc.NewQueryGRPCHandler(&mocks.RouterUsecaseMock{}, &mocks.PoolsUsecaseMock{}, tokensUsecase, nil, &mocks.ChainInfoUsecaseMock{}, staticChainStatusGetter{}, domain.NewStateSnapshotHolder(), domain.PricingConfig{DefaultQuoteHumanDenom: "usdc"}, *domain.DefaultConfig.DegradedMode, *domain.DefaultConfig.GRPCQuery, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	serviceInfo := grpcServer.GetServiceInfo()
+	require.Contains(t, serviceInfo, "sqs.query.v1beta1.SQSQuery")
+	require.Contains(t, serviceInfo, "grpc.reflection.v1.ServerReflection")
+}
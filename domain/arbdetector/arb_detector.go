@@ -0,0 +1,46 @@
+package arbdetectordomain
+
+import (
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+const (
+	// ArbDetectorPluginName is the name of the cyclic arbitrage detector plugin.
+	ArbDetectorPluginName = "arbdetector"
+)
+
+// CyclicArbOpportunity represents a cyclic arbitrage opportunity
+// that starts and ends in the same denom.
+type CyclicArbOpportunity struct {
+	// DenomIn is the denom that the cycle starts and ends in.
+	DenomIn string `json:"denom_in"`
+	// PoolIDs are the pool IDs of the cycle in the swap order.
+	PoolIDs []uint64 `json:"pool_ids"`
+	// TokenOutDenoms are the token out denoms of each pool in the cycle.
+	// The last denom is always equal to DenomIn.
+	TokenOutDenoms []string `json:"token_out_denoms"`
+	// AmountIn is the optimal amount in found by the sizing search.
+	AmountIn osmomath.Int `json:"amount_in"`
+	// AmountOut is the amount out of the cycle given AmountIn.
+	AmountOut osmomath.Int `json:"amount_out"`
+	// Profit is AmountOut minus AmountIn in DenomIn.
+	Profit osmomath.Int `json:"profit"`
+	// ProfitUSD is the profit valued in the default quote denom.
+	ProfitUSD osmomath.Dec `json:"profit_usd"`
+}
+
+// CyclicArbOpportunities represents the cyclic arbitrage opportunities
+// detected at the end of a given block.
+type CyclicArbOpportunities struct {
+	// Height is the height of the block at which the opportunities were detected.
+	Height uint64 `json:"height"`
+	// Opportunities are sorted by ProfitUSD in descending order.
+	Opportunities []CyclicArbOpportunity `json:"opportunities"`
+}
+
+// CyclicArbDetector provides the cyclic arbitrage opportunities
+// detected at the end of the latest processed block.
+type CyclicArbDetector interface {
+	// GetOpportunities returns the opportunities detected at the latest processed block.
+	GetOpportunities() CyclicArbOpportunities
+}
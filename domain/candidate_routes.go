@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 )
@@ -26,6 +28,64 @@ type CandidateRouteSearchOptions struct {
 	// If at least one of the callbacks in-slice returns true, the ShouldSkipPool function will
 	// also return true.
 	PoolFiltersAnyOf []CandidateRoutePoolFiltrerCb
+
+	// CandidateRouteDenomConstraints constrain the intermediate denoms of the routes.
+	CandidateRouteDenomConstraints
+
+	// IsUnlistedDenomCb returns true if the given denom is unlisted.
+	// Must be set if ExcludeUnlistedTokens is true.
+	IsUnlistedDenomCb func(denom string) bool
+}
+
+// CandidateRouteDenomConstraints constrain the intermediate denoms of candidate routes.
+// Intermediate denoms are the denoms a route goes through, excluding the token in and token out denoms.
+type CandidateRouteDenomConstraints struct {
+	// AllowedIntermediateDenoms, if non-empty, are the only denoms routes may go through.
+	AllowedIntermediateDenoms map[string]struct{}
+	// ForbiddenIntermediateDenoms are the denoms routes must not go through.
+	ForbiddenIntermediateDenoms map[string]struct{}
+	// MustIncludeDenom, if set, is the denom every route must go through.
+	// Trivially satisfied if it is the token in or token out denom.
+	MustIncludeDenom string
+	// ExcludeUnlistedTokens excludes routes going through unlisted tokens.
+	// Tokens without metadata are considered unlisted.
+	ExcludeUnlistedTokens bool
+}
+
+// IsEmpty returns true if no constraints are set.
+func (c CandidateRouteDenomConstraints) IsEmpty() bool {
+	return len(c.AllowedIntermediateDenoms) == 0 && len(c.ForbiddenIntermediateDenoms) == 0 && c.MustIncludeDenom == "" && !c.ExcludeUnlistedTokens
+}
+
+// ShouldSkipIntermediateDenom returns true if routes must not go through the given intermediate denom.
+func (c CandidateRouteSearchOptions) ShouldSkipIntermediateDenom(denom string) bool {
+	if len(c.AllowedIntermediateDenoms) > 0 {
+		if _, ok := c.AllowedIntermediateDenoms[denom]; !ok {
+			return true
+		}
+	}
+
+	if _, ok := c.ForbiddenIntermediateDenoms[denom]; ok {
+		return true
+	}
+
+	return c.ExcludeUnlistedTokens && c.IsUnlistedDenomCb != nil && c.IsUnlistedDenomCb(denom)
+}
+
+// IncludesRequiredDenom returns true if a route from token in to token out going through
+// the given intermediate denoms satisfies the must-include denom constraint.
+func (c CandidateRouteSearchOptions) IncludesRequiredDenom(tokenInDenom, tokenOutDenom string, intermediateDenoms ...string) bool {
+	if c.MustIncludeDenom == "" || c.MustIncludeDenom == tokenInDenom || c.MustIncludeDenom == tokenOutDenom {
+		return true
+	}
+
+	for _, denom := range intermediateDenoms {
+		if denom == c.MustIncludeDenom {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ShouldSkipPool returns true if the candidate route algorithm should skip
@@ -67,8 +127,17 @@ var (
 type CandidateRouteSearcher interface {
 	// FindCandidateRoutes finds candidate routes for a given tokenIn and tokenOutDenom
 	// using the given options.
+	// If the context is done, the search stops, returning the routes found so far.
 	// Returns the candidate routes and an error if any.
-	FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDenom string, options CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error)
+	FindCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, options CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error)
+}
+
+// CandidateRouteIndex holds the candidate routes precomputed for pairs of
+// the top denoms by liquidity capitalization.
+type CandidateRouteIndex interface {
+	// GetIndexedCandidateRoutes returns the indexed candidate routes from token in denom to token out denom.
+	// Returns false if the pair is not indexed.
+	GetIndexedCandidateRoutes(tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool)
 }
 
 // CandidateRouteDenomData represents the data for a candidate route for a given denom.
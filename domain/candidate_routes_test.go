@@ -134,3 +134,165 @@ func TestCandidateRouteSearchOptions_ShouldSkipPool(t *testing.T) {
 		})
 	}
 }
+
+// This test validates the ShouldSkipIntermediateDenom() method of the candidate route search options.
+func TestCandidateRouteSearchOptions_ShouldSkipIntermediateDenom(t *testing.T) {
+	const (
+		denomA = "denomA"
+		denomB = "denomB"
+	)
+
+	isUnlistedDenomB := func(denom string) bool {
+		return denom == denomB
+	}
+
+	tests := []struct {
+		name string
+
+		constraints domain.CandidateRouteDenomConstraints
+
+		denom string
+
+		expectedShouldSkip bool
+	}{
+		{
+			name:  "no constraints -> returns false",
+			denom: denomA,
+		},
+		{
+			name: "allowed -> returns false",
+			constraints: domain.CandidateRouteDenomConstraints{
+				AllowedIntermediateDenoms: map[string]struct{}{denomA: {}},
+			},
+			denom: denomA,
+		},
+		{
+			name: "not allowed -> returns true",
+			constraints: domain.CandidateRouteDenomConstraints{
+				AllowedIntermediateDenoms: map[string]struct{}{denomA: {}},
+			},
+			denom:              denomB,
+			expectedShouldSkip: true,
+		},
+		{
+			name: "forbidden -> returns true",
+			constraints: domain.CandidateRouteDenomConstraints{
+				ForbiddenIntermediateDenoms: map[string]struct{}{denomA: {}},
+			},
+			denom:              denomA,
+			expectedShouldSkip: true,
+		},
+		{
+			name: "both allowed and forbidden -> returns true",
+			constraints: domain.CandidateRouteDenomConstraints{
+				AllowedIntermediateDenoms:   map[string]struct{}{denomA: {}},
+				ForbiddenIntermediateDenoms: map[string]struct{}{denomA: {}},
+			},
+			denom:              denomA,
+			expectedShouldSkip: true,
+		},
+		{
+			name: "unlisted, excluded -> returns true",
+			constraints: domain.CandidateRouteDenomConstraints{
+				ExcludeUnlistedTokens: true,
+			},
+			denom:              denomB,
+			expectedShouldSkip: true,
+		},
+		{
+			name:  "unlisted, not excluded -> returns false",
+			denom: denomB,
+		},
+		{
+			name: "listed, excluded -> returns false",
+			constraints: domain.CandidateRouteDenomConstraints{
+				ExcludeUnlistedTokens: true,
+			},
+			denom: denomA,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			opts := domain.CandidateRouteSearchOptions{
+				CandidateRouteDenomConstraints: tc.constraints,
+				IsUnlistedDenomCb:              isUnlistedDenomB,
+			}
+
+			// System under test.
+			shouldSkip := opts.ShouldSkipIntermediateDenom(tc.denom)
+
+			// Validate result.
+			require.Equal(t, tc.expectedShouldSkip, shouldSkip)
+		})
+	}
+}
+
+// This test validates the IncludesRequiredDenom() method of the candidate route search options.
+func TestCandidateRouteSearchOptions_IncludesRequiredDenom(t *testing.T) {
+	const (
+		tokenInDenom  = "tokenIn"
+		tokenOutDenom = "tokenOut"
+		bridgeDenom   = "bridge"
+		otherDenom    = "other"
+	)
+
+	tests := []struct {
+		name string
+
+		mustIncludeDenom   string
+		intermediateDenoms []string
+
+		expectedIncludes bool
+	}{
+		{
+			name:             "no must-include denom -> returns true",
+			expectedIncludes: true,
+		},
+		{
+			name:               "must-include denom is intermediate -> returns true",
+			mustIncludeDenom:   bridgeDenom,
+			intermediateDenoms: []string{otherDenom, bridgeDenom},
+			expectedIncludes:   true,
+		},
+		{
+			name:               "must-include denom is not intermediate -> returns false",
+			mustIncludeDenom:   bridgeDenom,
+			intermediateDenoms: []string{otherDenom},
+			expectedIncludes:   false,
+		},
+		{
+			name:             "direct route -> returns false",
+			mustIncludeDenom: bridgeDenom,
+			expectedIncludes: false,
+		},
+		{
+			name:             "must-include denom is token in -> returns true",
+			mustIncludeDenom: tokenInDenom,
+			expectedIncludes: true,
+		},
+		{
+			name:             "must-include denom is token out -> returns true",
+			mustIncludeDenom: tokenOutDenom,
+			expectedIncludes: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			opts := domain.CandidateRouteSearchOptions{
+				CandidateRouteDenomConstraints: domain.CandidateRouteDenomConstraints{
+					MustIncludeDenom: tc.mustIncludeDenom,
+				},
+			}
+
+			// System under test.
+			includes := opts.IncludesRequiredDenom(tokenInDenom, tokenOutDenom, tc.intermediateDenoms...)
+
+			// Validate result.
+			require.Equal(t, tc.expectedIncludes, includes)
+		})
+	}
+}
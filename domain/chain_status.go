@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+// ChainStatus is the status of the chain and of ingest as observed by SQS.
+type ChainStatus string
+
+const (
+	// ChainStatusHealthy is the status when blocks are produced by the chain and ingested on time.
+	ChainStatusHealthy ChainStatus = "healthy"
+	// ChainStatusNodeLag is the status when the node is unreachable or catching up with the chain.
+	ChainStatusNodeLag ChainStatus = "node_lag"
+	// ChainStatusChainHalt is the status when the node is synced but the chain does not produce blocks.
+	ChainStatusChainHalt ChainStatus = "chain_halt"
+	// ChainStatusIngestStall is the status when the chain produces blocks but they are not ingested by SQS.
+	ChainStatusIngestStall ChainStatus = "ingest_stall"
+)
+
+// Degraded mode actions applied to quotes while the chain status is not healthy.
+const (
+	// DegradedModeActionNone serves quotes as is.
+	DegradedModeActionNone = "none"
+	// DegradedModeActionWarn adds a warning header to quote responses.
+	DegradedModeActionWarn = "warn"
+	// DegradedModeActionWidenSlippage adds a warning header and widens the reported price impact of quotes.
+	DegradedModeActionWidenSlippage = "widen-slippage"
+	// DegradedModeActionRefuse refuses quotes with 503 Service Unavailable.
+	DegradedModeActionRefuse = "refuse"
+)
+
+// DegradedModeWarningHeader is the response header with the warning of a quote served in degraded mode.
+const DegradedModeWarningHeader = "X-SQS-Warning"
+
+// DegradedModeConfig encapsulates the chain status detection and degraded mode configuration.
+type DegradedModeConfig struct {
+	// Action is the action applied to quotes while the chain status is not healthy.
+	// One of "none", "warn", "widen-slippage" or "refuse".
+	Action string `mapstructure:"action"`
+
+	// ExpectedBlockTimeMs is the expected interval between blocks in milliseconds.
+	ExpectedBlockTimeMs int `mapstructure:"expected-block-time-ms"`
+
+	// StallThresholdBlocks is the number of expected block intervals without a new block
+	// after which the chain is considered halted or ingest stalled.
+	StallThresholdBlocks int `mapstructure:"stall-threshold-blocks"`
+
+	// MaxIngestLagBlocks is the max number of blocks that ingest may lag behind the node
+	// before it is considered stalled.
+	MaxIngestLagBlocks uint64 `mapstructure:"max-ingest-lag-blocks"`
+
+	// CheckIntervalMs is the interval at which the chain status is checked in milliseconds.
+	CheckIntervalMs int `mapstructure:"check-interval-ms"`
+
+	// SlippageWideningFactor is the factor that the reported price impact of quotes is multiplied by
+	// with the "widen-slippage" action.
+	SlippageWideningFactor float64 `mapstructure:"slippage-widening-factor"`
+}
+
+// Validate validates the degraded mode config.
+// Returns an error if the action is unknown or any of the intervals, thresholds or factor is not positive.
+func (c DegradedModeConfig) Validate() error {
+	switch c.Action {
+	case DegradedModeActionNone, DegradedModeActionWarn, DegradedModeActionWidenSlippage, DegradedModeActionRefuse:
+	default:
+		return fmt.Errorf("unknown degraded mode action (%s)", c.Action)
+	}
+
+	if c.ExpectedBlockTimeMs <= 0 {
+		return fmt.Errorf("expected block time must be positive")
+	}
+
+	if c.StallThresholdBlocks <= 0 {
+		return fmt.Errorf("stall threshold blocks must be positive")
+	}
+
+	if c.CheckIntervalMs <= 0 {
+		return fmt.Errorf("chain status check interval must be positive")
+	}
+
+	if c.SlippageWideningFactor < 1 {
+		return fmt.Errorf("slippage widening factor must be at least 1")
+	}
+
+	return nil
+}
+
+// GetStallThreshold returns the duration without a new block after which
+// the chain is considered halted or ingest stalled.
+func (c DegradedModeConfig) GetStallThreshold() time.Duration {
+	return time.Duration(c.StallThresholdBlocks*c.ExpectedBlockTimeMs) * time.Millisecond
+}
+
+// NodeStatus is the sync status of the node.
+type NodeStatus struct {
+	LatestHeight    uint64
+	LatestBlockTime time.Time
+	CatchingUp      bool
+}
+
+// NodeStatusGetter returns the sync status of the node.
+type NodeStatusGetter interface {
+	GetNodeStatus(ctx context.Context) (NodeStatus, error)
+}
+
+// ChainStatusObservation is the state observed at a point in time
+// that the chain status is detected from.
+type ChainStatusObservation struct {
+	// Now is the time of the observation.
+	Now time.Time
+	// NodeStatus is the sync status of the node.
+	NodeStatus NodeStatus
+	// NodeStatusErr is the error fetching the node status, if any.
+	NodeStatusErr error
+	// IngestHeight is the latest height ingested by SQS.
+	IngestHeight uint64
+	// IngestTime is the time the latest height was ingested at. Zero if no block has been ingested yet.
+	IngestTime time.Time
+}
+
+// ChainStatusReport is the detected chain status with the block times it was detected from.
+type ChainStatusReport struct {
+	Status  ChainStatus `json:"status"`
+	Message string      `json:"message,omitempty"`
+
+	ExpectedBlockTimeMs int64  `json:"expected_block_time_ms"`
+	ObservedBlockTimeMs int64  `json:"observed_block_time_ms"`
+	NodeHeight          uint64 `json:"node_height"`
+	IngestHeight        uint64 `json:"ingest_height"`
+
+	// NodeCatchingUp is true if the node is catching up with the chain.
+	NodeCatchingUp bool `json:"node_catching_up"`
+	// NodeError is the error fetching the node status, if any.
+	NodeError string `json:"node_error,omitempty"`
+}
+
+// IsNodeStatusObserved returns true if the node status has been checked at least once.
+func (r ChainStatusReport) IsNodeStatusObserved() bool {
+	return r.NodeHeight > 0 || r.NodeError != ""
+}
+
+// ChainStatusGetter returns the latest detected chain status.
+type ChainStatusGetter interface {
+	GetChainStatus() ChainStatusReport
+}
+
+// DetectChainStatus detects the chain status from the given observation.
+// The node lagging or unreachable takes precedence since the chain cannot be observed without it.
+// Then, the chain is halted if the latest block of the synced node is older than the stall threshold.
+// Otherwise, ingest is stalled if the latest ingested block is older than the stall threshold
+// or lags behind the node height by more than the max ingest lag.
+// Returns the status and a message detailing it.
+func DetectChainStatus(observation ChainStatusObservation, config DegradedModeConfig) (ChainStatus, string) {
+	stallThreshold := config.GetStallThreshold()
+
+	if observation.NodeStatusErr != nil {
+		return ChainStatusNodeLag, fmt.Sprintf("node is unreachable: %s", observation.NodeStatusErr)
+	}
+
+	nodeStatus := observation.NodeStatus
+	if nodeStatus.CatchingUp {
+		return ChainStatusNodeLag, fmt.Sprintf("node is catching up at height (%d)", nodeStatus.LatestHeight)
+	}
+
+	if timeSinceBlock := observation.Now.Sub(nodeStatus.LatestBlockTime); timeSinceBlock > stallThreshold {
+		return ChainStatusChainHalt, fmt.Sprintf("no block produced since height (%d) for (%s), threshold (%s)", nodeStatus.LatestHeight, timeSinceBlock.Truncate(time.Millisecond), stallThreshold)
+	}
+
+	if observation.IngestTime.IsZero() {
+		return ChainStatusIngestStall, "no block has been ingested yet"
+	}
+
+	if timeSinceIngest := observation.Now.Sub(observation.IngestTime); timeSinceIngest > stallThreshold {
+		return ChainStatusIngestStall, fmt.Sprintf("no block ingested since height (%d) for (%s), threshold (%s)", observation.IngestHeight, timeSinceIngest.Truncate(time.Millisecond), stallThreshold)
+	}
+
+	if nodeStatus.LatestHeight > observation.IngestHeight && nodeStatus.LatestHeight-observation.IngestHeight > config.MaxIngestLagBlocks {
+		return ChainStatusIngestStall, fmt.Sprintf("ingest height (%d) lags behind node height (%d), max lag (%d)", observation.IngestHeight, nodeStatus.LatestHeight, config.MaxIngestLagBlocks)
+	}
+
+	return ChainStatusHealthy, ""
+}
+
+// slippageWideningFactorContextKey is the context key of the slippage widening factor.
+type slippageWideningFactorContextKey struct{}
+
+// ContextWithSlippageWideningFactor returns a copy of the context with the factor
+// that the reported price impact of quotes is multiplied by.
+func ContextWithSlippageWideningFactor(ctx context.Context, factor osmomath.Dec) context.Context {
+	return context.WithValue(ctx, slippageWideningFactorContextKey{}, factor)
+}
+
+// GetSlippageWideningFactor returns the slippage widening factor of the context.
+// Returns false if the reported price impact is not to be widened.
+func GetSlippageWideningFactor(ctx context.Context) (osmomath.Dec, bool) {
+	factor, ok := ctx.Value(slippageWideningFactorContextKey{}).(osmomath.Dec)
+	return factor, ok
+}
@@ -0,0 +1,126 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// This test validates detecting chain halts, ingest stalls and node lag from the observed state.
+func TestDetectChainStatus(t *testing.T) {
+	var (
+		now = time.Unix(1_700_000_000, 0)
+
+		config = domain.DegradedModeConfig{
+			ExpectedBlockTimeMs:  1000,
+			StallThresholdBlocks: 10,
+			MaxIngestLagBlocks:   5,
+		}
+
+		syncedNodeStatus = domain.NodeStatus{
+			LatestHeight:    100,
+			LatestBlockTime: now.Add(-time.Second),
+		}
+	)
+
+	tests := []struct {
+		name string
+
+		observation domain.ChainStatusObservation
+
+		expectedStatus domain.ChainStatus
+	}{
+		{
+			name: "healthy",
+			observation: domain.ChainStatusObservation{
+				Now:          now,
+				NodeStatus:   syncedNodeStatus,
+				IngestHeight: 99,
+				IngestTime:   now.Add(-2 * time.Second),
+			},
+			expectedStatus: domain.ChainStatusHealthy,
+		},
+		{
+			name: "node unreachable",
+			observation: domain.ChainStatusObservation{
+				Now:           now,
+				NodeStatusErr: errors.New("connection refused"),
+				IngestHeight:  99,
+				IngestTime:    now.Add(-2 * time.Second),
+			},
+			expectedStatus: domain.ChainStatusNodeLag,
+		},
+		{
+			name: "node catching up",
+			observation: domain.ChainStatusObservation{
+				Now: now,
+				NodeStatus: domain.NodeStatus{
+					LatestHeight:    50,
+					LatestBlockTime: now.Add(-time.Hour),
+					CatchingUp:      true,
+				},
+				IngestHeight: 50,
+				IngestTime:   now.Add(-time.Second),
+			},
+			expectedStatus: domain.ChainStatusNodeLag,
+		},
+		{
+			name: "chain halt",
+			observation: domain.ChainStatusObservation{
+				Now: now,
+				NodeStatus: domain.NodeStatus{
+					LatestHeight:    100,
+					LatestBlockTime: now.Add(-11 * time.Second),
+				},
+				IngestHeight: 100,
+				IngestTime:   now.Add(-11 * time.Second),
+			},
+			expectedStatus: domain.ChainStatusChainHalt,
+		},
+		{
+			name: "ingest stalled",
+			observation: domain.ChainStatusObservation{
+				Now:          now,
+				NodeStatus:   syncedNodeStatus,
+				IngestHeight: 98,
+				IngestTime:   now.Add(-11 * time.Second),
+			},
+			expectedStatus: domain.ChainStatusIngestStall,
+		},
+		{
+			name: "ingest lagging behind the node",
+			observation: domain.ChainStatusObservation{
+				Now:          now,
+				NodeStatus:   syncedNodeStatus,
+				IngestHeight: 94,
+				IngestTime:   now.Add(-time.Second),
+			},
+			expectedStatus: domain.ChainStatusIngestStall,
+		},
+		{
+			name: "no block ingested",
+			observation: domain.ChainStatusObservation{
+				Now:        now,
+				NodeStatus: syncedNodeStatus,
+			},
+			expectedStatus: domain.ChainStatusIngestStall,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, message := domain.DetectChainStatus(tc.observation, config)
+
+			require.Equal(t, tc.expectedStatus, status)
+			if tc.expectedStatus == domain.ChainStatusHealthy {
+				require.Empty(t, message)
+			} else {
+				require.NotEmpty(t, message)
+			}
+		})
+	}
+}
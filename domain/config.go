@@ -3,12 +3,17 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
 	orderbookplugindomain "github.com/osmosis-labs/sqs/domain/orderbook/plugin"
 	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
 	"github.com/spf13/viper"
 )
 
@@ -59,6 +64,15 @@ type Config struct {
 
 	// SideCarQueryServer CORS configuration.
 	CORS *CORSConfig `mapstructure:"cors"`
+
+	// Health check configuration.
+	Health *HealthConfig `mapstructure:"health"`
+
+	// Chain status detection and degraded mode configuration.
+	DegradedMode *DegradedModeConfig `mapstructure:"degraded-mode"`
+
+	// GRPC query server configuration.
+	GRPCQuery *GRPCQueryConfig `mapstructure:"grpc-query"`
 }
 
 const envPrefix = "SQS"
@@ -99,6 +113,11 @@ var (
 				641,
 				842,
 			},
+			GeneralCosmWasmQueryCache: GeneralCosmWasmQueryCacheConfig{
+				Enabled:              true,
+				MaxConcurrentQueries: 16,
+				MaxEntriesPerPool:    1000,
+			},
 		},
 		Router: &RouterConfig{
 			PreferredPoolIDs:                 []uint64{},
@@ -131,6 +150,20 @@ var (
 					FilterValue:  1,
 				},
 			},
+			PoolCircuitBreaker: PoolCircuitBreakerConfig{
+				Enabled:               true,
+				FailureThreshold:      5,
+				InitialBackoffSeconds: 30,
+				MaxBackoffSeconds:     600,
+			},
+			GeneralCosmWasmSplitQueryBudget: 0,
+			CandidateRouteSearchAlgorithm:   CandidateRouteSearchAlgorithmBFS,
+			QuoteTimeBudgetMs:               0,
+			MaxQuoteTimeBudgetMs:            10_000,
+			CandidateRouteIndex: CandidateRouteIndexConfig{
+				Enabled:      true,
+				NumTopDenoms: 20,
+			},
 		},
 		Pricing: &PricingConfig{
 			CacheExpiryMs:             2000,
@@ -154,11 +187,28 @@ var (
 			MaxReceiveMsgSizeBytes:         16777216,
 			ServerAddress:                  ":50051",
 			ServerConnectionTimeoutSeconds: 10,
+			ReplaySpeed:                    1,
 			Plugins: []Plugin{
 				&OrderBookPluginConfig{
 					Enabled: false,
 					Name:    orderbookplugindomain.OrderBookPluginName,
 				},
+				&ArbDetectorPluginConfig{
+					Enabled: false,
+					Name:    arbdetectordomain.ArbDetectorPluginName,
+				},
+				&RemotePluginHostConfig{
+					Enabled: false,
+					Name:    remoteplugindomain.RemotePluginHostName,
+				},
+				&WebhookPluginConfig{
+					Enabled: false,
+					Name:    webhookdomain.WebhookPluginName,
+				},
+				&EventPublisherPluginConfig{
+					Enabled: false,
+					Name:    eventpublisherdomain.EventPublisherPluginName,
+				},
 			},
 		},
 		OTEL: &OTELConfig{
@@ -170,6 +220,64 @@ var (
 			AllowedMethods: "HEAD, GET, POST, HEAD, GET, POST, DELETE, OPTIONS, PATCH, PUT",
 			AllowedOrigin:  "*",
 		},
+		Health: &HealthConfig{
+			MaxIngestLagBlocks:                10,
+			MinWarmCandidateRouteCacheEntries: 0,
+		},
+		DegradedMode: &DegradedModeConfig{
+			Action:                 DegradedModeActionWarn,
+			ExpectedBlockTimeMs:    1500,
+			StallThresholdBlocks:   20,
+			MaxIngestLagBlocks:     10,
+			CheckIntervalMs:        1000,
+			SlippageWideningFactor: 2,
+		},
+		GRPCQuery: &GRPCQueryConfig{
+			Enabled:                        false,
+			ServerAddress:                  ":50053",
+			MaxReceiveMsgSizeBytes:         16777216,
+			ServerConnectionTimeoutSeconds: 10,
+		},
+	}
+
+	// DefaultArbDetectorPluginConfig is the default cyclic arbitrage detector plugin configuration.
+	DefaultArbDetectorPluginConfig = ArbDetectorPluginConfig{
+		Enabled:          false,
+		Name:             arbdetectordomain.ArbDetectorPluginName,
+		MaxCycleLength:   3,
+		MinAmountInUSD:   10,
+		MaxAmountInUSD:   100_000,
+		MinProfitUSD:     1,
+		MaxOpportunities: 100,
+	}
+
+	// DefaultRemotePluginHostConfig is the default out-of-process plugin host configuration.
+	DefaultRemotePluginHostConfig = RemotePluginHostConfig{
+		Enabled:                  false,
+		Name:                     remoteplugindomain.RemotePluginHostName,
+		ServerAddress:            "127.0.0.1:50052",
+		ProcessEndBlockTimeoutMs: 5000,
+		MaxConsecutiveFailures:   10,
+	}
+
+	// DefaultWebhookPluginConfig is the default webhook notification plugin configuration.
+	DefaultWebhookPluginConfig = WebhookPluginConfig{
+		Enabled:        false,
+		Name:           webhookdomain.WebhookPluginName,
+		TimeoutMs:      5000,
+		MaxRetries:     3,
+		RetryBackoffMs: 500,
+	}
+
+	// DefaultEventPublisherPluginConfig is the default block event publisher plugin configuration.
+	DefaultEventPublisherPluginConfig = EventPublisherPluginConfig{
+		Enabled:          false,
+		Name:             eventpublisherdomain.EventPublisherPluginName,
+		Sink:             eventpublisherdomain.FileSinkType,
+		FilePath:         "events.ndjson",
+		NATSURL:          "nats://localhost:4222",
+		SubjectPrefix:    "sqs.events",
+		PublishTimeoutMs: 5000,
 	}
 )
 
@@ -306,6 +414,165 @@ func (o *OrderBookPluginConfig) IsEnabled() bool {
 
 var _ Plugin = &OrderBookPluginConfig{}
 
+// ArbDetectorPluginConfig encapsulates the cyclic arbitrage detector plugin configuration.
+type ArbDetectorPluginConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Name    string `mapstructure:"name"`
+	// MaxCycleLength is the maximum number of pools in a detected cycle.
+	MaxCycleLength int `mapstructure:"max-cycle-length"`
+	// MinAmountInUSD is the lower bound of the amount in search, valued in the default quote denom.
+	MinAmountInUSD float64 `mapstructure:"min-amount-in-usd"`
+	// MaxAmountInUSD is the upper bound of the amount in search, valued in the default quote denom.
+	MaxAmountInUSD float64 `mapstructure:"max-amount-in-usd"`
+	// MinProfitUSD is the minimum profit, valued in the default quote denom, for a cycle to be reported.
+	MinProfitUSD float64 `mapstructure:"min-profit-usd"`
+	// MaxOpportunities is the maximum number of opportunities retained per block.
+	MaxOpportunities int `mapstructure:"max-opportunities"`
+}
+
+// GetName implements Plugin.
+func (a *ArbDetectorPluginConfig) GetName() string {
+	return a.Name
+}
+
+// IsEnabled implements Plugin.
+func (a *ArbDetectorPluginConfig) IsEnabled() bool {
+	return a.Enabled
+}
+
+var _ Plugin = &ArbDetectorPluginConfig{}
+
+// RemotePluginHostConfig encapsulates the out-of-process plugin host configuration.
+type RemotePluginHostConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Name    string `mapstructure:"name"`
+	// ServerAddress is the address of the gRPC server that plugins register with
+	// and call back into. Defaults to the loopback interface.
+	ServerAddress string `mapstructure:"server-address"`
+	// AuthToken is the shared token that plugins must send in the remoteplugindomain.AuthTokenMetadataKey
+	// metadata of every call to the gRPC server. Calls are not authenticated if empty.
+	AuthToken string `mapstructure:"auth-token"`
+	// AllowedPluginAddresses are the only addresses that plugins may register with.
+	// Any address is allowed if empty.
+	AllowedPluginAddresses []string `mapstructure:"allowed-plugin-addresses"`
+	// ProcessEndBlockTimeoutMs is the maximum duration of a single plugin's end block processing.
+	ProcessEndBlockTimeoutMs int `mapstructure:"process-end-block-timeout-ms"`
+	// MaxConsecutiveFailures is the number of consecutive failures
+	// after which a plugin is deregistered. Zero disables deregistration.
+	MaxConsecutiveFailures int `mapstructure:"max-consecutive-failures"`
+}
+
+// Validate validates the remote plugin host config.
+// Since registered plugins receive the pool data and are dialed by the host, an enabled host
+// requires either an auth token or a plugin address allowlist.
+// Returns an error if the config is invalid. Nil is returned if the config is valid.
+func (r *RemotePluginHostConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+
+	if r.AuthToken == "" && len(r.AllowedPluginAddresses) == 0 {
+		return fmt.Errorf("remote plugin host requires an auth token or allowed plugin addresses")
+	}
+
+	return nil
+}
+
+// GetName implements Plugin.
+func (r *RemotePluginHostConfig) GetName() string {
+	return r.Name
+}
+
+// IsEnabled implements Plugin.
+func (r *RemotePluginHostConfig) IsEnabled() bool {
+	return r.Enabled
+}
+
+var _ Plugin = &RemotePluginHostConfig{}
+
+// WebhookPluginConfig encapsulates the webhook notification plugin configuration.
+type WebhookPluginConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Name    string `mapstructure:"name"`
+	// URL is the endpoint that notifications are delivered to.
+	URL string `mapstructure:"url"`
+	// Secret is the key used to sign notifications with HMAC-SHA256.
+	// Notifications are not signed if empty.
+	Secret string `mapstructure:"secret"`
+	// TimeoutMs is the timeout of a single delivery attempt.
+	TimeoutMs int `mapstructure:"timeout-ms"`
+	// MaxRetries is the maximum number of retries after a failed delivery attempt.
+	MaxRetries int `mapstructure:"max-retries"`
+	// RetryBackoffMs is the initial backoff between delivery attempts, doubled on every retry.
+	RetryBackoffMs int `mapstructure:"retry-backoff-ms"`
+	// Rules are the rules evaluated at the end of every block.
+	Rules []webhookdomain.Rule `mapstructure:"rules"`
+}
+
+// Validate validates the webhook plugin config.
+// Returns an error if the URL or any of the rules are invalid, including
+// thresholds that cannot be represented as decimals, or if rule names are not unique.
+// Nil is returned if the config is valid.
+func (w *WebhookPluginConfig) Validate() error {
+	if _, err := url.ParseRequestURI(w.URL); err != nil {
+		return fmt.Errorf("invalid webhook url %q: %w", w.URL, err)
+	}
+
+	ruleNames := make(map[string]struct{}, len(w.Rules))
+	for _, rule := range w.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		if _, ok := ruleNames[rule.Name]; ok {
+			return fmt.Errorf("duplicate webhook rule name %s", rule.Name)
+		}
+		ruleNames[rule.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// GetName implements Plugin.
+func (w *WebhookPluginConfig) GetName() string {
+	return w.Name
+}
+
+// IsEnabled implements Plugin.
+func (w *WebhookPluginConfig) IsEnabled() bool {
+	return w.Enabled
+}
+
+var _ Plugin = &WebhookPluginConfig{}
+
+// EventPublisherPluginConfig encapsulates the block event publisher plugin configuration.
+type EventPublisherPluginConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Name    string `mapstructure:"name"`
+	// Sink is the type of the sink that events are published to.
+	Sink eventpublisherdomain.SinkType `mapstructure:"sink"`
+	// FilePath is the path of the newline-delimited JSON file used by the file sink.
+	FilePath string `mapstructure:"file-path"`
+	// NATSURL is the URL of the NATS server used by the NATS sink.
+	NATSURL string `mapstructure:"nats-url"`
+	// SubjectPrefix is prepended to the event type to form the subject of an event.
+	SubjectPrefix string `mapstructure:"subject-prefix"`
+	// PublishTimeoutMs is the timeout for publishing all events of a block.
+	PublishTimeoutMs int `mapstructure:"publish-timeout-ms"`
+}
+
+// GetName implements Plugin.
+func (e *EventPublisherPluginConfig) GetName() string {
+	return e.Name
+}
+
+// IsEnabled implements Plugin.
+func (e *EventPublisherPluginConfig) IsEnabled() bool {
+	return e.Enabled
+}
+
+var _ Plugin = &EventPublisherPluginConfig{}
+
 type EndpointOTELConfig struct {
 	Quote float64 `mapstructure:"/router/quote"`
 	Other float64 `mapstructure:"other"`
@@ -345,6 +612,74 @@ func (c Config) Validate() error {
 		return err
 	}
 
+	// Validate the pool circuit breaker.
+	if err := c.Router.PoolCircuitBreaker.Validate(); err != nil {
+		return err
+	}
+
+	// Validate the generalized CosmWasm pool query cache.
+	if err := c.Pools.GeneralCosmWasmQueryCache.Validate(); err != nil {
+		return err
+	}
+
+	if c.Router.GeneralCosmWasmSplitQueryBudget < 0 {
+		return fmt.Errorf("general cosmwasm split query budget must not be negative")
+	}
+
+	// Validate the candidate route index.
+	if err := c.Router.CandidateRouteIndex.Validate(); err != nil {
+		return err
+	}
+
+	if c.Router.QuoteTimeBudgetMs < 0 {
+		return fmt.Errorf("quote time budget must not be negative")
+	}
+
+	if c.Router.MaxQuoteTimeBudgetMs <= 0 {
+		return fmt.Errorf("max quote time budget must be positive")
+	}
+
+	// Validate the health check.
+	if err := c.Health.Validate(); err != nil {
+		return err
+	}
+
+	// Validate the degraded mode.
+	if err := c.DegradedMode.Validate(); err != nil {
+		return err
+	}
+
+	// Validate the gRPC query server.
+	if err := c.GRPCQuery.Validate(); err != nil {
+		return err
+	}
+
+	// Validate the plugins.
+	if c.GRPCIngester != nil {
+		for _, plugin := range c.GRPCIngester.Plugins {
+			switch pluginConfig := plugin.(type) {
+			case *RemotePluginHostConfig:
+				if err := pluginConfig.Validate(); err != nil {
+					return err
+				}
+			case *WebhookPluginConfig:
+				if !pluginConfig.IsEnabled() {
+					continue
+				}
+
+				if err := pluginConfig.Validate(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	switch c.Router.CandidateRouteSearchAlgorithm {
+	case "", CandidateRouteSearchAlgorithmBFS, CandidateRouteSearchAlgorithmBestFirst:
+	default:
+		return fmt.Errorf("unsupported candidate route search algorithm (%s)", c.Router.CandidateRouteSearchAlgorithm)
+	}
+
 	return nil
 }
 
@@ -379,6 +714,20 @@ func PluginFactory(name string) Plugin {
 	switch name {
 	case orderbookplugindomain.OrderBookPluginName:
 		return &OrderBookPluginConfig{}
+	case arbdetectordomain.ArbDetectorPluginName:
+		// Pre-populate with defaults so that only the overrides
+		// need to be specified in the config file.
+		defaultArbDetectorConfig := DefaultArbDetectorPluginConfig
+		return &defaultArbDetectorConfig
+	case remoteplugindomain.RemotePluginHostName:
+		defaultRemotePluginHostConfig := DefaultRemotePluginHostConfig
+		return &defaultRemotePluginHostConfig
+	case webhookdomain.WebhookPluginName:
+		defaultWebhookConfig := DefaultWebhookPluginConfig
+		return &defaultWebhookConfig
+	case eventpublisherdomain.EventPublisherPluginName:
+		defaultEventPublisherConfig := DefaultEventPublisherPluginConfig
+		return &defaultEventPublisherConfig
 	// Add cases for other plugins as needed
 	default:
 		return nil
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/osmosis-labs/sqs/domain"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
 )
 
 // Note: test cases are code-generated as sanity checks. If extension is needed,
@@ -59,3 +62,57 @@ func TestValidateDynamicMinLiquidityCapDesc(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate_WebhookPlugin(t *testing.T) {
+	newConfig := func(webhookConfig domain.WebhookPluginConfig) domain.Config {
+		config := domain.DefaultConfig
+		config.GRPCIngester = &domain.GRPCIngesterConfig{
+			Plugins: []domain.Plugin{&webhookConfig},
+		}
+		return config
+	}
+
+	validRule := webhookdomain.Rule{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 0.5}
+
+	tests := []struct {
+		name    string
+		config  domain.WebhookPluginConfig
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: domain.WebhookPluginConfig{Enabled: true, URL: "http://localhost", Rules: []webhookdomain.Rule{validRule}},
+		},
+		{
+			name:   "disabled with invalid rule",
+			config: domain.WebhookPluginConfig{URL: "http://localhost", Rules: []webhookdomain.Rule{{Name: "rule", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 1e-19}}},
+		},
+		{
+			name:    "invalid url",
+			config:  domain.WebhookPluginConfig{Enabled: true, URL: "not a url", Rules: []webhookdomain.Rule{validRule}},
+			wantErr: true,
+		},
+		{
+			name:    "threshold with more decimals than supported",
+			config:  domain.WebhookPluginConfig{Enabled: true, URL: "http://localhost", Rules: []webhookdomain.Rule{{Name: "rule", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 1e-19}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate rule name",
+			config:  domain.WebhookPluginConfig{Enabled: true, URL: "http://localhost", Rules: []webhookdomain.Rule{validRule, validRule}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := newConfig(tt.config).Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -16,6 +16,11 @@ type CosmWasmPoolsParams struct {
 	Config                domain.CosmWasmPoolRouterConfig
 	WasmClient            wasmtypes.QueryClient
 	ScalingFactorGetterCb domain.ScalingFactorGetterCb
+	// QueryCache memoizes generalized cosmwasm pool queries. Nil if disabled.
+	QueryCache *QueryCache
+	// Registry resolves the routable pool implementation of a cosmwasm pool.
+	// If nil, the built-in implementations for the code IDs in Config are used.
+	Registry *PoolRegistry
 }
 
 // QueryCosmwasmContract queries the cosmwasm contract given the contract address, request and response
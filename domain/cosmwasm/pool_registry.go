@@ -0,0 +1,128 @@
+package cosmwasmdomain
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cwpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+// PoolConstructor constructs a routable pool from the given CosmWasm pool.
+// Returns error if the pool cannot be constructed (e.g. the pool model is missing required data).
+type PoolConstructor func(pool sqsdomain.PoolI, cosmWasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, params CosmWasmPoolsParams) (domain.RoutablePool, error)
+
+// PoolRegistration describes a CosmWasm pool implementation.
+// A pool matches the registration if its code ID is one of CodeIDs and,
+// when Contract is set, its contract info matches Contract and VersionConstraint.
+type PoolRegistration struct {
+	// Name uniquely identifies the registration.
+	Name string
+	// Contract is the cw2 contract name. Empty to match by code ID only.
+	Contract string
+	// VersionConstraint is the semver constraint on the cw2 contract version.
+	// Ignored if Contract is empty. Empty matches any valid version.
+	VersionConstraint string
+	// CodeIDs are the code IDs of the contracts backing the pool.
+	CodeIDs map[uint64]struct{}
+	// NewRoutablePool constructs the routable pool.
+	NewRoutablePool PoolConstructor
+
+	versionConstraint *semver.Constraints
+}
+
+// PoolRegistry holds the CosmWasm pool implementations.
+// Registrations are matched in registration order; the first match wins.
+type PoolRegistry struct {
+	mu            sync.RWMutex
+	registrations []PoolRegistration
+}
+
+// NewPoolRegistry returns an empty pool registry.
+func NewPoolRegistry() *PoolRegistry {
+	return &PoolRegistry{}
+}
+
+// Register adds the given registration to the registry.
+// Returns error if the name is empty or already registered, the constructor is nil
+// or the version constraint is invalid.
+func (r *PoolRegistry) Register(registration PoolRegistration) error {
+	if registration.Name == "" {
+		return fmt.Errorf("cosmwasm pool registration name must be set")
+	}
+
+	if registration.NewRoutablePool == nil {
+		return fmt.Errorf("cosmwasm pool registration (%s) must have a constructor", registration.Name)
+	}
+
+	if registration.Contract != "" {
+		versionConstraint := registration.VersionConstraint
+		if versionConstraint == "" {
+			versionConstraint = "*"
+		}
+
+		constraint, err := semver.NewConstraint(versionConstraint)
+		if err != nil {
+			return fmt.Errorf("cosmwasm pool registration (%s) has invalid version constraint (%s): %w", registration.Name, registration.VersionConstraint, err)
+		}
+		registration.versionConstraint = constraint
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.registrations {
+		if existing.Name == registration.Name {
+			return fmt.Errorf("cosmwasm pool registration (%s) already exists", registration.Name)
+		}
+	}
+
+	r.registrations = append(r.registrations, registration)
+
+	return nil
+}
+
+// Match returns the first registration matching the given code ID and pool model.
+// The model may be nil, in which case only registrations without a contract can match.
+// Returns false if there is no match.
+func (r *PoolRegistry) Match(codeID uint64, model *cosmwasmpool.CosmWasmPoolModel) (PoolRegistration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, registration := range r.registrations {
+		if _, ok := registration.CodeIDs[codeID]; !ok {
+			continue
+		}
+
+		if registration.Contract == "" {
+			return registration, true
+		}
+
+		if model != nil && model.ContractInfo.Matches(registration.Contract, registration.versionConstraint) {
+			return registration, true
+		}
+	}
+
+	return PoolRegistration{}, false
+}
+
+// CodeIDs returns the union of the code IDs of all registrations.
+func (r *PoolRegistry) CodeIDs() map[uint64]struct{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codeIDs := make(map[uint64]struct{})
+	for _, registration := range r.registrations {
+		for codeID := range registration.CodeIDs {
+			codeIDs[codeID] = struct{}{}
+		}
+	}
+
+	return codeIDs
+}
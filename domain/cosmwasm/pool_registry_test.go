@@ -0,0 +1,137 @@
+package cosmwasmdomain_test
+
+import (
+	"testing"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cwpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+type PoolRegistryTestSuite struct {
+	suite.Suite
+}
+
+const (
+	customContractName = "crates.io:custom-pool"
+	customCodeID       = uint64(10)
+	otherCodeID        = uint64(11)
+)
+
+var nilPoolConstructor = func(pool sqsdomain.PoolI, cosmWasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, params cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+	return nil, nil
+}
+
+func TestPoolRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolRegistryTestSuite))
+}
+
+// Validates that invalid registrations are rejected.
+func (s *PoolRegistryTestSuite) TestRegister_Invalid() {
+	tests := []struct {
+		name         string
+		registration cosmwasmdomain.PoolRegistration
+	}{
+		{
+			name:         "empty name",
+			registration: cosmwasmdomain.PoolRegistration{NewRoutablePool: nilPoolConstructor},
+		},
+		{
+			name:         "nil constructor",
+			registration: cosmwasmdomain.PoolRegistration{Name: "custom"},
+		},
+		{
+			name: "invalid version constraint",
+			registration: cosmwasmdomain.PoolRegistration{
+				Name:              "custom",
+				Contract:          customContractName,
+				VersionConstraint: "not a constraint",
+				NewRoutablePool:   nilPoolConstructor,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			registry := cosmwasmdomain.NewPoolRegistry()
+			s.Require().Error(registry.Register(tt.registration))
+		})
+	}
+}
+
+// Validates that the same name cannot be registered twice.
+func (s *PoolRegistryTestSuite) TestRegister_Duplicate() {
+	registry := cosmwasmdomain.NewPoolRegistry()
+
+	registration := cosmwasmdomain.PoolRegistration{Name: "custom", NewRoutablePool: nilPoolConstructor}
+
+	s.Require().NoError(registry.Register(registration))
+	s.Require().Error(registry.Register(registration))
+}
+
+// Validates matching by code ID and contract info, in registration order.
+func (s *PoolRegistryTestSuite) TestMatch() {
+	registry := cosmwasmdomain.NewPoolRegistry()
+
+	s.Require().NoError(registry.Register(cosmwasmdomain.PoolRegistration{
+		Name:              "versioned",
+		Contract:          customContractName,
+		VersionConstraint: ">= 2.0.0",
+		CodeIDs:           map[uint64]struct{}{customCodeID: {}},
+		NewRoutablePool:   nilPoolConstructor,
+	}))
+	s.Require().NoError(registry.Register(cosmwasmdomain.PoolRegistration{
+		Name:            "code-id-only",
+		CodeIDs:         map[uint64]struct{}{customCodeID: {}},
+		NewRoutablePool: nilPoolConstructor,
+	}))
+
+	tests := []struct {
+		name             string
+		codeID           uint64
+		model            *cosmwasmpool.CosmWasmPoolModel
+		expectedMatch    bool
+		expectedRegistry string
+	}{
+		{
+			name:             "contract and version match",
+			codeID:           customCodeID,
+			model:            cosmwasmpool.NewCWPoolModel(customContractName, "2.1.0", cosmwasmpool.CosmWasmPoolData{}),
+			expectedMatch:    true,
+			expectedRegistry: "versioned",
+		},
+		{
+			name:             "version does not match - falls through to code ID only",
+			codeID:           customCodeID,
+			model:            cosmwasmpool.NewCWPoolModel(customContractName, "1.0.0", cosmwasmpool.CosmWasmPoolData{}),
+			expectedMatch:    true,
+			expectedRegistry: "code-id-only",
+		},
+		{
+			name:             "nil model - falls through to code ID only",
+			codeID:           customCodeID,
+			expectedMatch:    true,
+			expectedRegistry: "code-id-only",
+		},
+		{
+			name:   "code ID does not match",
+			codeID: otherCodeID,
+			model:  cosmwasmpool.NewCWPoolModel(customContractName, "2.1.0", cosmwasmpool.CosmWasmPoolData{}),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			registration, ok := registry.Match(tt.codeID, tt.model)
+			s.Require().Equal(tt.expectedMatch, ok)
+			s.Require().Equal(tt.expectedRegistry, registration.Name)
+		})
+	}
+
+	s.Require().Equal(map[uint64]struct{}{customCodeID: {}}, registry.CodeIDs())
+}
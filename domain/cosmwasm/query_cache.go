@@ -0,0 +1,193 @@
+package cosmwasmdomain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
+)
+
+// ErrQueryBudgetExceeded is returned when the query budget of the context is exhausted.
+var ErrQueryBudgetExceeded = errors.New("cosmwasm pool query budget exceeded")
+
+// QueryCache memoizes generalized cosmwasm pool contract queries until the pool is updated in a block.
+// Concurrent identical queries are deduplicated and the number of concurrent queries to chain is capped.
+// Failed queries are not memoized.
+type QueryCache struct {
+	wasmClient wasmtypes.QueryClient
+
+	// semaphore caps the number of concurrent queries to chain.
+	semaphore chan struct{}
+
+	// maxEntriesPerPool caps the number of memoized queries per pool.
+	// The entries of a pool are reset once exceeded.
+	maxEntriesPerPool int
+
+	mu sync.Mutex
+	// pools maps pool IDs to the memoized queries keyed by the serialized request.
+	pools map[uint64]map[string]*queryCacheEntry
+}
+
+// queryCacheEntry is a memoized query. done is closed once the query completes.
+type queryCacheEntry struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// NewQueryCache returns a new query cache querying chain with the given client.
+func NewQueryCache(wasmClient wasmtypes.QueryClient, config domain.GeneralCosmWasmQueryCacheConfig) *QueryCache {
+	return &QueryCache{
+		wasmClient:        wasmClient,
+		semaphore:         make(chan struct{}, config.MaxConcurrentQueries),
+		maxEntriesPerPool: config.MaxEntriesPerPool,
+		pools:             make(map[uint64]map[string]*queryCacheEntry),
+	}
+}
+
+// Query queries the contract of the given pool, returning the memoized response if present.
+// If an identical query is in flight, waits for its response instead of querying chain.
+// The query to chain runs with the context of the caller that issued it. Waiting callers that receive
+// its context error while their own context is still active retry the query with their context.
+// Queries to chain consume the query budget of the context, if any.
+// Returns error if fails to query the contract, serialize request or deserialize response.
+func (c *QueryCache) Query(ctx context.Context, poolID uint64, contractAddress string, cosmWasmRequest any, cosmWasmResponse any) error {
+	bz, err := json.Marshal(cosmWasmRequest)
+	if err != nil {
+		return err
+	}
+
+	key := string(bz)
+
+	c.mu.Lock()
+	entries, ok := c.pools[poolID]
+	if !ok || len(entries) >= c.maxEntriesPerPool {
+		entries = make(map[string]*queryCacheEntry)
+		c.pools[poolID] = entries
+	}
+
+	entry, ok := entries[key]
+	if ok {
+		c.mu.Unlock()
+
+		domain.SQSCosmWasmPoolQueryCacheHitsCounter.Inc()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if entry.err != nil {
+			// The query failed due to the context of the caller that issued it.
+			// Retry with the context of this caller rather than failing.
+			if isContextError(entry.err) && ctx.Err() == nil {
+				return c.Query(ctx, poolID, contractAddress, cosmWasmRequest, cosmWasmResponse)
+			}
+
+			return entry.err
+		}
+
+		return json.Unmarshal(entry.data, cosmWasmResponse)
+	}
+
+	if err := ConsumeQueryBudget(ctx); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+
+	entry = &queryCacheEntry{done: make(chan struct{})}
+	entries[key] = entry
+	c.mu.Unlock()
+
+	domain.SQSCosmWasmPoolQueryCacheMissesCounter.Inc()
+
+	entry.data, entry.err = c.query(ctx, contractAddress, bz)
+
+	if entry.err != nil {
+		// Remove the failed query prior to completing it so that it is retried
+		// by the waiting and subsequent requests.
+		c.mu.Lock()
+		if entries[key] == entry {
+			delete(entries, key)
+		}
+		c.mu.Unlock()
+
+		close(entry.done)
+
+		return entry.err
+	}
+
+	close(entry.done)
+
+	return json.Unmarshal(entry.data, cosmWasmResponse)
+}
+
+// query queries the contract with the serialized request once a concurrency slot is available.
+func (c *QueryCache) query(ctx context.Context, contractAddress string, queryData []byte) ([]byte, error) {
+	select {
+	case c.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.semaphore }()
+
+	queryResponse, err := c.wasmClient.SmartContractState(ctx, &wasmtypes.QuerySmartContractStateRequest{
+		Address:   contractAddress,
+		QueryData: queryData,
+	}, grpc.Header(&metadata.MD{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return queryResponse.Data, nil
+}
+
+// Invalidate removes the memoized queries of the given pools.
+// Queries in flight for these pools complete without being memoized for subsequent requests.
+func (c *QueryCache) Invalidate(poolIDs map[uint64]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for poolID := range poolIDs {
+		delete(c.pools, poolID)
+	}
+}
+
+type queryBudgetKey struct{}
+
+// WithQueryBudget returns a context limiting the number of cosmwasm pool queries to chain
+// issued with it to the given budget. Memoized queries do not consume the budget.
+func WithQueryBudget(ctx context.Context, budget int64) context.Context {
+	remaining := &atomic.Int64{}
+	remaining.Store(budget)
+	return context.WithValue(ctx, queryBudgetKey{}, remaining)
+}
+
+// ConsumeQueryBudget consumes a query from the query budget of the context.
+// Returns ErrQueryBudgetExceeded if the budget is exhausted. Nil if the context has no budget.
+func ConsumeQueryBudget(ctx context.Context) error {
+	remaining, ok := ctx.Value(queryBudgetKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+
+	if remaining.Add(-1) < 0 {
+		domain.SQSCosmWasmPoolQueryBudgetExceededCounter.Inc()
+		return ErrQueryBudgetExceeded
+	}
+
+	return nil
+}
+
+// isContextError returns true if the error is caused by a canceled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,232 @@
+package cosmwasmdomain_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+)
+
+type QueryCacheTestSuite struct {
+	suite.Suite
+}
+
+// wasmClientMock counts the smart contract state queries, echoing the query data back.
+type wasmClientMock struct {
+	wasmtypes.QueryClient
+
+	queryCount atomic.Int64
+	// release blocks the queries until closed if set.
+	release chan struct{}
+	err     error
+}
+
+// queryRequest is the request used in tests.
+type queryRequest struct {
+	Amount string `json:"amount"`
+}
+
+const (
+	defaultPoolID          = uint64(1)
+	otherPoolID            = uint64(2)
+	defaultContractAddress = "osmo1contract"
+
+	waitTimeout = 5 * time.Second
+	waitTick    = time.Millisecond
+)
+
+var defaultConfig = domain.GeneralCosmWasmQueryCacheConfig{
+	Enabled:              true,
+	MaxConcurrentQueries: 2,
+	MaxEntriesPerPool:    3,
+}
+
+func TestQueryCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryCacheTestSuite))
+}
+
+// SmartContractState implements wasmtypes.QueryClient.
+func (m *wasmClientMock) SmartContractState(ctx context.Context, in *wasmtypes.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*wasmtypes.QuerySmartContractStateResponse, error) {
+	m.queryCount.Add(1)
+
+	if m.release != nil {
+		select {
+		case <-m.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &wasmtypes.QuerySmartContractStateResponse{Data: in.QueryData}, nil
+}
+
+// query queries the cache, validating that the response echoes the request.
+func (s *QueryCacheTestSuite) query(ctx context.Context, cache *cosmwasmdomain.QueryCache, poolID uint64, amount string) error {
+	response := queryRequest{}
+	if err := cache.Query(ctx, poolID, defaultContractAddress, queryRequest{Amount: amount}, &response); err != nil {
+		return err
+	}
+
+	s.Require().Equal(amount, response.Amount)
+	return nil
+}
+
+// Tests that queries are memoized per pool until the pool is invalidated.
+func (s *QueryCacheTestSuite) TestQuery_MemoizedUntilInvalidated() {
+	wasmClient := &wasmClientMock{}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "1"))
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "1"))
+	s.Require().NoError(s.query(context.Background(), cache, otherPoolID, "1"))
+	s.Require().Equal(int64(2), wasmClient.queryCount.Load())
+
+	// Different request.
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "2"))
+	s.Require().Equal(int64(3), wasmClient.queryCount.Load())
+
+	// Only the invalidated pool is queried again.
+	cache.Invalidate(map[uint64]struct{}{defaultPoolID: {}})
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "1"))
+	s.Require().NoError(s.query(context.Background(), cache, otherPoolID, "1"))
+	s.Require().Equal(int64(4), wasmClient.queryCount.Load())
+}
+
+// Tests that the entries of a pool are reset once the max entries per pool is exceeded.
+func (s *QueryCacheTestSuite) TestQuery_MaxEntriesPerPool() {
+	wasmClient := &wasmClientMock{}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	for _, amount := range []string{"1", "2", "3", "4"} {
+		s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, amount))
+	}
+	s.Require().Equal(int64(4), wasmClient.queryCount.Load())
+
+	// "4" was memoized after the reset while "1" was evicted.
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "4"))
+	s.Require().Equal(int64(4), wasmClient.queryCount.Load())
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "1"))
+	s.Require().Equal(int64(5), wasmClient.queryCount.Load())
+}
+
+// Tests that concurrent identical queries are deduplicated.
+func (s *QueryCacheTestSuite) TestQuery_Deduplicated() {
+	wasmClient := &wasmClientMock{release: make(chan struct{})}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	const numQueries = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numQueries)
+	for i := 0; i < numQueries; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.query(context.Background(), cache, defaultPoolID, "1")
+		}()
+	}
+
+	s.Require().Eventually(func() bool {
+		return wasmClient.queryCount.Load() == 1
+	}, waitTimeout, waitTick)
+
+	close(wasmClient.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		s.Require().NoError(err)
+	}
+	s.Require().Equal(int64(1), wasmClient.queryCount.Load())
+}
+
+// Tests that the callers waiting on a query cancelled by the caller that issued it
+// retry the query with their own context rather than failing.
+func (s *QueryCacheTestSuite) TestQuery_LeaderCancelled() {
+	wasmClient := &wasmClientMock{release: make(chan struct{})}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	defer cancelLeader()
+
+	leaderErr := make(chan error, 1)
+	go func() {
+		leaderErr <- s.query(leaderCtx, cache, defaultPoolID, "1")
+	}()
+
+	s.Require().Eventually(func() bool {
+		return wasmClient.queryCount.Load() == 1
+	}, waitTimeout, waitTick)
+
+	hitsBefore := testutil.ToFloat64(domain.SQSCosmWasmPoolQueryCacheHitsCounter)
+
+	followerErr := make(chan error, 1)
+	go func() {
+		followerErr <- s.query(context.Background(), cache, defaultPoolID, "1")
+	}()
+
+	// The follower waits on the in-flight query.
+	s.Require().Eventually(func() bool {
+		return testutil.ToFloat64(domain.SQSCosmWasmPoolQueryCacheHitsCounter) == hitsBefore+1
+	}, waitTimeout, waitTick)
+
+	cancelLeader()
+	s.Require().ErrorIs(<-leaderErr, context.Canceled)
+
+	// The follower retries the query with its own context.
+	s.Require().Eventually(func() bool {
+		return wasmClient.queryCount.Load() == 2
+	}, waitTimeout, waitTick)
+
+	close(wasmClient.release)
+	s.Require().NoError(<-followerErr)
+}
+
+// Tests that failed queries are not memoized.
+func (s *QueryCacheTestSuite) TestQuery_ErrorNotMemoized() {
+	queryErr := errors.New("contract query failed")
+
+	wasmClient := &wasmClientMock{err: queryErr}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	s.Require().ErrorIs(s.query(context.Background(), cache, defaultPoolID, "1"), queryErr)
+
+	wasmClient.err = nil
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "1"))
+	s.Require().Equal(int64(2), wasmClient.queryCount.Load())
+}
+
+// Tests that only queries to chain consume the query budget.
+func (s *QueryCacheTestSuite) TestQuery_Budget() {
+	wasmClient := &wasmClientMock{}
+	cache := cosmwasmdomain.NewQueryCache(wasmClient, defaultConfig)
+
+	ctx := cosmwasmdomain.WithQueryBudget(context.Background(), 2)
+
+	s.Require().NoError(s.query(ctx, cache, defaultPoolID, "1"))
+	s.Require().NoError(s.query(ctx, cache, defaultPoolID, "1"))
+	s.Require().NoError(s.query(ctx, cache, defaultPoolID, "2"))
+
+	s.Require().ErrorIs(s.query(ctx, cache, defaultPoolID, "3"), cosmwasmdomain.ErrQueryBudgetExceeded)
+
+	// Memoized queries are served with the exhausted budget.
+	s.Require().NoError(s.query(ctx, cache, defaultPoolID, "2"))
+
+	// Contexts without a budget are not limited.
+	s.Require().NoError(s.query(context.Background(), cache, defaultPoolID, "3"))
+	s.Require().Equal(int64(3), wasmClient.queryCount.Load())
+}
@@ -329,3 +329,86 @@ type StaticRateLimiterInvalidUpperLimitError struct {
 func (e StaticRateLimiterInvalidUpperLimitError) Error() string {
 	return fmt.Sprintf("invalid upper limit (%s) for weight (%s) and denom (%s)", e.UpperLimit, e.Weight, e.Denom)
 }
+
+type UnsupportedIngestProtocolVersionError struct {
+	Version       uint32
+	LatestVersion uint32
+}
+
+func (e UnsupportedIngestProtocolVersionError) Error() string {
+	return fmt.Sprintf("ingest protocol version (%d) is not supported, latest supported version is (%d)", e.Version, e.LatestVersion)
+}
+
+type PoolDeltaUnknownPoolError struct {
+	PoolID uint64
+}
+
+func (e PoolDeltaUnknownPoolError) Error() string {
+	return fmt.Sprintf("delta received for pool (%d) that was not ingested previously", e.PoolID)
+}
+
+type PoolDeltaChecksumMismatchError struct {
+	PoolID           uint64
+	ExpectedChecksum string
+	ActualChecksum   string
+}
+
+func (e PoolDeltaChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch after applying delta to pool (%d), expected (%s), actual (%s)", e.PoolID, e.ExpectedChecksum, e.ActualChecksum)
+}
+
+type IngestHeightGapError struct {
+	ExpectedHeight uint64
+	ActualHeight   uint64
+}
+
+func (e IngestHeightGapError) Error() string {
+	return fmt.Sprintf("gap in ingested heights, expected height (%d), received (%d), full resync required", e.ExpectedHeight, e.ActualHeight)
+}
+
+type IngestOutOfOrderHeightError struct {
+	LatestHeight uint64
+	ActualHeight uint64
+}
+
+func (e IngestOutOfOrderHeightError) Error() string {
+	return fmt.Sprintf("height (%d) received out of order, latest received height is (%d), full resync required", e.ActualHeight, e.LatestHeight)
+}
+
+type PoolZeroBalancesError struct {
+	PoolID uint64
+}
+
+func (e PoolZeroBalancesError) Error() string {
+	return fmt.Sprintf("pool (%d) has zero balances", e.PoolID)
+}
+
+type ConcentratedTicksNotOrderedError struct {
+	PoolID uint64
+	// Index is the index of the first tick range that is empty or overlaps the previous one.
+	Index int
+}
+
+func (e ConcentratedTicksNotOrderedError) Error() string {
+	return fmt.Sprintf("tick ranges of pool (%d) are not ordered or overlap at index (%d)", e.PoolID, e.Index)
+}
+
+type UnexpectedCosmWasmPoolModelError struct {
+	PoolID   uint64
+	PoolType string
+}
+
+func (e UnexpectedCosmWasmPoolModelError) Error() string {
+	return fmt.Sprintf("pool (%d) of type (%s) has an unexpected cosmwasm pool model", e.PoolID, e.PoolType)
+}
+
+// MinHeightNotReachedError is returned if the height that a request is served from
+// is behind the min height requested by the client.
+type MinHeightNotReachedError struct {
+	MinHeight uint64
+	Height    uint64
+}
+
+func (e MinHeightNotReachedError) Error() string {
+	return fmt.Sprintf("height (%d) is behind the requested min height (%d)", e.Height, e.MinHeight)
+}
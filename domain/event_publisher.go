@@ -0,0 +1,21 @@
+package domain
+
+import (
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// BlockEvent is a block event published by the event publisher plugin.
+// The payloads reuse the API response shapes so that consumers can mirror SQS state.
+type BlockEvent struct {
+	// Height is the height of the block that the event was produced at.
+	Height uint64 `json:"height"`
+	// Type is the type of the event.
+	Type eventpublisherdomain.EventType `json:"type"`
+	// Pools are set for the pool events.
+	Pools []PoolResponse `json:"pools,omitempty"`
+	// Prices are set for the price events.
+	Prices PricesResult `json:"prices,omitempty"`
+	// TakerFees are set for the taker fee events.
+	TakerFees []sqsdomain.TakerFeeForPair `json:"taker_fees,omitempty"`
+}
@@ -0,0 +1,43 @@
+package eventpublisherdomain
+
+import (
+	"context"
+)
+
+const (
+	// EventPublisherPluginName is the name of the block event publisher plugin.
+	EventPublisherPluginName = "eventpublisher"
+)
+
+// SinkType is the type of the sink that events are published to.
+type SinkType string
+
+const (
+	// FileSinkType appends events as newline-delimited JSON to a local file.
+	FileSinkType SinkType = "file"
+	// NATSSinkType publishes events to a NATS server.
+	NATSSinkType SinkType = "nats"
+)
+
+// EventType is the type of a block event.
+type EventType string
+
+const (
+	// PoolsCreatedEventType is published for the pools observed for the first time in a block.
+	PoolsCreatedEventType EventType = "pools-created"
+	// PoolsChangedEventType is published for the previously observed pools updated in a block.
+	PoolsChangedEventType EventType = "pools-changed"
+	// PricesUpdatedEventType is published for the prices of the denoms updated in a block.
+	PricesUpdatedEventType EventType = "prices-updated"
+	// TakerFeesUpdatedEventType is published for the taker fees changed in a block.
+	TakerFeesUpdatedEventType EventType = "taker-fees-updated"
+)
+
+// Sink is a destination that events are published to.
+// The subject maps to a NATS subject or a Kafka topic.
+type Sink interface {
+	// Publish publishes the data to the given subject.
+	Publish(ctx context.Context, subject string, data []byte) error
+	// Close releases the resources held by the sink.
+	Close() error
+}
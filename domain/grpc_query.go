@@ -0,0 +1,28 @@
+package domain
+
+import "fmt"
+
+// GRPCQueryConfig encapsulates the gRPC query server configuration.
+type GRPCQueryConfig struct {
+	// Flag to enable the gRPC query server.
+	Enabled bool `mapstructure:"enabled"`
+
+	// The address of the gRPC query server.
+	ServerAddress string `mapstructure:"server-address"`
+
+	// The maximum number of bytes to receive in a single gRPC message.
+	MaxReceiveMsgSizeBytes int `mapstructure:"max-receive-msg-size-bytes"`
+
+	// The number of seconds to wait for a connection to the server.
+	ServerConnectionTimeoutSeconds int `mapstructure:"server-connection-timeout-seconds"`
+}
+
+// Validate validates the gRPC query server config.
+// Returns an error if the server is enabled without an address.
+func (c GRPCQueryConfig) Validate() error {
+	if c.Enabled && c.ServerAddress == "" {
+		return fmt.Errorf("grpc query server address must be set when enabled")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "fmt"
+
+// Readiness statuses.
+const (
+	ReadinessStatusReady    = "ready"
+	ReadinessStatusNotReady = "not_ready"
+)
+
+// Readiness components.
+const (
+	NodeReadinessComponent                            = "node"
+	IngestLagReadinessComponent                       = "ingest_lag"
+	IngestStallReadinessComponent                     = "ingest_stall"
+	PriceUpdatesReadinessComponent                    = "price_updates"
+	PoolLiquidityUpdatesReadinessComponent            = "pool_liquidity_updates"
+	CandidateRouteSearchDataUpdatesReadinessComponent = "candidate_route_search_data_updates"
+	CacheWarmnessReadinessComponent                   = "cache_warmness"
+)
+
+// HealthConfig encapsulates the health check configuration.
+type HealthConfig struct {
+	// MaxIngestLagBlocks is the max number of blocks that the latest ingested height
+	// may lag behind the node height.
+	MaxIngestLagBlocks uint64 `mapstructure:"max-ingest-lag-blocks"`
+
+	// MinWarmCandidateRouteCacheEntries is the min number of candidate route cache entries
+	// for the cache to be considered warm. Zero disables the check.
+	MinWarmCandidateRouteCacheEntries int `mapstructure:"min-warm-candidate-route-cache-entries"`
+}
+
+// Validate validates the health check config.
+// Returns an error if the min warm cache entries is negative.
+func (c HealthConfig) Validate() error {
+	if c.MinWarmCandidateRouteCacheEntries < 0 {
+		return fmt.Errorf("min warm candidate route cache entries must not be negative")
+	}
+
+	return nil
+}
+
+// ReadinessComponentReport is the readiness of a single component.
+type ReadinessComponentReport struct {
+	// Ready is true if the component is ready.
+	Ready bool `json:"ready"`
+	// Message details the state of the component or why it is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+// ReadinessReport is the readiness of the service, aggregated from its components.
+type ReadinessReport struct {
+	// Status is ReadinessStatusReady if all components are ready. ReadinessStatusNotReady otherwise.
+	Status string `json:"status"`
+	// Components are the readiness reports by component.
+	Components map[string]ReadinessComponentReport `json:"components"`
+}
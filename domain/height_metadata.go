@@ -0,0 +1,26 @@
+package domain
+
+// Response headers reporting the height metadata of the response.
+const (
+	IngestHeightHeader  = "X-SQS-Ingest-Height"
+	PricingHeightHeader = "X-SQS-Pricing-Height"
+	SnapshotAgeHeader   = "X-SQS-Snapshot-Age-Ms"
+)
+
+// MinHeightMetadataKey is the gRPC request metadata key with the min height
+// that the response must be served from.
+const MinHeightMetadataKey = "x-sqs-min-height"
+
+// HeightMetadata is the block height metadata of a response, telling clients which block
+// the response reflects and how old it is.
+type HeightMetadata struct {
+	// IngestHeight is the latest ingested height.
+	IngestHeight uint64 `json:"ingest_height"`
+	// PricingHeight is the height of the latest price update.
+	PricingHeight uint64 `json:"pricing_height"`
+	// SnapshotHeight is the height of the state that the response is served from.
+	SnapshotHeight uint64 `json:"snapshot_height"`
+	// SnapshotAgeMs is the time since the state that the response is served from was ingested, in milliseconds.
+	// Zero if no block has been ingested yet.
+	SnapshotAgeMs int64 `json:"snapshot_age_ms"`
+}
@@ -17,6 +17,18 @@ type GRPCIngesterConfig struct {
 
 	// Plugins encapsulates the plugins config.
 	Plugins []Plugin `mapstructure:"plugins"`
+
+	// RecordPath is the path of the log that every received block is recorded to.
+	// Blocks are not recorded if empty.
+	RecordPath string `mapstructure:"record-path"`
+
+	// ReplayPath is the path of a recorded log to replay instead of starting the GRPC ingester server.
+	// Blocks are ingested from the server if empty.
+	ReplayPath string `mapstructure:"replay-path"`
+
+	// ReplaySpeed is the multiplier of the recorded pace that blocks are replayed at.
+	// For example, 2 replays twice as fast as recorded. Blocks are replayed without delay if zero.
+	ReplaySpeed float64 `mapstructure:"replay-speed"`
 }
 
 // BlockPoolMetadata contains the metadata about unique pools
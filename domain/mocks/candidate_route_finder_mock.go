@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/sqsdomain"
@@ -14,6 +16,6 @@ type CandidateRouteFinderMock struct {
 var _ domain.CandidateRouteSearcher = CandidateRouteFinderMock{}
 
 // FindCandidateRoutes implements domain.CandidateRouteSearcher.
-func (c CandidateRouteFinderMock) FindCandidateRoutes(tokenIn types.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
+func (c CandidateRouteFinderMock) FindCandidateRoutes(ctx context.Context, tokenIn types.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
 	return c.Routes, c.Error
 }
@@ -1,6 +1,12 @@
 package mocks
 
-import "github.com/osmosis-labs/sqs/domain/mvc"
+import (
+	"context"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+)
 
 var _ mvc.ChainInfoUsecase = &ChainInfoUsecaseMock{}
 
@@ -8,6 +14,8 @@ var _ mvc.ChainInfoUsecase = &ChainInfoUsecaseMock{}
 type ChainInfoUsecaseMock struct {
 	GetLatestHeightFunc                         func() (uint64, error)
 	StoreLatestHeightFunc                       func(height uint64)
+	GetHeightMetadataFunc                       func(ctx context.Context) domain.HeightMetadata
+	GetObservedBlockTimeFunc                    func() time.Duration
 	ValidatePriceUpdatesFunc                    func() error
 	ValidatePoolLiquidityUpdatesFunc            func() error
 	ValidateCandidateRouteSearchDataUpdatesFunc func() error
@@ -26,6 +34,20 @@ func (m *ChainInfoUsecaseMock) StoreLatestHeight(height uint64) {
 	}
 }
 
+func (m *ChainInfoUsecaseMock) GetHeightMetadata(ctx context.Context) domain.HeightMetadata {
+	if m.GetHeightMetadataFunc != nil {
+		return m.GetHeightMetadataFunc(ctx)
+	}
+	return domain.HeightMetadata{}
+}
+
+func (m *ChainInfoUsecaseMock) GetObservedBlockTime() time.Duration {
+	if m.GetObservedBlockTimeFunc != nil {
+		return m.GetObservedBlockTimeFunc()
+	}
+	return 0
+}
+
 func (m *ChainInfoUsecaseMock) ValidatePriceUpdates() error {
 	if m.ValidatePriceUpdatesFunc != nil {
 		return m.ValidatePriceUpdatesFunc()
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+var _ mvc.IngestUsecase = &IngestUsecaseMock{}
+
+// IngestUsecaseMock is a mock implementation of the IngestUsecase interface
+type IngestUsecaseMock struct {
+	ProcessBlockDataFunc              func(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) error
+	ApplyPoolDeltasFunc               func(req *types.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error)
+	RegisterEndBlockProcessPluginFunc func(plugin domain.EndBlockProcessPlugin)
+	RegisterStateSnapshotHolderFunc   func(stateSnapshotHolder *domain.StateSnapshotHolder, candidateRouteSearchDataHolder mvc.CandidateRouteSearchDataHolder)
+}
+
+func (m *IngestUsecaseMock) ProcessBlockData(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) error {
+	if m.ProcessBlockDataFunc != nil {
+		return m.ProcessBlockDataFunc(ctx, height, takerFeesMap, pools, removedPoolIDs)
+	}
+	return nil
+}
+
+func (m *IngestUsecaseMock) ApplyPoolDeltas(req *types.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error) {
+	if m.ApplyPoolDeltasFunc != nil {
+		return m.ApplyPoolDeltasFunc(req)
+	}
+	return nil, nil
+}
+
+func (m *IngestUsecaseMock) RegisterEndBlockProcessPlugin(plugin domain.EndBlockProcessPlugin) {
+	if m.RegisterEndBlockProcessPluginFunc != nil {
+		m.RegisterEndBlockProcessPluginFunc(plugin)
+	}
+}
+
+func (m *IngestUsecaseMock) RegisterStateSnapshotHolder(stateSnapshotHolder *domain.StateSnapshotHolder, candidateRouteSearchDataHolder mvc.CandidateRouteSearchDataHolder) {
+	if m.RegisterStateSnapshotHolderFunc != nil {
+		m.RegisterStateSnapshotHolderFunc(stateSnapshotHolder, candidateRouteSearchDataHolder)
+	}
+}
@@ -22,13 +22,22 @@ type PoolsUsecaseMock struct {
 	GetAllPoolsFunc                     func() ([]sqsdomain.PoolI, error)
 	GetPoolsFunc                        func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error)
 	StorePoolsFunc                      func(pools []sqsdomain.PoolI) error
-	GetRoutesFromCandidatesFunc         func(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
+	GetRoutesFromCandidatesFunc         func(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
 	GetTickModelMapFunc                 func(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error)
 	GetPoolFunc                         func(poolID uint64) (sqsdomain.PoolI, error)
 	GetPoolSpotPriceFunc                func(ctx context.Context, poolID uint64, takerFee osmomath.Dec, quoteAsset, baseAsset string) (osmomath.BigDec, error)
 	GetCosmWasmPoolConfigFunc           func() domain.CosmWasmPoolRouterConfig
 	CalcExitCFMMPoolFunc                func(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error)
 	GetAllCanonicalOrderbookPoolIDsFunc func() ([]domain.CanonicalOrderBooksResult, error)
+	DeletePoolsFunc                     func(poolIDs []uint64)
+	QuarantinePoolsFunc                 func(quarantinedPools []domain.QuarantinedPool)
+	ReleaseQuarantinedPoolsFunc         func(poolIDs []uint64) []uint64
+	GetQuarantinedPoolsFunc             func() []domain.QuarantinedPool
+	IsPoolQuarantinedFunc               func(poolID uint64) bool
+	RegisterPoolCircuitBreakerFunc      func(poolCircuitBreaker domain.PoolCircuitBreaker)
+	InvalidateCosmWasmPoolQueriesFunc   func(poolIDs map[uint64]struct{})
+	RegisterCosmWasmPoolFunc            func(registration cosmwasmdomain.PoolRegistration) error
+	GetAlloyTransmuterCapacitiesFunc    func() []domain.AlloyTransmuterCapacity
 
 	Pools        []sqsdomain.PoolI
 	TickModelMap map[uint64]*sqsdomain.TickModel
@@ -60,6 +69,80 @@ func (pm *PoolsUsecaseMock) StorePools(pools []sqsdomain.PoolI) error {
 	panic("unimplemented")
 }
 
+// DeletePools implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) DeletePools(poolIDs []uint64) {
+	if pm.DeletePoolsFunc != nil {
+		pm.DeletePoolsFunc(poolIDs)
+		return
+	}
+	panic("unimplemented")
+}
+
+// QuarantinePools implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) QuarantinePools(quarantinedPools []domain.QuarantinedPool) {
+	if pm.QuarantinePoolsFunc != nil {
+		pm.QuarantinePoolsFunc(quarantinedPools)
+		return
+	}
+	panic("unimplemented")
+}
+
+// ReleaseQuarantinedPools implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) ReleaseQuarantinedPools(poolIDs []uint64) []uint64 {
+	if pm.ReleaseQuarantinedPoolsFunc != nil {
+		return pm.ReleaseQuarantinedPoolsFunc(poolIDs)
+	}
+	panic("unimplemented")
+}
+
+// GetQuarantinedPools implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetQuarantinedPools() []domain.QuarantinedPool {
+	if pm.GetQuarantinedPoolsFunc != nil {
+		return pm.GetQuarantinedPoolsFunc()
+	}
+	panic("unimplemented")
+}
+
+// IsPoolQuarantined implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) IsPoolQuarantined(poolID uint64) bool {
+	if pm.IsPoolQuarantinedFunc != nil {
+		return pm.IsPoolQuarantinedFunc(poolID)
+	}
+	panic("unimplemented")
+}
+
+// RegisterPoolCircuitBreaker implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker) {
+	if pm.RegisterPoolCircuitBreakerFunc != nil {
+		pm.RegisterPoolCircuitBreakerFunc(poolCircuitBreaker)
+		return
+	}
+	panic("unimplemented")
+}
+
+// InvalidateCosmWasmPoolQueries implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) InvalidateCosmWasmPoolQueries(poolIDs map[uint64]struct{}) {
+	if pm.InvalidateCosmWasmPoolQueriesFunc != nil {
+		pm.InvalidateCosmWasmPoolQueriesFunc(poolIDs)
+	}
+}
+
+// GetAlloyTransmuterCapacities implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetAlloyTransmuterCapacities() []domain.AlloyTransmuterCapacity {
+	if pm.GetAlloyTransmuterCapacitiesFunc != nil {
+		return pm.GetAlloyTransmuterCapacitiesFunc()
+	}
+	panic("unimplemented")
+}
+
+// RegisterCosmWasmPool implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) RegisterCosmWasmPool(registration cosmwasmdomain.PoolRegistration) error {
+	if pm.RegisterCosmWasmPoolFunc != nil {
+		return pm.RegisterCosmWasmPoolFunc(registration)
+	}
+	panic("unimplemented")
+}
+
 // GetCosmWasmPoolConfig implements mvc.PoolsUsecase.
 func (pm *PoolsUsecaseMock) GetCosmWasmPoolConfig() domain.CosmWasmPoolRouterConfig {
 	if pm.GetCosmWasmPoolConfigFunc != nil {
@@ -83,9 +166,9 @@ func (pm *PoolsUsecaseMock) GetPools(opts ...domain.PoolsOption) ([]sqsdomain.Po
 // GetRoutesFromCandidates implements mvc.PoolsUsecase.
 // Note that taker fee are ignored and not set
 // Note that tick models are not set
-func (pm *PoolsUsecaseMock) GetRoutesFromCandidates(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom string, tokenOutDenom string) ([]route.RouteImpl, error) {
+func (pm *PoolsUsecaseMock) GetRoutesFromCandidates(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom string, tokenOutDenom string) ([]route.RouteImpl, error) {
 	if pm.GetRoutesFromCandidatesFunc != nil {
-		return pm.GetRoutesFromCandidatesFunc(candidateRoutes, tokenInDenom, tokenOutDenom)
+		return pm.GetRoutesFromCandidatesFunc(ctx, candidateRoutes, tokenInDenom, tokenOutDenom)
 	}
 
 	finalRoutes := make([]route.RouteImpl, 0, len(candidateRoutes.Routes))
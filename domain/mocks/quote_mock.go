@@ -0,0 +1,105 @@
+package mocks
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/log"
+)
+
+type QuoteMock struct {
+	GetAmountInFunc                func() sdk.Coin
+	GetAmountOutFunc               func() osmomath.Int
+	GetRouteFunc                   func() []domain.SplitRoute
+	GetEffectiveFeeFunc            func() osmomath.Dec
+	GetPriceImpactFunc             func() osmomath.Dec
+	GetInBaseOutQuoteSpotPriceFunc func() osmomath.Dec
+	GetIsPartialFunc               func() bool
+	PrepareResultFunc              func(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error)
+	StringFunc                     func() string
+}
+
+var _ domain.Quote = &QuoteMock{}
+
+// GetAmountIn implements domain.Quote.
+func (q *QuoteMock) GetAmountIn() sdk.Coin {
+	if q.GetAmountInFunc != nil {
+		return q.GetAmountInFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetAmountOut implements domain.Quote.
+func (q *QuoteMock) GetAmountOut() osmomath.Int {
+	if q.GetAmountOutFunc != nil {
+		return q.GetAmountOutFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetRoute implements domain.Quote.
+func (q *QuoteMock) GetRoute() []domain.SplitRoute {
+	if q.GetRouteFunc != nil {
+		return q.GetRouteFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetEffectiveFee implements domain.Quote.
+func (q *QuoteMock) GetEffectiveFee() osmomath.Dec {
+	if q.GetEffectiveFeeFunc != nil {
+		return q.GetEffectiveFeeFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetPriceImpact implements domain.Quote.
+func (q *QuoteMock) GetPriceImpact() osmomath.Dec {
+	if q.GetPriceImpactFunc != nil {
+		return q.GetPriceImpactFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetInBaseOutQuoteSpotPrice implements domain.Quote.
+func (q *QuoteMock) GetInBaseOutQuoteSpotPrice() osmomath.Dec {
+	if q.GetInBaseOutQuoteSpotPriceFunc != nil {
+		return q.GetInBaseOutQuoteSpotPriceFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// GetIsPartial implements domain.Quote.
+func (q *QuoteMock) GetIsPartial() bool {
+	if q.GetIsPartialFunc != nil {
+		return q.GetIsPartialFunc()
+	}
+
+	panic("unimplemented")
+}
+
+// PrepareResult implements domain.Quote.
+func (q *QuoteMock) PrepareResult(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
+	if q.PrepareResultFunc != nil {
+		return q.PrepareResultFunc(ctx, scalingFactor, logger)
+	}
+
+	panic("unimplemented")
+}
+
+// String implements domain.Quote.
+func (q *QuoteMock) String() string {
+	if q.StringFunc != nil {
+		return q.StringFunc()
+	}
+
+	panic("unimplemented")
+}
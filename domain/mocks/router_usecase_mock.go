@@ -24,7 +24,7 @@ type RouterUsecaseMock struct {
 	GetCustomDirectQuoteFunc                     func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, poolID uint64) (domain.Quote, error)
 	GetCustomDirectQuoteMultiPoolFunc            func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error)
 	GetCustomDirectQuoteMultiPoolInGivenOutFunc  func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error)
-	GetCandidateRoutesFunc                       func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error)
+	GetCandidateRoutesFunc                       func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error)
 	GetTakerFeeFunc                              func(poolID uint64) ([]sqsdomain.TakerFeeForPair, error)
 	SetTakerFeesFunc                             func(takerFees sqsdomain.TakerFeeMap)
 	GetCachedCandidateRoutesFunc                 func(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool, error)
@@ -35,6 +35,10 @@ type RouterUsecaseMock struct {
 	ConvertMinTokensPoolLiquidityCapToFilterFunc func(minTokensPoolLiquidityCap uint64) uint64
 	SetSortedPoolsFunc                           func(pools []sqsdomain.PoolI)
 	GetMinPoolLiquidityCapFilterFunc             func(tokenInDenom string, tokenOutDenom string) (uint64, error)
+	RegisterPoolCircuitBreakerFunc               func(poolCircuitBreaker domain.PoolCircuitBreaker)
+	RegisterCandidateRouteIndexFunc              func(candidateRouteIndex domain.CandidateRouteIndex)
+	EnableRequestCoalescingFunc                  func(latestHeightGetter domain.LatestHeightGetter)
+	GetRouteCacheStatsFunc                       func() domain.RouteCacheStats
 }
 
 // GetMinPoolLiquidityCapFilter implements mvc.RouterUsecase.
@@ -101,9 +105,9 @@ func (m *RouterUsecaseMock) GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.
 	panic("unimplemented")
 }
 
-func (m *RouterUsecaseMock) GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error) {
+func (m *RouterUsecaseMock) GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error) {
 	if m.GetCandidateRoutesFunc != nil {
-		return m.GetCandidateRoutesFunc(ctx, tokenIn, tokenOutDenom)
+		return m.GetCandidateRoutesFunc(ctx, tokenIn, tokenOutDenom, opts...)
 	}
 	return sqsdomain.CandidateRoutes{}, nil
 }
@@ -168,3 +172,34 @@ func (m *RouterUsecaseMock) SetSortedPools(pools []sqsdomain.PoolI) {
 		m.SetSortedPoolsFunc(pools)
 	}
 }
+
+func (m *RouterUsecaseMock) RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker) {
+	if m.RegisterPoolCircuitBreakerFunc != nil {
+		m.RegisterPoolCircuitBreakerFunc(poolCircuitBreaker)
+		return
+	}
+	panic("unimplemented")
+}
+
+func (m *RouterUsecaseMock) RegisterCandidateRouteIndex(candidateRouteIndex domain.CandidateRouteIndex) {
+	if m.RegisterCandidateRouteIndexFunc != nil {
+		m.RegisterCandidateRouteIndexFunc(candidateRouteIndex)
+		return
+	}
+	panic("unimplemented")
+}
+
+func (m *RouterUsecaseMock) EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter) {
+	if m.EnableRequestCoalescingFunc != nil {
+		m.EnableRequestCoalescingFunc(latestHeightGetter)
+		return
+	}
+	panic("unimplemented")
+}
+
+func (m *RouterUsecaseMock) GetRouteCacheStats() domain.RouteCacheStats {
+	if m.GetRouteCacheStatsFunc != nil {
+		return m.GetRouteCacheStatsFunc()
+	}
+	panic("unimplemented")
+}
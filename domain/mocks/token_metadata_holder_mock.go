@@ -1,10 +1,16 @@
 package mocks
 
-import "github.com/osmosis-labs/sqs/domain/mvc"
+import (
+	"fmt"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+)
 
 type TokenMetadataHolderMock struct {
 	MockMinPoolLiquidityCap      uint64
 	MockMinPoolLiquidityCapError error
+	MockMetadataByChainDenom     map[string]domain.Token
 }
 
 var _ mvc.TokenMetadataHolder = &TokenMetadataHolderMock{}
@@ -13,3 +19,12 @@ var _ mvc.TokenMetadataHolder = &TokenMetadataHolderMock{}
 func (t *TokenMetadataHolderMock) GetMinPoolLiquidityCap(denomA string, denomB string) (uint64, error) {
 	return t.MockMinPoolLiquidityCap, t.MockMinPoolLiquidityCapError
 }
+
+// GetMetadataByChainDenom implements mvc.TokenMetadataHolder.
+func (t *TokenMetadataHolderMock) GetMetadataByChainDenom(denom string) (domain.Token, error) {
+	token, ok := t.MockMetadataByChainDenom[denom]
+	if !ok {
+		return domain.Token{}, fmt.Errorf("metadata for denom (%s) is not found", denom)
+	}
+	return token, nil
+}
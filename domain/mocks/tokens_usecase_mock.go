@@ -31,6 +31,7 @@ type TokensUsecaseMock struct {
 	UpdateAssetsAtHeightIntervalSyncFunc func(height uint64) error
 	SetTokenRegistryLoaderFunc           func(loader domain.TokenRegistryLoader)
 	ClearPoolDenomMetadataFunc           func()
+	EnableRequestCoalescingFunc          func(latestHeightGetter domain.LatestHeightGetter)
 }
 
 var _ mvc.TokensUsecase = &TokensUsecaseMock{}
@@ -172,3 +173,12 @@ func (m *TokensUsecaseMock) ClearPoolDenomMetadata() {
 	}
 	panic("unimplemented")
 }
+
+// EnableRequestCoalescing implements mvc.TokensUsecase.
+func (m *TokensUsecaseMock) EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter) {
+	if m.EnableRequestCoalescingFunc != nil {
+		m.EnableRequestCoalescingFunc(latestHeightGetter)
+		return
+	}
+	panic("unimplemented")
+}
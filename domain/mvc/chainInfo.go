@@ -1,5 +1,12 @@
 package mvc
 
+import (
+	"context"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
 // ChainInfoUsecase is the interface that defines the methods for the chain info usecase
 type ChainInfoUsecase interface {
 	// GetLatestHeight returns the latest height stored
@@ -8,6 +15,13 @@ type ChainInfoUsecase interface {
 	GetLatestHeight() (uint64, error)
 	// StoreLatestHeight stores the latest height in the usecase
 	StoreLatestHeight(height uint64)
+	// GetHeightMetadata returns the height metadata of the response to the request with the given context.
+	// The snapshot height and age are of the state snapshot pinned to the context, if any.
+	// Otherwise, they are of the latest ingested height.
+	GetHeightMetadata(ctx context.Context) domain.HeightMetadata
+	// GetObservedBlockTime returns the moving average of the interval between stored heights.
+	// Zero until at least two heights are stored.
+	GetObservedBlockTime() time.Duration
 	// ValidatePriceUpdates validates the price updates
 	// Returns nil if the price updates are valid
 	// Returns error otherwise.
@@ -10,11 +10,26 @@ import (
 
 // IngestUsecase represent the ingest's usecases
 type IngestUsecase interface {
-	// ProcessBlockData processes the block data as defined by height, takerFeesMap and poolData
-	// Prior to loading pools into the repository, the pools are transformed and instrumented with pool TVL data.
-	ProcessBlockData(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, poolData []*types.PoolData) (err error)
+	// ProcessBlockData processes the block data as defined by height, takerFeesMap, pools by pool ID and removedPoolIDs
+	// Prior to loading pools into the repository, the pools are validated and instrumented with pool TVL data.
+	ProcessBlockData(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) (err error)
+
+	// ApplyPoolDeltas applies the pool deltas of the block process request onto the pool data
+	// ingested previously and returns all pools updated in the block, parsed, by pool ID.
+	// The pools failing to be parsed are skipped.
+	// Must be called for every block in order, prior to processing the block data.
+	// Returns error if the request version is unsupported, if a delta is received for an unknown pool
+	// or if the checksum of the pool data after applying a delta does not match.
+	// In that case, the stored pool data is left untouched and the node is expected to reingest all pools.
+	ApplyPoolDeltas(req *types.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error)
 
 	// RegisterEndBlockProcessPlugin registers the end block process plugin
 	// That is called at the end of the block
 	RegisterEndBlockProcessPlugin(plugin domain.EndBlockProcessPlugin)
+
+	// RegisterStateSnapshotHolder registers the holder that a state snapshot of the pools, taker fees and
+	// candidate route search data is swapped into once each block is processed.
+	// The candidate route search data is read from the given holder after it is computed for the block.
+	// CONTRACT: called before processing blocks.
+	RegisterStateSnapshotHolder(stateSnapshotHolder *domain.StateSnapshotHolder, candidateRouteSearchDataHolder CandidateRouteSearchDataHolder)
 }
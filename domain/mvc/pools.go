@@ -5,6 +5,7 @@ import (
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,12 +21,14 @@ type PoolsUsecase interface {
 
 	// GetRoutesFromCandidates converts candidate routes to routes intrusmented with all the data necessary for estimating
 	// a swap. This data entails the pool data, the taker fee.
-	GetRoutesFromCandidates(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
+	// Uses the pools and taker fees of the state snapshot pinned to the context, if any.
+	GetRoutesFromCandidates(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
 
 	GetTickModelMap(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error)
 	// GetPool returns the pool with the given ID.
 	GetPool(poolID uint64) (sqsdomain.PoolI, error)
 	// GetPoolSpotPrice returns the spot price of the given pool given the taker fee, quote and base assets.
+	// Uses the pool of the state snapshot pinned to the context, if any.
 	GetPoolSpotPrice(ctx context.Context, poolID uint64, takerFee osmomath.Dec, quoteAsset, baseAsset string) (osmomath.BigDec, error)
 
 	GetCosmWasmPoolConfig() domain.CosmWasmPoolRouterConfig
@@ -43,6 +46,40 @@ type PoolsUsecase interface {
 	// IsCanonicalOrderbookPool returns true if the given pool ID is a canonical orderbook pool
 	// for some token pair.
 	IsCanonicalOrderbookPool(poolID uint64) bool
+
+	// DeletePools deletes the pools with the given IDs.
+	// Pools that do not exist are ignored.
+	DeletePools(poolIDs []uint64)
+
+	// QuarantinePools excludes the given pools from routing.
+	// The quarantine height of the pools that are already quarantined is retained.
+	QuarantinePools(quarantinedPools []domain.QuarantinedPool)
+	// ReleaseQuarantinedPools releases the pools with the given IDs from quarantine.
+	// Returns the IDs of the released pools, ignoring the pools that were not quarantined.
+	ReleaseQuarantinedPools(poolIDs []uint64) []uint64
+	// GetQuarantinedPools returns the quarantined pools sorted by pool ID.
+	GetQuarantinedPools() []domain.QuarantinedPool
+	// IsPoolQuarantined returns true if the pool with the given ID is quarantined from routing.
+	IsPoolQuarantined(poolID uint64) bool
+
+	// RegisterPoolCircuitBreaker registers the circuit breaker whose open pools
+	// are skipped when converting candidate routes into routes.
+	// CONTRACT: called before serving requests.
+	RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker)
+
+	// InvalidateCosmWasmPoolQueries removes the memoized generalized cosmwasm pool queries
+	// of the given pools. Called for the pools updated within a block.
+	InvalidateCosmWasmPoolQueries(poolIDs map[uint64]struct{})
+
+	// GetAlloyTransmuterCapacities returns the rate limiter state and the maximum swap in amount
+	// of each asset in the alloyed transmuter pools, sorted by pool ID.
+	GetAlloyTransmuterCapacities() []domain.AlloyTransmuterCapacity
+
+	// RegisterCosmWasmPool registers a custom cosmwasm pool implementation and whitelists
+	// its code IDs for routing. Built-in implementations take precedence on matching.
+	// Returns error if the registration is invalid or its name is already registered.
+	// CONTRACT: called before ingest starts.
+	RegisterCosmWasmPool(registration cosmwasmdomain.PoolRegistration) error
 }
 
 type PoolHandler interface {
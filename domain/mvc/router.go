@@ -73,7 +73,7 @@ type RouterUsecase interface {
 	// Underlying implementation uses GetCustomDirectQuote.
 	GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error)
 	// GetCandidateRoutes returns the candidate routes for the given tokenIn and tokenOutDenom.
-	GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error)
+	GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error)
 	// GetTakerFee returns the taker fee for all token pairs in a pool.
 	GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForPair, error)
 	// SetTakerFees sets the taker fees for all token pairs in all pools.
@@ -82,6 +82,8 @@ type RouterUsecase interface {
 	// It does not recompute the routes if they are not present in cache.
 	// Since we may cache zero routes, it returns false if the routes are not present in cache. Returns true otherwise.
 	// Returns error if cache is disabled.
+	// With best-first search, the candidate routes are cached per order of magnitude of the token in amount
+	// and are not found by denoms alone.
 	GetCachedCandidateRoutes(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool, error)
 	// StoreRoutes stores all router state in the files locally. Used for debugging.
 	StoreRouterStateFiles() error
@@ -93,6 +95,9 @@ type RouterUsecase interface {
 
 	GetConfig() domain.RouterConfig
 
+	// GetRouteCacheStats returns the number of entries in the candidate and ranked route caches.
+	GetRouteCacheStats() domain.RouteCacheStats
+
 	// GetMinPoolLiquidityCapFilter returns the min pool liquidity capitalization filter for the given tokenIn and tokenOutDenom.
 	// It is used to filter out pools with liquidity less than the output of this function.
 	// Returns error if one of the denom metadata is not found.
@@ -111,4 +116,19 @@ type RouterUsecase interface {
 	// CONTRACT: the pools are already sorted according to the desired parameters.
 	// See sortPools() function.
 	SetSortedPools(pools []sqsdomain.PoolI)
+
+	// RegisterPoolCircuitBreaker registers the circuit breaker excluding pools
+	// with repeated quote failures from routing.
+	// CONTRACT: called before serving requests.
+	RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker)
+
+	// RegisterCandidateRouteIndex registers the index of precomputed candidate routes
+	// used instead of searching for candidate routes of indexed pairs.
+	// CONTRACT: called before serving requests.
+	RegisterCandidateRouteIndex(candidateRouteIndex domain.CandidateRouteIndex)
+
+	// EnableRequestCoalescing enables sharing the result of identical concurrent quotes
+	// within the block returned by the latest height getter.
+	// CONTRACT: called before serving requests.
+	EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter)
 }
@@ -22,6 +22,9 @@ type TokenMetadataHolder interface {
 	// Returns error if there is no pool liquidity metadata for one of the tokens.
 	// Returns error if pool liquidity metadata is large enough to cause overflow.
 	GetMinPoolLiquidityCap(denomA, denomB string) (uint64, error)
+
+	// GetMetadataByChainDenom returns token metadata for a given chain denom.
+	GetMetadataByChainDenom(denom string) (domain.Token, error)
 }
 
 // TokensUsecase defines an interface for the tokens usecase.
@@ -32,9 +35,6 @@ type TokensUsecase interface {
 	// LoadTokens loads token meta data by chain denom into tokensUseCase.
 	LoadTokens(tokenMetadataByChainDenom map[string]domain.Token)
 
-	// GetMetadataByChainDenom returns token metadata for a given chain denom.
-	GetMetadataByChainDenom(denom string) (domain.Token, error)
-
 	// GetFullTokenMetadata returns token metadata for all chain denoms as a map.
 	GetFullTokenMetadata() (map[string]domain.Token, error)
 
@@ -96,6 +96,11 @@ type TokensUsecase interface {
 
 	// SetTokenRegistryLoader sets the token registry loader.
 	SetTokenRegistryLoader(loader domain.TokenRegistryLoader)
+
+	// EnableRequestCoalescing enables sharing the result of identical concurrent price requests
+	// within the block returned by the latest height getter.
+	// CONTRACT: called before serving requests.
+	EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter)
 }
 
 // ValidateChainDenomQueryParam validates the chain denom query parameter.
@@ -136,6 +141,12 @@ func ValidateChainDenomsQueryParam(c echo.Context, tokensUsecase TokensUsecase,
 		return nil, err
 	}
 
+	return ValidateChainDenoms(tokensUsecase, denoms, isHumanDenoms)
+}
+
+// ValidateChainDenoms validates the given denoms, translating them to chain denoms
+// if isHumanDenoms is true. Shared by the HTTP and gRPC handlers.
+func ValidateChainDenoms(tokensUsecase TokensUsecase, denoms []string, isHumanDenoms bool) ([]string, error) {
 	chainDenoms := make([]string, len(denoms))
 	for i, denom := range denoms {
 		chainDenom, err := ValidateChainDenomQueryParam(tokensUsecase, denom, isHumanDenoms)
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+)
+
+// Pool circuit breaker states.
+const (
+	// PoolCircuitBreakerClosed is the state of a pool that is routed normally
+	// while its consecutive quote failures are below the threshold.
+	PoolCircuitBreakerClosed = "closed"
+	// PoolCircuitBreakerOpen is the state of a pool excluded from routing.
+	// The pool is still retained in the candidate routes so that it is routed again once half-open.
+	PoolCircuitBreakerOpen = "open"
+	// PoolCircuitBreakerHalfOpen is the state of a tripped pool whose backoff expired.
+	// The pool is routed again. A success closes the breaker while a failure reopens it
+	// with a doubled backoff.
+	PoolCircuitBreakerHalfOpen = "half_open"
+)
+
+// PoolCircuitBreakerState is the circuit breaker state of a pool with recent quote failures.
+type PoolCircuitBreakerState struct {
+	PoolID uint64 `json:"pool_id"`
+	State  string `json:"state"`
+	// ConsecutiveFailures is the number of quote failures since the latest success.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// Trips is the number of times the breaker opened since the latest success.
+	Trips     int    `json:"trips"`
+	LastError string `json:"last_error"`
+	// OpenUntil is the time until which the pool is excluded. Zero if the breaker never opened.
+	OpenUntil time.Time `json:"open_until"`
+}
+
+// PoolCircuitBreaker tracks quote failures of pools across router requests,
+// temporarily excluding pools with repeated failures from routing.
+type PoolCircuitBreaker interface {
+	// RecordQuoteSuccess records a successful quote over the pool, closing its breaker.
+	// Successes while the breaker is open are ignored since these are of requests
+	// that were in flight when the breaker opened.
+	RecordQuoteSuccess(poolID uint64)
+
+	// RecordQuoteFailure records a quote failure of the pool.
+	// Failures that are expected for healthy pools, such as insufficient liquidity
+	// or cancelled requests, are ignored.
+	RecordQuoteFailure(poolID uint64, err error)
+
+	// IsPoolOpen returns true if the pool is currently excluded from routing.
+	IsPoolOpen(poolID uint64) bool
+
+	// GetStates returns the states of all pools with recent quote failures sorted by pool ID.
+	GetStates() []PoolCircuitBreakerState
+}
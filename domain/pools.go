@@ -5,6 +5,10 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+	"github.com/osmosis-labs/sqs/sqsdomain"
 )
 
 // CosmWasmPoolRouterConfig is the config for the CosmWasm pools in the router
@@ -17,6 +21,8 @@ type CosmWasmPoolRouterConfig struct {
 	OrderbookCodeIDs map[uint64]struct{}
 	// code IDs for the generalized cosmwasm pool type
 	GeneralCosmWasmCodeIDs map[uint64]struct{}
+	// code IDs for the pool types registered via the cosmwasm pool registry
+	CustomCosmWasmCodeIDs map[uint64]struct{}
 
 	// ChainGRPCGatewayEndpoint is the endpoint for the chain's gRPC gateway
 	ChainGRPCGatewayEndpoint string
@@ -84,6 +90,51 @@ func (c CanonicalOrderBooksResult) Validate() error {
 	return nil
 }
 
+// QuarantinedPool is a pool excluded from routing because it failed ingest-time validation.
+// It is released once a later block passes validation.
+type QuarantinedPool struct {
+	PoolID   uint64 `json:"pool_id"`
+	PoolType string `json:"pool_type"`
+	// Reason is the latest validation failure.
+	Reason string `json:"reason"`
+	// QuarantinedHeight is the height at which the pool was quarantined.
+	QuarantinedHeight uint64 `json:"quarantined_height"`
+	// LatestFailedHeight is the latest height at which the pool failed validation.
+	LatestFailedHeight uint64 `json:"latest_failed_height"`
+}
+
+// AlloyTransmuterCapacity is the rate limiter state of an alloyed transmuter pool.
+type AlloyTransmuterCapacity struct {
+	PoolID       uint64                         `json:"pool_id"`
+	AlloyedDenom string                         `json:"alloyed_denom"`
+	Assets       []AlloyTransmuterAssetCapacity `json:"assets"`
+}
+
+// AlloyTransmuterAssetCapacity is the rate limiter state of an asset in an alloyed transmuter pool.
+type AlloyTransmuterAssetCapacity struct {
+	Denom string `json:"denom"`
+	// Weight is the current normalized weight of the asset in the pool.
+	Weight osmomath.Dec `json:"weight"`
+	// StaticUpperLimit is the upper limit of the weight. Nil if there is no static limiter.
+	StaticUpperLimit *osmomath.Dec `json:"static_upper_limit,omitempty"`
+	// ChangeLimiter is the change limiter state. Nil if there is no change limiter.
+	ChangeLimiter *AlloyTransmuterChangeLimiterState `json:"change_limiter,omitempty"`
+	// MaxSwapInAmount is the maximum amount that can be swapped in before a limiter trips.
+	// It is an estimate if limited by the change limiter. Nil if unlimited.
+	MaxSwapInAmount *osmomath.Int `json:"max_swap_in_amount,omitempty"`
+}
+
+// AlloyTransmuterChangeLimiterState is the window state of an alloyed transmuter change limiter.
+type AlloyTransmuterChangeLimiterState struct {
+	WindowSize    uint64 `json:"window_size"`
+	DivisionCount uint64 `json:"division_count"`
+	// MovingAverage is the moving average of the weight over the window.
+	MovingAverage  osmomath.Dec `json:"moving_average"`
+	BoundaryOffset osmomath.Dec `json:"boundary_offset"`
+	// UpperLimit is the moving average plus the boundary offset.
+	UpperLimit osmomath.Dec `json:"upper_limit"`
+}
+
 type PoolsOptions struct {
 	MinPoolLiquidityCap  uint64
 	PoolIDFilter         []uint64
@@ -122,3 +173,42 @@ func WithMarketIncentives(withMarketIncentives bool) PoolsOption {
 		o.WithMarketIncentives = withMarketIncentives
 	}
 }
+
+// PoolResponse is a structure for serializing pool result returned to clients.
+type PoolResponse struct {
+	ChainModel poolmanagertypes.PoolI    `json:"chain_model"`
+	Balances   sdk.Coins                 `json:"balances"`
+	Type       poolmanagertypes.PoolType `json:"type"`
+	// In some cases, spread factor might be duplicated in the chain model.
+	// However, we duplicate it here for client convinience to be able to always
+	// rely on it being present.
+	SpreadFactor      osmomath.Dec `json:"spread_factor"`
+	LiquidityCap      osmomath.Int `json:"liquidity_cap"`
+	LiquidityCapError string       `json:"liquidity_cap_error"`
+
+	APRData  passthroughdomain.PoolAPRDataStatusWrap  `json:"apr_data,omitempty"`
+	FeesData passthroughdomain.PoolFeesDataStatusWrap `json:"fees_data,omitempty"`
+}
+
+// NewPoolResponse converts a given pool to the appropriate response type.
+func NewPoolResponse(pool sqsdomain.PoolI) PoolResponse {
+	return PoolResponse{
+		ChainModel:        pool.GetUnderlyingPool(),
+		Balances:          pool.GetSQSPoolModel().Balances,
+		Type:              pool.GetType(),
+		SpreadFactor:      pool.GetSQSPoolModel().SpreadFactor,
+		LiquidityCap:      pool.GetLiquidityCap(),
+		LiquidityCapError: pool.GetLiquidityCapError(),
+		APRData:           pool.GetAPRData(),
+		FeesData:          pool.GetFeesData(),
+	}
+}
+
+// NewPoolsResponse converts the given pools to the appropriate response type.
+func NewPoolsResponse(pools []sqsdomain.PoolI) []PoolResponse {
+	resultPools := make([]PoolResponse, 0, len(pools))
+	for _, pool := range pools {
+		resultPools = append(resultPools, NewPoolResponse(pool))
+	}
+	return resultPools
+}
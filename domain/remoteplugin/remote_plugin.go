@@ -0,0 +1,43 @@
+package remoteplugindomain
+
+import (
+	"fmt"
+
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+const (
+	// RemotePluginHostName is the name of the out-of-process plugin host.
+	RemotePluginHostName = "remote"
+
+	// AuthTokenMetadataKey is the gRPC metadata key that plugins send the shared auth token in.
+	AuthTokenMetadataKey = "x-sqs-plugin-token"
+)
+
+// PluginAddressNotAllowedError is returned when a plugin registers with an address
+// that is not in the allowed plugin addresses.
+type PluginAddressNotAllowedError struct {
+	Address string
+}
+
+func (e PluginAddressNotAllowedError) Error() string {
+	return fmt.Sprintf("plugin address (%s) is not allowed", e.Address)
+}
+
+// PluginHost manages the out-of-process ingest plugins that register over gRPC.
+type PluginHost interface {
+	// RegisterPlugin registers a plugin serving the SQSPlugin gRPC service at the given address.
+	// Registering a plugin with an existing name replaces it.
+	// Returns error if the name or address are invalid or PluginAddressNotAllowedError
+	// if the address is not allowed.
+	RegisterPlugin(name, address string) error
+
+	// DeregisterPlugin deregisters the plugin with the given name.
+	// Returns error if the plugin is not registered.
+	DeregisterPlugin(name string) error
+
+	// GetPoolData returns the snapshots of the pools with the given IDs
+	// encoded in the same format as the ingested pool data.
+	// Returns error if any of the pools is not found or fails to be encoded.
+	GetPoolData(poolIDs []uint64) ([]*prototypes.PoolData, error)
+}
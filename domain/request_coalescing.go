@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Requests that can be coalesced. Used as the metric label.
+const (
+	CoalescedQuoteRequest  = "quote"
+	CoalescedPricesRequest = "prices"
+)
+
+// LatestHeightGetter returns the latest blockchain height ingested by SQS.
+// Coalesced requests are keyed by height so that results are never shared across blocks.
+type LatestHeightGetter interface {
+	GetLatestHeight() uint64
+}
+
+// CoalesceRequest runs fn once for all concurrent callers with the same key within the group,
+// sharing its result between them. The shared computation runs with the context of the caller that started it.
+// Callers that joined the computation and receive a context error while their own context is still
+// active recompute the result with their context rather than failing.
+// Returns the context error if the caller's context is done before the result is available.
+// Note that the result is shared by reference. Callers mutating it must copy it first.
+func CoalesceRequest[T any](ctx context.Context, group *singleflight.Group, key string, request string, fn func(ctx context.Context) (T, error)) (T, error) {
+	isLeader := false
+	resultCh := group.DoChan(key, func() (val any, err error) {
+		isLeader = true
+
+		// The computation runs on a separate goroutine that must not crash the process.
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in coalesced %s request: %v", request, r)
+			}
+		}()
+
+		return fn(ctx)
+	})
+
+	var result singleflight.Result
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case result = <-resultCh:
+	}
+
+	// isLeader is safe to read since the result is sent after fn returns.
+	if isLeader {
+		return getCoalescedResult[T](result)
+	}
+
+	SQSCoalescedRequestsCounter.WithLabelValues(request).Inc()
+
+	if isContextError(result.Err) && ctx.Err() == nil {
+		return fn(ctx)
+	}
+
+	return getCoalescedResult[T](result)
+}
+
+// getCoalescedResult returns the typed value and error of the coalesced result.
+func getCoalescedResult[T any](result singleflight.Result) (T, error) {
+	if result.Err != nil {
+		var zero T
+		return zero, result.Err
+	}
+
+	// Comma-ok since the value is nil for nil interface results.
+	val, _ := result.Val.(T)
+	return val, nil
+}
+
+// isContextError returns true if the error is caused by a canceled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,145 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+const testCoalescingKey = "key"
+
+// This test validates that identical concurrent requests share the result of a single computation.
+func TestCoalesceRequest_SharesResult(t *testing.T) {
+	const numRequests = 5
+
+	var (
+		group    singleflight.Group
+		numCalls atomic.Int32
+		release  = make(chan struct{})
+		wg       sync.WaitGroup
+	)
+
+	results := make([]int, numRequests)
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			result, err := domain.CoalesceRequest(context.Background(), &group, testCoalescingKey, domain.CoalescedQuoteRequest, func(ctx context.Context) (int, error) {
+				numCalls.Add(1)
+				<-release
+				return 42, nil
+			})
+			require.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+
+	// Wait for the first computation to start and give the rest of the requests time to join it.
+	require.Eventually(t, func() bool { return numCalls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), numCalls.Load())
+	for _, result := range results {
+		require.Equal(t, 42, result)
+	}
+}
+
+// This test validates that a request joining a computation canceled by the context of the
+// request that started it recomputes the result with its own context.
+func TestCoalesceRequest_RecomputesOnLeaderContextCanceled(t *testing.T) {
+	var (
+		group    singleflight.Group
+		numCalls atomic.Int32
+	)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+
+	leaderErrCh := make(chan error, 1)
+	go func() {
+		_, err := domain.CoalesceRequest(leaderCtx, &group, testCoalescingKey, domain.CoalescedPricesRequest, func(ctx context.Context) (int, error) {
+			numCalls.Add(1)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		leaderErrCh <- err
+	}()
+
+	require.Eventually(t, func() bool { return numCalls.Load() == 1 }, time.Second, time.Millisecond)
+
+	followerResultCh := make(chan int, 1)
+	go func() {
+		result, err := domain.CoalesceRequest(context.Background(), &group, testCoalescingKey, domain.CoalescedPricesRequest, func(ctx context.Context) (int, error) {
+			numCalls.Add(1)
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			return 42, nil
+		})
+		require.NoError(t, err)
+		followerResultCh <- result
+	}()
+
+	// Give the follower time to join the computation before canceling it.
+	time.Sleep(50 * time.Millisecond)
+	cancelLeader()
+
+	require.ErrorIs(t, <-leaderErrCh, context.Canceled)
+	require.Equal(t, 42, <-followerResultCh)
+	require.Equal(t, int32(2), numCalls.Load())
+}
+
+// This test validates that the request returns as soon as its context is done
+// without waiting for the computation.
+func TestCoalesceRequest_ContextDone(t *testing.T) {
+	var group singleflight.Group
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := domain.CoalesceRequest(ctx, &group, testCoalescingKey, domain.CoalescedQuoteRequest, func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// This test validates that errors and panics of the computation are returned as errors.
+func TestCoalesceRequest_Error(t *testing.T) {
+	var group singleflight.Group
+
+	expectedErr := errors.New("computation error")
+	_, err := domain.CoalesceRequest(context.Background(), &group, testCoalescingKey, domain.CoalescedQuoteRequest, func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	})
+	require.ErrorIs(t, err, expectedErr)
+
+	_, err = domain.CoalesceRequest(context.Background(), &group, testCoalescingKey, domain.CoalescedQuoteRequest, func(ctx context.Context) (int, error) {
+		panic("computation panic")
+	})
+	require.ErrorContains(t, err, "computation panic")
+}
+
+// This test validates that nil interface results are returned without panicking.
+func TestCoalesceRequest_NilInterfaceResult(t *testing.T) {
+	var group singleflight.Group
+
+	result, err := domain.CoalesceRequest(context.Background(), &group, testCoalescingKey, domain.CoalescedQuoteRequest, func(ctx context.Context) (domain.Quote, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
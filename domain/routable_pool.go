@@ -68,3 +68,13 @@ type RoutablePool interface {
 
 	String() string
 }
+
+// CapacityLimitedPool is a routable pool that limits the amount that can be swapped in
+// before the swap is rejected by the pool (e.g. by a rate limiter).
+type CapacityLimitedPool interface {
+	RoutablePool
+
+	// GetMaxTokenInAmount returns the maximum amount of the given denom that can be swapped in.
+	// Returns false if the amount is not limited.
+	GetMaxTokenInAmount(tokenInDenom string) (osmomath.Int, bool, error)
+}
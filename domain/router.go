@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/log"
@@ -64,6 +66,8 @@ type Quote interface {
 	GetEffectiveFee() osmomath.Dec
 	GetPriceImpact() osmomath.Dec
 	GetInBaseOutQuoteSpotPrice() osmomath.Dec
+	// GetIsPartial returns true if the quote is the best found before the quote time budget was exhausted.
+	GetIsPartial() bool
 
 	// PrepareResult mutates the quote to prepare
 	// it with the data formatted for output to the client.
@@ -109,6 +113,105 @@ type RouterConfig struct {
 
 	// DynamicMinLiquidityCapFiltersAsc is a list of dynamic min liquidity cap filters in descending order.
 	DynamicMinLiquidityCapFiltersDesc []DynamicMinLiquidityCapFilterEntry `mapstructure:"dynamic-min-liquidity-cap-filters-desc"`
+
+	// PoolCircuitBreaker configures the circuit breaker excluding pools
+	// with repeated quote failures from candidate route search.
+	PoolCircuitBreaker PoolCircuitBreakerConfig `mapstructure:"pool-circuit-breaker"`
+
+	// GeneralCosmWasmSplitQueryBudget is the maximum number of generalized CosmWasm pool queries
+	// to chain that computing a split quote may issue. Memoized queries are not counted.
+	// Zero, the default, excludes routes with generalized CosmWasm pools from splits.
+	GeneralCosmWasmSplitQueryBudget int `mapstructure:"general-cosmwasm-split-query-budget"`
+
+	// CandidateRouteSearchAlgorithm is the algorithm used for candidate route search.
+	// One of CandidateRouteSearchAlgorithmBFS (default) or CandidateRouteSearchAlgorithmBestFirst.
+	CandidateRouteSearchAlgorithm string `mapstructure:"candidate-route-search-algorithm"`
+
+	// CandidateRouteIndex configures the index of candidate routes precomputed
+	// for pairs of the top denoms by liquidity capitalization.
+	CandidateRouteIndex CandidateRouteIndexConfig `mapstructure:"candidate-route-index"`
+
+	// QuoteTimeBudgetMs is the time budget of computing an optimal quote in milliseconds.
+	// Once exhausted, the best quote found so far is returned, marked as partial.
+	// Zero disables the budget.
+	QuoteTimeBudgetMs int `mapstructure:"quote-time-budget-ms"`
+
+	// MaxQuoteTimeBudgetMs is the maximum time budget in milliseconds a quote request may set
+	// to override QuoteTimeBudgetMs. Requests setting a larger budget are rejected.
+	MaxQuoteTimeBudgetMs int `mapstructure:"max-quote-time-budget-ms"`
+}
+
+// CandidateRouteIndexConfig is the configuration of the candidate route index.
+type CandidateRouteIndexConfig struct {
+	// Enabled defines if the candidate route index is enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// NumTopDenoms is the number of denoms with the highest liquidity capitalization
+	// whose pairings are indexed.
+	NumTopDenoms int `mapstructure:"num-top-denoms"`
+}
+
+// Validate validates the candidate route index config.
+// Returns an error if the index is enabled with a non-positive number of top denoms.
+func (c CandidateRouteIndexConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.NumTopDenoms <= 0 {
+		return fmt.Errorf("candidate route index number of top denoms must be positive, was (%d)", c.NumTopDenoms)
+	}
+
+	return nil
+}
+
+const (
+	// CandidateRouteSearchAlgorithmBFS searches candidate routes breadth-first,
+	// returning the first found routes.
+	CandidateRouteSearchAlgorithmBFS = "bfs"
+	// CandidateRouteSearchAlgorithmBestFirst searches candidate routes best-first,
+	// expanding the routes with the highest estimated output first.
+	CandidateRouteSearchAlgorithmBestFirst = "best-first"
+)
+
+// PoolCircuitBreakerConfig is the configuration of the pool circuit breaker.
+type PoolCircuitBreakerConfig struct {
+	// Enabled defines if the pool circuit breaker is enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the number of consecutive quote failures of a pool
+	// after which the breaker opens for the pool.
+	FailureThreshold int `mapstructure:"failure-threshold"`
+
+	// InitialBackoffSeconds is how long the pool is excluded for after the first trip.
+	// The backoff doubles with every consecutive trip.
+	InitialBackoffSeconds int `mapstructure:"initial-backoff-seconds"`
+
+	// MaxBackoffSeconds caps the exponential backoff.
+	MaxBackoffSeconds int `mapstructure:"max-backoff-seconds"`
+}
+
+// Validate validates the pool circuit breaker config.
+// Returns an error if the breaker is enabled with a non-positive threshold or backoff
+// or if the max backoff is smaller than the initial backoff.
+func (c PoolCircuitBreakerConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.FailureThreshold <= 0 {
+		return fmt.Errorf("pool circuit breaker failure threshold must be positive")
+	}
+
+	if c.InitialBackoffSeconds <= 0 {
+		return fmt.Errorf("pool circuit breaker initial backoff must be positive")
+	}
+
+	if c.MaxBackoffSeconds < c.InitialBackoffSeconds {
+		return fmt.Errorf("pool circuit breaker max backoff must not be smaller than the initial backoff")
+	}
+
+	return nil
 }
 
 type PoolsConfig struct {
@@ -125,6 +228,40 @@ type PoolsConfig struct {
 	// NOTE: that these pools make network requests to chain for quote estimation.
 	// As a result, they are excluded from split routes.
 	GeneralCosmWasmCodeIDs []uint64 `mapstructure:"general-cosmwasm-code-ids"`
+
+	// GeneralCosmWasmQueryCache configures the memoization of generalized CosmWasm pool queries.
+	GeneralCosmWasmQueryCache GeneralCosmWasmQueryCacheConfig `mapstructure:"general-cosmwasm-query-cache"`
+}
+
+// GeneralCosmWasmQueryCacheConfig is the configuration of the generalized CosmWasm pool query cache.
+// Quote and spot price queries are memoized until the pool is updated in a block.
+type GeneralCosmWasmQueryCacheConfig struct {
+	// Enabled defines if the query cache is enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxConcurrentQueries caps the number of concurrent queries to chain.
+	MaxConcurrentQueries int `mapstructure:"max-concurrent-queries"`
+
+	// MaxEntriesPerPool caps the number of memoized queries per pool.
+	MaxEntriesPerPool int `mapstructure:"max-entries-per-pool"`
+}
+
+// Validate validates the generalized CosmWasm pool query cache config.
+// Returns an error if the cache is enabled with a non-positive cap.
+func (c GeneralCosmWasmQueryCacheConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxConcurrentQueries <= 0 {
+		return fmt.Errorf("general cosmwasm query cache max concurrent queries must be positive")
+	}
+
+	if c.MaxEntriesPerPool <= 0 {
+		return fmt.Errorf("general cosmwasm query cache max entries per pool must be positive")
+	}
+
+	return nil
 }
 
 const DisableSplitRoutes = 0
@@ -137,6 +274,12 @@ type RouterState struct {
 	CandidateRouteSearchData map[string]CandidateRouteDenomData
 }
 
+// RouteCacheStats are the number of entries in the route caches.
+type RouteCacheStats struct {
+	CandidateRoutes int `json:"candidate_routes"`
+	RankedRoutes    int `json:"ranked_routes"`
+}
+
 // RouterOptions defines the options for the router
 // By default, the router config that is defined on the router usecase is set.
 // The caller of GetQuote(...) may overwrite the config with the options provided here.
@@ -159,6 +302,12 @@ type RouterOptions struct {
 	// If at least one of the callbacks in-slice returns true, the ShouldSkipPool function will
 	// also return true.
 	CandidateRoutesPoolFiltersAnyOf []CandidateRoutePoolFiltrerCb
+	// CandidateRouteDenomConstraints constrain the intermediate denoms of candidate routes.
+	// If set, the candidate route and ranked route caches are bypassed.
+	CandidateRouteDenomConstraints CandidateRouteDenomConstraints
+	// QuoteTimeBudget is the time budget of computing the quote. Once exhausted,
+	// the best quote found so far is returned, marked as partial. Zero disables the budget.
+	QuoteTimeBudget time.Duration
 }
 
 // DefaultRouterOptions defines the default options for the router
@@ -175,6 +324,13 @@ func WithMinPoolLiquidityCap(minPoolLiquidityCap uint64) RouterOption {
 	}
 }
 
+// WithQuoteTimeBudget configures the router options with the quote time budget.
+func WithQuoteTimeBudget(quoteTimeBudget time.Duration) RouterOption {
+	return func(o *RouterOptions) {
+		o.QuoteTimeBudget = quoteTimeBudget
+	}
+}
+
 // WithMaxPoolsPerRoute configures the router options with the max pools per route.
 func WithMaxPoolsPerRoute(maxPoolsPerRoute int) RouterOption {
 	return func(o *RouterOptions) {
@@ -217,6 +373,13 @@ func WithCandidateRoutesPoolFiltersAnyOf(filters ...CandidateRoutePoolFiltrerCb)
 	}
 }
 
+// WithCandidateRouteDenomConstraints configures the router options with the candidate route denom constraints.
+func WithCandidateRouteDenomConstraints(constraints CandidateRouteDenomConstraints) RouterOption {
+	return func(o *RouterOptions) {
+		o.CandidateRouteDenomConstraints = constraints
+	}
+}
+
 // CandidateRouteSearchDataWorker defines the interface for the candidate route search data worker.
 // It pre-computes data necessary for efficiently computing candidate routes.
 type CandidateRouteSearchDataWorker interface {
@@ -233,5 +396,6 @@ type CandidateRouteSearchDataWorker interface {
 // PricingUpdateListener defines the interface for the candidate route search data listener.
 type CandidateRouteSearchDataUpdateListener interface {
 	// OnSearchDataUpdate notifies the listener of the candidate route data update.
-	OnSearchDataUpdate(ctx context.Context, height uint64) error
+	// blockPoolMetaData contains the denoms and pools updated within the block.
+	OnSearchDataUpdate(ctx context.Context, height uint64, blockPoolMetaData BlockPoolMetadata) error
 }
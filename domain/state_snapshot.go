@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"maps"
+	"sync/atomic"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// StateSnapshotHeightHeader is the response header reporting the height of the state snapshot
+// that the request was served from.
+const StateSnapshotHeightHeader = "X-SQS-Snapshot-Height"
+
+// stateSnapshotPoolShards is the number of shards that the pools of a state snapshot are split into by pool ID.
+const stateSnapshotPoolShards = 64
+
+// StateSnapshot is a view of the pools, taker fees and candidate route search data
+// ingested at a given height. Pools carry their tick models.
+// Requests pinning a snapshot observe the pools, taker fees and candidate route search data of a single block
+// for their lifetime, even if the next block is ingested concurrently.
+//
+// The pool objects are shared with the latest state and the other snapshots rather than copied.
+// As a result, their liquidity capitalization, which is repriced in place asynchronously after the block
+// is processed, may reflect the pricing of a later block.
+// CONTRACT: the snapshot and its pools must never be mutated other than by the liquidity capitalization repricing.
+type StateSnapshot struct {
+	height    uint64
+	createdAt time.Time
+	// poolShards are the pools split into shards by pool ID. Shards without updated or removed pools
+	// are shared with the previous snapshot.
+	poolShards               [stateSnapshotPoolShards]map[uint64]sqsdomain.PoolI
+	takerFees                sqsdomain.TakerFeeMap
+	candidateRouteSearchData map[string]CandidateRouteDenomData
+}
+
+// stateSnapshotContextKey is the context key of the pinned state snapshot.
+type stateSnapshotContextKey struct{}
+
+// Update returns a new snapshot at the given height with the updated pools and taker fees applied
+// onto the ones of this snapshot and the removed pools deleted. The candidate route search data is replaced.
+// This snapshot is copied on write, remaining unchanged. The receiver may be nil if there is no previous snapshot.
+//
+// Only the pool shards containing updated or removed pools are copied, so the cost is proportional to the number
+// of pools in the block times the shard size rather than to the number of all pools. The taker fees are copied
+// only if the block updates them.
+func (s *StateSnapshot) Update(height uint64, updatedPools []sqsdomain.PoolI, removedPoolIDs []uint64, takerFees sqsdomain.TakerFeeMap, candidateRouteSearchData map[string]CandidateRouteDenomData) *StateSnapshot {
+	var (
+		poolShards        [stateSnapshotPoolShards]map[uint64]sqsdomain.PoolI
+		snapshotTakerFees sqsdomain.TakerFeeMap
+	)
+
+	if s != nil {
+		poolShards = s.poolShards
+		snapshotTakerFees = s.takerFees
+	}
+
+	// Copies the shard of the given pool on first write.
+	isShardCopied := [stateSnapshotPoolShards]bool{}
+	getMutableShard := func(poolID uint64) map[uint64]sqsdomain.PoolI {
+		shardIndex := poolID % stateSnapshotPoolShards
+		if !isShardCopied[shardIndex] {
+			shard := make(map[uint64]sqsdomain.PoolI, len(poolShards[shardIndex])+1)
+			maps.Copy(shard, poolShards[shardIndex])
+			poolShards[shardIndex] = shard
+			isShardCopied[shardIndex] = true
+		}
+		return poolShards[shardIndex]
+	}
+
+	for _, pool := range updatedPools {
+		getMutableShard(pool.GetId())[pool.GetId()] = pool
+	}
+
+	for _, poolID := range removedPoolIDs {
+		delete(getMutableShard(poolID), poolID)
+	}
+
+	if len(takerFees) > 0 {
+		snapshotTakerFees = maps.Clone(snapshotTakerFees)
+		if snapshotTakerFees == nil {
+			snapshotTakerFees = make(sqsdomain.TakerFeeMap, len(takerFees))
+		}
+
+		for denomPair, takerFee := range takerFees {
+			snapshotTakerFees.SetTakerFee(denomPair.Denom0, denomPair.Denom1, takerFee)
+		}
+	}
+
+	return &StateSnapshot{
+		height:                   height,
+		createdAt:                time.Now(),
+		poolShards:               poolShards,
+		takerFees:                snapshotTakerFees,
+		candidateRouteSearchData: candidateRouteSearchData,
+	}
+}
+
+// GetHeight returns the height of the snapshot.
+func (s *StateSnapshot) GetHeight() uint64 {
+	return s.height
+}
+
+// GetCreatedAt returns the time the snapshot was created at, once its block was processed.
+func (s *StateSnapshot) GetCreatedAt() time.Time {
+	return s.createdAt
+}
+
+// GetPool returns the pool with the given ID.
+// Returns PoolNotFoundError if the pool is not in the snapshot.
+func (s *StateSnapshot) GetPool(poolID uint64) (sqsdomain.PoolI, error) {
+	pool, ok := s.poolShards[poolID%stateSnapshotPoolShards][poolID]
+	if !ok {
+		return nil, PoolNotFoundError{PoolID: poolID}
+	}
+
+	return pool, nil
+}
+
+// GetTakerFee returns the taker fee for the given pair of denominations.
+// Returns false if the taker fee is not in the snapshot.
+func (s *StateSnapshot) GetTakerFee(denom0, denom1 string) (osmomath.Dec, bool) {
+	if !s.takerFees.Has(denom0, denom1) {
+		return osmomath.Dec{}, false
+	}
+
+	return s.takerFees.GetTakerFee(denom0, denom1), true
+}
+
+// GetDenomData returns the candidate route search data for the given denom.
+// Returns an empty struct if the denom is not found.
+func (s *StateSnapshot) GetDenomData(denom string) (CandidateRouteDenomData, error) {
+	return s.candidateRouteSearchData[denom], nil
+}
+
+// StateSnapshotHolder holds the latest state snapshot. Ingest swaps in a new snapshot atomically
+// once the block is fully processed.
+type StateSnapshotHolder struct {
+	snapshot atomic.Pointer[StateSnapshot]
+}
+
+// NewStateSnapshotHolder returns a new state snapshot holder without a snapshot.
+func NewStateSnapshotHolder() *StateSnapshotHolder {
+	return &StateSnapshotHolder{}
+}
+
+// Load returns the latest state snapshot. Nil if no block has been ingested yet.
+func (h *StateSnapshotHolder) Load() *StateSnapshot {
+	return h.snapshot.Load()
+}
+
+// Store atomically swaps in the given state snapshot.
+func (h *StateSnapshotHolder) Store(snapshot *StateSnapshot) {
+	h.snapshot.Store(snapshot)
+}
+
+// ContextWithStateSnapshot returns a copy of the context pinning the given state snapshot.
+func ContextWithStateSnapshot(ctx context.Context, snapshot *StateSnapshot) context.Context {
+	return context.WithValue(ctx, stateSnapshotContextKey{}, snapshot)
+}
+
+// GetStateSnapshot returns the state snapshot pinned to the context.
+// Returns false if no snapshot is pinned, in which case the latest state is to be used.
+func GetStateSnapshot(ctx context.Context) (*StateSnapshot, bool) {
+	snapshot, ok := ctx.Value(stateSnapshotContextKey{}).(*StateSnapshot)
+	return snapshot, ok && snapshot != nil
+}
+
+// GetRequestHeight returns the height of the state snapshot pinned to the context, if any.
+// Otherwise, returns the latest height. The snapshot height may lag the latest height while a block is being ingested.
+func GetRequestHeight(ctx context.Context, latestHeightGetter LatestHeightGetter) uint64 {
+	if snapshot, ok := GetStateSnapshot(ctx); ok {
+		return snapshot.GetHeight()
+	}
+
+	return latestHeightGetter.GetLatestHeight()
+}
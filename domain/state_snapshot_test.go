@@ -0,0 +1,149 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type staticHeightGetter uint64
+
+// GetLatestHeight implements domain.LatestHeightGetter.
+func (h staticHeightGetter) GetLatestHeight() uint64 {
+	return uint64(h)
+}
+
+// This test validates that updating a state snapshot applies the block updates onto a copy,
+// leaving the previous snapshot unchanged.
+func TestStateSnapshot_Update(t *testing.T) {
+	var (
+		poolOne      = &mocks.MockRoutablePool{ID: 1}
+		poolTwo      = &mocks.MockRoutablePool{ID: 2}
+		poolOneNext  = &mocks.MockRoutablePool{ID: 1}
+		takerFee     = osmomath.MustNewDecFromStr("0.001")
+		takerFeeNext = osmomath.MustNewDecFromStr("0.002")
+
+		searchData = map[string]domain.CandidateRouteDenomData{
+			"uosmo": {SortedPools: []sqsdomain.PoolI{poolOne, poolTwo}},
+		}
+	)
+
+	// Nil receiver on the first block.
+	var initial *domain.StateSnapshot
+	first := initial.Update(1, []sqsdomain.PoolI{poolOne, poolTwo}, nil, sqsdomain.TakerFeeMap{{Denom0: "uatom", Denom1: "uosmo"}: takerFee}, searchData)
+
+	second := first.Update(2, []sqsdomain.PoolI{poolOneNext}, []uint64{2}, sqsdomain.TakerFeeMap{{Denom0: "uatom", Denom1: "uosmo"}: takerFeeNext}, nil)
+
+	// First snapshot is unchanged.
+	require.Equal(t, uint64(1), first.GetHeight())
+
+	pool, err := first.GetPool(1)
+	require.NoError(t, err)
+	require.Same(t, poolOne, pool)
+
+	_, err = first.GetPool(2)
+	require.NoError(t, err)
+
+	actualTakerFee, ok := first.GetTakerFee("uosmo", "uatom")
+	require.True(t, ok)
+	require.Equal(t, takerFee, actualTakerFee)
+
+	denomData, err := first.GetDenomData("uosmo")
+	require.NoError(t, err)
+	require.Len(t, denomData.SortedPools, 2)
+
+	// Second snapshot reflects the block updates.
+	require.Equal(t, uint64(2), second.GetHeight())
+
+	pool, err = second.GetPool(1)
+	require.NoError(t, err)
+	require.Same(t, poolOneNext, pool)
+
+	_, err = second.GetPool(2)
+	require.ErrorIs(t, err, domain.PoolNotFoundError{PoolID: 2})
+
+	actualTakerFee, ok = second.GetTakerFee("uatom", "uosmo")
+	require.True(t, ok)
+	require.Equal(t, takerFeeNext, actualTakerFee)
+
+	_, ok = second.GetTakerFee("uatom", "uion")
+	require.False(t, ok)
+
+	denomData, err = second.GetDenomData("uosmo")
+	require.NoError(t, err)
+	require.Empty(t, denomData.SortedPools)
+}
+
+// This test validates that updating a state snapshot copies the pool shards it writes to,
+// covering pools sharing a shard, and keeps the taker fees if the block does not update them.
+func TestStateSnapshot_Update_SameShard(t *testing.T) {
+	var (
+		// Pool IDs are sharded by the remainder of the division by the number of shards.
+		poolOne         = &mocks.MockRoutablePool{ID: 1}
+		poolSameShard   = &mocks.MockRoutablePool{ID: 65}
+		poolOtherShard  = &mocks.MockRoutablePool{ID: 2}
+		poolOneNext     = &mocks.MockRoutablePool{ID: 1}
+		defaultTakerFee = osmomath.MustNewDecFromStr("0.001")
+	)
+
+	first := (*domain.StateSnapshot)(nil).Update(1, []sqsdomain.PoolI{poolOne, poolSameShard, poolOtherShard}, nil, sqsdomain.TakerFeeMap{{Denom0: "uatom", Denom1: "uosmo"}: defaultTakerFee}, nil)
+
+	second := first.Update(2, []sqsdomain.PoolI{poolOneNext}, []uint64{65}, nil, nil)
+
+	// First snapshot is unchanged.
+	pool, err := first.GetPool(1)
+	require.NoError(t, err)
+	require.Same(t, poolOne, pool)
+
+	pool, err = first.GetPool(65)
+	require.NoError(t, err)
+	require.Same(t, poolSameShard, pool)
+
+	// Second snapshot reflects the block updates while keeping the pools of the other shards.
+	pool, err = second.GetPool(1)
+	require.NoError(t, err)
+	require.Same(t, poolOneNext, pool)
+
+	_, err = second.GetPool(65)
+	require.ErrorIs(t, err, domain.PoolNotFoundError{PoolID: 65})
+
+	pool, err = second.GetPool(2)
+	require.NoError(t, err)
+	require.Same(t, poolOtherShard, pool)
+
+	actualTakerFee, ok := second.GetTakerFee("uosmo", "uatom")
+	require.True(t, ok)
+	require.Equal(t, defaultTakerFee, actualTakerFee)
+}
+
+// This test validates pinning the state snapshot to the context and the height of the request.
+func TestStateSnapshot_Context(t *testing.T) {
+	const latestHeight = staticHeightGetter(11)
+
+	holder := domain.NewStateSnapshotHolder()
+	require.Nil(t, holder.Load())
+
+	ctx := context.Background()
+	_, ok := domain.GetStateSnapshot(ctx)
+	require.False(t, ok)
+	require.Equal(t, uint64(11), domain.GetRequestHeight(ctx, latestHeight))
+
+	holder.Store(holder.Load().Update(10, nil, nil, nil, nil))
+
+	pinnedCtx := domain.ContextWithStateSnapshot(ctx, holder.Load())
+
+	// A newer snapshot swapped in does not affect the pinned one.
+	holder.Store(holder.Load().Update(11, nil, nil, nil, nil))
+
+	snapshot, ok := domain.GetStateSnapshot(pinnedCtx)
+	require.True(t, ok)
+	require.Equal(t, uint64(10), snapshot.GetHeight())
+	require.Equal(t, uint64(10), domain.GetRequestHeight(pinnedCtx, latestHeight))
+	require.Equal(t, uint64(11), holder.Load().GetHeight())
+}
@@ -37,6 +37,46 @@ var (
 	// * err - the error message occurred
 	SQSIngestUsecaseParsePoolErrorMetricName = "sqs_ingest_usecase_parse_pool_error_total"
 
+	// sqs_ingest_usecase_apply_pool_delta_error_total
+	//
+	// counter that measures the number of blocks whose pool deltas failed to apply in ingest usecase,
+	// triggering a full reingest
+	SQSIngestUsecaseApplyPoolDeltaErrorMetricName = "sqs_ingest_usecase_apply_pool_delta_error_total"
+
+	// sqs_ingest_usecase_pool_validation_error_total
+	//
+	// counter that measures the number of ingested pools failing validation and quarantined from routing
+	SQSIngestUsecasePoolValidationErrorMetricName = "sqs_ingest_usecase_pool_validation_error_total"
+
+	// sqs_ingest_usecase_quarantined_pools
+	//
+	// gauge that measures the number of pools currently quarantined from routing
+	SQSIngestUsecaseQuarantinedPoolsMetricName = "sqs_ingest_usecase_quarantined_pools"
+
+	// sqs_ingest_handler_queue_depth
+	//
+	// gauge that measures the number of received blocks waiting to be processed
+	SQSIngestHandlerQueueDepthMetricName = "sqs_ingest_handler_queue_depth"
+
+	// sqs_ingest_handler_lag_blocks
+	//
+	// gauge that measures the number of blocks between the latest received and the latest processed height
+	SQSIngestHandlerLagBlocksMetricName = "sqs_ingest_handler_lag_blocks"
+
+	// sqs_ingest_handler_coalesced_blocks_total
+	//
+	// counter that measures the number of intermediate blocks superseded by a newer block
+	// and coalesced into it instead of being processed separately
+	SQSIngestHandlerCoalescedBlocksMetricName = "sqs_ingest_handler_coalesced_blocks_total"
+
+	// sqs_ingest_handler_resync_total
+	//
+	// counter that measures the number of full resyncs requested from the node
+	//
+	// Has the following labels:
+	// * reason - the reason of the resync: gap, out_of_order, apply_pool_delta_error or process_block_error
+	SQSIngestHandlerResyncMetricName = "sqs_ingest_handler_resync_total"
+
 	// sqs_pricing_worker_compute_error_counter
 	//
 	// counter that measures the number of errors that occur during pricing worker computation
@@ -143,6 +183,156 @@ var (
 	// counter that measures the number of pricing coingecko cache misses
 	SQSPricingCoingeckoCacheMissesCounterMetricName = "sqs_pricing_coingecko_cache_misses_total"
 
+	// sqs_arb_detector_process_block_duration
+	//
+	// gauge that measures the duration of detecting cyclic arbitrage opportunities at the end of a block in milliseconds
+	SQSArbDetectorProcessBlockDurationMetricName = "sqs_arb_detector_process_block_duration"
+
+	// sqs_arb_detector_opportunities_total
+	//
+	// counter that measures the number of cyclic arbitrage opportunities detected
+	SQSArbDetectorOpportunitiesCounterMetricName = "sqs_arb_detector_opportunities_total"
+
+	// sqs_arb_detector_best_profit_usd
+	//
+	// gauge that tracks the profit of the best cyclic arbitrage opportunity detected at the latest block, valued in the default quote denom
+	SQSArbDetectorBestProfitUSDMetricName = "sqs_arb_detector_best_profit_usd"
+
+	// sqs_arb_detector_error_total
+	//
+	// counter that measures the number of errors that occur during cyclic arbitrage detection
+	SQSArbDetectorErrorCounterMetricName = "sqs_arb_detector_error_total"
+
+	// sqs_remote_plugin_process_end_block_error_total
+	//
+	// counter that measures the number of failed or timed out end block calls to out-of-process plugins
+	//
+	// Has the following labels:
+	// * plugin - the name of the plugin
+	SQSRemotePluginProcessEndBlockErrorCounterMetricName = "sqs_remote_plugin_process_end_block_error_total"
+
+	// sqs_remote_plugin_skipped_total
+	//
+	// counter that measures the number of blocks skipped for out-of-process plugins
+	// because the previous block was still being processed
+	//
+	// Has the following labels:
+	// * plugin - the name of the plugin
+	SQSRemotePluginSkippedCounterMetricName = "sqs_remote_plugin_skipped_total"
+
+	// sqs_remote_plugin_registered
+	//
+	// gauge that tracks the number of registered out-of-process plugins
+	SQSRemotePluginRegisteredGaugeMetricName = "sqs_remote_plugin_registered"
+
+	// sqs_webhook_alerts_total
+	//
+	// counter that measures the number of fired webhook alerts
+	//
+	// Has the following labels:
+	// * type - the type of the fired rule
+	SQSWebhookAlertsCounterMetricName = "sqs_webhook_alerts_total"
+
+	// sqs_webhook_delivery_error_total
+	//
+	// counter that measures the number of webhook notifications that failed to be delivered after all retries
+	SQSWebhookDeliveryErrorCounterMetricName = "sqs_webhook_delivery_error_total"
+
+	// sqs_event_publisher_published_total
+	//
+	// counter that measures the number of published block events
+	//
+	// Has the following labels:
+	// * type - the type of the published event
+	SQSEventPublisherPublishedCounterMetricName = "sqs_event_publisher_published_total"
+
+	// sqs_event_publisher_error_total
+	//
+	// counter that measures the number of block events that failed to be published
+	SQSEventPublisherErrorCounterMetricName = "sqs_event_publisher_error_total"
+
+	// sqs_router_pool_circuit_breaker_trips_total
+	//
+	// counter that measures the number of times the circuit breaker opened for a pool
+	//
+	// Has the following labels:
+	// * pool_id - the ID of the pool
+	SQSRouterPoolCircuitBreakerTripsCounterMetricName = "sqs_router_pool_circuit_breaker_trips_total"
+
+	// sqs_router_pool_circuit_breaker_tripped_pools
+	//
+	// gauge that measures the number of pools with a tripped circuit breaker that did not recover yet
+	SQSRouterPoolCircuitBreakerTrippedPoolsMetricName = "sqs_router_pool_circuit_breaker_tripped_pools"
+
+	// sqs_cosmwasm_pool_query_cache_hits_total
+	//
+	// counter that measures the number of generalized cosmwasm pool queries served from the query cache
+	SQSCosmWasmPoolQueryCacheHitsCounterMetricName = "sqs_cosmwasm_pool_query_cache_hits_total"
+
+	// sqs_cosmwasm_pool_query_cache_misses_total
+	//
+	// counter that measures the number of generalized cosmwasm pool queries issued to chain by the query cache
+	SQSCosmWasmPoolQueryCacheMissesCounterMetricName = "sqs_cosmwasm_pool_query_cache_misses_total"
+
+	// sqs_cosmwasm_pool_query_budget_exceeded_total
+	//
+	// counter that measures the number of generalized cosmwasm pool queries rejected due to an exhausted query budget
+	SQSCosmWasmPoolQueryBudgetExceededCounterMetricName = "sqs_cosmwasm_pool_query_budget_exceeded_total"
+
+	// sqs_router_candidate_route_index_pairs
+	//
+	// gauge that measures the number of denom pairs in the candidate route index
+	SQSRouterCandidateRouteIndexPairsMetricName = "sqs_router_candidate_route_index_pairs"
+
+	// sqs_router_candidate_route_index_recomputed_pairs_total
+	//
+	// counter that measures the number of denom pairs whose candidate routes were recomputed by the candidate route index
+	SQSRouterCandidateRouteIndexRecomputedPairsCounterMetricName = "sqs_router_candidate_route_index_recomputed_pairs_total"
+
+	// sqs_coalesced_requests_total
+	//
+	// counter that measures the number of requests served by sharing the result of an identical in-flight request
+	//
+	// Has the following labels:
+	// * request - the coalesced request, either "quote" or "prices"
+	SQSCoalescedRequestsCounterMetricName = "sqs_coalesced_requests_total"
+
+	// sqs_router_partial_quotes_total
+	//
+	// counter that measures the number of partial quotes returned due to the quote time budget being exhausted
+	SQSRouterPartialQuotesCounterMetricName = "sqs_router_partial_quotes_total"
+
+	// sqs_chain_observed_block_time_ms
+	//
+	// gauge that measures the moving average of the interval between ingested blocks in milliseconds
+	SQSChainObservedBlockTimeMetricName = "sqs_chain_observed_block_time_ms"
+
+	// sqs_chain_status
+	//
+	// gauge that is 1 for the currently detected chain status and 0 for the others
+	//
+	// Has the following labels:
+	// * status - the chain status, one of "healthy", "node_lag", "chain_halt" or "ingest_stall"
+	SQSChainStatusMetricName = "sqs_chain_status"
+
+	// sqs_chain_status_transitions_total
+	//
+	// counter that measures the number of chain status transitions
+	//
+	// Has the following labels:
+	// * from - the previous chain status
+	// * to - the new chain status
+	SQSChainStatusTransitionsCounterMetricName = "sqs_chain_status_transitions_total"
+
+	// sqs_degraded_mode_quotes_total
+	//
+	// counter that measures the number of quote requests to which the degraded mode action was applied
+	//
+	// Has the following labels:
+	// * action - the degraded mode action
+	// * status - the chain status
+	SQSDegradedModeQuotesCounterMetricName = "sqs_degraded_mode_quotes_total"
+
 	SQSIngestHandlerProcessBlockDurationGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Name: SQSIngestUsecaseProcessBlockDurationMetricName,
@@ -171,6 +361,56 @@ var (
 		},
 	)
 
+	SQSIngestHandlerApplyPoolDeltaErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSIngestUsecaseApplyPoolDeltaErrorMetricName,
+			Help: "counter that measures the number of blocks whose pool deltas failed to apply in ingest usecase, triggering a full reingest",
+		},
+	)
+
+	SQSIngestUsecasePoolValidationErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSIngestUsecasePoolValidationErrorMetricName,
+			Help: "counter that measures the number of ingested pools failing validation and quarantined from routing",
+		},
+	)
+
+	SQSIngestUsecaseQuarantinedPoolsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSIngestUsecaseQuarantinedPoolsMetricName,
+			Help: "gauge that measures the number of pools currently quarantined from routing",
+		},
+	)
+
+	SQSIngestHandlerQueueDepthGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSIngestHandlerQueueDepthMetricName,
+			Help: "gauge that measures the number of received blocks waiting to be processed",
+		},
+	)
+
+	SQSIngestHandlerLagBlocksGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSIngestHandlerLagBlocksMetricName,
+			Help: "gauge that measures the number of blocks between the latest received and the latest processed height",
+		},
+	)
+
+	SQSIngestHandlerCoalescedBlocksCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSIngestHandlerCoalescedBlocksMetricName,
+			Help: "counter that measures the number of intermediate blocks superseded by a newer block and coalesced into it",
+		},
+	)
+
+	SQSIngestHandlerResyncCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSIngestHandlerResyncMetricName,
+			Help: "counter that measures the number of full resyncs requested from the node",
+		},
+		[]string{"reason"},
+	)
+
 	SQSPricingWorkerComputeErrorCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: SQSPricingWorkerComputeErrorCounterMetricName,
@@ -290,6 +530,183 @@ var (
 			Help: "Total number of pricing coingecko cache misses",
 		},
 	)
+
+	SQSArbDetectorProcessBlockDurationGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSArbDetectorProcessBlockDurationMetricName,
+			Help: "gauge that measures the duration of detecting cyclic arbitrage opportunities at the end of a block in milliseconds",
+		},
+	)
+
+	SQSArbDetectorOpportunitiesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSArbDetectorOpportunitiesCounterMetricName,
+			Help: "Total number of cyclic arbitrage opportunities detected",
+		},
+	)
+
+	SQSArbDetectorBestProfitUSDGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSArbDetectorBestProfitUSDMetricName,
+			Help: "Profit of the best cyclic arbitrage opportunity detected at the latest block, valued in the default quote denom",
+		},
+	)
+
+	SQSArbDetectorErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSArbDetectorErrorCounterMetricName,
+			Help: "Total number of errors that occur during cyclic arbitrage detection",
+		},
+	)
+
+	SQSRemotePluginProcessEndBlockErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSRemotePluginProcessEndBlockErrorCounterMetricName,
+			Help: "Total number of failed or timed out end block calls to out-of-process plugins",
+		},
+		[]string{"plugin"},
+	)
+
+	SQSRemotePluginSkippedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSRemotePluginSkippedCounterMetricName,
+			Help: "Total number of blocks skipped for out-of-process plugins still processing the previous block",
+		},
+		[]string{"plugin"},
+	)
+
+	SQSRemotePluginRegisteredGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSRemotePluginRegisteredGaugeMetricName,
+			Help: "Number of registered out-of-process plugins",
+		},
+	)
+
+	SQSWebhookAlertsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSWebhookAlertsCounterMetricName,
+			Help: "Total number of fired webhook alerts",
+		},
+		[]string{"type"},
+	)
+
+	SQSWebhookDeliveryErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSWebhookDeliveryErrorCounterMetricName,
+			Help: "Total number of webhook notifications that failed to be delivered after all retries",
+		},
+	)
+
+	SQSEventPublisherPublishedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSEventPublisherPublishedCounterMetricName,
+			Help: "Total number of published block events",
+		},
+		[]string{"type"},
+	)
+
+	SQSEventPublisherErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSEventPublisherErrorCounterMetricName,
+			Help: "Total number of block events that failed to be published",
+		},
+	)
+
+	SQSRouterPoolCircuitBreakerTripsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSRouterPoolCircuitBreakerTripsCounterMetricName,
+			Help: "Total number of times the circuit breaker opened for a pool",
+		},
+		[]string{"pool_id"},
+	)
+
+	SQSRouterPoolCircuitBreakerTrippedPoolsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSRouterPoolCircuitBreakerTrippedPoolsMetricName,
+			Help: "gauge that measures the number of pools with a tripped circuit breaker that did not recover yet",
+		},
+	)
+
+	SQSCosmWasmPoolQueryCacheHitsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSCosmWasmPoolQueryCacheHitsCounterMetricName,
+			Help: "Total number of generalized cosmwasm pool queries served from the query cache",
+		},
+	)
+
+	SQSCosmWasmPoolQueryCacheMissesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSCosmWasmPoolQueryCacheMissesCounterMetricName,
+			Help: "Total number of generalized cosmwasm pool queries issued to chain by the query cache",
+		},
+	)
+
+	SQSCosmWasmPoolQueryBudgetExceededCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSCosmWasmPoolQueryBudgetExceededCounterMetricName,
+			Help: "Total number of generalized cosmwasm pool queries rejected due to an exhausted query budget",
+		},
+	)
+
+	SQSRouterCandidateRouteIndexPairsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSRouterCandidateRouteIndexPairsMetricName,
+			Help: "gauge that measures the number of denom pairs in the candidate route index",
+		},
+	)
+
+	SQSRouterCandidateRouteIndexRecomputedPairsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSRouterCandidateRouteIndexRecomputedPairsCounterMetricName,
+			Help: "Total number of denom pairs whose candidate routes were recomputed by the candidate route index",
+		},
+	)
+
+	SQSCoalescedRequestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSCoalescedRequestsCounterMetricName,
+			Help: "Total number of requests served by sharing the result of an identical in-flight request",
+		},
+		[]string{"request"},
+	)
+
+	SQSRouterPartialQuotesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSRouterPartialQuotesCounterMetricName,
+			Help: "Total number of partial quotes returned due to the quote time budget being exhausted",
+		},
+	)
+
+	SQSChainObservedBlockTimeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSChainObservedBlockTimeMetricName,
+			Help: "gauge that measures the moving average of the interval between ingested blocks in milliseconds",
+		},
+	)
+
+	SQSChainStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: SQSChainStatusMetricName,
+			Help: "gauge that is 1 for the currently detected chain status and 0 for the others",
+		},
+		[]string{"status"},
+	)
+
+	SQSChainStatusTransitionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSChainStatusTransitionsCounterMetricName,
+			Help: "Total number of chain status transitions",
+		},
+		[]string{"from", "to"},
+	)
+
+	SQSDegradedModeQuotesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSDegradedModeQuotesCounterMetricName,
+			Help: "Total number of quote requests to which the degraded mode action was applied",
+		},
+		[]string{"action", "status"},
+	)
 )
 
 func init() {
@@ -297,6 +714,13 @@ func init() {
 	prometheus.MustRegister(SQSIngestHandlerProcessBlockErrorCounter)
 	prometheus.MustRegister(SQSIngestHandlerProcessOrderbookPoolErrorCounter)
 	prometheus.MustRegister(SQSIngestHandlerPoolParseErrorCounter)
+	prometheus.MustRegister(SQSIngestHandlerApplyPoolDeltaErrorCounter)
+	prometheus.MustRegister(SQSIngestUsecasePoolValidationErrorCounter)
+	prometheus.MustRegister(SQSIngestUsecaseQuarantinedPoolsGauge)
+	prometheus.MustRegister(SQSIngestHandlerQueueDepthGauge)
+	prometheus.MustRegister(SQSIngestHandlerLagBlocksGauge)
+	prometheus.MustRegister(SQSIngestHandlerCoalescedBlocksCounter)
+	prometheus.MustRegister(SQSIngestHandlerResyncCounter)
 	prometheus.MustRegister(SQSPricingWorkerComputeDurationGauge)
 	prometheus.MustRegister(SQSPricingWorkerComputeErrorCounter)
 	prometheus.MustRegister(SQSPoolLiquidityPricingWorkerComputeDurationGauge)
@@ -314,4 +738,28 @@ func init() {
 	prometheus.MustRegister(SQSPricingSpotPriceError)
 	prometheus.MustRegister(SQSPricingCoingeckoCacheHitsCounter)
 	prometheus.MustRegister(SQSPricingCoingeckoCacheMissesCounter)
+	prometheus.MustRegister(SQSArbDetectorProcessBlockDurationGauge)
+	prometheus.MustRegister(SQSArbDetectorOpportunitiesCounter)
+	prometheus.MustRegister(SQSArbDetectorBestProfitUSDGauge)
+	prometheus.MustRegister(SQSArbDetectorErrorCounter)
+	prometheus.MustRegister(SQSRemotePluginProcessEndBlockErrorCounter)
+	prometheus.MustRegister(SQSRemotePluginSkippedCounter)
+	prometheus.MustRegister(SQSRemotePluginRegisteredGauge)
+	prometheus.MustRegister(SQSWebhookAlertsCounter)
+	prometheus.MustRegister(SQSWebhookDeliveryErrorCounter)
+	prometheus.MustRegister(SQSEventPublisherPublishedCounter)
+	prometheus.MustRegister(SQSEventPublisherErrorCounter)
+	prometheus.MustRegister(SQSRouterPoolCircuitBreakerTripsCounter)
+	prometheus.MustRegister(SQSRouterPoolCircuitBreakerTrippedPoolsGauge)
+	prometheus.MustRegister(SQSCosmWasmPoolQueryCacheHitsCounter)
+	prometheus.MustRegister(SQSCosmWasmPoolQueryCacheMissesCounter)
+	prometheus.MustRegister(SQSCosmWasmPoolQueryBudgetExceededCounter)
+	prometheus.MustRegister(SQSRouterCandidateRouteIndexPairsGauge)
+	prometheus.MustRegister(SQSRouterCandidateRouteIndexRecomputedPairsCounter)
+	prometheus.MustRegister(SQSCoalescedRequestsCounter)
+	prometheus.MustRegister(SQSRouterPartialQuotesCounter)
+	prometheus.MustRegister(SQSChainObservedBlockTimeGauge)
+	prometheus.MustRegister(SQSChainStatusGauge)
+	prometheus.MustRegister(SQSChainStatusTransitionsCounter)
+	prometheus.MustRegister(SQSDegradedModeQuotesCounter)
 }
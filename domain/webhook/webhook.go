@@ -0,0 +1,112 @@
+package webhookdomain
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+const (
+	// WebhookPluginName is the name of the webhook notification plugin.
+	WebhookPluginName = "webhook"
+
+	// SignatureHeader is the header containing the hex-encoded HMAC-SHA256 signature
+	// of the request body, keyed by the configured secret.
+	SignatureHeader = "X-SQS-Signature"
+)
+
+// RuleType is the type of a webhook notification rule.
+type RuleType string
+
+const (
+	// PoolLiquidityCapDropRuleType fires when the liquidity capitalization of a pool
+	// drops by at least the threshold percentage between consecutive updates.
+	PoolLiquidityCapDropRuleType RuleType = "pool-liquidity-cap-drop"
+	// DenomLiquidityCapDropRuleType fires when the total liquidity capitalization of a denom
+	// across all pools drops by at least the threshold percentage between consecutive updates.
+	DenomLiquidityCapDropRuleType RuleType = "denom-liquidity-cap-drop"
+	// PriceMoveRuleType fires when the price of a denom moves by at least
+	// the threshold percentage in either direction between consecutive updates.
+	PriceMoveRuleType RuleType = "price-move"
+	// TakerFeeChangeRuleType fires when the taker fee of a denom pair changes.
+	TakerFeeChangeRuleType RuleType = "taker-fee-change"
+)
+
+// Rule is a webhook notification rule.
+type Rule struct {
+	// Name is the unique name of the rule, reported in the alerts.
+	Name string `mapstructure:"name"`
+	// Type is the type of the rule.
+	Type RuleType `mapstructure:"type"`
+	// ThresholdPercent is the minimum change in percent for the rule to fire.
+	// Ignored by the taker fee change rule.
+	ThresholdPercent float64 `mapstructure:"threshold-percent"`
+	// Denoms optionally restricts the rule to the given chain denoms.
+	// For pool rules, a pool matches if it contains any of the denoms.
+	// For taker fee rules, a pair matches if it contains any of the denoms.
+	Denoms []string `mapstructure:"denoms"`
+}
+
+// Validate validates the rule.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("webhook rule name cannot be empty")
+	}
+
+	switch r.Type {
+	case PoolLiquidityCapDropRuleType, DenomLiquidityCapDropRuleType, PriceMoveRuleType:
+		if r.ThresholdPercent <= 0 {
+			return fmt.Errorf("webhook rule %s threshold percent must be positive, was %v", r.Name, r.ThresholdPercent)
+		}
+
+		if _, err := r.Threshold(); err != nil {
+			return err
+		}
+	case TakerFeeChangeRuleType:
+	default:
+		return fmt.Errorf("webhook rule %s has unsupported type %s", r.Name, r.Type)
+	}
+
+	return nil
+}
+
+// Threshold returns the threshold percent as a decimal.
+// The shortest decimal representation of the configured value is parsed so that
+// no precision is lost to fixed-width formatting.
+// Returns an error if the threshold has more decimal places than supported by osmomath.Dec.
+func (r Rule) Threshold() (osmomath.Dec, error) {
+	threshold, err := osmomath.NewDecFromStr(strconv.FormatFloat(r.ThresholdPercent, 'f', -1, 64))
+	if err != nil {
+		return osmomath.Dec{}, fmt.Errorf("webhook rule %s has invalid threshold percent %v: %w", r.Name, r.ThresholdPercent, err)
+	}
+
+	return threshold, nil
+}
+
+// Alert is a single fired rule.
+type Alert struct {
+	// Rule is the name of the fired rule.
+	Rule string `json:"rule"`
+	// Type is the type of the fired rule.
+	Type RuleType `json:"type"`
+	// Denom is the denom the alert refers to, if any.
+	// For taker fee alerts, it is formatted as "denom0/denom1".
+	Denom string `json:"denom,omitempty"`
+	// PoolID is the pool the alert refers to, if any.
+	PoolID uint64 `json:"pool_id,omitempty"`
+	// Previous is the value before the change.
+	Previous string `json:"previous"`
+	// Current is the value after the change.
+	Current string `json:"current"`
+	// ChangePercent is the signed change in percent.
+	ChangePercent string `json:"change_percent"`
+}
+
+// Notification is the JSON payload delivered to the webhook for a block.
+type Notification struct {
+	// Height is the height of the block at which the alerts fired.
+	Height uint64 `json:"height"`
+	// Alerts are the alerts that fired at the block.
+	Alerts []Alert `json:"alerts"`
+}
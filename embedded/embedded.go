@@ -0,0 +1,403 @@
+// Package embedded builds the routing core of the sidecar query server for embedding in Go services,
+// independent of the HTTP server.
+//
+// The router, pools, tokens and pricing usecases are constructed from the injected pools, taker fees
+// and token metadata without any network dependencies. The state is updated by feeding blocks
+// that are processed by the same ingest pipeline as the blocks pushed by the node.
+// Note that quotes over generalized CosmWasm pools query the chain at the configured gRPC gateway endpoint.
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	chaininforepo "github.com/osmosis-labs/sqs/chaininfo/repository"
+	chaininfousecase "github.com/osmosis-labs/sqs/chaininfo/usecase"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	orderbookgrpcclientdomain "github.com/osmosis-labs/sqs/domain/orderbook/grpcclient"
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+	ingestusecase "github.com/osmosis-labs/sqs/ingest/usecase"
+	"github.com/osmosis-labs/sqs/log"
+	orderbookrepository "github.com/osmosis-labs/sqs/orderbook/repository"
+	orderbookusecase "github.com/osmosis-labs/sqs/orderbook/usecase"
+	poolsusecase "github.com/osmosis-labs/sqs/pools/usecase"
+	routerrepo "github.com/osmosis-labs/sqs/router/repository"
+	routerusecase "github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/circuitbreaker"
+	routerworker "github.com/osmosis-labs/sqs/router/usecase/worker"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+	"github.com/osmosis-labs/sqs/sqsutil/datafetchers"
+	tokensusecase "github.com/osmosis-labs/sqs/tokens/usecase"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing"
+	pricingworker "github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
+)
+
+// State is the chain state that the router is initialized from.
+type State struct {
+	// Height is the height of the block of the pools and taker fees.
+	Height uint64
+	// Pools are the pools to route over. If empty, the pools are fed with the blocks.
+	Pools []sqsdomain.PoolI
+	// TakerFees are the taker fees by denom pair.
+	TakerFees sqsdomain.TakerFeeMap
+	// Tokens is the token metadata by chain denom.
+	// Must contain the default quote denom of the pricing config.
+	Tokens map[string]domain.Token
+}
+
+// Block is a block update fed to the router.
+type Block struct {
+	// Height is the height of the block. Must be greater than the height of the previous block.
+	Height uint64
+	// Pools are the pools created or updated in the block.
+	Pools []sqsdomain.PoolI
+	// RemovedPoolIDs are the IDs of the pools removed in the block.
+	RemovedPoolIDs []uint64
+	// TakerFees are the taker fees set in the block by denom pair.
+	// The taker fees of the denom pairs absent from the map are retained.
+	TakerFees sqsdomain.TakerFeeMap
+}
+
+// Option configures the optional, network-backed parts of the router.
+type Option func(*options)
+
+type options struct {
+	orderBookClient orderbookgrpcclientdomain.OrderBookClient
+
+	aprFetcher      datafetchers.MapFetcher[uint64, passthroughdomain.PoolAPR]
+	poolFeesFetcher datafetchers.MapFetcher[uint64, passthroughdomain.PoolFee]
+}
+
+// WithOrderBookClient enables processing the ticks of the orderbook pools
+// by fetching them from the orderbook contracts with the given client.
+func WithOrderBookClient(orderBookClient orderbookgrpcclientdomain.OrderBookClient) Option {
+	return func(o *options) {
+		o.orderBookClient = orderBookClient
+	}
+}
+
+// WithPoolDataFetchers registers the fetchers of the pool APR and fees data
+// returned by the pools usecase with market incentives.
+func WithPoolDataFetchers(aprFetcher datafetchers.MapFetcher[uint64, passthroughdomain.PoolAPR], poolFeesFetcher datafetchers.MapFetcher[uint64, passthroughdomain.PoolFee]) Option {
+	return func(o *options) {
+		o.aprFetcher = aprFetcher
+		o.poolFeesFetcher = poolFeesFetcher
+	}
+}
+
+// Router is the routing core of the sidecar query server.
+// It is safe for concurrent use. The blocks are processed one at a time.
+type Router struct {
+	codec codec.Codec
+
+	routerRepository     routerrepo.RouterRepository
+	routerUsecase        mvc.RouterUsecase
+	pricingRouterUsecase mvc.RouterUsecase
+	poolsUsecase         mvc.PoolsUsecase
+	tokensUsecase        mvc.TokensUsecase
+	chainInfoUsecase     mvc.ChainInfoUsecase
+	orderBookUsecase     mvc.OrderBookUsecase
+	ingestUsecase        mvc.IngestUsecase
+
+	// poolCircuitBreaker is nil if the pool circuit breaker is disabled.
+	poolCircuitBreaker  domain.PoolCircuitBreaker
+	stateSnapshotHolder *domain.StateSnapshotHolder
+
+	defaultQuoteDenom string
+	liquidityPricer   domain.LiquidityPricer
+
+	// processBlockMx serializes the processing of the blocks.
+	processBlockMx sync.Mutex
+}
+
+// New returns the router built from the given state with the router, pools and pricing configs.
+// The codec must have the pool interfaces registered.
+// If the state contains pools, they are processed as the block at the state height.
+// Returns error if the default quote denom has no token metadata or if processing the pools fails.
+func New(config domain.Config, appCodec codec.Codec, state State, logger log.Logger, opts ...Option) (*Router, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	routerRepository := routerrepo.New(logger)
+
+	tokensUseCase := tokensusecase.NewTokensUsecase(state.Tokens, config.UpdateAssetsHeightInterval, logger)
+
+	poolsUseCase, err := poolsusecase.NewPoolsUsecase(config.Pools, config.ChainGRPCGatewayEndpoint, routerRepository, tokensUseCase.GetChainScalingFactorByDenomMut, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.aprFetcher != nil && o.poolFeesFetcher != nil {
+		poolsUseCase.RegisterAPRFetcher(o.aprFetcher)
+		poolsUseCase.RegisterPoolFeesFetcher(o.poolFeesFetcher)
+	}
+
+	candidateRouteSearcher, err := routerusecase.NewCandidateRouteSearcher(config.Router.CandidateRouteSearchAlgorithm, routerRepository, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	cosmWasmPoolConfig := poolsUseCase.GetCosmWasmPoolConfig()
+
+	routerUsecase := routerusecase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, cosmWasmPoolConfig, logger, cache.New(), cache.New())
+
+	// Initialize chain pricing strategy
+	pricingRouterUsecase := routerusecase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, cosmWasmPoolConfig, logger, cache.New(), cache.New())
+
+	chainInfoRepository := chaininforepo.New()
+	chainInfoUseCase := chaininfousecase.NewChainInfoUsecase(chainInfoRepository)
+
+	// Share the results of identical concurrent quotes and price requests within a block
+	routerUsecase.EnableRequestCoalescing(chainInfoRepository)
+	pricingRouterUsecase.EnableRequestCoalescing(chainInfoRepository)
+	tokensUseCase.EnableRequestCoalescing(chainInfoRepository)
+
+	// Initialize the pool circuit breaker shared by the router usecases
+	var poolCircuitBreaker domain.PoolCircuitBreaker
+	if config.Router.PoolCircuitBreaker.Enabled {
+		poolCircuitBreaker = circuitbreaker.New(config.Router.PoolCircuitBreaker)
+
+		poolsUseCase.RegisterPoolCircuitBreaker(poolCircuitBreaker)
+		routerUsecase.RegisterPoolCircuitBreaker(poolCircuitBreaker)
+		pricingRouterUsecase.RegisterPoolCircuitBreaker(poolCircuitBreaker)
+	}
+
+	chainPricingSource, err := pricing.NewPricingStrategy(*config.Pricing, tokensUseCase, pricingRouterUsecase)
+	if err != nil {
+		return nil, err
+	}
+
+	tokensUseCase.RegisterPricingStrategy(domain.ChainPricingSourceType, chainPricingSource)
+
+	// Get the default quote denom
+	defaultQuoteDenom, err := tokensUseCase.GetChainDenom(config.Pricing.DefaultQuoteHumanDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidityPricer := pricingworker.NewLiquidityPricer(defaultQuoteDenom, tokensUseCase.GetChainScalingFactorByDenomMut)
+
+	// The orderbook pools are processed only if the orderbook client is configured.
+	var orderBookUseCase mvc.OrderBookUsecase
+	if o.orderBookClient != nil {
+		orderBookUseCase = orderbookusecase.New(orderbookrepository.New(), o.orderBookClient, poolsUseCase, tokensUseCase, logger)
+	}
+
+	quotePriceUpdateWorker := pricingworker.New(tokensUseCase, defaultQuoteDenom, config.Pricing.WorkerMinPoolLiquidityCap, logger)
+
+	poolLiquidityComputeWorker := pricingworker.NewPoolLiquidityWorker(tokensUseCase, poolsUseCase, liquidityPricer, logger)
+
+	candidateRouteSearchDataWorker := routerworker.NewCandidateRouteSearchDataWorker(poolsUseCase, routerRepository, config.Router.PreferredPoolIDs, cosmWasmPoolConfig, logger)
+
+	// Register chain info use case (healthcheck) as a listener to the candidate route search data worker.
+	candidateRouteSearchDataWorker.RegisterListener(chainInfoUseCase)
+
+	// Precompute candidate routes for the top denoms on candidate route search data updates.
+	// The index is computed for a unit amount, so it is not used with best-first search
+	// whose candidate routes depend on the token in amount.
+	if config.Router.CandidateRouteIndex.Enabled && config.Router.CandidateRouteSearchAlgorithm == domain.CandidateRouteSearchAlgorithmBestFirst {
+		logger.Warn("candidate route index is not supported with best-first candidate route search, disabling it")
+	} else if config.Router.CandidateRouteIndex.Enabled {
+		candidateRouteIndexWorker := routerworker.NewCandidateRouteIndexWorker(routerUsecase, tokensUseCase, config.Router.CandidateRouteIndex.NumTopDenoms, logger)
+		candidateRouteSearchDataWorker.RegisterListener(candidateRouteIndexWorker)
+		routerUsecase.RegisterCandidateRouteIndex(candidateRouteIndexWorker)
+	}
+
+	// chain info use case acts as the healthcheck. It receives updates from the pricing worker.
+	// It then passes the healthcheck as long as updates are received at the appropriate intervals.
+	quotePriceUpdateWorker.RegisterListener(chainInfoUseCase)
+
+	// pool liquidity compute worker listens to the quote price update worker.
+	quotePriceUpdateWorker.RegisterListener(poolLiquidityComputeWorker)
+
+	// Register chain info use case as a listener to the pool liquidity compute worker (healthcheck).
+	poolLiquidityComputeWorker.RegisterListener(chainInfoUseCase)
+
+	ingestUseCase, err := ingestusecase.NewIngestUsecase(
+		poolsUseCase,
+		routerUsecase,
+		pricingRouterUsecase,
+		tokensUseCase,
+		chainInfoUseCase,
+		appCodec,
+		quotePriceUpdateWorker,
+		candidateRouteSearchDataWorker,
+		orderBookUseCase,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Swap in the state snapshot pinned by requests at the end of each block.
+	stateSnapshotHolder := domain.NewStateSnapshotHolder()
+	ingestUseCase.RegisterStateSnapshotHolder(stateSnapshotHolder, routerRepository)
+
+	r := &Router{
+		codec: appCodec,
+
+		routerRepository:     routerRepository,
+		routerUsecase:        routerUsecase,
+		pricingRouterUsecase: pricingRouterUsecase,
+		poolsUsecase:         poolsUseCase,
+		tokensUsecase:        tokensUseCase,
+		chainInfoUsecase:     chainInfoUseCase,
+		orderBookUsecase:     orderBookUseCase,
+		ingestUsecase:        ingestUseCase,
+
+		poolCircuitBreaker:  poolCircuitBreaker,
+		stateSnapshotHolder: stateSnapshotHolder,
+
+		defaultQuoteDenom: defaultQuoteDenom,
+		liquidityPricer:   liquidityPricer,
+	}
+
+	if len(state.Pools) > 0 {
+		if err := r.ProcessBlock(context.Background(), Block{Height: state.Height, Pools: state.Pools, TakerFees: state.TakerFees}); err != nil {
+			return nil, err
+		}
+	} else if len(state.TakerFees) > 0 {
+		routerUsecase.SetTakerFees(state.TakerFees)
+	}
+
+	return r, nil
+}
+
+// ProcessBlock processes the given block, updating the pools and taker fees
+// and recomputing the candidate route search data and prices.
+// The pools are encoded the same way as by the node and ingested as by the GRPC ingester.
+// Returns error if a pool fails to be encoded or if the block processing fails.
+func (r *Router) ProcessBlock(ctx context.Context, block Block) error {
+	poolData := make([]*prototypes.PoolData, 0, len(block.Pools))
+	for _, pool := range block.Pools {
+		data, err := r.encodePool(pool)
+		if err != nil {
+			return err
+		}
+
+		poolData = append(poolData, data)
+	}
+
+	r.processBlockMx.Lock()
+	defer r.processBlockMx.Unlock()
+
+	pools, err := r.ingestUsecase.ApplyPoolDeltas(&prototypes.ProcessBlockRequest{
+		BlockHeight: block.Height,
+		Pools:       poolData,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.ingestUsecase.ProcessBlockData(ctx, block.Height, block.TakerFees, pools, block.RemovedPoolIDs)
+}
+
+// encodePool encodes the pool the same way the node does.
+func (r *Router) encodePool(pool sqsdomain.PoolI) (*prototypes.PoolData, error) {
+	chainModel, err := r.codec.MarshalInterfaceJSON(pool.GetUnderlyingPool())
+	if err != nil {
+		return nil, err
+	}
+
+	sqsModel, err := json.Marshal(pool.GetSQSPoolModel())
+	if err != nil {
+		return nil, err
+	}
+
+	var tickModel []byte
+	if pool.GetType() == poolmanagertypes.Concentrated {
+		model, err := pool.GetTickModel()
+		if err != nil {
+			return nil, err
+		}
+
+		tickModel, err = json.Marshal(model)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &prototypes.PoolData{
+		ChainModel: chainModel,
+		SqsModel:   sqsModel,
+		TickModel:  tickModel,
+	}, nil
+}
+
+// LoadTokens loads the given token metadata by chain denom, overwriting the metadata of the same denoms.
+func (r *Router) LoadTokens(tokens map[string]domain.Token) {
+	r.tokensUsecase.LoadTokens(tokens)
+}
+
+// GetRouterUsecase returns the router usecase computing the quotes.
+func (r *Router) GetRouterUsecase() mvc.RouterUsecase {
+	return r.routerUsecase
+}
+
+// GetPricingRouterUsecase returns the router usecase used by the chain pricing strategy.
+func (r *Router) GetPricingRouterUsecase() mvc.RouterUsecase {
+	return r.pricingRouterUsecase
+}
+
+// GetPoolsUsecase returns the pools usecase.
+func (r *Router) GetPoolsUsecase() mvc.PoolsUsecase {
+	return r.poolsUsecase
+}
+
+// GetTokensUsecase returns the tokens usecase with the chain pricing strategy registered.
+func (r *Router) GetTokensUsecase() mvc.TokensUsecase {
+	return r.tokensUsecase
+}
+
+// GetChainInfoUsecase returns the chain info usecase tracking the latest processed height.
+func (r *Router) GetChainInfoUsecase() mvc.ChainInfoUsecase {
+	return r.chainInfoUsecase
+}
+
+// GetOrderBookUsecase returns the orderbook usecase.
+// Nil unless configured with WithOrderBookClient.
+func (r *Router) GetOrderBookUsecase() mvc.OrderBookUsecase {
+	return r.orderBookUsecase
+}
+
+// GetIngestUsecase returns the ingest usecase processing the blocks.
+// End block process plugins can be registered on it.
+func (r *Router) GetIngestUsecase() mvc.IngestUsecase {
+	return r.ingestUsecase
+}
+
+// GetRouterRepository returns the router repository holding the taker fees and candidate route search data.
+func (r *Router) GetRouterRepository() routerrepo.RouterRepository {
+	return r.routerRepository
+}
+
+// GetPoolCircuitBreaker returns the pool circuit breaker.
+// Nil if the pool circuit breaker is disabled.
+func (r *Router) GetPoolCircuitBreaker() domain.PoolCircuitBreaker {
+	return r.poolCircuitBreaker
+}
+
+// GetStateSnapshotHolder returns the holder of the state snapshot of the latest processed block.
+func (r *Router) GetStateSnapshotHolder() *domain.StateSnapshotHolder {
+	return r.stateSnapshotHolder
+}
+
+// GetDefaultQuoteDenom returns the chain denom of the default quote human denom of the pricing config.
+func (r *Router) GetDefaultQuoteDenom() string {
+	return r.defaultQuoteDenom
+}
+
+// GetLiquidityPricer returns the liquidity pricer in the default quote denom.
+func (r *Router) GetLiquidityPricer() domain.LiquidityPricer {
+	return r.liquidityPricer
+}
@@ -0,0 +1,150 @@
+package embedded_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v25/app"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/embedded"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type EmbeddedTestSuite struct {
+	suite.Suite
+}
+
+const (
+	uatom = "uatom"
+	uosmo = "uosmo"
+	uusdc = "ibc/usdc"
+
+	atomOsmoPoolID uint64 = 1
+	osmoUSDCPoolID uint64 = 2
+
+	defaultHeight uint64 = 100
+)
+
+var (
+	encodingConfig = app.MakeEncodingConfig()
+
+	tokens = map[string]domain.Token{
+		uatom: {HumanDenom: "atom", CoinMinimalDenom: uatom, Precision: 6},
+		uosmo: {HumanDenom: "osmo", CoinMinimalDenom: uosmo, Precision: 6},
+		uusdc: {HumanDenom: "usdc", CoinMinimalDenom: uusdc, Precision: 6},
+	}
+)
+
+func TestEmbeddedTestSuite(t *testing.T) {
+	suite.Run(t, new(EmbeddedTestSuite))
+}
+
+// Tests that the router built from the injected state quotes over the injected pools.
+func (s *EmbeddedTestSuite) TestNew() {
+	router := s.newRouter()
+
+	quote, err := router.GetRouterUsecase().GetOptimalQuote(context.Background(), sdk.NewCoin(uatom, osmomath.NewInt(1_000_000)), uusdc)
+	s.Require().NoError(err)
+
+	s.Require().True(quote.GetAmountOut().IsPositive())
+	s.Require().Len(quote.GetRoute(), 1)
+	s.Require().Len(quote.GetRoute()[0].GetPools(), 2)
+
+	s.Require().Equal(uusdc, router.GetDefaultQuoteDenom())
+
+	latestHeight, err := router.GetChainInfoUsecase().GetLatestHeight()
+	s.Require().NoError(err)
+	s.Require().Equal(defaultHeight, latestHeight)
+
+	snapshot := router.GetStateSnapshotHolder().Load()
+	s.Require().NotNil(snapshot)
+	s.Require().Equal(defaultHeight, snapshot.GetHeight())
+}
+
+// Tests that the fed blocks update the pools, the taker fees and the latest height.
+func (s *EmbeddedTestSuite) TestProcessBlock() {
+	router := s.newRouter()
+
+	takerFees := sqsdomain.TakerFeeMap{}
+	takerFees.SetTakerFee(uatom, uosmo, osmomath.MustNewDecFromStr("0.003"))
+
+	err := router.ProcessBlock(context.Background(), embedded.Block{
+		Height:         defaultHeight + 1,
+		RemovedPoolIDs: []uint64{osmoUSDCPoolID},
+		TakerFees:      takerFees,
+	})
+	s.Require().NoError(err)
+
+	_, err = router.GetPoolsUsecase().GetPool(osmoUSDCPoolID)
+	s.Require().Error(err)
+
+	takerFee, err := router.GetRouterUsecase().GetTakerFee(atomOsmoPoolID)
+	s.Require().NoError(err)
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.003"), takerFee[0].TakerFee)
+
+	_, err = router.GetRouterUsecase().GetOptimalQuote(context.Background(), sdk.NewCoin(uatom, osmomath.NewInt(1_000_000)), uusdc)
+	s.Require().Error(err)
+
+	latestHeight, err := router.GetChainInfoUsecase().GetLatestHeight()
+	s.Require().NoError(err)
+	s.Require().Equal(defaultHeight+1, latestHeight)
+}
+
+// newRouter returns a router over the ATOM/OSMO and OSMO/USDC balancer pools.
+func (s *EmbeddedTestSuite) newRouter() *embedded.Router {
+	takerFees := sqsdomain.TakerFeeMap{}
+	takerFees.SetTakerFee(uatom, uosmo, osmomath.MustNewDecFromStr("0.001"))
+	takerFees.SetTakerFee(uosmo, uusdc, osmomath.MustNewDecFromStr("0.001"))
+
+	state := embedded.State{
+		Height: defaultHeight,
+		Pools: []sqsdomain.PoolI{
+			s.newBalancerPool(atomOsmoPoolID, sdk.NewCoin(uatom, osmomath.NewInt(1_000_000_000)), sdk.NewCoin(uosmo, osmomath.NewInt(10_000_000_000))),
+			s.newBalancerPool(osmoUSDCPoolID, sdk.NewCoin(uosmo, osmomath.NewInt(10_000_000_000)), sdk.NewCoin(uusdc, osmomath.NewInt(5_000_000_000))),
+		},
+		TakerFees: takerFees,
+		Tokens:    tokens,
+	}
+
+	router, err := embedded.New(domain.DefaultConfig, encodingConfig.Marshaler, state, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	return router
+}
+
+// newBalancerPool returns a balancer pool of the given ID with the given balances of equal weights.
+func (s *EmbeddedTestSuite) newBalancerPool(poolID uint64, balances ...sdk.Coin) sqsdomain.PoolI {
+	poolAssets := make([]balancer.PoolAsset, 0, len(balances))
+	poolDenoms := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		poolAssets = append(poolAssets, balancer.PoolAsset{Token: balance, Weight: osmomath.NewInt(1)})
+		poolDenoms = append(poolDenoms, balance.Denom)
+	}
+
+	balancerPool, err := balancer.NewBalancerPool(
+		poolID,
+		balancer.PoolParams{SwapFee: osmomath.MustNewDecFromStr("0.002"), ExitFee: osmomath.ZeroDec()},
+		poolAssets,
+		"",
+		time.Now(),
+	)
+	s.Require().NoError(err)
+
+	return &sqsdomain.PoolWrapper{
+		ChainModel: &balancerPool,
+		SQSModel: sqsdomain.SQSPool{
+			PoolLiquidityCap: osmomath.NewInt(1_000_000),
+			PoolDenoms:       poolDenoms,
+			Balances:         sdk.NewCoins(balances...),
+			SpreadFactor:     balancerPool.GetSpreadFactor(sdk.Context{}),
+		},
+	}
+}
@@ -0,0 +1,253 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+const (
+	resyncReasonGap                 = "gap"
+	resyncReasonOutOfOrder          = "out_of_order"
+	resyncReasonApplyPoolDeltaError = "apply_pool_delta_error"
+	resyncReasonProcessBlockError   = "process_block_error"
+)
+
+// queuedBlock is a received block waiting to be processed.
+type queuedBlock struct {
+	// ctx carries the span of the latest block coalesced into this one.
+	ctx            context.Context
+	height         uint64
+	takerFeesMap   sqsdomain.TakerFeeMap
+	pools          map[uint64]sqsdomain.PoolI
+	removedPoolIDs map[uint64]struct{}
+	// numBlocks is the number of received blocks coalesced into this one.
+	numBlocks int
+}
+
+// newQueuedBlock returns a new queued block.
+func newQueuedBlock(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) *queuedBlock {
+	block := &queuedBlock{
+		ctx:            ctx,
+		height:         height,
+		takerFeesMap:   takerFeesMap,
+		pools:          pools,
+		removedPoolIDs: make(map[uint64]struct{}, len(removedPoolIDs)),
+		numBlocks:      1,
+	}
+
+	if block.pools == nil {
+		block.pools = make(map[uint64]sqsdomain.PoolI)
+	}
+
+	for _, poolID := range removedPoolIDs {
+		block.removedPoolIDs[poolID] = struct{}{}
+	}
+
+	return block
+}
+
+// coalesce merges the newer block into this one so that processing the result is equivalent
+// to processing both blocks in order. The newer pools supersede the older ones and the
+// newer taker fees, which always contain all pairs, replace the older ones.
+func (b *queuedBlock) coalesce(newer *queuedBlock) {
+	for poolID, pool := range newer.pools {
+		b.pools[poolID] = pool
+		delete(b.removedPoolIDs, poolID)
+	}
+
+	for poolID := range newer.removedPoolIDs {
+		delete(b.pools, poolID)
+		b.removedPoolIDs[poolID] = struct{}{}
+	}
+
+	b.ctx = newer.ctx
+	b.height = newer.height
+	b.takerFeesMap = newer.takerFeesMap
+	b.numBlocks += newer.numBlocks
+}
+
+// sortedRemovedPoolIDs returns the removed pool IDs in ascending order.
+func (b *queuedBlock) sortedRemovedPoolIDs() []uint64 {
+	removedPoolIDs := make([]uint64, 0, len(b.removedPoolIDs))
+	for poolID := range b.removedPoolIDs {
+		removedPoolIDs = append(removedPoolIDs, poolID)
+	}
+
+	sort.Slice(removedPoolIDs, func(i, j int) bool { return removedPoolIDs[i] < removedPoolIDs[j] })
+
+	return removedPoolIDs
+}
+
+// blockQueue processes the received blocks one at a time in strict height order.
+//
+// Blocks received while the previous block is processed are coalesced into a single pending block
+// so that, when processing falls behind, the superseded intermediate blocks are skipped.
+//
+// A gap or an out-of-order height requires a full resync: the error is returned to the node
+// that reingests all pools at the next block, which is accepted at any height.
+type blockQueue struct {
+	ingestUseCase mvc.IngestUsecase
+	logger        log.Logger
+
+	mx sync.Mutex
+	// pending is the block waiting to be processed, nil if none.
+	pending *queuedBlock
+	// latestHeight is the height of the latest enqueued block.
+	// Zero if no block was enqueued yet or a full resync is required.
+	latestHeight uint64
+	// receivedHeight is the height of the latest enqueued block that is not reset on resync.
+	receivedHeight uint64
+	// processedHeight is the height of the latest processed block.
+	processedHeight uint64
+	// processErr is the latest processing error not yet returned to the node.
+	processErr error
+
+	// notify signals the processing goroutine that a block is pending.
+	notify chan struct{}
+}
+
+// newBlockQueue returns a new block queue processing blocks with the given ingest usecase.
+// Run must be called for blocks to be processed.
+func newBlockQueue(ingestUseCase mvc.IngestUsecase, logger log.Logger) *blockQueue {
+	return &blockQueue{
+		ingestUseCase: ingestUseCase,
+		logger:        logger,
+
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// checkHeight validates that the given height follows the latest enqueued height.
+// Returns the pending processing error if any.
+// On error, a full resync is required and the next block is accepted at any height.
+func (q *blockQueue) checkHeight(height uint64) error {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.processErr != nil {
+		err := q.processErr
+		q.processErr = nil
+		q.requireResyncLocked(resyncReasonProcessBlockError)
+		return err
+	}
+
+	if q.latestHeight == 0 || height == q.latestHeight+1 {
+		return nil
+	}
+
+	if height <= q.latestHeight {
+		err := domain.IngestOutOfOrderHeightError{LatestHeight: q.latestHeight, ActualHeight: height}
+		q.requireResyncLocked(resyncReasonOutOfOrder)
+		return err
+	}
+
+	err := domain.IngestHeightGapError{ExpectedHeight: q.latestHeight + 1, ActualHeight: height}
+	q.requireResyncLocked(resyncReasonGap)
+	return err
+}
+
+// requireResync requires a full resync for the given reason.
+// The next block is accepted at any height.
+func (q *blockQueue) requireResync(reason string) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	q.requireResyncLocked(reason)
+}
+
+// requireResyncLocked requires a full resync for the given reason.
+// CONTRACT: the caller holds the lock.
+func (q *blockQueue) requireResyncLocked(reason string) {
+	q.latestHeight = 0
+
+	domain.SQSIngestHandlerResyncCounter.WithLabelValues(reason).Inc()
+}
+
+// push enqueues the block, coalescing it into the pending block if any.
+// CONTRACT: checkHeight succeeded for the block height.
+func (q *blockQueue) push(block *queuedBlock) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.pending == nil {
+		q.pending = block
+	} else {
+		q.pending.coalesce(block)
+	}
+
+	q.latestHeight = block.height
+	q.receivedHeight = block.height
+
+	q.updateMetricsLocked()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+		// Already notified.
+	}
+}
+
+// run processes the pending blocks until the context is cancelled.
+func (q *blockQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.notify:
+		}
+
+		q.mx.Lock()
+		block := q.pending
+		q.pending = nil
+		q.updateMetricsLocked()
+		q.mx.Unlock()
+
+		if block == nil {
+			continue
+		}
+
+		if block.numBlocks > 1 {
+			q.logger.Info("coalesced blocks", zap.Uint64("height", block.height), zap.Int("num_blocks", block.numBlocks))
+			domain.SQSIngestHandlerCoalescedBlocksCounter.Add(float64(block.numBlocks - 1))
+		}
+
+		err := q.ingestUseCase.ProcessBlockData(block.ctx, block.height, block.takerFeesMap, block.pools, block.sortedRemovedPoolIDs())
+
+		q.mx.Lock()
+		if err != nil {
+			// Increment error counter
+			q.logger.Error(domain.SQSIngestUsecaseProcessBlockErrorMetricName, zap.Uint64("height", block.height), zap.Error(err))
+			domain.SQSIngestHandlerProcessBlockErrorCounter.Inc()
+
+			q.processErr = err
+		}
+		q.processedHeight = block.height
+		q.updateMetricsLocked()
+		q.mx.Unlock()
+	}
+}
+
+// updateMetricsLocked updates the queue depth and lag metrics.
+// CONTRACT: the caller holds the lock.
+func (q *blockQueue) updateMetricsLocked() {
+	queueDepth := 0
+	if q.pending != nil {
+		queueDepth = q.pending.numBlocks
+	}
+	domain.SQSIngestHandlerQueueDepthGauge.Set(float64(queueDepth))
+
+	// Prior to processing the first block, the lag is the number of received blocks.
+	lag := uint64(queueDepth)
+	if q.processedHeight > 0 && q.receivedHeight > q.processedHeight {
+		lag = q.receivedHeight - q.processedHeight
+	}
+	domain.SQSIngestHandlerLagBlocksGauge.Set(float64(lag))
+}
@@ -0,0 +1,238 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	ingestgrpc "github.com/osmosis-labs/sqs/ingest/delivery/grpc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type BlockQueueTestSuite struct {
+	suite.Suite
+}
+
+const waitTimeout = 5 * time.Second
+
+var (
+	defaultPool = &mocks.MockRoutablePool{PoolLiquidityCap: osmomath.NewInt(1)}
+	updatedPool = &mocks.MockRoutablePool{PoolLiquidityCap: osmomath.NewInt(2)}
+)
+
+// processedBlock is a block processed by the mock ingest usecase.
+type processedBlock struct {
+	height         uint64
+	pools          map[uint64]sqsdomain.PoolI
+	removedPoolIDs []uint64
+}
+
+func TestBlockQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockQueueTestSuite))
+}
+
+// Tests that heights must be strictly sequential and that
+// any gap or out-of-order height requires a resync accepting the next height.
+func (s *BlockQueueTestSuite) TestCheckHeight() {
+	tests := []struct {
+		name string
+
+		enqueuedHeights []uint64
+		height          uint64
+
+		expectedErr          error
+		expectedResyncReason string
+	}{
+		{
+			name:   "first block at any height",
+			height: 100,
+		},
+		{
+			name:            "next height",
+			enqueuedHeights: []uint64{100},
+			height:          101,
+		},
+		{
+			name:            "gap",
+			enqueuedHeights: []uint64{100},
+			height:          102,
+
+			expectedErr:          domain.IngestHeightGapError{ExpectedHeight: 101, ActualHeight: 102},
+			expectedResyncReason: ingestgrpc.ResyncReasonGap,
+		},
+		{
+			name:            "duplicate height",
+			enqueuedHeights: []uint64{100},
+			height:          100,
+
+			expectedErr:          domain.IngestOutOfOrderHeightError{LatestHeight: 100, ActualHeight: 100},
+			expectedResyncReason: ingestgrpc.ResyncReasonOutOfOrder,
+		},
+		{
+			name:            "lower height",
+			enqueuedHeights: []uint64{100, 101},
+			height:          99,
+
+			expectedErr:          domain.IngestOutOfOrderHeightError{LatestHeight: 101, ActualHeight: 99},
+			expectedResyncReason: ingestgrpc.ResyncReasonOutOfOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.Run(tt.name, func() {
+			queue := ingestgrpc.NewBlockQueue(&mocks.IngestUsecaseMock{}, &log.NoOpLogger{})
+
+			for _, height := range tt.enqueuedHeights {
+				s.Require().NoError(queue.CheckHeight(height))
+				queue.Push(ingestgrpc.NewQueuedBlock(height, nil, nil, nil))
+			}
+
+			var resyncBefore float64
+			if tt.expectedResyncReason != "" {
+				resyncBefore = testutil.ToFloat64(domain.SQSIngestHandlerResyncCounter.WithLabelValues(tt.expectedResyncReason))
+			}
+
+			err := queue.CheckHeight(tt.height)
+
+			if tt.expectedErr == nil {
+				s.Require().NoError(err)
+				return
+			}
+
+			s.Require().Error(err)
+			s.Require().Equal(tt.expectedErr, err)
+			s.Require().Equal(resyncBefore+1, testutil.ToFloat64(domain.SQSIngestHandlerResyncCounter.WithLabelValues(tt.expectedResyncReason)))
+
+			// The next block is accepted at any height after a resync is required.
+			s.Require().NoError(queue.CheckHeight(tt.height + 10))
+		})
+	}
+}
+
+// Tests that coalescing a newer block is equivalent to processing both blocks in order.
+func (s *BlockQueueTestSuite) TestCoalesce() {
+	takerFees := sqsdomain.TakerFeeMap{}
+
+	block := ingestgrpc.NewQueuedBlock(100, nil, map[uint64]sqsdomain.PoolI{
+		1: defaultPool,
+		2: defaultPool,
+	}, []uint64{3, 4})
+
+	block.Coalesce(ingestgrpc.NewQueuedBlock(101, takerFees, map[uint64]sqsdomain.PoolI{
+		// Updated.
+		1: updatedPool,
+		// Re-created after removal.
+		3: updatedPool,
+	}, []uint64{2}))
+
+	s.Require().Equal(uint64(101), block.Height())
+	s.Require().Equal(2, block.NumBlocks())
+	s.Require().Equal(map[uint64]sqsdomain.PoolI{
+		1: updatedPool,
+		3: updatedPool,
+	}, block.Pools())
+	s.Require().Equal([]uint64{2, 4}, block.SortedRemovedPoolIDs())
+}
+
+// Tests that the blocks received while a block is processed are coalesced
+// and processed in order, and that a processing error is returned at the next block.
+func (s *BlockQueueTestSuite) TestRun() {
+	var (
+		processed = make(chan processedBlock, 10)
+		release   = make(chan struct{})
+
+		processErr = errors.New("failed to process block")
+	)
+
+	ingestUseCase := &mocks.IngestUsecaseMock{
+		ProcessBlockDataFunc: func(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) error {
+			<-release
+
+			processed <- processedBlock{height: height, pools: pools, removedPoolIDs: removedPoolIDs}
+
+			if height == 104 {
+				return processErr
+			}
+			return nil
+		},
+	}
+
+	queue := ingestgrpc.NewBlockQueue(ingestUseCase, &log.NoOpLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	coalescedBefore := testutil.ToFloat64(domain.SQSIngestHandlerCoalescedBlocksCounter)
+
+	push := func(height uint64, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) {
+		s.Require().NoError(queue.CheckHeight(height))
+		queue.Push(ingestgrpc.NewQueuedBlock(height, sqsdomain.TakerFeeMap{}, pools, removedPoolIDs))
+	}
+
+	// Block 100 starts processing and blocks until released.
+	push(100, map[uint64]sqsdomain.PoolI{1: defaultPool}, nil)
+	s.Require().Eventually(func() bool {
+		return testutil.ToFloat64(domain.SQSIngestHandlerQueueDepthGauge) == 0
+	}, waitTimeout, time.Millisecond)
+
+	// Blocks 101 to 103 are received while block 100 is processed.
+	push(101, map[uint64]sqsdomain.PoolI{2: defaultPool}, nil)
+	push(102, map[uint64]sqsdomain.PoolI{2: updatedPool}, []uint64{3})
+	push(103, map[uint64]sqsdomain.PoolI{1: updatedPool}, nil)
+
+	s.Require().Equal(float64(3), testutil.ToFloat64(domain.SQSIngestHandlerQueueDepthGauge))
+	// No block was processed yet, so the lag is the number of pending blocks.
+	s.Require().Equal(float64(3), testutil.ToFloat64(domain.SQSIngestHandlerLagBlocksGauge))
+
+	close(release)
+
+	s.Require().Equal(processedBlock{
+		height:         100,
+		pools:          map[uint64]sqsdomain.PoolI{1: defaultPool},
+		removedPoolIDs: []uint64{},
+	}, s.waitProcessed(processed))
+
+	s.Require().Equal(processedBlock{
+		height:         103,
+		pools:          map[uint64]sqsdomain.PoolI{1: updatedPool, 2: updatedPool},
+		removedPoolIDs: []uint64{3},
+	}, s.waitProcessed(processed))
+
+	s.Require().Equal(coalescedBefore+2, testutil.ToFloat64(domain.SQSIngestHandlerCoalescedBlocksCounter))
+
+	s.Require().Eventually(func() bool {
+		return testutil.ToFloat64(domain.SQSIngestHandlerLagBlocksGauge) == 0
+	}, waitTimeout, time.Millisecond)
+
+	// The processing error of block 104 is returned when receiving block 105.
+	push(104, nil, nil)
+	s.Require().Equal(uint64(104), s.waitProcessed(processed).height)
+
+	s.Require().Eventually(func() bool {
+		return errors.Is(queue.CheckHeight(105), processErr)
+	}, waitTimeout, time.Millisecond)
+
+	// A resync is required, accepting the next block at any height.
+	s.Require().NoError(queue.CheckHeight(110))
+}
+
+// waitProcessed waits for the next processed block.
+func (s *BlockQueueTestSuite) waitProcessed(processed <-chan processedBlock) processedBlock {
+	select {
+	case block := <-processed:
+		return block
+	case <-time.After(waitTimeout):
+		s.FailNow("timed out waiting for processed block")
+		return processedBlock{}
+	}
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type (
+	BlockQueue  = blockQueue
+	QueuedBlock = queuedBlock
+)
+
+const (
+	ResyncReasonGap               = resyncReasonGap
+	ResyncReasonOutOfOrder        = resyncReasonOutOfOrder
+	ResyncReasonProcessBlockError = resyncReasonProcessBlockError
+)
+
+func NewBlockQueue(ingestUseCase mvc.IngestUsecase, logger log.Logger) *BlockQueue {
+	return newBlockQueue(ingestUseCase, logger)
+}
+
+func NewQueuedBlock(height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) *QueuedBlock {
+	return newQueuedBlock(context.Background(), height, takerFeesMap, pools, removedPoolIDs)
+}
+
+func (q *blockQueue) CheckHeight(height uint64) error {
+	return q.checkHeight(height)
+}
+
+func (q *blockQueue) Push(block *QueuedBlock) {
+	q.push(block)
+}
+
+func (q *blockQueue) Run(ctx context.Context) {
+	q.run(ctx)
+}
+
+func (b *queuedBlock) Coalesce(newer *QueuedBlock) {
+	b.coalesce(newer)
+}
+
+func (b *queuedBlock) Height() uint64 {
+	return b.height
+}
+
+func (b *queuedBlock) Pools() map[uint64]sqsdomain.PoolI {
+	return b.pools
+}
+
+func (b *queuedBlock) SortedRemovedPoolIDs() []uint64 {
+	return b.sortedRemovedPoolIDs()
+}
+
+func (b *queuedBlock) NumBlocks() int {
+	return b.numBlocks
+}
@@ -2,11 +2,12 @@ package grpc
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
-	"github.com/osmosis-labs/sqs/domain/workerpool"
+	"github.com/osmosis-labs/sqs/ingest/recorder"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
@@ -27,7 +28,13 @@ type IngestGRPCHandler struct {
 
 	prototypes.UnimplementedSQSIngesterServer
 
-	blockProcessDispatcher *workerpool.Dispatcher[uint64]
+	// blockQueue processes the received blocks in order.
+	blockQueue *blockQueue
+	// mx serializes the validation, delta application and enqueuing of the received blocks.
+	mx sync.Mutex
+
+	// recorder records every received block if configured, nil otherwise.
+	recorder *recorder.Recorder
 }
 
 type IngestProcessBlockArgs struct {
@@ -35,10 +42,6 @@ type IngestProcessBlockArgs struct {
 }
 
 const (
-	// numBlockProcessWorkers is the number of workers to process blocks concurrently
-	// TODO: move to config
-	numBlockProcessWorkers = 2
-
 	tracerName = "sqs-ingest-handler"
 )
 
@@ -49,14 +52,17 @@ var (
 var _ prototypes.SQSIngesterServer = &IngestGRPCHandler{}
 
 // NewIngestHandler will initialize the ingest/ resources endpoint
-func NewIngestGRPCHandler(us mvc.IngestUsecase, grpcIngesterConfig domain.GRPCIngesterConfig, logger log.Logger) (*grpc.Server, error) {
+// Every received block is recorded by the given recorder unless it is nil.
+// The caller owns the recorder and closes it once the returned server is stopped.
+func NewIngestGRPCHandler(us mvc.IngestUsecase, blockRecorder *recorder.Recorder, grpcIngesterConfig domain.GRPCIngesterConfig, logger log.Logger) (*grpc.Server, error) {
 	ingestHandler := &IngestGRPCHandler{
-		ingestUseCase:          us,
-		logger:                 logger,
-		blockProcessDispatcher: workerpool.NewDispatcher[uint64](numBlockProcessWorkers),
+		ingestUseCase: us,
+		logger:        logger,
+		blockQueue:    newBlockQueue(us, logger),
+		recorder:      blockRecorder,
 	}
 
-	go ingestHandler.blockProcessDispatcher.Run()
+	go ingestHandler.blockQueue.run(context.Background())
 
 	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(grpcIngesterConfig.MaxReceiveMsgSizeBytes), grpc.ConnectionTimeout(time.Second*time.Duration(grpcIngesterConfig.ServerConnectionTimeoutSeconds)))
 	prototypes.RegisterSQSIngesterServer(grpcServer, ingestHandler)
@@ -82,63 +88,45 @@ func (i *IngestGRPCHandler) ProcessBlock(ctx context.Context, req *prototypes.Pr
 	parentCtx, span := tracer.Start(parentCtx, "IngestGRPCHandler.ProcessBlock", trace.WithSpanKind(trace.SpanKindServer))
 	defer span.End()
 
+	// Record the raw request prior to any processing so that failures can be reproduced.
+	// Failing to record must not affect ingestion.
+	if i.recorder != nil {
+		if err := i.recorder.Record(time.Now(), req); err != nil {
+			i.logger.Error("failed to record block", zap.Uint64("height", req.BlockHeight), zap.Error(err))
+		}
+	}
+
 	if err := takerFeeMap.UnmarshalJSON(req.TakerFeesMap); err != nil {
 		return nil, err
 	}
 
-	// Empty result queue and return the first error encountered if any
-	// THis allows to trigger the fallback mechanism, reingesting all data
-	// if any error is detected. Under normal circumstances, this should not
-	// be triggered.
-	err := i.emptyResults()
-	if err != nil {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	// Validate the height ordering and return the latest processing error if any.
+	// Returning an error triggers the fallback mechanism, reingesting all data
+	// at the next block. Under normal circumstances, this should not be triggered.
+	if err := i.blockQueue.checkHeight(req.BlockHeight); err != nil {
+		i.logger.Error("full resync required", zap.Uint64("height", req.BlockHeight), zap.Error(err))
 		return nil, err
 	}
 
-	// Dispatch block processing
-	i.blockProcessDispatcher.JobQueue <- workerpool.Job[uint64]{
-		Task: func() (uint64, error) {
-			// Process block data
-			// Note that this executed a new background context since the parent context
-			// if the RPC call will be cancelled after the RPC call is done.
-			ctx := context.Background()
-			span := trace.SpanFromContext(parentCtx)
-			ctx = trace.ContextWithSpan(ctx, span)
-
-			if err := i.ingestUseCase.ProcessBlockData(ctx, req.BlockHeight, takerFeeMap, req.Pools); err != nil {
-				// Increment error counter
-				i.logger.Error(domain.SQSIngestUsecaseProcessBlockErrorMetricName, zap.Uint64("height", req.BlockHeight), zap.Error(err))
-				domain.SQSIngestHandlerProcessBlockErrorCounter.Inc()
-
-				return req.BlockHeight, err
-			}
-
-			return req.BlockHeight, nil
-		},
+	// Apply the pool deltas synchronously since every delta depends on the pool data of the previous block.
+	// On failure, the error triggers the same fallback mechanism, reingesting all data.
+	pools, err := i.ingestUseCase.ApplyPoolDeltas(req)
+	if err != nil {
+		i.logger.Error(domain.SQSIngestUsecaseApplyPoolDeltaErrorMetricName, zap.Uint64("height", req.BlockHeight), zap.Error(err))
+		domain.SQSIngestHandlerApplyPoolDeltaErrorCounter.Inc()
+		i.blockQueue.requireResync(resyncReasonApplyPoolDeltaError)
+
+		return nil, err
 	}
 
-	return &prototypes.ProcessBlockReply{}, nil
-}
+	// Enqueue block processing.
+	// Note that the block is processed with a new background context since the parent context
+	// of the RPC call will be cancelled after the RPC call is done.
+	blockCtx := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(parentCtx))
+	i.blockQueue.push(newQueuedBlock(blockCtx, req.BlockHeight, takerFeeMap, pools, req.RemovedPoolIds))
 
-// emptyResults will empty the result queue and return the first error encountered if any.
-// If no errors are encountered, it will return nil.
-func (i *IngestGRPCHandler) emptyResults() error {
-	// TODO: consider loop bound
-	for {
-		select {
-		// Empty result queue and return if there are any errors
-		// to trigger the fallback mechanism, reingesting all data.
-		case prevResult := <-i.blockProcessDispatcher.ResultQueue:
-			if prevResult.Err != nil {
-				i.logger.Error(domain.SQSIngestUsecaseProcessBlockErrorMetricName, zap.Uint64("height", prevResult.Result), zap.Error(prevResult.Err))
-				// Increment error counter
-				domain.SQSIngestHandlerProcessBlockErrorCounter.Inc()
-
-				return prevResult.Err
-			}
-		default:
-			// No more results in the channel, continue execution
-			return nil
-		}
-	}
+	return &prototypes.ProcessBlockReply{}, nil
 }
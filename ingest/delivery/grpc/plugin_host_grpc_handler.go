@@ -0,0 +1,189 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	"github.com/osmosis-labs/sqs/log"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// PluginHostGRPCHandler serves the gRPC API that out-of-process plugins
+// register with and call back into.
+type PluginHostGRPCHandler struct {
+	logger log.Logger
+
+	pluginHost    remoteplugindomain.PluginHost
+	routerUseCase mvc.RouterUsecase
+	tokensUseCase mvc.TokensUsecase
+
+	prototypes.UnimplementedSQSPluginHostServer
+}
+
+var _ prototypes.SQSPluginHostServer = &PluginHostGRPCHandler{}
+
+// NewPluginHostGRPCHandler will initialize the plugin host gRPC server.
+// If the auth token is configured, every call must carry it in the remoteplugindomain.AuthTokenMetadataKey metadata.
+func NewPluginHostGRPCHandler(pluginHost remoteplugindomain.PluginHost, routerUseCase mvc.RouterUsecase, tokensUseCase mvc.TokensUsecase, grpcIngesterConfig domain.GRPCIngesterConfig, remotePluginHostConfig domain.RemotePluginHostConfig, logger log.Logger) (*grpc.Server, error) {
+	pluginHostHandler := &PluginHostGRPCHandler{
+		logger:        logger,
+		pluginHost:    pluginHost,
+		routerUseCase: routerUseCase,
+		tokensUseCase: tokensUseCase,
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(grpcIngesterConfig.MaxReceiveMsgSizeBytes),
+		grpc.ConnectionTimeout(time.Second * time.Duration(grpcIngesterConfig.ServerConnectionTimeoutSeconds)),
+	}
+
+	if remotePluginHostConfig.AuthToken != "" {
+		serverOpts = append(serverOpts, grpc.UnaryInterceptor(authTokenInterceptor(remotePluginHostConfig.AuthToken)))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	prototypes.RegisterSQSPluginHostServer(grpcServer, pluginHostHandler)
+
+	return grpcServer, nil
+}
+
+// RegisterPlugin implements types.SQSPluginHostServer.
+func (p *PluginHostGRPCHandler) RegisterPlugin(ctx context.Context, req *prototypes.RegisterPluginRequest) (*prototypes.RegisterPluginReply, error) {
+	if err := p.pluginHost.RegisterPlugin(req.Name, req.Address); err != nil {
+		if errors.As(err, &remoteplugindomain.PluginAddressNotAllowedError{}) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &prototypes.RegisterPluginReply{}, nil
+}
+
+// DeregisterPlugin implements types.SQSPluginHostServer.
+func (p *PluginHostGRPCHandler) DeregisterPlugin(ctx context.Context, req *prototypes.DeregisterPluginRequest) (*prototypes.DeregisterPluginReply, error) {
+	if err := p.pluginHost.DeregisterPlugin(req.Name); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &prototypes.DeregisterPluginReply{}, nil
+}
+
+// GetPools implements types.SQSPluginHostServer.
+func (p *PluginHostGRPCHandler) GetPools(ctx context.Context, req *prototypes.GetPoolsRequest) (*prototypes.GetPoolsReply, error) {
+	pools, err := p.pluginHost.GetPoolData(req.PoolIds)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &prototypes.GetPoolsReply{Pools: pools}, nil
+}
+
+// GetQuote implements types.SQSPluginHostServer.
+func (p *PluginHostGRPCHandler) GetQuote(ctx context.Context, req *prototypes.GetQuoteRequest) (*prototypes.GetQuoteReply, error) {
+	tokenIn, err := sdk.ParseCoinNormalized(req.TokenIn)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.TokenOutDenom == "" {
+		return nil, status.Error(codes.InvalidArgument, "token out denom cannot be empty")
+	}
+
+	var routerOpts []domain.RouterOption
+	if req.SingleRoute {
+		routerOpts = append(routerOpts, domain.WithMaxSplitRoutes(domain.DisableSplitRoutes))
+	}
+
+	quote, err := p.routerUseCase.GetOptimalQuote(ctx, tokenIn, req.TokenOutDenom, routerOpts...)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	scalingFactor, err := p.tokensUseCase.GetSpotPriceScalingFactorByDenom(req.TokenOutDenom, tokenIn.Denom)
+	if err != nil {
+		// Note that we do not fail the quote if scaling factor fetching fails.
+		// Instead, we simply set it to zero to validate future calculations downstream.
+		scalingFactor = osmomath.ZeroDec()
+	}
+
+	if _, _, err := quote.PrepareResult(ctx, scalingFactor, p.logger); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	reply := &prototypes.GetQuoteReply{
+		AmountIn:     quote.GetAmountIn().String(),
+		AmountOut:    quote.GetAmountOut().String(),
+		Routes:       make([]*prototypes.QuoteRoute, 0, len(quote.GetRoute())),
+		EffectiveFee: quote.GetEffectiveFee().String(),
+		PriceImpact:  quote.GetPriceImpact().String(),
+	}
+
+	for _, route := range quote.GetRoute() {
+		quoteRoute := &prototypes.QuoteRoute{
+			InAmount:  route.GetAmountIn().String(),
+			OutAmount: route.GetAmountOut().String(),
+		}
+
+		for _, pool := range route.GetPools() {
+			quoteRoute.PoolIds = append(quoteRoute.PoolIds, pool.GetId())
+			quoteRoute.TokenOutDenoms = append(quoteRoute.TokenOutDenoms, pool.GetTokenOutDenom())
+		}
+
+		reply.Routes = append(reply.Routes, quoteRoute)
+	}
+
+	return reply, nil
+}
+
+// GetPrices implements types.SQSPluginHostServer.
+func (p *PluginHostGRPCHandler) GetPrices(ctx context.Context, req *prototypes.GetPricesRequest) (*prototypes.GetPricesReply, error) {
+	if len(req.BaseDenoms) == 0 || req.QuoteDenom == "" {
+		return nil, status.Error(codes.InvalidArgument, "base denoms and quote denom must be specified")
+	}
+
+	prices, err := p.tokensUseCase.GetPrices(ctx, req.BaseDenoms, []string{req.QuoteDenom}, domain.ChainPricingSourceType)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	reply := &prototypes.GetPricesReply{
+		Prices: make([]*prototypes.Price, 0, len(req.BaseDenoms)),
+	}
+
+	for _, baseDenom := range req.BaseDenoms {
+		reply.Prices = append(reply.Prices, &prototypes.Price{
+			BaseDenom: baseDenom,
+			Price:     prices.GetPriceForDenom(baseDenom, req.QuoteDenom).String(),
+		})
+	}
+
+	return reply, nil
+}
+
+// authTokenInterceptor returns a unary server interceptor rejecting the calls
+// that do not carry the given auth token.
+func authTokenInterceptor(authToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		tokens := md.Get(remoteplugindomain.AuthTokenMetadataKey)
+		if len(tokens) != 1 || subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(authToken)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid plugin auth token")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,396 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	ingestgrpc "github.com/osmosis-labs/sqs/ingest/delivery/grpc"
+	"github.com/osmosis-labs/sqs/log"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+type PluginHostGRPCHandlerTestSuite struct {
+	suite.Suite
+}
+
+const (
+	uosmo = "uosmo"
+	uusdc = "ibc/usdc"
+
+	defaultAuthToken = "secret"
+	allowedAddress   = "127.0.0.1:60000"
+)
+
+// pluginHostMock serves the pool data of a single pool and allows
+// registering plugins at allowedAddress only.
+type pluginHostMock struct {
+	poolData *prototypes.PoolData
+}
+
+var _ remoteplugindomain.PluginHost = &pluginHostMock{}
+
+// RegisterPlugin implements remoteplugindomain.PluginHost.
+func (p *pluginHostMock) RegisterPlugin(name, address string) error {
+	if address != allowedAddress {
+		return remoteplugindomain.PluginAddressNotAllowedError{Address: address}
+	}
+	return nil
+}
+
+// DeregisterPlugin implements remoteplugindomain.PluginHost.
+func (p *pluginHostMock) DeregisterPlugin(name string) error {
+	return nil
+}
+
+// GetPoolData implements remoteplugindomain.PluginHost.
+func (p *pluginHostMock) GetPoolData(poolIDs []uint64) ([]*prototypes.PoolData, error) {
+	if len(poolIDs) != 1 || poolIDs[0] != 1 {
+		return nil, errors.New("pool not found")
+	}
+	return []*prototypes.PoolData{p.poolData}, nil
+}
+
+func TestPluginHostGRPCHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PluginHostGRPCHandlerTestSuite))
+}
+
+// newPluginHostClient starts the plugin host server with the given auth token over an in-memory listener
+// and returns a client connected to it.
+func (s *PluginHostGRPCHandlerTestSuite) newPluginHostClient(routerUsecase *mocks.RouterUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock, authToken string) prototypes.SQSPluginHostClient {
+	config := domain.DefaultRemotePluginHostConfig
+	config.AuthToken = authToken
+
+	pluginHost := &pluginHostMock{poolData: &prototypes.PoolData{ChainModel: []byte(`{"id":1}`)}}
+
+	grpcServer, err := ingestgrpc.NewPluginHostGRPCHandler(pluginHost, routerUsecase, tokensUsecase, *domain.DefaultConfig.GRPCIngester, config, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	s.T().Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() })
+
+	return prototypes.NewSQSPluginHostClient(conn)
+}
+
+// withAuthToken returns a context carrying the given auth token.
+func withAuthToken(authToken string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), remoteplugindomain.AuthTokenMetadataKey, authToken)
+}
+
+// Tests that the calls without the configured auth token are rejected.
+func (s *PluginHostGRPCHandlerTestSuite) TestAuthToken() {
+	tests := []struct {
+		name string
+
+		configuredToken string
+		ctx             context.Context
+
+		expectedCode codes.Code
+	}{
+		{
+			name:            "valid token",
+			configuredToken: defaultAuthToken,
+			ctx:             withAuthToken(defaultAuthToken),
+
+			expectedCode: codes.OK,
+		},
+		{
+			name:            "invalid token",
+			configuredToken: defaultAuthToken,
+			ctx:             withAuthToken("invalid"),
+
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:            "missing token",
+			configuredToken: defaultAuthToken,
+			ctx:             context.Background(),
+
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "no token configured",
+			ctx:  context.Background(),
+
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			client := s.newPluginHostClient(&mocks.RouterUsecaseMock{}, &mocks.TokensUsecaseMock{}, tc.configuredToken)
+
+			_, err := client.GetPools(tc.ctx, &prototypes.GetPoolsRequest{PoolIds: []uint64{1}})
+			s.Require().Equal(tc.expectedCode, status.Code(err))
+
+			_, err = client.RegisterPlugin(tc.ctx, &prototypes.RegisterPluginRequest{Name: "plugin", Address: allowedAddress})
+			s.Require().Equal(tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+// Tests that registering a plugin at an address that is not allowed is denied.
+func (s *PluginHostGRPCHandlerTestSuite) TestRegisterPlugin_NotAllowed() {
+	client := s.newPluginHostClient(&mocks.RouterUsecaseMock{}, &mocks.TokensUsecaseMock{}, defaultAuthToken)
+
+	_, err := client.RegisterPlugin(withAuthToken(defaultAuthToken), &prototypes.RegisterPluginRequest{Name: "plugin", Address: "10.0.0.1:60000"})
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *PluginHostGRPCHandlerTestSuite) TestGetPools() {
+	client := s.newPluginHostClient(&mocks.RouterUsecaseMock{}, &mocks.TokensUsecaseMock{}, defaultAuthToken)
+
+	reply, err := client.GetPools(withAuthToken(defaultAuthToken), &prototypes.GetPoolsRequest{PoolIds: []uint64{1}})
+	s.Require().NoError(err)
+	s.Require().Len(reply.Pools, 1)
+	s.Require().Equal([]byte(`{"id":1}`), reply.Pools[0].ChainModel)
+
+	_, err = client.GetPools(withAuthToken(defaultAuthToken), &prototypes.GetPoolsRequest{PoolIds: []uint64{2}})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *PluginHostGRPCHandlerTestSuite) TestGetQuote() {
+	route := &mocks.RouteMock{
+		GetPoolsFunc: func() []domain.RoutablePool {
+			return []domain.RoutablePool{&mocks.MockRoutablePool{ID: 1, TokenOutDenom: uusdc}}
+		},
+	}
+
+	quote := &mocks.QuoteMock{
+		GetAmountInFunc:     func() sdk.Coin { return sdk.NewCoin(uosmo, osmomath.NewInt(1000)) },
+		GetAmountOutFunc:    func() osmomath.Int { return osmomath.NewInt(500) },
+		GetRouteFunc:        func() []domain.SplitRoute { return []domain.SplitRoute{&splitRouteMock{RouteMock: route}} },
+		GetEffectiveFeeFunc: func() osmomath.Dec { return osmomath.MustNewDecFromStr("0.002") },
+		GetPriceImpactFunc:  func() osmomath.Dec { return osmomath.MustNewDecFromStr("-0.01") },
+		PrepareResultFunc: func(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
+			return nil, osmomath.Dec{}, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  *prototypes.GetQuoteRequest
+
+		quoteErr error
+
+		expectedCode        codes.Code
+		expectedSingleRoute bool
+		expectedReply       *prototypes.GetQuoteReply
+	}{
+		{
+			name: "valid quote",
+			req:  &prototypes.GetQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+
+			expectedCode: codes.OK,
+			expectedReply: &prototypes.GetQuoteReply{
+				AmountIn:  "1000uosmo",
+				AmountOut: "500",
+				Routes: []*prototypes.QuoteRoute{
+					{PoolIds: []uint64{1}, TokenOutDenoms: []string{uusdc}, InAmount: "1000", OutAmount: "500"},
+				},
+				EffectiveFee: "0.002000000000000000",
+				PriceImpact:  "-0.010000000000000000",
+			},
+		},
+		{
+			name: "single route",
+			req:  &prototypes.GetQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc, SingleRoute: true},
+
+			expectedCode:        codes.OK,
+			expectedSingleRoute: true,
+		},
+		{
+			name: "invalid token in",
+			req:  &prototypes.GetQuoteRequest{TokenIn: "uosmo", TokenOutDenom: uusdc},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "empty token out denom",
+			req:  &prototypes.GetQuoteRequest{TokenIn: "1000uosmo"},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:     "quote error",
+			req:      &prototypes.GetQuoteRequest{TokenIn: "1000uosmo", TokenOutDenom: uusdc},
+			quoteErr: errors.New("no routes"),
+
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			var (
+				actualTokenIn       sdk.Coin
+				actualTokenOutDenom string
+				actualOptions       domain.RouterOptions
+			)
+
+			routerUsecase := &mocks.RouterUsecaseMock{
+				GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+					actualTokenIn, actualTokenOutDenom = tokenIn, tokenOutDenom
+
+					actualOptions = domain.RouterOptions{MaxSplitRoutes: -1}
+					for _, opt := range opts {
+						opt(&actualOptions)
+					}
+
+					if tc.quoteErr != nil {
+						return nil, tc.quoteErr
+					}
+					return quote, nil
+				},
+			}
+
+			tokensUsecase := &mocks.TokensUsecaseMock{
+				GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+					return osmomath.OneDec(), nil
+				},
+			}
+
+			client := s.newPluginHostClient(routerUsecase, tokensUsecase, defaultAuthToken)
+
+			reply, err := client.GetQuote(withAuthToken(defaultAuthToken), tc.req)
+			s.Require().Equal(tc.expectedCode, status.Code(err))
+
+			if tc.expectedCode == codes.OK {
+				s.Require().Equal(sdk.NewCoin(uosmo, osmomath.NewInt(1000)), actualTokenIn)
+				s.Require().Equal(uusdc, actualTokenOutDenom)
+				s.Require().Equal(tc.expectedSingleRoute, actualOptions.MaxSplitRoutes == domain.DisableSplitRoutes)
+			}
+
+			if tc.expectedReply != nil {
+				s.Require().Equal(tc.expectedReply.String(), reply.String())
+			}
+		})
+	}
+}
+
+func (s *PluginHostGRPCHandlerTestSuite) TestGetPrices() {
+	tests := []struct {
+		name string
+		req  *prototypes.GetPricesRequest
+
+		pricesErr error
+
+		expectedCode  codes.Code
+		expectedReply *prototypes.GetPricesReply
+	}{
+		{
+			name: "valid prices",
+			req:  &prototypes.GetPricesRequest{BaseDenoms: []string{uosmo, "uatom"}, QuoteDenom: uusdc},
+
+			expectedCode: codes.OK,
+			expectedReply: &prototypes.GetPricesReply{
+				Prices: []*prototypes.Price{
+					{BaseDenom: uosmo, Price: osmomath.MustNewBigDecFromStr("0.5").String()},
+					// Missing prices are zero.
+					{BaseDenom: "uatom", Price: osmomath.ZeroBigDec().String()},
+				},
+			},
+		},
+		{
+			name: "no base denoms",
+			req:  &prototypes.GetPricesRequest{QuoteDenom: uusdc},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "no quote denom",
+			req:  &prototypes.GetPricesRequest{BaseDenoms: []string{uosmo}},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:      "prices error",
+			req:       &prototypes.GetPricesRequest{BaseDenoms: []string{uosmo}, QuoteDenom: uusdc},
+			pricesErr: errors.New("failed to compute prices"),
+
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			var (
+				actualQuoteDenoms       []string
+				actualPricingSourceType domain.PricingSourceType
+			)
+
+			tokensUsecase := &mocks.TokensUsecaseMock{
+				GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+					actualQuoteDenoms, actualPricingSourceType = quoteDenoms, pricingSourceType
+
+					if tc.pricesErr != nil {
+						return nil, tc.pricesErr
+					}
+					return domain.PricesResult{
+						uosmo: {uusdc: osmomath.MustNewBigDecFromStr("0.5")},
+					}, nil
+				},
+			}
+
+			client := s.newPluginHostClient(&mocks.RouterUsecaseMock{}, tokensUsecase, defaultAuthToken)
+
+			reply, err := client.GetPrices(withAuthToken(defaultAuthToken), tc.req)
+			s.Require().Equal(tc.expectedCode, status.Code(err))
+
+			if tc.expectedCode == codes.OK {
+				s.Require().Equal([]string{uusdc}, actualQuoteDenoms)
+				s.Require().Equal(domain.ChainPricingSourceType, actualPricingSourceType)
+			}
+
+			if tc.expectedReply != nil {
+				s.Require().Equal(tc.expectedReply.String(), reply.String())
+			}
+		})
+	}
+}
+
+// splitRouteMock is a split route of 1000 in and 500 out over the given route.
+type splitRouteMock struct {
+	*mocks.RouteMock
+}
+
+var _ domain.SplitRoute = &splitRouteMock{}
+
+// GetAmountIn implements domain.SplitRoute.
+func (r *splitRouteMock) GetAmountIn() osmomath.Int {
+	return osmomath.NewInt(1000)
+}
+
+// GetAmountOut implements domain.SplitRoute.
+func (r *splitRouteMock) GetAmountOut() osmomath.Int {
+	return osmomath.NewInt(500)
+}
@@ -0,0 +1,229 @@
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// The log is a sequence of independently framed records. Every record is laid out as:
+// - uvarint length of the remainder of the record
+// - gzip member of:
+//   - 8 byte big-endian unix timestamp in nanoseconds of when the request was received
+//   - protobuf-encoded ProcessBlockRequest
+//
+// Since every record is self-contained, a record truncated by an abrupt termination
+// only affects itself and is discarded when the log is reopened for appending.
+const (
+	timestampLength = 8
+
+	// maxRecordLength bounds the length of a single record to guard against corrupted logs.
+	maxRecordLength = 1 << 30
+)
+
+// Record is a single recorded block.
+type Record struct {
+	// ReceivedAt is the time that the request was received at.
+	ReceivedAt time.Time
+	// Request is the raw block process request.
+	Request *prototypes.ProcessBlockRequest
+}
+
+// Recorder appends the received block process requests to an on-disk log.
+type Recorder struct {
+	mx   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder returns a new recorder appending to the log at the given path.
+// The log is created if it does not exist. A trailing record truncated by an abrupt
+// termination of a previous recorder is discarded.
+// Returns error if the existing log is corrupted.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	validLength, err := completeRecordsLength(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if err := file.Truncate(validLength); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if _, err := file.Seek(validLength, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Recorder{
+		file: file,
+	}, nil
+}
+
+// Record appends the request to the log.
+// Every record is written in full so that the log remains readable
+// up until the last record if the process terminates abruptly.
+func (r *Recorder) Record(receivedAt time.Time, req *prototypes.ProcessBlockRequest) error {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	timestamp := make([]byte, timestampLength)
+	binary.BigEndian.PutUint64(timestamp, uint64(receivedAt.UnixNano()))
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(timestamp); err != nil {
+		return err
+	}
+	if _, err := gzipWriter.Write(data); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	record := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+compressed.Len()), uint64(compressed.Len()))
+	record = append(record, compressed.Bytes()...)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	_, err = r.file.Write(record)
+	return err
+}
+
+// Close closes the log.
+func (r *Recorder) Close() error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.file.Close()
+}
+
+// completeRecordsLength returns the length of the complete records at the start of the file.
+// Returns error if a record length is invalid.
+func completeRecordsLength(file *os.File) (int64, error) {
+	reader := bufio.NewReader(file)
+
+	var validLength int64
+	for {
+		length, err := binary.ReadUvarint(reader)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return validLength, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if length == 0 || length > maxRecordLength {
+			return 0, fmt.Errorf("invalid record length %d at offset %d", length, validLength)
+		}
+
+		skipped, err := io.CopyN(io.Discard, reader, int64(length))
+		if errors.Is(err, io.EOF) {
+			return validLength, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		validLength += int64(uvarintLength(length)) + skipped
+	}
+}
+
+// uvarintLength returns the number of bytes that the value is encoded to as a uvarint.
+func uvarintLength(value uint64) int {
+	return len(binary.AppendUvarint(nil, value))
+}
+
+// Reader reads the records from a recorded log.
+type Reader struct {
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// NewReader returns a new reader of the log at the given path.
+func NewReader(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		file:   file,
+		reader: bufio.NewReader(file),
+	}, nil
+}
+
+// Next returns the next record.
+// Returns io.EOF if there are no more records.
+// A record truncated by an abrupt termination of the recorder is reported as io.EOF.
+func (r *Reader) Next() (Record, error) {
+	length, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, err
+	}
+
+	if length == 0 || length > maxRecordLength {
+		return Record{}, fmt.Errorf("invalid record length %d", length)
+	}
+
+	compressed := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, compressed); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, err
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return Record{}, err
+	}
+
+	data, err := io.ReadAll(gzipReader)
+	if err != nil {
+		return Record{}, err
+	}
+
+	if len(data) < timestampLength {
+		return Record{}, fmt.Errorf("invalid record data length %d", len(data))
+	}
+
+	req := &prototypes.ProcessBlockRequest{}
+	if err := proto.Unmarshal(data[timestampLength:], req); err != nil {
+		return Record{}, err
+	}
+
+	return Record{
+		ReceivedAt: time.Unix(0, int64(binary.BigEndian.Uint64(data[:timestampLength]))),
+		Request:    req,
+	}, nil
+}
+
+// Close closes the log.
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
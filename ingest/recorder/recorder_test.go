@@ -0,0 +1,277 @@
+package recorder_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/ingest/recorder"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+type RecorderTestSuite struct {
+	suite.Suite
+}
+
+const defaultHeight uint64 = 100
+
+var defaultReceivedAt = time.Unix(1_700_000_000, 0)
+
+func TestRecorderTestSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+// newRequest returns a new block process request at the given height with a single pool
+// and a single taker fee.
+func (s *RecorderTestSuite) newRequest(height uint64) *prototypes.ProcessBlockRequest {
+	takerFees, err := sqsdomain.TakerFeeMap{
+		sqsdomain.DenomPair{Denom0: "uatom", Denom1: "uosmo"}: osmomath.MustNewDecFromStr("0.001"),
+	}.MarshalJSON()
+	s.Require().NoError(err)
+
+	return &prototypes.ProcessBlockRequest{
+		BlockHeight:  height,
+		TakerFeesMap: takerFees,
+		Pools: []*prototypes.PoolData{
+			{
+				ChainModel: []byte(fmt.Sprintf(`{"id":%d}`, height)),
+				SqsModel:   []byte(`{}`),
+			},
+		},
+	}
+}
+
+// recordBlocks records the given number of blocks starting at defaultHeight one second apart
+// and returns the path of the log. Every block is recorded by a new recorder to exercise appends.
+func (s *RecorderTestSuite) recordBlocks(numBlocks int) string {
+	path := filepath.Join(s.T().TempDir(), "blocks.log")
+
+	for i := 0; i < numBlocks; i++ {
+		blockRecorder, err := recorder.NewRecorder(path)
+		s.Require().NoError(err)
+
+		err = blockRecorder.Record(defaultReceivedAt.Add(time.Duration(i)*time.Second), s.newRequest(defaultHeight+uint64(i)))
+		s.Require().NoError(err)
+
+		s.Require().NoError(blockRecorder.Close())
+	}
+
+	return path
+}
+
+func (s *RecorderTestSuite) TestRecordAndRead() {
+	const numBlocks = 3
+
+	path := s.recordBlocks(numBlocks)
+
+	reader, err := recorder.NewReader(path)
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	for i := 0; i < numBlocks; i++ {
+		record, err := reader.Next()
+		s.Require().NoError(err)
+
+		expectedRequest := s.newRequest(defaultHeight + uint64(i))
+
+		s.Require().Equal(defaultReceivedAt.Add(time.Duration(i)*time.Second).UnixNano(), record.ReceivedAt.UnixNano())
+		s.Require().Equal(expectedRequest.BlockHeight, record.Request.BlockHeight)
+		s.Require().Equal(expectedRequest.TakerFeesMap, record.Request.TakerFeesMap)
+		s.Require().Len(record.Request.Pools, 1)
+		s.Require().Equal(expectedRequest.Pools[0].ChainModel, record.Request.Pools[0].ChainModel)
+	}
+
+	_, err = reader.Next()
+	s.Require().ErrorIs(err, io.EOF)
+}
+
+// Validates that a record truncated by an abrupt termination of the recorder
+// is treated as the end of the log.
+func (s *RecorderTestSuite) TestRead_TruncatedRecord() {
+	path := filepath.Join(s.T().TempDir(), "blocks.log")
+
+	blockRecorder, err := recorder.NewRecorder(path)
+	s.Require().NoError(err)
+
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight)))
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight+1)))
+
+	// Flushed but not closed, simulating a crash.
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Require().NoError(blockRecorder.Close())
+
+	// Truncate the second record.
+	s.Require().NoError(os.WriteFile(path, data[:len(data)-10], 0o644))
+
+	reader, err := recorder.NewReader(path)
+	s.Require().NoError(err)
+
+	record, err := reader.Next()
+	s.Require().NoError(err)
+	s.Require().Equal(defaultHeight, record.Request.BlockHeight)
+
+	_, err = reader.Next()
+	s.Require().ErrorIs(err, io.EOF)
+}
+
+// Validates that a recorder reopened after an abrupt termination discards the truncated record
+// and that the log is replayed in full, including the records appended after the restart.
+func (s *RecorderTestSuite) TestRecord_ReopenAfterCrash() {
+	path := filepath.Join(s.T().TempDir(), "blocks.log")
+
+	blockRecorder, err := recorder.NewRecorder(path)
+	s.Require().NoError(err)
+
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight)))
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight+1)))
+
+	// Never closed, simulating a crash in the middle of writing the second record.
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(path, data[:len(data)-10], 0o644))
+
+	blockRecorder, err = recorder.NewRecorder(path)
+	s.Require().NoError(err)
+
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight+2)))
+	s.Require().NoError(blockRecorder.Record(defaultReceivedAt, s.newRequest(defaultHeight+3)))
+
+	reader, err := recorder.NewReader(path)
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	heights := make([]uint64, 0)
+	ingestUseCase := &mocks.IngestUsecaseMock{
+		ApplyPoolDeltasFunc: func(req *prototypes.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error) {
+			return map[uint64]sqsdomain.PoolI{}, nil
+		},
+		ProcessBlockDataFunc: func(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) error {
+			heights = append(heights, height)
+			return nil
+		},
+	}
+
+	replayed, err := recorder.Replay(context.Background(), reader, ingestUseCase, 0, &log.NoOpLogger{})
+	s.Require().NoError(err)
+	s.Require().Equal(3, replayed)
+	s.Require().Equal([]uint64{defaultHeight, defaultHeight + 2, defaultHeight + 3}, heights)
+}
+
+// Validates that a corrupted log is not silently truncated when reopened.
+func (s *RecorderTestSuite) TestNewRecorder_CorruptedLog() {
+	path := filepath.Join(s.T().TempDir(), "blocks.log")
+
+	// Uvarint of a length exceeding the maximum record length.
+	s.Require().NoError(os.WriteFile(path, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, 0o644))
+
+	_, err := recorder.NewRecorder(path)
+	s.Require().Error(err)
+}
+
+func (s *RecorderTestSuite) TestReplay() {
+	tests := []struct {
+		name string
+
+		speed     float64
+		failAt    uint64
+		cancelCtx bool
+
+		expectedHeights  []uint64
+		expectedReplayed int
+		expectedMinTime  time.Duration
+		expectError      bool
+	}{
+		{
+			name:  "without delay",
+			speed: 0,
+
+			expectedHeights:  []uint64{defaultHeight, defaultHeight + 1, defaultHeight + 2},
+			expectedReplayed: 3,
+		},
+		{
+			name: "at 20x speed",
+			// Blocks are recorded 1s apart, so 2 delays of 50ms.
+			speed: 20,
+
+			expectedHeights:  []uint64{defaultHeight, defaultHeight + 1, defaultHeight + 2},
+			expectedReplayed: 3,
+			expectedMinTime:  100 * time.Millisecond,
+		},
+		{
+			name:   "failed block is skipped",
+			failAt: defaultHeight + 1,
+
+			expectedHeights:  []uint64{defaultHeight, defaultHeight + 1, defaultHeight + 2},
+			expectedReplayed: 2,
+		},
+		{
+			name:      "cancelled context",
+			speed:     1,
+			cancelCtx: true,
+
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			path := s.recordBlocks(3)
+
+			reader, err := recorder.NewReader(path)
+			s.Require().NoError(err)
+			defer reader.Close()
+
+			heights := make([]uint64, 0)
+			ingestUseCase := &mocks.IngestUsecaseMock{
+				ApplyPoolDeltasFunc: func(req *prototypes.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error) {
+					pools := make(map[uint64]sqsdomain.PoolI, len(req.Pools))
+					for i := range req.Pools {
+						pools[uint64(i)] = &mocks.MockRoutablePool{ID: uint64(i)}
+					}
+					return pools, nil
+				},
+				ProcessBlockDataFunc: func(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) error {
+					s.Require().Len(takerFeesMap, 1)
+					s.Require().Len(pools, 1)
+
+					heights = append(heights, height)
+					if height == tc.failAt {
+						return fmt.Errorf("failed to process block %d", height)
+					}
+					return nil
+				},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancelCtx {
+				cancel()
+			}
+
+			startTime := time.Now()
+
+			replayed, err := recorder.Replay(ctx, reader, ingestUseCase, tc.speed, &log.NoOpLogger{})
+			if tc.expectError {
+				s.Require().Error(err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedReplayed, replayed)
+			s.Require().Equal(tc.expectedHeights, heights)
+			s.Require().GreaterOrEqual(time.Since(startTime), tc.expectedMinTime)
+		})
+	}
+}
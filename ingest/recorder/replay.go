@@ -0,0 +1,75 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// Replay feeds the records of the log into the ingest use case in order.
+//
+// The records are replayed at the recorded pace multiplied by speed.
+// For example, speed 2 replays twice as fast as recorded. If speed is zero,
+// the records are replayed without delay.
+//
+// A record that fails to be processed is logged and skipped,
+// matching the behavior of the GRPC ingester.
+// Returns the number of successfully processed records and error if the log fails to be read.
+func Replay(ctx context.Context, reader *Reader, ingestUseCase mvc.IngestUsecase, speed float64, logger log.Logger) (int, error) {
+	var (
+		previousReceivedAt time.Time
+		replayed           int
+	)
+
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, err
+		}
+
+		if speed > 0 && !previousReceivedAt.IsZero() {
+			delay := time.Duration(float64(record.ReceivedAt.Sub(previousReceivedAt)) / speed)
+			if delay > 0 {
+				select {
+				case <-ctx.Done():
+					return replayed, ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+		}
+		previousReceivedAt = record.ReceivedAt
+
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+
+		takerFeeMap := sqsdomain.TakerFeeMap{}
+		if err := takerFeeMap.UnmarshalJSON(record.Request.TakerFeesMap); err != nil {
+			logger.Error("failed to unmarshal replayed taker fees", zap.Uint64("height", record.Request.BlockHeight), zap.Error(err))
+			continue
+		}
+
+		pools, err := ingestUseCase.ApplyPoolDeltas(record.Request)
+		if err != nil {
+			logger.Error("failed to apply replayed pool deltas", zap.Uint64("height", record.Request.BlockHeight), zap.Error(err))
+			continue
+		}
+
+		if err := ingestUseCase.ProcessBlockData(ctx, record.Request.BlockHeight, takerFeeMap, pools, record.Request.RemovedPoolIds); err != nil {
+			logger.Error("failed to process replayed block", zap.Uint64("height", record.Request.BlockHeight), zap.Error(err))
+			continue
+		}
+
+		replayed++
+	}
+}
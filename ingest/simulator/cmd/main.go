@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/osmosis-labs/osmosis/v25/app"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osmosis-labs/sqs/ingest/simulator"
+	sqslog "github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/usecase/routertesting/parsing"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// Simulates an Osmosis chain from pool and taker fee fixtures and pushes the blocks to SQS.
+func main() {
+	poolsFile := flag.String("pools", "router/usecase/routertesting/parsing/pools.json", "pools fixture file")
+	takerFeesFile := flag.String("taker-fees", "router/usecase/routertesting/parsing/taker_fees.json", "taker fees fixture file")
+	ingestAddress := flag.String("ingest-address", "localhost:50051", "address of the SQS GRPC ingester")
+	statusAddress := flag.String("status-address", "localhost:26657", "address to serve the Tendermint RPC status stand-in at")
+	numBlocks := flag.Uint64("blocks", 0, "number of blocks to simulate, 0 simulates until interrupted")
+
+	config := simulator.DefaultConfig
+	flag.Uint64Var(&config.InitialHeight, "initial-height", config.InitialHeight, "height of the first block")
+	flag.DurationVar(&config.BlockTime, "block-time", config.BlockTime, "time between blocks")
+	flag.Int64Var(&config.Seed, "seed", config.Seed, "seed of the random mutations")
+	flag.IntVar(&config.SwapsPerBlock, "swaps", config.SwapsPerBlock, "number of random swaps per block")
+	flag.Float64Var(&config.MaxSwapFraction, "max-swap-fraction", config.MaxSwapFraction, "maximum fraction of the token in balance swapped")
+	flag.IntVar(&config.LiquidityChangesPerBlock, "liquidity-changes", config.LiquidityChangesPerBlock, "number of random liquidity changes per block")
+	flag.Float64Var(&config.MaxLiquidityChangeFraction, "max-liquidity-change-fraction", config.MaxLiquidityChangeFraction, "maximum fraction the liquidity of a pool changes by")
+	flag.Float64Var(&config.NewPoolProbability, "new-pool-probability", config.NewPoolProbability, "probability of a new pool being created in a block")
+
+	flag.Parse()
+
+	logger, err := sqslog.NewLogger(false, "", "info")
+	if err != nil {
+		panic(fmt.Errorf("error while creating logger: %s", err))
+	}
+
+	pools, _, err := parsing.ReadPools(*poolsFile)
+	if err != nil {
+		panic(fmt.Errorf("failed to read pools: %w", err))
+	}
+
+	takerFees, err := parsing.ReadTakerFees(*takerFeesFile)
+	if err != nil {
+		panic(fmt.Errorf("failed to read taker fees: %w", err))
+	}
+
+	sim, err := simulator.New(pools, takerFees, app.MakeEncodingConfig().Marshaler, config, logger)
+	if err != nil {
+		panic(fmt.Errorf("failed to create simulator: %w", err))
+	}
+
+	conn, err := grpc.NewClient(*ingestAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(fmt.Errorf("failed to create ingest client: %w", err))
+	}
+	defer conn.Close()
+
+	go func() {
+		logger.Info("Starting status server", zap.String("address", *statusAddress))
+		if err := http.ListenAndServe(*statusAddress, sim.StatusHandler()); err != nil {
+			panic(fmt.Errorf("status server failed: %w", err))
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	logger.Info("Starting chain simulator", zap.Int("pools", len(pools)), zap.String("ingest_address", *ingestAddress))
+
+	if err := sim.Run(ctx, prototypes.NewSQSIngesterClient(conn), *numBlocks); err != nil && ctx.Err() == nil {
+		panic(fmt.Errorf("chain simulator failed: %w", err))
+	}
+}
@@ -0,0 +1,323 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	clmath "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/math"
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
+	"github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/swapstrategy"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/stableswap"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+	"go.uber.org/zap"
+
+	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+	"github.com/osmosis-labs/sqs/router/usecase/pools"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// smallestDec is the smallest amount that the concentrated swap strategy considers.
+var smallestDec = osmomath.BigDecFromDec(osmomath.SmallestDec())
+
+// swap performs a random swap over a random balancer, stableswap or concentrated pool
+// using the router's pool math. Returns the ID of the swapped pool and true
+// if the swap was performed.
+//
+// CosmWasm pools are never swapped since their state is owned by the contracts.
+func (s *Simulator) swap(ctx context.Context) (uint64, bool) {
+	pool, ok := s.randomPool(poolmanagertypes.Balancer, poolmanagertypes.Stableswap, poolmanagertypes.Concentrated)
+	if !ok {
+		return 0, false
+	}
+
+	liquidity := getLiquidity(pool)
+	if len(liquidity) < 2 {
+		return 0, false
+	}
+
+	tokenInIndex := s.rand.Intn(len(liquidity))
+	tokenOutIndex := (tokenInIndex + 1 + s.rand.Intn(len(liquidity)-1)) % len(liquidity)
+
+	tokenIn := sdk.NewCoin(liquidity[tokenInIndex].Denom, s.randomFraction(liquidity[tokenInIndex].Amount, s.config.MaxSwapFraction))
+	if !tokenIn.Amount.IsPositive() {
+		return 0, false
+	}
+
+	tokenOutDenom := liquidity[tokenOutIndex].Denom
+
+	routablePool, err := pools.NewRoutablePool(pool, tokenOutDenom, osmomath.ZeroDec(), cosmwasmdomain.CosmWasmPoolsParams{})
+	if err != nil {
+		s.logger.Debug("failed to create routable pool", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+		return 0, false
+	}
+
+	tokenOut, err := routablePool.CalculateTokenOutByTokenIn(ctx, tokenIn)
+	if err != nil {
+		s.logger.Debug("failed to simulate swap", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+		return 0, false
+	}
+
+	if !tokenOut.Amount.IsPositive() || tokenOut.Amount.GTE(liquidity.AmountOf(tokenOutDenom)) {
+		return 0, false
+	}
+
+	if chainModel, ok := pool.ChainModel.(*concentratedmodel.Pool); ok {
+		if err := applyConcentratedSwap(chainModel, pool.TickModel, tokenIn); err != nil {
+			s.logger.Debug("failed to apply concentrated swap", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+			return 0, false
+		}
+	}
+
+	if err := setLiquidity(pool, liquidity.Add(tokenIn).Sub(tokenOut)); err != nil {
+		s.logger.Debug("failed to apply swap", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+		return 0, false
+	}
+
+	return pool.GetId(), true
+}
+
+// changeLiquidity scales the liquidity of a random balancer, stableswap or concentrated pool
+// by a random factor. Returns the ID of the changed pool and true if the liquidity was changed.
+func (s *Simulator) changeLiquidity() (uint64, bool) {
+	pool, ok := s.randomPool(poolmanagertypes.Balancer, poolmanagertypes.Stableswap, poolmanagertypes.Concentrated)
+	if !ok {
+		return 0, false
+	}
+
+	// Factor in [1 - max, 1 + max].
+	factor := osmomath.MustNewDecFromStr(fmt.Sprintf("%.6f", 1+s.config.MaxLiquidityChangeFraction*(2*s.rand.Float64()-1)))
+	if !factor.IsPositive() {
+		return 0, false
+	}
+
+	switch chainModel := pool.ChainModel.(type) {
+	case *balancer.Pool:
+		chainModel.TotalShares.Amount = chainModel.TotalShares.Amount.ToLegacyDec().Mul(factor).TruncateInt()
+	case *stableswap.Pool:
+		chainModel.TotalShares.Amount = chainModel.TotalShares.Amount.ToLegacyDec().Mul(factor).TruncateInt()
+	case *concentratedmodel.Pool:
+		// Scaling the liquidity at every tick retains the current price.
+		chainModel.CurrentTickLiquidity = chainModel.CurrentTickLiquidity.Mul(factor)
+
+		if pool.TickModel != nil {
+			for i := range pool.TickModel.Ticks {
+				pool.TickModel.Ticks[i].LiquidityAmount = pool.TickModel.Ticks[i].LiquidityAmount.Mul(factor)
+			}
+		}
+	}
+
+	scaledLiquidity := sdk.NewCoins()
+	for _, coin := range getLiquidity(pool) {
+		scaledLiquidity = scaledLiquidity.Add(sdk.NewCoin(coin.Denom, coin.Amount.ToLegacyDec().Mul(factor).TruncateInt()))
+	}
+
+	if err := setLiquidity(pool, scaledLiquidity); err != nil {
+		s.logger.Debug("failed to change liquidity", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+		return 0, false
+	}
+
+	return pool.GetId(), true
+}
+
+// createPool creates a new pool by cloning a random balancer pool.
+// Returns the ID of the created pool and true if the pool was created.
+func (s *Simulator) createPool() (uint64, bool) {
+	template, ok := s.randomPool(poolmanagertypes.Balancer)
+	if !ok {
+		return 0, false
+	}
+
+	templateChainModel, ok := template.ChainModel.(*balancer.Pool)
+	if !ok {
+		return 0, false
+	}
+
+	chainModelBz, err := json.Marshal(templateChainModel)
+	if err != nil {
+		return 0, false
+	}
+
+	var chainModel balancer.Pool
+	if err := json.Unmarshal(chainModelBz, &chainModel); err != nil {
+		return 0, false
+	}
+
+	poolID := s.nextPoolID
+	s.nextPoolID++
+
+	chainModel.Id = poolID
+	chainModel.Address = poolmanagertypes.NewPoolAddress(poolID).String()
+	chainModel.TotalShares.Denom = fmt.Sprintf("gamm/pool/%d", poolID)
+
+	sqsModel := template.SQSModel
+	sqsModel.Balances = sdk.NewCoins(template.SQSModel.Balances...)
+	sqsModel.PoolDenoms = append([]string{}, template.SQSModel.PoolDenoms...)
+
+	s.pools[poolID] = &sqsdomain.PoolWrapper{
+		ChainModel: &chainModel,
+		SQSModel:   sqsModel,
+	}
+
+	return poolID, true
+}
+
+// randomPool returns a random pool of any of the given types.
+// Returns false if there are no such pools.
+func (s *Simulator) randomPool(poolTypes ...poolmanagertypes.PoolType) (*sqsdomain.PoolWrapper, bool) {
+	poolIDs := make([]uint64, 0)
+	for poolID, pool := range s.pools {
+		for _, poolType := range poolTypes {
+			if pool.GetType() == poolType {
+				poolIDs = append(poolIDs, poolID)
+				break
+			}
+		}
+	}
+
+	if len(poolIDs) == 0 {
+		return nil, false
+	}
+
+	// Sort for the selection to be reproducible given the seed.
+	sort.Slice(poolIDs, func(i, j int) bool { return poolIDs[i] < poolIDs[j] })
+
+	return s.pools[poolIDs[s.rand.Intn(len(poolIDs))]], true
+}
+
+// randomFraction returns a random fraction of the amount in [0, maxFraction).
+func (s *Simulator) randomFraction(amount osmomath.Int, maxFraction float64) osmomath.Int {
+	fraction := osmomath.MustNewDecFromStr(fmt.Sprintf("%.6f", maxFraction*s.rand.Float64()))
+	return amount.ToLegacyDec().Mul(fraction).TruncateInt()
+}
+
+// applyConcentratedSwap moves the current sqrt price, tick and tick liquidity of the concentrated pool
+// to where the swap of the given token in ends. The tick model buckets are walked the same way
+// as the router's concentrated pool does when computing the token out.
+// Returns error if the pool does not have enough liquidity to complete the swap.
+func applyConcentratedSwap(chainModel *concentratedmodel.Pool, tickModel *sqsdomain.TickModel, tokenIn sdk.Coin) error {
+	if tickModel == nil || tickModel.HasNoLiquidity || len(tickModel.Ticks) == 0 {
+		return domain.ConcentratedNoLiquidityError{PoolId: chainModel.Id}
+	}
+
+	isZeroForOne := tokenIn.Denom == chainModel.Token0
+	swapStrategy := swapstrategy.New(isZeroForOne, smallestDec, &storetypes.KVStoreKey{}, chainModel.SpreadFactor)
+
+	var (
+		currentSqrtPrice   = chainModel.CurrentSqrtPrice
+		currentBucketIndex = tickModel.CurrentTickIndex
+		amountRemainingIn  = tokenIn.Amount.ToLegacyDec()
+	)
+
+	for {
+		if currentBucketIndex < 0 || currentBucketIndex >= int64(len(tickModel.Ticks)) {
+			return domain.ConcentratedNotEnoughLiquidityToCompleteSwapError{
+				PoolId:   chainModel.Id,
+				AmountIn: sdk.NewCoins(tokenIn).String(),
+			}
+		}
+
+		currentBucket := tickModel.Ticks[currentBucketIndex]
+
+		nextInitializedTickIndex := currentBucket.UpperTick
+		if isZeroForOne {
+			nextInitializedTickIndex = currentBucket.LowerTick
+		}
+
+		sqrtPriceTarget, err := clmath.TickToSqrtPrice(nextInitializedTickIndex)
+		if err != nil {
+			return err
+		}
+
+		sqrtPriceNext, amountInConsumed, _, spreadRewardChargeTotal := swapStrategy.ComputeSwapWithinBucketOutGivenIn(currentSqrtPrice, sqrtPriceTarget, currentBucket.LiquidityAmount, amountRemainingIn)
+
+		amountRemainingIn = amountRemainingIn.Sub(amountInConsumed).Sub(spreadRewardChargeTotal)
+		currentSqrtPrice = sqrtPriceNext
+
+		// The swap ends in the current bucket.
+		if !amountRemainingIn.IsPositive() {
+			break
+		}
+
+		if isZeroForOne {
+			currentBucketIndex--
+		} else {
+			currentBucketIndex++
+		}
+	}
+
+	currentTick, err := clmath.CalculateSqrtPriceToTick(currentSqrtPrice)
+	if err != nil {
+		return err
+	}
+
+	// A swap ending exactly at a bucket boundary leaves the current tick in the adjacent bucket.
+	for currentBucketIndex > 0 && currentTick < tickModel.Ticks[currentBucketIndex].LowerTick {
+		currentBucketIndex--
+	}
+	for currentBucketIndex < int64(len(tickModel.Ticks))-1 && currentTick >= tickModel.Ticks[currentBucketIndex].UpperTick {
+		currentBucketIndex++
+	}
+
+	chainModel.CurrentSqrtPrice = currentSqrtPrice
+	chainModel.CurrentTick = currentTick
+	chainModel.CurrentTickLiquidity = tickModel.Ticks[currentBucketIndex].LiquidityAmount
+	tickModel.CurrentTickIndex = currentBucketIndex
+
+	return nil
+}
+
+// getLiquidity returns the liquidity of the pool.
+// Balancer and stableswap liquidity is read from the chain model,
+// the others from the SQS model balances. Concentrated liquidity is limited
+// to the balances of the pool tokens.
+func getLiquidity(pool *sqsdomain.PoolWrapper) sdk.Coins {
+	switch chainModel := pool.ChainModel.(type) {
+	case *balancer.Pool:
+		liquidity := sdk.NewCoins()
+		for _, poolAsset := range chainModel.PoolAssets {
+			liquidity = liquidity.Add(poolAsset.Token)
+		}
+		return liquidity
+	case *stableswap.Pool:
+		return sdk.NewCoins(chainModel.PoolLiquidity...)
+	case *concentratedmodel.Pool:
+		balances := sdk.NewCoins(pool.SQSModel.Balances...)
+		return sdk.NewCoins(
+			sdk.NewCoin(chainModel.Token0, balances.AmountOf(chainModel.Token0)),
+			sdk.NewCoin(chainModel.Token1, balances.AmountOf(chainModel.Token1)),
+		)
+	default:
+		return sdk.NewCoins(pool.SQSModel.Balances...)
+	}
+}
+
+// setLiquidity sets the liquidity of the pool in the chain model
+// and updates the matching SQS model balances.
+func setLiquidity(pool *sqsdomain.PoolWrapper, liquidity sdk.Coins) error {
+	switch chainModel := pool.ChainModel.(type) {
+	case *balancer.Pool:
+		if err := chainModel.UpdatePoolAssetBalances(liquidity); err != nil {
+			return err
+		}
+	case *stableswap.Pool:
+		chainModel.PoolLiquidity = liquidity
+	}
+
+	balances := make(sdk.Coins, 0, len(pool.SQSModel.Balances))
+	for _, balance := range pool.SQSModel.Balances {
+		if amount := liquidity.AmountOf(balance.Denom); amount.IsPositive() {
+			balance.Amount = amount
+		}
+		balances = append(balances, balance)
+	}
+	pool.SQSModel.Balances = balances
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"go.uber.org/zap"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// Config is the configuration of the chain simulator.
+type Config struct {
+	// InitialHeight is the height of the first simulated block.
+	InitialHeight uint64
+	// BlockTime is the time between simulated blocks.
+	BlockTime time.Duration
+	// Seed seeds the random mutations so that simulations are reproducible.
+	Seed int64
+	// SwapsPerBlock is the number of random swaps per block.
+	SwapsPerBlock int
+	// MaxSwapFraction is the maximum fraction of the token in balance that is swapped.
+	MaxSwapFraction float64
+	// LiquidityChangesPerBlock is the number of random liquidity changes per block.
+	LiquidityChangesPerBlock int
+	// MaxLiquidityChangeFraction is the maximum fraction that the liquidity of a pool changes by.
+	MaxLiquidityChangeFraction float64
+	// NewPoolProbability is the probability of a new pool being created in a block.
+	NewPoolProbability float64
+}
+
+// DefaultConfig is the default chain simulator configuration.
+var DefaultConfig = Config{
+	InitialHeight:              1,
+	BlockTime:                  1500 * time.Millisecond,
+	Seed:                       1,
+	SwapsPerBlock:              10,
+	MaxSwapFraction:            0.01,
+	LiquidityChangesPerBlock:   2,
+	MaxLiquidityChangeFraction: 0.1,
+	NewPoolProbability:         0.05,
+}
+
+// Simulator simulates an Osmosis chain from pool and taker fee fixtures.
+//
+// Every block, it mutates the pools with random swaps, liquidity changes and new pools
+// and pushes the changed pools to SQS the same way the node does: all pools at the first block
+// and after a failed push, only the changed pools otherwise.
+type Simulator struct {
+	codec  codec.Codec
+	config Config
+	logger log.Logger
+
+	mx        sync.Mutex
+	rand      *rand.Rand
+	pools     map[uint64]*sqsdomain.PoolWrapper
+	takerFees sqsdomain.TakerFeeMap
+	height    uint64
+	// nextPoolID is the ID of the next created pool.
+	nextPoolID uint64
+	// pushAll is true if all pools must be pushed at the next block.
+	pushAll bool
+
+	// latestHeight is the height of the latest block pushed successfully.
+	// Reported by the status endpoint.
+	latestHeight atomic.Uint64
+}
+
+// New returns a new chain simulator of the given pools and taker fees.
+// The codec must have the pool interfaces registered.
+// Returns error if any of the pools is not backed by a pool wrapper.
+func New(pools []sqsdomain.PoolI, takerFees sqsdomain.TakerFeeMap, codec codec.Codec, config Config, logger log.Logger) (*Simulator, error) {
+	poolsByID := make(map[uint64]*sqsdomain.PoolWrapper, len(pools))
+
+	var maxPoolID uint64
+	for _, pool := range pools {
+		poolWrapper, ok := pool.(*sqsdomain.PoolWrapper)
+		if !ok {
+			return nil, fmt.Errorf("pool %d is of type %T, expected %T", pool.GetId(), pool, &sqsdomain.PoolWrapper{})
+		}
+
+		poolsByID[pool.GetId()] = poolWrapper
+
+		if pool.GetId() > maxPoolID {
+			maxPoolID = pool.GetId()
+		}
+	}
+
+	return &Simulator{
+		codec:  codec,
+		config: config,
+		logger: logger,
+
+		rand:       rand.New(rand.NewSource(config.Seed)),
+		pools:      poolsByID,
+		takerFees:  takerFees,
+		nextPoolID: maxPoolID + 1,
+		pushAll:    true,
+	}, nil
+}
+
+// NextBlock simulates the next block and returns the block process request to push.
+// The first block contains all pools and is not mutated.
+func (s *Simulator) NextBlock(ctx context.Context) (*prototypes.ProcessBlockRequest, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	changedPoolIDs := make(map[uint64]struct{})
+
+	if s.height == 0 {
+		s.height = s.config.InitialHeight
+	} else {
+		s.height++
+
+		for i := 0; i < s.config.SwapsPerBlock; i++ {
+			if poolID, ok := s.swap(ctx); ok {
+				changedPoolIDs[poolID] = struct{}{}
+			}
+		}
+
+		for i := 0; i < s.config.LiquidityChangesPerBlock; i++ {
+			if poolID, ok := s.changeLiquidity(); ok {
+				changedPoolIDs[poolID] = struct{}{}
+			}
+		}
+
+		if s.rand.Float64() < s.config.NewPoolProbability {
+			if poolID, ok := s.createPool(); ok {
+				changedPoolIDs[poolID] = struct{}{}
+			}
+		}
+	}
+
+	if s.pushAll {
+		for poolID := range s.pools {
+			changedPoolIDs[poolID] = struct{}{}
+		}
+	}
+
+	poolIDs := make([]uint64, 0, len(changedPoolIDs))
+	for poolID := range changedPoolIDs {
+		poolIDs = append(poolIDs, poolID)
+	}
+	sort.Slice(poolIDs, func(i, j int) bool { return poolIDs[i] < poolIDs[j] })
+
+	poolData := make([]*prototypes.PoolData, 0, len(poolIDs))
+	for _, poolID := range poolIDs {
+		data, err := s.encodePool(s.pools[poolID])
+		if err != nil {
+			return nil, err
+		}
+
+		poolData = append(poolData, data)
+	}
+
+	takerFeesBz, err := s.takerFees.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototypes.ProcessBlockRequest{
+		BlockHeight:  s.height,
+		TakerFeesMap: takerFeesBz,
+		Pools:        poolData,
+	}, nil
+}
+
+// Run simulates a block every block time and pushes it to SQS via the given client
+// until the context is cancelled or the given number of blocks is pushed.
+// If numBlocks is zero, runs until the context is cancelled.
+// A failed push is logged and all pools are pushed at the next block.
+func (s *Simulator) Run(ctx context.Context, client prototypes.SQSIngesterClient, numBlocks uint64) error {
+	ticker := time.NewTicker(s.config.BlockTime)
+	defer ticker.Stop()
+
+	for pushed := uint64(0); numBlocks == 0 || pushed < numBlocks; pushed++ {
+		req, err := s.NextBlock(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.ProcessBlock(ctx, req); err != nil {
+			s.logger.Error("failed to push block", zap.Uint64("height", req.BlockHeight), zap.Error(err))
+
+			s.mx.Lock()
+			s.pushAll = true
+			s.mx.Unlock()
+		} else {
+			s.logger.Info("pushed block", zap.Uint64("height", req.BlockHeight), zap.Int("pools", len(req.Pools)))
+
+			s.mx.Lock()
+			s.pushAll = false
+			s.mx.Unlock()
+
+			s.latestHeight.Store(req.BlockHeight)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// LatestHeight returns the height of the latest block pushed successfully.
+func (s *Simulator) LatestHeight() uint64 {
+	return s.latestHeight.Load()
+}
+
+// encodePool encodes the pool the same way the node does.
+func (s *Simulator) encodePool(pool *sqsdomain.PoolWrapper) (*prototypes.PoolData, error) {
+	chainModel, err := s.codec.MarshalInterfaceJSON(pool.ChainModel)
+	if err != nil {
+		return nil, err
+	}
+
+	sqsModel, err := json.Marshal(pool.SQSModel)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickModel []byte
+	if pool.GetType() == poolmanagertypes.Concentrated {
+		tickModel, err = json.Marshal(pool.TickModel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &prototypes.PoolData{
+		ChainModel: chainModel,
+		SqsModel:   sqsModel,
+		TickModel:  tickModel,
+	}, nil
+}
@@ -0,0 +1,371 @@
+package simulator_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v25/app"
+	clmath "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/math"
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/stableswap"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	chaininfoclient "github.com/osmosis-labs/sqs/chaininfo/client"
+	"github.com/osmosis-labs/sqs/ingest/simulator"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+type SimulatorTestSuite struct {
+	suite.Suite
+}
+
+const (
+	balancerPoolID   uint64 = 1
+	stableswapPoolID uint64 = 2
+
+	concentratedPoolID uint64 = 3
+
+	defaultInitialHeight uint64 = 100
+)
+
+var encodingConfig = app.MakeEncodingConfig()
+
+func TestSimulatorTestSuite(t *testing.T) {
+	suite.Run(t, new(SimulatorTestSuite))
+}
+
+// mockIngesterServer is a SQSIngester server that forwards the received requests to a channel.
+type mockIngesterServer struct {
+	prototypes.UnimplementedSQSIngesterServer
+
+	// failures is the number of requests to fail before succeeding.
+	failures int
+	requests chan *prototypes.ProcessBlockRequest
+}
+
+// ProcessBlock implements types.SQSIngesterServer.
+func (m *mockIngesterServer) ProcessBlock(ctx context.Context, req *prototypes.ProcessBlockRequest) (*prototypes.ProcessBlockReply, error) {
+	if m.failures > 0 {
+		m.failures--
+		return nil, errors.New("ingest failed")
+	}
+
+	m.requests <- req
+	return &prototypes.ProcessBlockReply{}, nil
+}
+
+// Tests that the first block pushes all pools unmutated and the following blocks
+// push only the mutated pools at increasing heights.
+func (s *SimulatorTestSuite) TestRun_ChangedPools() {
+	config := simulator.DefaultConfig
+	config.InitialHeight = defaultInitialHeight
+	config.BlockTime = time.Millisecond
+	config.NewPoolProbability = 0
+
+	sim := s.newSimulator(config)
+	client, ingester := s.startIngester()
+
+	err := sim.Run(context.Background(), client, 2)
+	s.Require().NoError(err)
+
+	firstBlock := <-ingester.requests
+	s.Require().Equal(defaultInitialHeight, firstBlock.BlockHeight)
+	s.Require().Equal([]uint64{balancerPoolID, stableswapPoolID}, s.decodePoolIDs(firstBlock))
+	s.Require().NotEmpty(firstBlock.TakerFeesMap)
+
+	firstLiquidity := s.decodeLiquidity(firstBlock)
+
+	secondBlock := <-ingester.requests
+	s.Require().Equal(defaultInitialHeight+1, secondBlock.BlockHeight)
+	s.Require().NotEmpty(secondBlock.Pools)
+
+	for poolID, liquidity := range s.decodeLiquidity(secondBlock) {
+		s.Require().NotEqual(firstLiquidity[poolID], liquidity, "pool %d was pushed without changes", poolID)
+	}
+}
+
+// Tests that new pools are created with the next pool ID.
+func (s *SimulatorTestSuite) TestRun_NewPool() {
+	config := simulator.DefaultConfig
+	config.BlockTime = time.Millisecond
+	config.SwapsPerBlock = 0
+	config.LiquidityChangesPerBlock = 0
+	config.NewPoolProbability = 1
+
+	sim := s.newSimulator(config)
+	client, ingester := s.startIngester()
+
+	err := sim.Run(context.Background(), client, 2)
+	s.Require().NoError(err)
+
+	<-ingester.requests
+
+	secondBlock := <-ingester.requests
+	s.Require().Equal([]uint64{stableswapPoolID + 1}, s.decodePoolIDs(secondBlock))
+}
+
+// Tests that all pools are pushed again after a failed push.
+func (s *SimulatorTestSuite) TestRun_FailedPush() {
+	config := simulator.DefaultConfig
+	config.BlockTime = time.Millisecond
+	config.SwapsPerBlock = 0
+	config.LiquidityChangesPerBlock = 0
+	config.NewPoolProbability = 0
+
+	sim := s.newSimulator(config)
+	client, ingester := s.startIngester()
+	ingester.failures = 1
+
+	err := sim.Run(context.Background(), client, 2)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingester.requests, 1)
+
+	block := <-ingester.requests
+	s.Require().Equal(config.InitialHeight+1, block.BlockHeight)
+	s.Require().Equal([]uint64{balancerPoolID, stableswapPoolID}, s.decodePoolIDs(block))
+	s.Require().Equal(block.BlockHeight, sim.LatestHeight())
+}
+
+// Tests that swaps over a concentrated pool move the current sqrt price and keep
+// the current tick, the current bucket and the current tick liquidity consistent.
+func (s *SimulatorTestSuite) TestRun_ConcentratedSwap() {
+	config := simulator.DefaultConfig
+	config.BlockTime = time.Millisecond
+	config.MaxSwapFraction = 0.5
+	config.LiquidityChangesPerBlock = 0
+	config.NewPoolProbability = 0
+
+	sim := s.newConcentratedSimulator(config)
+	client, ingester := s.startIngester()
+
+	err := sim.Run(context.Background(), client, 2)
+	s.Require().NoError(err)
+
+	firstBlock := <-ingester.requests
+	firstPools := s.decodePools(firstBlock)
+	s.Require().Len(firstPools, 1)
+	initialPool, ok := firstPools[0].(*concentratedmodel.Pool)
+	s.Require().True(ok)
+
+	secondBlock := <-ingester.requests
+	s.Require().Len(secondBlock.Pools, 1)
+
+	secondPools := s.decodePools(secondBlock)
+	pool, ok := secondPools[0].(*concentratedmodel.Pool)
+	s.Require().True(ok)
+	s.Require().False(pool.CurrentSqrtPrice.Equal(initialPool.CurrentSqrtPrice))
+
+	expectedTick, err := clmath.CalculateSqrtPriceToTick(pool.CurrentSqrtPrice)
+	s.Require().NoError(err)
+	s.Require().Equal(expectedTick, pool.CurrentTick)
+
+	var tickModel sqsdomain.TickModel
+	s.Require().NoError(json.Unmarshal(secondBlock.Pools[0].TickModel, &tickModel))
+
+	currentBucket := tickModel.Ticks[tickModel.CurrentTickIndex]
+	s.Require().True(pool.IsCurrentTickInRange(currentBucket.LowerTick, currentBucket.UpperTick))
+	s.Require().Equal(currentBucket.LiquidityAmount, pool.CurrentTickLiquidity)
+
+	var sqsModel sqsdomain.SQSPool
+	s.Require().NoError(json.Unmarshal(secondBlock.Pools[0].SqsModel, &sqsModel))
+	s.Require().NotEqual(sdk.NewCoins(defaultConcentratedBalances...), sqsModel.Balances)
+}
+
+// Tests that Run pushes the blocks over gRPC and that the status endpoint
+// reports the latest pushed height to the chain info client used by /healthcheck.
+func (s *SimulatorTestSuite) TestRun() {
+	config := simulator.DefaultConfig
+	config.InitialHeight = defaultInitialHeight
+	config.BlockTime = time.Millisecond
+
+	sim := s.newSimulator(config)
+
+	client, ingester := s.startIngester()
+
+	const numBlocks = 3
+
+	err := sim.Run(context.Background(), client, numBlocks)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingester.requests, numBlocks)
+	for i := uint64(0); i < numBlocks; i++ {
+		req := <-ingester.requests
+		s.Require().Equal(defaultInitialHeight+i, req.BlockHeight)
+	}
+
+	statusServer := httptest.NewServer(sim.StatusHandler())
+	defer statusServer.Close()
+
+	chainInfoClient, err := chaininfoclient.NewClient("osmosis-1", statusServer.URL)
+	s.Require().NoError(err)
+
+	latestHeight, err := chainInfoClient.GetLatestHeight(context.Background())
+	s.Require().NoError(err)
+	s.Require().Equal(defaultInitialHeight+numBlocks-1, latestHeight)
+}
+
+// startIngester starts a mock ingester server and returns a client connected to it.
+func (s *SimulatorTestSuite) startIngester() (prototypes.SQSIngesterClient, *mockIngesterServer) {
+	ingester := &mockIngesterServer{requests: make(chan *prototypes.ProcessBlockRequest, 10)}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	server := grpc.NewServer()
+	prototypes.RegisterSQSIngesterServer(server, ingester)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	s.T().Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = conn.Close() })
+
+	return prototypes.NewSQSIngesterClient(conn), ingester
+}
+
+// newSimulator returns a simulator over a balancer and a stableswap pool.
+func (s *SimulatorTestSuite) newSimulator(config simulator.Config) *simulator.Simulator {
+	balancerPool, err := balancer.NewBalancerPool(
+		balancerPoolID,
+		balancer.PoolParams{SwapFee: osmomath.MustNewDecFromStr("0.002"), ExitFee: osmomath.ZeroDec()},
+		[]balancer.PoolAsset{
+			{Token: sdk.NewCoin("uatom", osmomath.NewInt(1_000_000_000)), Weight: osmomath.NewInt(1)},
+			{Token: sdk.NewCoin("uosmo", osmomath.NewInt(10_000_000_000)), Weight: osmomath.NewInt(1)},
+		},
+		"",
+		time.Now(),
+	)
+	s.Require().NoError(err)
+
+	stableswapPool, err := stableswap.NewStableswapPool(
+		stableswapPoolID,
+		stableswap.PoolParams{SwapFee: osmomath.MustNewDecFromStr("0.0005"), ExitFee: osmomath.ZeroDec()},
+		sdk.NewCoins(sdk.NewCoin("uusdc", osmomath.NewInt(5_000_000_000)), sdk.NewCoin("uusdt", osmomath.NewInt(5_000_000_000))),
+		[]uint64{1, 1},
+		"",
+		"",
+	)
+	s.Require().NoError(err)
+
+	pools := []sqsdomain.PoolI{
+		&sqsdomain.PoolWrapper{
+			ChainModel: &balancerPool,
+			SQSModel: sqsdomain.SQSPool{
+				PoolLiquidityCap: osmomath.NewInt(1_000),
+				PoolDenoms:       []string{"uatom", "uosmo"},
+				SpreadFactor:     balancerPool.GetSpreadFactor(sdk.Context{}),
+			},
+		},
+		&sqsdomain.PoolWrapper{
+			ChainModel: &stableswapPool,
+			SQSModel: sqsdomain.SQSPool{
+				PoolLiquidityCap: osmomath.NewInt(1_000),
+				PoolDenoms:       []string{"uusdc", "uusdt"},
+				SpreadFactor:     stableswapPool.GetSpreadFactor(sdk.Context{}),
+			},
+		},
+	}
+
+	takerFees := sqsdomain.TakerFeeMap{}
+	takerFees.SetTakerFee("uatom", "uosmo", osmomath.MustNewDecFromStr("0.001"))
+
+	sim, err := simulator.New(pools, takerFees, encodingConfig.Marshaler, config, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	return sim
+}
+
+// defaultConcentratedBalances are the balances of the concentrated pool of newConcentratedSimulator.
+var defaultConcentratedBalances = sdk.NewCoins(sdk.NewCoin("uion", osmomath.NewInt(1_000_000_000)), sdk.NewCoin("uosmo", osmomath.NewInt(1_000_000_000)))
+
+// newConcentratedSimulator returns a simulator over a concentrated pool at tick zero
+// with three adjacent liquidity buckets.
+func (s *SimulatorTestSuite) newConcentratedSimulator(config simulator.Config) *simulator.Simulator {
+	concentratedPool, err := concentratedmodel.NewConcentratedLiquidityPool(concentratedPoolID, "uion", "uosmo", 1, osmomath.MustNewDecFromStr("0.001"))
+	s.Require().NoError(err)
+
+	currentSqrtPrice, err := clmath.TickToSqrtPrice(0)
+	s.Require().NoError(err)
+
+	liquidity := osmomath.NewDec(10_000_000_000)
+
+	concentratedPool.CurrentSqrtPrice = currentSqrtPrice
+	concentratedPool.CurrentTick = 0
+	concentratedPool.CurrentTickLiquidity = liquidity
+
+	pools := []sqsdomain.PoolI{
+		&sqsdomain.PoolWrapper{
+			ChainModel: &concentratedPool,
+			SQSModel: sqsdomain.SQSPool{
+				PoolLiquidityCap: osmomath.NewInt(1_000),
+				Balances:         defaultConcentratedBalances,
+				PoolDenoms:       []string{"uion", "uosmo"},
+				SpreadFactor:     concentratedPool.SpreadFactor,
+			},
+			TickModel: &sqsdomain.TickModel{
+				Ticks: []sqsdomain.LiquidityDepthsWithRange{
+					{LowerTick: -200_000, UpperTick: -100_000, LiquidityAmount: liquidity.MulInt64(2)},
+					{LowerTick: -100_000, UpperTick: 100_000, LiquidityAmount: liquidity},
+					{LowerTick: 100_000, UpperTick: 200_000, LiquidityAmount: liquidity.MulInt64(2)},
+				},
+				CurrentTickIndex: 1,
+			},
+		},
+	}
+
+	sim, err := simulator.New(pools, sqsdomain.TakerFeeMap{}, encodingConfig.Marshaler, config, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	return sim
+}
+
+// decodePoolIDs returns the IDs of the pools in the request in order.
+func (s *SimulatorTestSuite) decodePoolIDs(req *prototypes.ProcessBlockRequest) []uint64 {
+	poolIDs := make([]uint64, 0, len(req.Pools))
+	for _, pool := range s.decodePools(req) {
+		poolIDs = append(poolIDs, pool.GetId())
+	}
+	return poolIDs
+}
+
+// decodeLiquidity returns the total pool liquidity by pool ID of the pools in the request.
+func (s *SimulatorTestSuite) decodeLiquidity(req *prototypes.ProcessBlockRequest) map[uint64]string {
+	liquidity := make(map[uint64]string, len(req.Pools))
+	for _, pool := range s.decodePools(req) {
+		switch pool := pool.(type) {
+		case *balancer.Pool:
+			liquidity[pool.GetId()] = pool.GetTotalPoolLiquidity(sdk.Context{}).String() + pool.GetTotalShares().String()
+		case *stableswap.Pool:
+			liquidity[pool.GetId()] = pool.GetTotalPoolLiquidity(sdk.Context{}).String() + pool.GetTotalShares().String()
+		}
+	}
+	return liquidity
+}
+
+// decodePools decodes the chain models of the pools in the request.
+func (s *SimulatorTestSuite) decodePools(req *prototypes.ProcessBlockRequest) []poolmanagertypes.PoolI {
+	pools := make([]poolmanagertypes.PoolI, 0, len(req.Pools))
+	for _, poolData := range req.Pools {
+		var pool poolmanagertypes.PoolI
+		s.Require().NoError(encodingConfig.Marshaler.UnmarshalInterfaceJSON(poolData.ChainModel, &pool))
+		pools = append(pools, pool)
+	}
+	return pools
+}
@@ -0,0 +1,76 @@
+package simulator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const statusMethod = "status"
+
+// StatusHandler returns a handler standing in for the Tendermint RPC status endpoint.
+// It serves both the URI endpoint GET /status, used by /healthcheck,
+// and the JSON-RPC POST / with the status method, used by the RPC client at startup.
+// The reported latest height is the height of the latest block pushed successfully.
+func (s *Simulator) StatusHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		s.writeResponse(w, rpctypes.NewRPCSuccessResponse(rpctypes.JSONRPCIntID(-1), s.status()))
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.writeResponse(w, rpctypes.RPCInvalidRequestError(nil, err))
+			return
+		}
+
+		var req rpctypes.RPCRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			s.writeResponse(w, rpctypes.RPCParseError(err))
+			return
+		}
+
+		if req.Method != statusMethod {
+			s.writeResponse(w, rpctypes.RPCMethodNotFoundError(req.ID))
+			return
+		}
+
+		s.writeResponse(w, rpctypes.NewRPCSuccessResponse(req.ID, s.status()))
+	})
+
+	return mux
+}
+
+// status returns the status of the simulated node.
+func (s *Simulator) status() *coretypes.ResultStatus {
+	return &coretypes.ResultStatus{
+		SyncInfo: coretypes.SyncInfo{
+			LatestBlockHeight: int64(s.LatestHeight()),
+			LatestBlockTime:   time.Now(),
+		},
+	}
+}
+
+// writeResponse writes the JSON-RPC response. The result is already encoded
+// with the Tendermint JSON encoding by the response constructors.
+func (s *Simulator) writeResponse(w http.ResponseWriter, resp rpctypes.RPCResponse) {
+	respBz, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(respBz)
+}
@@ -1,11 +1,14 @@
 package usecase
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+	"github.com/osmosis-labs/sqs/sqsdomain/proto/types"
 )
 
 type (
@@ -39,3 +42,23 @@ func ComputeNormalizationScalingFactors(standardNormalizationFactor osmomath.Int
 func ProcessAlloyedPool(sqsModel *sqsdomain.SQSPool) error {
 	return processAlloyedPool(sqsModel)
 }
+
+func RemovePoolsFromDenomLiquidityMap(denomLiquidityMap domain.DenomPoolLiquidityMap, poolIDs []uint64) domain.DenomPoolLiquidityMap {
+	return removePoolsFromDenomLiquidityMap(denomLiquidityMap, poolIDs)
+}
+
+func ValidatePool(pool sqsdomain.PoolI, cosmWasmPoolConfig domain.CosmWasmPoolRouterConfig) error {
+	return validatePool(pool, cosmWasmPoolConfig)
+}
+
+func (p *ingestUseCase) ParsePool(pool *types.PoolData) (sqsdomain.PoolI, error) {
+	return p.parsePool(pool)
+}
+
+func (p *ingestUseCase) ValidatePools(ctx context.Context, height uint64, pools map[uint64]sqsdomain.PoolI) ([]sqsdomain.PoolI, domain.BlockPoolMetadata, error) {
+	return p.validatePools(ctx, height, pools)
+}
+
+func (p *ingestUseCase) StoreStateSnapshot(height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools []sqsdomain.PoolI, removedPoolIDs []uint64) {
+	p.storeStateSnapshot(height, takerFeesMap, pools, removedPoolIDs)
+}
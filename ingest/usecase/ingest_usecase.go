@@ -39,6 +39,12 @@ type ingestUseCase struct {
 
 	denomLiquidityMap domain.DenomPoolLiquidityMap
 
+	// poolData is the latest encoded data of every ingested pool
+	// that the pool deltas are applied onto.
+	// Only retained for the nodes sending pool deltas.
+	poolData   map[uint64]*types.PoolData
+	poolDataMx sync.Mutex
+
 	// Worker that computes prices for all tokens with the default quote.
 	defaultQuotePriceUpdateWorker domain.PricingWorker
 
@@ -48,6 +54,12 @@ type ingestUseCase struct {
 	// endBlockProcessPlugins are the plugins to execute at the end of the block.
 	endBlockProcessPlugins []domain.EndBlockProcessPlugin
 
+	// stateSnapshotHolder is the holder that the state snapshot is swapped into at the end of each block.
+	// Nil if state snapshots are disabled.
+	stateSnapshotHolder *domain.StateSnapshotHolder
+	// candidateRouteSearchDataHolder holds the candidate route search data captured by the state snapshot.
+	candidateRouteSearchDataHolder mvc.CandidateRouteSearchDataHolder
+
 	// The first height observed after start-up
 	// See firstBlockPoolCountThreshold for details.
 	firstHeightAfterStartUp atomic.Uint64
@@ -60,6 +72,8 @@ type ingestUseCase struct {
 
 type poolResult struct {
 	pool sqsdomain.PoolI
+	// data is the encoded data that the pool was parsed from.
+	data *types.PoolData
 	err  error
 }
 
@@ -87,6 +101,7 @@ var (
 )
 
 // NewIngestUsecase will create a new pools use case object
+// The orderbook use case is optional. If nil, the orderbook pools are not processed for their ticks.
 func NewIngestUsecase(poolsUseCase mvc.PoolsUsecase, routerUseCase mvc.RouterUsecase, pricingRouterUsecase mvc.RouterUsecase, tokensUseCase mvc.TokensUsecase, chainInfoUseCase mvc.ChainInfoUsecase, codec codec.Codec, quotePriceUpdateWorker domain.PricingWorker, candidateRouteSearchWorker domain.CandidateRouteSearchDataWorker, orderBookUseCase mvc.OrderBookUsecase, logger log.Logger) (mvc.IngestUsecase, error) {
 	return &ingestUseCase{
 		codec: codec,
@@ -99,6 +114,8 @@ func NewIngestUsecase(poolsUseCase mvc.PoolsUsecase, routerUseCase mvc.RouterUse
 
 		denomLiquidityMap: make(domain.DenomPoolLiquidityMap),
 
+		poolData: make(map[uint64]*types.PoolData),
+
 		logger: logger,
 
 		defaultQuotePriceUpdateWorker: quotePriceUpdateWorker,
@@ -111,11 +128,11 @@ func NewIngestUsecase(poolsUseCase mvc.PoolsUsecase, routerUseCase mvc.RouterUse
 	}, nil
 }
 
-func (p *ingestUseCase) ProcessBlockData(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, poolData []*types.PoolData) (err error) {
+func (p *ingestUseCase) ProcessBlockData(ctx context.Context, height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools map[uint64]sqsdomain.PoolI, removedPoolIDs []uint64) (err error) {
 	ctx, span := tracer.Start(ctx, "ingestUseCase.ProcessBlockData")
 	defer span.End()
 
-	if p.firstHeightAfterStartUp.Load() == 0 && len(poolData) > firstBlockPoolCountThreshold {
+	if p.firstHeightAfterStartUp.Load() == 0 && len(pools) > firstBlockPoolCountThreshold {
 		p.logger.Info("setting first block height", zap.Uint64("height", height))
 		p.firstHeightAfterStartUp.Store(height)
 		p.firstBlockWg.Add(1)
@@ -127,17 +144,29 @@ func (p *ingestUseCase) ProcessBlockData(ctx context.Context, height uint64, tak
 
 	p.routerUsecase.SetTakerFees(takerFeesMap)
 
-	// Parse the pools
-	pools, uniqueBlockPoolMetadata, err := p.parsePoolData(ctx, poolData)
+	// Validate the pools
+	validatedPools, uniqueBlockPoolMetadata, err := p.validatePools(ctx, height, pools)
 	if err != nil {
 		return err
 	}
 
 	// Store the pools
-	if err := p.poolsUseCase.StorePools(pools); err != nil {
+	if err := p.poolsUseCase.StorePools(validatedPools); err != nil {
 		return err
 	}
 
+	// Memoized generalized cosmwasm pool queries are stale once the pool is updated.
+	p.poolsUseCase.InvalidateCosmWasmPoolQueries(uniqueBlockPoolMetadata.PoolIDs)
+
+	// Delete the removed pools and their liquidity contributions.
+	if len(removedPoolIDs) > 0 {
+		p.poolsUseCase.DeletePools(removedPoolIDs)
+
+		p.denomLiquidityMap = removePoolsFromDenomLiquidityMap(p.denomLiquidityMap, removedPoolIDs)
+
+		domain.SQSIngestUsecaseQuarantinedPoolsGauge.Set(float64(len(p.poolsUseCase.GetQuarantinedPools())))
+	}
+
 	// Get all pools (already updated with the newly ingested pools)
 	allPools, err := p.poolsUseCase.GetAllPools()
 	if err != nil {
@@ -187,6 +216,9 @@ func (p *ingestUseCase) ProcessBlockData(ctx context.Context, height uint64, tak
 		p.defaultQuotePriceUpdateWorker.UpdatePricesAsync(height, uniqueBlockPoolMetadata)
 	}
 
+	// Swap in the state snapshot of the block so that requests observe a consistent state.
+	p.storeStateSnapshot(height, takerFeesMap, validatedPools, removedPoolIDs)
+
 	// Store the latest ingested height.
 	p.chainInfoUseCase.StoreLatestHeight(height)
 
@@ -209,6 +241,27 @@ func (p *ingestUseCase) RegisterEndBlockProcessPlugin(plugin domain.EndBlockProc
 	p.endBlockProcessPlugins = append(p.endBlockProcessPlugins, plugin)
 }
 
+// RegisterStateSnapshotHolder implements mvc.IngestUsecase.
+func (p *ingestUseCase) RegisterStateSnapshotHolder(stateSnapshotHolder *domain.StateSnapshotHolder, candidateRouteSearchDataHolder mvc.CandidateRouteSearchDataHolder) {
+	p.stateSnapshotHolder = stateSnapshotHolder
+	p.candidateRouteSearchDataHolder = candidateRouteSearchDataHolder
+}
+
+// storeStateSnapshot builds the state snapshot of the block by applying the block's pools and taker fees
+// onto the previous snapshot, copying it on write, and atomically swaps it in.
+// No-op if state snapshots are disabled.
+func (p *ingestUseCase) storeStateSnapshot(height uint64, takerFeesMap sqsdomain.TakerFeeMap, pools []sqsdomain.PoolI, removedPoolIDs []uint64) {
+	if p.stateSnapshotHolder == nil {
+		return
+	}
+
+	candidateRouteSearchData := p.candidateRouteSearchDataHolder.GetCandidateRouteSearchData()
+
+	snapshot := p.stateSnapshotHolder.Load().Update(height, pools, removedPoolIDs, takerFeesMap, candidateRouteSearchData)
+
+	p.stateSnapshotHolder.Store(snapshot)
+}
+
 // updateAssetsAtHeightIntervalAsync updates the assets at the height interval asynchronously.
 // Any error that occurs during the update is recorded in the error counter.
 func (p *ingestUseCase) updateAssetsAtHeightIntervalAsync(height uint64) {
@@ -221,20 +274,31 @@ func (p *ingestUseCase) updateAssetsAtHeightIntervalAsync(height uint64) {
 }
 
 // sortAndStorePools sorts the pools and stores them in the router.
+// Quarantined pools are excluded.
 // TODO: instead of resorting all pools every block, we should put the updated pools in the correct position
 func (p *ingestUseCase) sortAndStorePools(pools []sqsdomain.PoolI) {
 	cosmWasmPoolConfig := p.poolsUseCase.GetCosmWasmPoolConfig()
 	routerConfig := p.routerUsecase.GetConfig()
 
-	sortedPools, _ := routerusecase.ValidateAndSortPools(pools, cosmWasmPoolConfig, routerConfig.PreferredPoolIDs, p.logger)
+	routablePools := make([]sqsdomain.PoolI, 0, len(pools))
+	for _, pool := range pools {
+		if p.poolsUseCase.IsPoolQuarantined(pool.GetId()) {
+			continue
+		}
+
+		routablePools = append(routablePools, pool)
+	}
+
+	sortedPools, _ := routerusecase.ValidateAndSortPools(routablePools, cosmWasmPoolConfig, routerConfig.PreferredPoolIDs, p.logger)
 
 	// Sort the pools and store them in the router.
 	p.routerUsecase.SetSortedPools(sortedPools)
 	p.pricingRouterUsecase.SetSortedPools(sortedPools)
 }
 
-// parsePoolData parses the pool data and returns the pool objects.
-func (p *ingestUseCase) parsePoolData(ctx context.Context, poolData []*types.PoolData) ([]sqsdomain.PoolI, domain.BlockPoolMetadata, error) {
+// parsePools parses the pool data concurrently and returns the parsed pools and their data by pool ID.
+// The pools that fail to be parsed are logged and skipped.
+func (p *ingestUseCase) parsePools(poolData []*types.PoolData) (map[uint64]sqsdomain.PoolI, map[uint64]*types.PoolData) {
 	poolResultChan := make(chan poolResult, len(poolData))
 
 	// Parse the pools concurrently
@@ -244,78 +308,131 @@ func (p *ingestUseCase) parsePoolData(ctx context.Context, poolData []*types.Poo
 
 			poolResultChan <- poolResult{
 				pool: poolResultData,
+				data: pool,
 				err:  err,
 			}
 		}(pool)
 	}
 
-	parsedPools := make([]sqsdomain.PoolI, 0, len(poolData))
+	pools := make(map[uint64]sqsdomain.PoolI, len(poolData))
+	parsedPoolData := make(map[uint64]*types.PoolData, len(poolData))
+
+	for i := 0; i < len(poolData); i++ {
+		poolResult := <-poolResultChan
+		if poolResult.err != nil {
+			// Increment parse pool error counter
+			p.logger.Error(domain.SQSIngestUsecaseParsePoolErrorMetricName, zap.Error(poolResult.err))
+			domain.SQSIngestHandlerPoolParseErrorCounter.Inc()
+			continue
+		}
+
+		poolID := poolResult.pool.GetId()
+		pools[poolID] = poolResult.pool
+		parsedPoolData[poolID] = poolResult.data
+	}
+
+	return pools, parsedPoolData
+}
+
+// validatePools validates the parsed pools and returns them along with the block pool metadata.
+// The pools failing validation are quarantined from routing: they are returned to be stored
+// but do not contribute to the denom liquidity map. The quarantined pools that pass validation are released.
+func (p *ingestUseCase) validatePools(ctx context.Context, height uint64, pools map[uint64]sqsdomain.PoolI) ([]sqsdomain.PoolI, domain.BlockPoolMetadata, error) {
+	cosmWasmPoolConfig := p.poolsUseCase.GetCosmWasmPoolConfig()
+
+	validatedPools := make([]sqsdomain.PoolI, 0, len(pools))
 
 	uniqueData := domain.BlockPoolMetadata{
-		PoolIDs:       make(map[uint64]struct{}, len(poolData)),
+		PoolIDs:       make(map[uint64]struct{}, len(pools)),
 		UpdatedDenoms: make(map[string]struct{}),
 	}
 
 	currentBlockLiquidityMap := domain.DenomPoolLiquidityMap{}
 
-	// Collect the parsed pools
-	for i := 0; i < len(poolData); i++ {
-		select {
-		case poolResult := <-poolResultChan:
-			if poolResult.err != nil {
-				// Increment parse pool error counter
-				p.logger.Error(domain.SQSIngestUsecaseParsePoolErrorMetricName, zap.Error(poolResult.err))
-				domain.SQSIngestHandlerPoolParseErrorCounter.Inc()
-				continue
+	var (
+		quarantinedPools      []domain.QuarantinedPool
+		quarantinedPoolIDs    []uint64
+		quarantinedPoolDenoms = make(map[string]struct{})
+		validatedPoolIDs      = make([]uint64, 0, len(pools))
+	)
+
+	// Validate the parsed pools
+	for _, pool := range pools {
+		if err := ctx.Err(); err != nil {
+			return nil, domain.BlockPoolMetadata{}, err
+		}
+
+		validationErr := validatePool(pool, cosmWasmPoolConfig)
+
+		// Get balances and pool ID.
+		sqsModel := pool.GetSQSPoolModel()
+		currentPoolBalances := sqsModel.Balances
+		poolID := pool.GetId()
+
+		// Quarantine the pool, removing it from the candidate routes of its denoms.
+		if validationErr != nil {
+			p.logger.Error(domain.SQSIngestUsecasePoolValidationErrorMetricName, zap.Uint64("pool_id", poolID), zap.Error(validationErr))
+			domain.SQSIngestUsecasePoolValidationErrorCounter.Inc()
+
+			quarantinedPools = append(quarantinedPools, domain.QuarantinedPool{
+				PoolID:             poolID,
+				PoolType:           poolmanagertypes.PoolType_name[int32(pool.GetType())],
+				Reason:             validationErr.Error(),
+				QuarantinedHeight:  height,
+				LatestFailedHeight: height,
+			})
+			quarantinedPoolIDs = append(quarantinedPoolIDs, poolID)
+
+			for _, denom := range pool.GetPoolDenoms() {
+				quarantinedPoolDenoms[denom] = struct{}{}
 			}
 
-			// Get balances and pool ID.
-			sqsModel := poolResult.pool.GetSQSPoolModel()
-			currentPoolBalances := sqsModel.Balances
-			poolID := poolResult.pool.GetId()
+			// The quarantined pool is still stored so that it can be inspected.
+			validatedPools = append(validatedPools, pool)
+			continue
+		}
 
-			// Update block liquidity map.
-			currentBlockLiquidityMap = updateCurrentBlockLiquidityMapFromBalances(currentBlockLiquidityMap, currentPoolBalances, poolID)
+		validatedPoolIDs = append(validatedPoolIDs, poolID)
 
-			// Separately update unique denoms.
-			for _, balance := range currentPoolBalances {
-				if balance.Validate() != nil {
-					p.logger.Debug("invalid pool balance", zap.Uint64("pool_id", poolID), zap.String("denom", balance.Denom), zap.String("amount", balance.Amount.String()))
-					continue
-				}
+		// Update block liquidity map.
+		currentBlockLiquidityMap = updateCurrentBlockLiquidityMapFromBalances(currentBlockLiquidityMap, currentPoolBalances, poolID)
 
-				uniqueData.UpdatedDenoms[balance.Denom] = struct{}{}
+		// Separately update unique denoms.
+		for _, balance := range currentPoolBalances {
+			if balance.Validate() != nil {
+				p.logger.Debug("invalid pool balance", zap.Uint64("pool_id", poolID), zap.String("denom", balance.Denom), zap.String("amount", balance.Amount.String()))
+				continue
 			}
 
-			// Handle the alloyed LP share stemming from the "minting" pools.
-			// See updateCurrentBlockLiquidityMapAlloyed for details.
-			cosmWasmModel := sqsModel.CosmWasmPoolModel
-			if cosmWasmModel != nil && cosmWasmModel.IsAlloyTransmuter() {
-				alloyedDenom := cosmWasmModel.Data.AlloyTransmuter.AlloyedDenom
-				uniqueData.UpdatedDenoms[alloyedDenom] = struct{}{}
-
-				currentBlockLiquidityMap = updateCurrentBlockLiquidityMapAlloyed(currentBlockLiquidityMap, poolID, alloyedDenom)
-			}
+			uniqueData.UpdatedDenoms[balance.Denom] = struct{}{}
+		}
 
-			// Process the orderbook pool.
-			if cosmWasmModel != nil && cosmWasmModel.IsOrderbook() {
-				// Process the orderbook pool asynchronously as to avoid blocking the main ingest goroutine
-				// and to avoid potential deadlock.
-				go func() {
-					if err := p.orderBookUseCase.ProcessPool(ctx, poolResult.pool); err != nil {
-						domain.SQSIngestHandlerProcessOrderbookPoolErrorCounter.Inc()
-						p.logger.Error(domain.SQSIngestUsecaseProcessOrderbookPoolErrorMetricName, zap.Error(err), zap.Uint64("pool_id", poolID))
-					}
-				}()
-			}
+		// Handle the alloyed LP share stemming from the "minting" pools.
+		// See updateCurrentBlockLiquidityMapAlloyed for details.
+		cosmWasmModel := sqsModel.CosmWasmPoolModel
+		if cosmWasmModel != nil && cosmWasmModel.IsAlloyTransmuter() {
+			alloyedDenom := cosmWasmModel.Data.AlloyTransmuter.AlloyedDenom
+			uniqueData.UpdatedDenoms[alloyedDenom] = struct{}{}
 
-			// Update unique pools.
-			uniqueData.PoolIDs[poolID] = struct{}{}
+			currentBlockLiquidityMap = updateCurrentBlockLiquidityMapAlloyed(currentBlockLiquidityMap, poolID, alloyedDenom)
+		}
 
-			parsedPools = append(parsedPools, poolResult.pool)
-		case <-ctx.Done():
-			return nil, domain.BlockPoolMetadata{}, ctx.Err()
+		// Process the orderbook pool if the orderbook use case is configured.
+		if cosmWasmModel != nil && cosmWasmModel.IsOrderbook() && p.orderBookUseCase != nil {
+			// Process the orderbook pool asynchronously as to avoid blocking the main ingest goroutine
+			// and to avoid potential deadlock.
+			go func(pool sqsdomain.PoolI, poolID uint64) {
+				if err := p.orderBookUseCase.ProcessPool(ctx, pool); err != nil {
+					domain.SQSIngestHandlerProcessOrderbookPoolErrorCounter.Inc()
+					p.logger.Error(domain.SQSIngestUsecaseProcessOrderbookPoolErrorMetricName, zap.Error(err), zap.Uint64("pool_id", poolID))
+				}
+			}(pool, poolID)
 		}
+
+		// Update unique pools.
+		uniqueData.PoolIDs[poolID] = struct{}{}
+
+		validatedPools = append(validatedPools, pool)
 	}
 
 	// Transfer the updated block denom liquidity data to the global map.
@@ -323,10 +440,36 @@ func (p *ingestUseCase) parsePoolData(ctx context.Context, poolData []*types.Poo
 	// in the current block. We need to merge this data with the holistic existing data.
 	p.denomLiquidityMap = transferDenomLiquidityMap(p.denomLiquidityMap, currentBlockLiquidityMap)
 
+	if len(quarantinedPools) > 0 {
+		// Remove the previous liquidity contributions of the quarantined pools.
+		p.denomLiquidityMap = removePoolsFromDenomLiquidityMap(p.denomLiquidityMap, quarantinedPoolIDs)
+
+		// Recompute the candidate routes of the denoms that are still routable without the quarantined pools.
+		for denom := range quarantinedPoolDenoms {
+			if _, ok := p.denomLiquidityMap[denom]; ok {
+				uniqueData.UpdatedDenoms[denom] = struct{}{}
+			}
+		}
+
+		p.poolsUseCase.QuarantinePools(quarantinedPools)
+	}
+
+	var releasedPoolIDs []uint64
+	if len(validatedPoolIDs) > 0 {
+		releasedPoolIDs = p.poolsUseCase.ReleaseQuarantinedPools(validatedPoolIDs)
+		if len(releasedPoolIDs) > 0 {
+			p.logger.Info("released quarantined pools", zap.Uint64("height", height), zap.Uint64s("pool_ids", releasedPoolIDs))
+		}
+	}
+
+	if len(quarantinedPools) > 0 || len(releasedPoolIDs) > 0 {
+		domain.SQSIngestUsecaseQuarantinedPoolsGauge.Set(float64(len(p.poolsUseCase.GetQuarantinedPools())))
+	}
+
 	// Update unique denoms.
 	uniqueData.DenomPoolLiquidityMap = p.denomLiquidityMap
 
-	return parsedPools, uniqueData, nil
+	return validatedPools, uniqueData, nil
 }
 
 // updateCurrentBlockLiquidityMapFromBalances updates the current block liquidity map with the balance from the pool of the supplied ID.
@@ -447,6 +590,32 @@ func transferDenomLiquidityMap(transferTo, transferFrom domain.DenomPoolLiquidit
 	return transferTo
 }
 
+// removePoolsFromDenomLiquidityMap removes the liquidity contributions of the given pools
+// from the denom liquidity map. Denoms left without pools are removed.
+// Returns the updated map.
+func removePoolsFromDenomLiquidityMap(denomLiquidityMap domain.DenomPoolLiquidityMap, poolIDs []uint64) domain.DenomPoolLiquidityMap {
+	for denom, denomLiquidityData := range denomLiquidityMap {
+		for _, poolID := range poolIDs {
+			poolLiquidity, ok := denomLiquidityData.Pools[poolID]
+			if !ok {
+				continue
+			}
+
+			denomLiquidityData.TotalLiquidity = denomLiquidityData.TotalLiquidity.Sub(poolLiquidity)
+			delete(denomLiquidityData.Pools, poolID)
+		}
+
+		if len(denomLiquidityData.Pools) == 0 {
+			delete(denomLiquidityMap, denom)
+			continue
+		}
+
+		denomLiquidityMap[denom] = denomLiquidityData
+	}
+
+	return denomLiquidityMap
+}
+
 // parsePool parses the pool data and returns the pool object
 // For concentrated pools, it also processes the tick model
 func (p *ingestUseCase) parsePool(pool *types.PoolData) (sqsdomain.PoolI, error) {
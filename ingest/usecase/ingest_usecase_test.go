@@ -445,7 +445,7 @@ func (s *IngestUseCaseTestSuite) TestCallUpdateAssetsAtHeightIntervalSync() {
 			s.Require().NoError(err)
 
 			for height := 0; height < tt.wantCallCount; height++ {
-				err = ingester.ProcessBlockData(context.TODO(), uint64(height)+1, nil, nil)
+				err = ingester.ProcessBlockData(context.TODO(), uint64(height)+1, nil, nil, nil)
 				s.Require().NoError(err)
 			}
 
@@ -0,0 +1,179 @@
+package arbdetector
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// arbDetectorIngestPlugin is a plugin that detects cyclic arbitrage opportunities
+// across all pools at the end of the block.
+//
+// For every pool updated in the block, it attempts to close a cycle over every denom pair
+// of the pool by reusing the router's candidate route search. Each cycle is then sized
+// by simulating swaps over the cycle with the router's custom direct quote math.
+type arbDetectorIngestPlugin struct {
+	poolsUseCase  mvc.PoolsUsecase
+	routerUseCase mvc.RouterUsecase
+	tokensUseCase mvc.TokensUsecase
+
+	config            domain.ArbDetectorPluginConfig
+	defaultQuoteDenom string
+
+	atomicBool atomic.Bool
+
+	opportunitiesMx sync.RWMutex
+	opportunities   arbdetectordomain.CyclicArbOpportunities
+
+	logger log.Logger
+}
+
+var (
+	_ domain.EndBlockProcessPlugin        = &arbDetectorIngestPlugin{}
+	_ arbdetectordomain.CyclicArbDetector = &arbDetectorIngestPlugin{}
+)
+
+const (
+	tracerName = "sqs-arb-detector"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+)
+
+// New returns a new cyclic arbitrage detector plugin.
+func New(poolsUseCase mvc.PoolsUsecase, routerUseCase mvc.RouterUsecase, tokensUseCase mvc.TokensUsecase, config domain.ArbDetectorPluginConfig, defaultQuoteDenom string, logger log.Logger) *arbDetectorIngestPlugin {
+	return &arbDetectorIngestPlugin{
+		poolsUseCase:  poolsUseCase,
+		routerUseCase: routerUseCase,
+		tokensUseCase: tokensUseCase,
+
+		config:            config,
+		defaultQuoteDenom: defaultQuoteDenom,
+
+		atomicBool: atomic.Bool{},
+
+		opportunities: arbdetectordomain.CyclicArbOpportunities{
+			Opportunities: []arbdetectordomain.CyclicArbOpportunity{},
+		},
+
+		logger: logger,
+	}
+}
+
+// ProcessEndBlock implements domain.EndBlockProcessPlugin.
+func (a *arbDetectorIngestPlugin) ProcessEndBlock(ctx context.Context, blockHeight uint64, metadata domain.BlockPoolMetadata) error {
+	ctx, span := tracer.Start(ctx, "arbDetectorIngestPlugin.ProcessEndBlock")
+	defer span.End()
+
+	// For simplicity, we allow only one block to be processed at a time.
+	// If the previous block is still being processed, the current one is skipped.
+	if !a.atomicBool.CompareAndSwap(false, true) {
+		a.logger.Info("arb detector is already in progress", zap.Uint64("block_height", blockHeight))
+		return nil
+	}
+	defer a.atomicBool.Store(false)
+
+	startTime := time.Now()
+	defer func() {
+		domain.SQSArbDetectorProcessBlockDurationGauge.Set(float64(time.Since(startTime).Milliseconds()))
+	}()
+
+	cycles := a.findCycles(ctx, metadata.PoolIDs)
+
+	span.SetAttributes(attribute.Int("cycles", len(cycles)))
+
+	if len(cycles) == 0 {
+		a.storeOpportunities(blockHeight, []arbdetectordomain.CyclicArbOpportunity{})
+		return nil
+	}
+
+	// Get prices for all the unique denoms that cycles start in.
+	uniqueDenoms := make([]string, 0)
+	seenDenoms := make(map[string]struct{})
+	for _, c := range cycles {
+		if _, ok := seenDenoms[c.denomIn]; !ok {
+			seenDenoms[c.denomIn] = struct{}{}
+			uniqueDenoms = append(uniqueDenoms, c.denomIn)
+		}
+	}
+
+	prices, err := a.tokensUseCase.GetPrices(ctx, uniqueDenoms, []string{a.defaultQuoteDenom}, domain.ChainPricingSourceType)
+	if err != nil {
+		domain.SQSArbDetectorErrorCounter.Inc()
+		a.logger.Error("failed to get prices for arb detection", zap.Uint64("block_height", blockHeight), zap.Error(err))
+		return err
+	}
+
+	minProfitUSD, err := osmomath.NewDecFromStr(formatFloat(a.config.MinProfitUSD))
+	if err != nil {
+		return err
+	}
+
+	opportunities := make([]arbdetectordomain.CyclicArbOpportunity, 0)
+	for _, c := range cycles {
+		opportunity, isProfitable, err := a.sizeCycle(ctx, c, prices)
+		if err != nil {
+			domain.SQSArbDetectorErrorCounter.Inc()
+			a.logger.Debug("failed to size cycle", zap.String("denom_in", c.denomIn), zap.Uint64s("pool_ids", c.poolIDs), zap.Error(err))
+			continue
+		}
+
+		if !isProfitable || opportunity.ProfitUSD.LT(minProfitUSD) {
+			continue
+		}
+
+		opportunities = append(opportunities, opportunity)
+	}
+
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		return opportunities[i].ProfitUSD.GT(opportunities[j].ProfitUSD)
+	})
+
+	if a.config.MaxOpportunities > 0 && len(opportunities) > a.config.MaxOpportunities {
+		opportunities = opportunities[:a.config.MaxOpportunities]
+	}
+
+	domain.SQSArbDetectorOpportunitiesCounter.Add(float64(len(opportunities)))
+	if len(opportunities) > 0 {
+		domain.SQSArbDetectorBestProfitUSDGauge.Set(opportunities[0].ProfitUSD.MustFloat64())
+	} else {
+		domain.SQSArbDetectorBestProfitUSDGauge.Set(0)
+	}
+
+	a.storeOpportunities(blockHeight, opportunities)
+
+	a.logger.Info("arb detection completed", zap.Uint64("block_height", blockHeight), zap.Int("cycles", len(cycles)), zap.Int("opportunities", len(opportunities)))
+
+	return nil
+}
+
+// GetOpportunities implements arbdetectordomain.CyclicArbDetector.
+func (a *arbDetectorIngestPlugin) GetOpportunities() arbdetectordomain.CyclicArbOpportunities {
+	a.opportunitiesMx.RLock()
+	defer a.opportunitiesMx.RUnlock()
+
+	return a.opportunities
+}
+
+// storeOpportunities replaces the opportunities with the ones detected at the given height.
+func (a *arbDetectorIngestPlugin) storeOpportunities(height uint64, opportunities []arbdetectordomain.CyclicArbOpportunity) {
+	a.opportunitiesMx.Lock()
+	defer a.opportunitiesMx.Unlock()
+
+	a.opportunities = arbdetectordomain.CyclicArbOpportunities{
+		Height:        height,
+		Opportunities: opportunities,
+	}
+}
@@ -0,0 +1,176 @@
+package arbdetector_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/arbdetector"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type ArbDetectorTestSuite struct {
+	suite.Suite
+}
+
+const (
+	updatedPoolID uint64 = 1
+	closingPoolID uint64 = 2
+	defaultHeight uint64 = 100
+)
+
+var (
+	UOSMO = routertesting.UOSMO
+	USDC  = routertesting.USDC
+
+	// cycleLiquidity is the virtual liquidity of the simulated cycle.
+	cycleLiquidity = osmomath.NewInt(1_000_000_000)
+)
+
+func TestArbDetectorTestSuite(t *testing.T) {
+	suite.Run(t, new(ArbDetectorTestSuite))
+}
+
+// simulateCycleOutGivenIn simulates a cycle with a 10% price discrepancy
+// and constant product slippage over cycleLiquidity:
+// out = 1.1 * in * L / (L + in)
+//
+// The profit is maximized at in = L * (sqrt(1.1) - 1) ~= 0.0488 * L.
+func simulateCycleOutGivenIn(amountIn osmomath.Int) osmomath.Int {
+	return amountIn.MulRaw(11).Mul(cycleLiquidity).Quo(cycleLiquidity.Add(amountIn).MulRaw(10))
+}
+
+func (s *ArbDetectorTestSuite) TestProcessEndBlock() {
+	tests := []struct {
+		name string
+
+		candidateRoutes sqsdomain.CandidateRoutes
+		minProfitUSD    float64
+
+		expectedPoolIDs []uint64
+	}{
+		{
+			name: "cycle closed by another pool is detected",
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{Pools: []sqsdomain.CandidatePool{{ID: closingPoolID, TokenOutDenom: UOSMO}}},
+				},
+			},
+			minProfitUSD: 1,
+
+			expectedPoolIDs: []uint64{updatedPoolID, closingPoolID},
+		},
+		{
+			name: "cycle closed by the updated pool is skipped",
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{Pools: []sqsdomain.CandidatePool{{ID: updatedPoolID, TokenOutDenom: UOSMO}}},
+				},
+			},
+			minProfitUSD: 1,
+		},
+		{
+			name: "cycle exceeding max cycle length is skipped",
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{Pools: []sqsdomain.CandidatePool{{ID: 3, TokenOutDenom: "uion"}, {ID: 4, TokenOutDenom: "uatom"}, {ID: closingPoolID, TokenOutDenom: UOSMO}}},
+				},
+			},
+			minProfitUSD: 1,
+		},
+		{
+			name: "cycle below min profit is skipped",
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{Pools: []sqsdomain.CandidatePool{{ID: closingPoolID, TokenOutDenom: UOSMO}}},
+				},
+			},
+			minProfitUSD: 1_000_000,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			poolsUseCase := &mocks.PoolsUsecaseMock{
+				GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+					return &mocks.MockRoutablePool{ID: poolID, Denoms: []string{UOSMO, USDC}}, nil
+				},
+			}
+
+			routerUseCase := &mocks.RouterUsecaseMock{
+				GetCandidateRoutesFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error) {
+					// Only close the cycle starting in UOSMO.
+					if tokenOutDenom != UOSMO {
+						return sqsdomain.CandidateRoutes{}, nil
+					}
+					return tc.candidateRoutes, nil
+				},
+				GetCustomDirectQuoteMultiPoolFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error) {
+					return &mocks.QuoteMock{
+						GetAmountOutFunc: func() osmomath.Int {
+							return simulateCycleOutGivenIn(tokenIn.Amount)
+						},
+						GetRouteFunc: func() []domain.SplitRoute {
+							return nil
+						},
+					}, nil
+				},
+			}
+
+			tokensUseCase := &mocks.TokensUsecaseMock{
+				GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+					return domain.PricesResult{
+						UOSMO: {USDC: osmomath.OneBigDec()},
+					}, nil
+				},
+				GetChainScalingFactorByDenomMutFunc: func(denom string) (osmomath.Dec, error) {
+					return osmomath.NewDec(1_000_000), nil
+				},
+			}
+
+			config := domain.DefaultArbDetectorPluginConfig
+			config.MinProfitUSD = tc.minProfitUSD
+
+			plugin := arbdetector.New(poolsUseCase, routerUseCase, tokensUseCase, config, USDC, &log.NoOpLogger{})
+
+			err := plugin.ProcessEndBlock(context.Background(), defaultHeight, domain.BlockPoolMetadata{
+				PoolIDs: map[uint64]struct{}{updatedPoolID: {}},
+			})
+			s.Require().NoError(err)
+
+			result := plugin.GetOpportunities()
+			s.Require().Equal(defaultHeight, result.Height)
+
+			if len(tc.expectedPoolIDs) == 0 {
+				s.Require().Empty(result.Opportunities)
+				return
+			}
+
+			s.Require().Len(result.Opportunities, 1)
+			opportunity := result.Opportunities[0]
+
+			s.Require().Equal(UOSMO, opportunity.DenomIn)
+			s.Require().Equal(tc.expectedPoolIDs, opportunity.PoolIDs)
+			s.Require().Equal([]string{USDC, UOSMO}, opportunity.TokenOutDenoms)
+			s.Require().Equal(simulateCycleOutGivenIn(opportunity.AmountIn), opportunity.AmountOut)
+			s.Require().Equal(opportunity.AmountOut.Sub(opportunity.AmountIn), opportunity.Profit)
+
+			// The optimal amount in is ~48.8 OSMO. Validate that the sizing search
+			// converges to it within 1%.
+			expectedOptimalAmountIn := osmomath.NewInt(48_808_848)
+			errTolerance := osmomath.ErrTolerance{MultiplicativeTolerance: osmomath.MustNewDecFromStr("0.01")}
+			s.Require().Equal(0, errTolerance.Compare(expectedOptimalAmountIn, opportunity.AmountIn), "expected %s, actual %s", expectedOptimalAmountIn, opportunity.AmountIn)
+
+			// Profit is ~2.4 OSMO at $1 each.
+			s.Require().True(opportunity.ProfitUSD.GT(osmomath.NewDec(2)))
+			s.Require().True(opportunity.ProfitUSD.LT(osmomath.NewDec(3)))
+		})
+	}
+}
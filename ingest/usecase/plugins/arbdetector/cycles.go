@@ -0,0 +1,115 @@
+package arbdetector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"go.uber.org/zap"
+)
+
+// cycle is a candidate cyclic route that starts and ends in denomIn.
+type cycle struct {
+	denomIn        string
+	poolIDs        []uint64
+	tokenOutDenoms []string
+}
+
+// key returns a unique key identifying the cycle.
+func (c cycle) key() string {
+	var sb strings.Builder
+	sb.WriteString(c.denomIn)
+	for _, poolID := range c.poolIDs {
+		sb.WriteString(fmt.Sprintf("/%d", poolID))
+	}
+	return sb.String()
+}
+
+// findCycles finds candidate cycles that contain at least one of the given updated pools.
+//
+// For every ordered denom pair (A, B) of an updated pool, the cycle starts by swapping A for B
+// over the updated pool. It is closed by every candidate route from B back to A that does not
+// contain the updated pool and keeps the cycle within the configured maximum length.
+func (a *arbDetectorIngestPlugin) findCycles(ctx context.Context, poolIDs map[uint64]struct{}) []cycle {
+	// Sort pool IDs for deterministic output.
+	sortedPoolIDs := make([]uint64, 0, len(poolIDs))
+	for poolID := range poolIDs {
+		sortedPoolIDs = append(sortedPoolIDs, poolID)
+	}
+	sort.Slice(sortedPoolIDs, func(i, j int) bool {
+		return sortedPoolIDs[i] < sortedPoolIDs[j]
+	})
+
+	cycles := make([]cycle, 0)
+	seenCycles := make(map[string]struct{})
+
+	for _, poolID := range sortedPoolIDs {
+		pool, err := a.poolsUseCase.GetPool(poolID)
+		if err != nil {
+			a.logger.Debug("failed to get pool for arb detection", zap.Uint64("pool_id", poolID), zap.Error(err))
+			continue
+		}
+
+		poolDenoms := pool.GetPoolDenoms()
+
+		for _, denomIn := range poolDenoms {
+			for _, denomOut := range poolDenoms {
+				if denomIn == denomOut {
+					continue
+				}
+
+				// Find candidate routes closing the cycle from denomOut back to denomIn.
+				// The amount is irrelevant for the candidate route search.
+				candidateRoutes, err := a.routerUseCase.GetCandidateRoutes(ctx, sdk.NewCoin(denomOut, osmomath.OneInt()), denomIn)
+				if err != nil {
+					a.logger.Debug("failed to get candidate routes for arb detection", zap.String("denom_in", denomOut), zap.String("denom_out", denomIn), zap.Error(err))
+					continue
+				}
+
+				for _, candidateRoute := range candidateRoutes.Routes {
+					if len(candidateRoute.Pools) == 0 || len(candidateRoute.Pools)+1 > a.config.MaxCycleLength {
+						continue
+					}
+
+					c := cycle{
+						denomIn:        denomIn,
+						poolIDs:        make([]uint64, 0, len(candidateRoute.Pools)+1),
+						tokenOutDenoms: make([]string, 0, len(candidateRoute.Pools)+1),
+					}
+
+					c.poolIDs = append(c.poolIDs, poolID)
+					c.tokenOutDenoms = append(c.tokenOutDenoms, denomOut)
+
+					containsUpdatedPool := false
+					for _, candidatePool := range candidateRoute.Pools {
+						if candidatePool.ID == poolID {
+							containsUpdatedPool = true
+							break
+						}
+
+						c.poolIDs = append(c.poolIDs, candidatePool.ID)
+						c.tokenOutDenoms = append(c.tokenOutDenoms, candidatePool.TokenOutDenom)
+					}
+
+					// Swapping back over the same pool cannot be profitable.
+					if containsUpdatedPool {
+						continue
+					}
+
+					key := c.key()
+					if _, ok := seenCycles[key]; ok {
+						continue
+					}
+					seenCycles[key] = struct{}{}
+
+					cycles = append(cycles, c)
+				}
+			}
+		}
+	}
+
+	return cycles
+}
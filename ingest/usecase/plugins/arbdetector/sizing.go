@@ -0,0 +1,176 @@
+package arbdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	arbdetectordomain "github.com/osmosis-labs/sqs/domain/arbdetector"
+)
+
+const (
+	// sizingLadderSteps is the number of amounts in, spaced geometrically
+	// between the configured min and max, that are simulated to bracket the optimum.
+	sizingLadderSteps = 8
+	// sizingRefinementIterations is the maximum number of ternary search iterations
+	// used to refine the optimum within the bracket.
+	sizingRefinementIterations = 16
+)
+
+var errGeneralizedCosmWasmPoolInCycle = errors.New("cycle contains generalized cosmwasm pool")
+
+// sizeCycle finds the amount in that maximizes the profit of the given cycle.
+//
+// The profit of swapping over a cycle of pools is a concave function of the amount in:
+// it grows while the price discrepancy dominates and decreases once the slippage dominates.
+// As a result, we first evaluate a geometric ladder of amounts to bracket the optimum,
+// and then refine it with a ternary search.
+//
+// Returns false if no profitable amount in is found.
+// Returns error if the price or scaling factor for the cycle's denom in is not found, or if
+// the cycle contains a generalized cosmwasm pool that requires network queries to simulate.
+func (a *arbDetectorIngestPlugin) sizeCycle(ctx context.Context, c cycle, prices domain.PricesResult) (arbdetectordomain.CyclicArbOpportunity, bool, error) {
+	price := prices.GetPriceForDenom(c.denomIn, a.defaultQuoteDenom)
+	if price.IsZero() {
+		return arbdetectordomain.CyclicArbOpportunity{}, false, fmt.Errorf("price not found for %s", c.denomIn)
+	}
+
+	scalingFactor, err := a.tokensUseCase.GetChainScalingFactorByDenomMut(c.denomIn)
+	if err != nil {
+		return arbdetectordomain.CyclicArbOpportunity{}, false, err
+	}
+
+	ladder, err := a.computeAmountInLadder(price, scalingFactor)
+	if err != nil {
+		return arbdetectordomain.CyclicArbOpportunity{}, false, err
+	}
+
+	// Evaluate the ladder, tracking the best amount in.
+	bestIndex := -1
+	bestProfit := osmomath.ZeroInt()
+	for i, amountIn := range ladder {
+		profit, ok, err := a.simulateCycle(ctx, c, amountIn)
+		if err != nil {
+			return arbdetectordomain.CyclicArbOpportunity{}, false, err
+		}
+
+		if ok && profit.GT(bestProfit) {
+			bestIndex = i
+			bestProfit = profit
+		}
+	}
+
+	if bestIndex == -1 {
+		return arbdetectordomain.CyclicArbOpportunity{}, false, nil
+	}
+
+	// Refine the optimum between the neighbours of the best ladder entry.
+	lo := ladder[max(bestIndex-1, 0)]
+	hi := ladder[min(bestIndex+1, len(ladder)-1)]
+	bestAmountIn := ladder[bestIndex]
+
+	for i := 0; i < sizingRefinementIterations; i++ {
+		third := hi.Sub(lo).QuoRaw(3)
+		if third.IsZero() {
+			break
+		}
+
+		m1 := lo.Add(third)
+		m2 := hi.Sub(third)
+
+		profit1, ok1, err := a.simulateCycle(ctx, c, m1)
+		if err != nil {
+			return arbdetectordomain.CyclicArbOpportunity{}, false, err
+		}
+
+		profit2, ok2, err := a.simulateCycle(ctx, c, m2)
+		if err != nil {
+			return arbdetectordomain.CyclicArbOpportunity{}, false, err
+		}
+
+		if ok1 && profit1.GT(bestProfit) {
+			bestProfit, bestAmountIn = profit1, m1
+		}
+		if ok2 && profit2.GT(bestProfit) {
+			bestProfit, bestAmountIn = profit2, m2
+		}
+
+		// Failing simulations at the larger amount are treated as exceeding the available liquidity.
+		if !ok2 || (ok1 && profit1.GT(profit2)) {
+			hi = m2
+		} else {
+			lo = m1
+		}
+	}
+
+	profitUSD := osmomath.BigDecFromSDKInt(bestProfit).MulMut(price).QuoMut(osmomath.BigDecFromDec(scalingFactor)).Dec()
+
+	return arbdetectordomain.CyclicArbOpportunity{
+		DenomIn:        c.denomIn,
+		PoolIDs:        c.poolIDs,
+		TokenOutDenoms: c.tokenOutDenoms,
+		AmountIn:       bestAmountIn,
+		AmountOut:      bestAmountIn.Add(bestProfit),
+		Profit:         bestProfit,
+		ProfitUSD:      profitUSD,
+	}, true, nil
+}
+
+// simulateCycle simulates swapping amountIn over the cycle and returns the profit in the cycle's denom in.
+// Returns false if the simulation fails, for example due to insufficient liquidity.
+// Returns error if the cycle contains a generalized cosmwasm pool.
+func (a *arbDetectorIngestPlugin) simulateCycle(ctx context.Context, c cycle, amountIn osmomath.Int) (osmomath.Int, bool, error) {
+	if !amountIn.IsPositive() {
+		return osmomath.Int{}, false, nil
+	}
+
+	quote, err := a.routerUseCase.GetCustomDirectQuoteMultiPool(ctx, sdk.NewCoin(c.denomIn, amountIn), c.tokenOutDenoms, c.poolIDs)
+	if err != nil {
+		return osmomath.Int{}, false, nil
+	}
+
+	for _, route := range quote.GetRoute() {
+		if route.ContainsGeneralizedCosmWasmPool() {
+			return osmomath.Int{}, false, errGeneralizedCosmWasmPoolInCycle
+		}
+	}
+
+	return quote.GetAmountOut().Sub(amountIn), true, nil
+}
+
+// computeAmountInLadder returns the amounts in, spaced geometrically between the configured
+// min and max amounts in, valued in the default quote denom.
+func (a *arbDetectorIngestPlugin) computeAmountInLadder(price osmomath.BigDec, scalingFactor osmomath.Dec) ([]osmomath.Int, error) {
+	minUSD, maxUSD := a.config.MinAmountInUSD, a.config.MaxAmountInUSD
+	if minUSD <= 0 || maxUSD < minUSD {
+		return nil, fmt.Errorf("invalid amount in range [%f, %f]", minUSD, maxUSD)
+	}
+
+	ratio := math.Pow(maxUSD/minUSD, 1/float64(sizingLadderSteps-1))
+
+	ladder := make([]osmomath.Int, 0, sizingLadderSteps)
+	valueUSD := minUSD
+	for i := 0; i < sizingLadderSteps; i++ {
+		valueUSDDec, err := osmomath.NewDecFromStr(formatFloat(valueUSD))
+		if err != nil {
+			return nil, err
+		}
+
+		amountIn := osmomath.BigDecFromDecMut(valueUSDDec.MulMut(scalingFactor)).QuoMut(price).Dec().TruncateInt()
+		ladder = append(ladder, amountIn)
+
+		valueUSD *= ratio
+	}
+
+	return ladder, nil
+}
+
+// formatFloat formats the given float for parsing into osmomath.Dec.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 6, 64)
+}
@@ -0,0 +1,235 @@
+package eventpublisher
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// eventPublisherIngestPlugin is a plugin that publishes structured events
+// about the state updated in a block to a sink at the end of every block.
+//
+// Pools observed for the first time are published as created and the rest as changed.
+// Taker fees are published in full at the first block and only the changed ones afterwards.
+//
+// Events are published at most once: the state is advanced even if publishing fails.
+// The ingest use case runs the plugins asynchronously, so the processing of consecutive blocks may overlap
+// and acquire the lock out of order. The blocks are processed one at a time, and a block at or below
+// the last processed height is dropped so that the events are published in height order.
+type eventPublisherIngestPlugin struct {
+	poolsUseCase     mvc.PoolsUsecase
+	tokensUseCase    mvc.TokensUsecase
+	routerRepository mvc.RouterRepository
+
+	sink eventpublisherdomain.Sink
+
+	config            domain.EventPublisherPluginConfig
+	defaultQuoteDenom string
+
+	// mx serializes the block processing, guarding lastHeight, knownPoolIDs and takerFees.
+	mx sync.Mutex
+	// lastHeight is the height of the latest processed block.
+	lastHeight   uint64
+	knownPoolIDs map[uint64]struct{}
+	takerFees    sqsdomain.TakerFeeMap
+
+	logger log.Logger
+}
+
+var _ domain.EndBlockProcessPlugin = &eventPublisherIngestPlugin{}
+
+const (
+	tracerName = "sqs-event-publisher"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+)
+
+// New returns a new block event publisher plugin publishing to the given sink.
+func New(poolsUseCase mvc.PoolsUsecase, tokensUseCase mvc.TokensUsecase, routerRepository mvc.RouterRepository, sink eventpublisherdomain.Sink, config domain.EventPublisherPluginConfig, defaultQuoteDenom string, logger log.Logger) *eventPublisherIngestPlugin {
+	return &eventPublisherIngestPlugin{
+		poolsUseCase:     poolsUseCase,
+		tokensUseCase:    tokensUseCase,
+		routerRepository: routerRepository,
+
+		sink: sink,
+
+		config:            config,
+		defaultQuoteDenom: defaultQuoteDenom,
+
+		knownPoolIDs: make(map[uint64]struct{}),
+
+		logger: logger,
+	}
+}
+
+// ProcessEndBlock implements domain.EndBlockProcessPlugin.
+func (e *eventPublisherIngestPlugin) ProcessEndBlock(ctx context.Context, blockHeight uint64, metadata domain.BlockPoolMetadata) error {
+	ctx, span := tracer.Start(ctx, "eventPublisherIngestPlugin.ProcessEndBlock")
+	defer span.End()
+
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if blockHeight <= e.lastHeight {
+		e.logger.Warn("dropping block events of out of order block", zap.Uint64("block_height", blockHeight), zap.Uint64("last_height", e.lastHeight))
+		return nil
+	}
+	e.lastHeight = blockHeight
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(e.config.PublishTimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	events, err := e.collectEvents(ctx, blockHeight, metadata)
+	if err != nil {
+		domain.SQSEventPublisherErrorCounter.Inc()
+		e.logger.Error("failed to collect block events", zap.Uint64("block_height", blockHeight), zap.Error(err))
+		return err
+	}
+
+	span.SetAttributes(attribute.Int("events", len(events)))
+
+	var publishErr error
+	for _, event := range events {
+		if err := e.publish(ctx, event); err != nil {
+			domain.SQSEventPublisherErrorCounter.Inc()
+			e.logger.Error("failed to publish block event", zap.Uint64("block_height", blockHeight), zap.String("type", string(event.Type)), zap.Error(err))
+
+			if publishErr == nil {
+				publishErr = err
+			}
+			continue
+		}
+
+		domain.SQSEventPublisherPublishedCounter.WithLabelValues(string(event.Type)).Inc()
+	}
+
+	return publishErr
+}
+
+// collectEvents collects the events for the block and advances the state.
+// CONTRACT: the caller holds mx.
+// Events are returned in the order of pools created, pools changed, prices and taker fees.
+// Events without payload are omitted.
+func (e *eventPublisherIngestPlugin) collectEvents(ctx context.Context, blockHeight uint64, metadata domain.BlockPoolMetadata) ([]domain.BlockEvent, error) {
+	events := make([]domain.BlockEvent, 0, 4)
+
+	if len(metadata.PoolIDs) > 0 {
+		poolIDs := make([]uint64, 0, len(metadata.PoolIDs))
+		for poolID := range metadata.PoolIDs {
+			poolIDs = append(poolIDs, poolID)
+		}
+
+		pools, err := e.poolsUseCase.GetPools(domain.WithPoolIDFilter(poolIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Slice(pools, func(i, j int) bool {
+			return pools[i].GetId() < pools[j].GetId()
+		})
+
+		createdPools := make([]sqsdomain.PoolI, 0)
+		changedPools := make([]sqsdomain.PoolI, 0, len(pools))
+		for _, pool := range pools {
+			if _, ok := e.knownPoolIDs[pool.GetId()]; ok {
+				changedPools = append(changedPools, pool)
+				continue
+			}
+
+			e.knownPoolIDs[pool.GetId()] = struct{}{}
+			createdPools = append(createdPools, pool)
+		}
+
+		if len(createdPools) > 0 {
+			events = append(events, domain.BlockEvent{
+				Height: blockHeight,
+				Type:   eventpublisherdomain.PoolsCreatedEventType,
+				Pools:  domain.NewPoolsResponse(createdPools),
+			})
+		}
+
+		if len(changedPools) > 0 {
+			events = append(events, domain.BlockEvent{
+				Height: blockHeight,
+				Type:   eventpublisherdomain.PoolsChangedEventType,
+				Pools:  domain.NewPoolsResponse(changedPools),
+			})
+		}
+	}
+
+	if len(metadata.UpdatedDenoms) > 0 {
+		updatedDenoms := make([]string, 0, len(metadata.UpdatedDenoms))
+		for denom := range metadata.UpdatedDenoms {
+			updatedDenoms = append(updatedDenoms, denom)
+		}
+
+		prices, err := e.tokensUseCase.GetPrices(ctx, updatedDenoms, []string{e.defaultQuoteDenom}, domain.ChainPricingSourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(prices) > 0 {
+			events = append(events, domain.BlockEvent{
+				Height: blockHeight,
+				Type:   eventpublisherdomain.PricesUpdatedEventType,
+				Prices: prices,
+			})
+		}
+	}
+
+	takerFees := e.routerRepository.GetAllTakerFees()
+
+	changedTakerFees := make([]sqsdomain.TakerFeeForPair, 0)
+	for denomPair, takerFee := range takerFees {
+		if previousTakerFee, ok := e.takerFees[denomPair]; ok && previousTakerFee.Equal(takerFee) {
+			continue
+		}
+
+		changedTakerFees = append(changedTakerFees, sqsdomain.TakerFeeForPair{
+			Denom0:   denomPair.Denom0,
+			Denom1:   denomPair.Denom1,
+			TakerFee: takerFee,
+		})
+	}
+	e.takerFees = takerFees
+
+	if len(changedTakerFees) > 0 {
+		sort.Slice(changedTakerFees, func(i, j int) bool {
+			if changedTakerFees[i].Denom0 != changedTakerFees[j].Denom0 {
+				return changedTakerFees[i].Denom0 < changedTakerFees[j].Denom0
+			}
+			return changedTakerFees[i].Denom1 < changedTakerFees[j].Denom1
+		})
+
+		events = append(events, domain.BlockEvent{
+			Height:    blockHeight,
+			Type:      eventpublisherdomain.TakerFeesUpdatedEventType,
+			TakerFees: changedTakerFees,
+		})
+	}
+
+	return events, nil
+}
+
+// publish serializes the event and publishes it to the subject of its type.
+func (e *eventPublisherIngestPlugin) publish(ctx context.Context, event domain.BlockEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return e.sink.Publish(ctx, e.config.SubjectPrefix+"."+string(event.Type), data)
+}
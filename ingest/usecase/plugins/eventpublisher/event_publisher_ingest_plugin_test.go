@@ -0,0 +1,447 @@
+package eventpublisher_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/eventpublisher"
+	"github.com/osmosis-labs/sqs/log"
+	routerrepo "github.com/osmosis-labs/sqs/router/repository"
+	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type EventPublisherTestSuite struct {
+	suite.Suite
+}
+
+const defaultHeight uint64 = 100
+
+var (
+	UOSMO = routertesting.UOSMO
+	USDC  = routertesting.USDC
+)
+
+func TestEventPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(EventPublisherTestSuite))
+}
+
+// publishedEvent is the subset of the published event used for assertions.
+// Pools are identified by their liquidity capitalization, set to 1000 * pool ID.
+type publishedEvent struct {
+	Height uint64                         `json:"height"`
+	Type   eventpublisherdomain.EventType `json:"type"`
+	Pools  []struct {
+		LiquidityCap string `json:"liquidity_cap"`
+	} `json:"pools"`
+	Prices    map[string]map[string]string `json:"prices"`
+	TakerFees []sqsdomain.TakerFeeForPair  `json:"taker_fees"`
+}
+
+func (s *EventPublisherTestSuite) TestProcessEndBlock() {
+	filePath := filepath.Join(s.T().TempDir(), "events.ndjson")
+
+	sink, err := eventpublisher.NewFileSink(filePath)
+	s.Require().NoError(err)
+
+	poolsUseCase := &mocks.PoolsUsecaseMock{
+		GetPoolsFunc: func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error) {
+			options := domain.PoolsOptions{}
+			for _, opt := range opts {
+				opt(&options)
+			}
+
+			pools := make([]sqsdomain.PoolI, 0, len(options.PoolIDFilter))
+			for _, poolID := range options.PoolIDFilter {
+				pools = append(pools, &mocks.MockRoutablePool{
+					ID:               poolID,
+					PoolLiquidityCap: osmomath.NewIntFromUint64(poolID * 1000),
+				})
+			}
+			return pools, nil
+		},
+	}
+
+	tokensUseCase := &mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			return domain.PricesResult{
+				UOSMO: {USDC: osmomath.NewBigDec(2)},
+			}, nil
+		},
+	}
+
+	routerRepository := routerrepo.New(&log.NoOpLogger{})
+	routerRepository.SetTakerFee(UOSMO, USDC, osmomath.MustNewDecFromStr("0.001"))
+
+	config := domain.DefaultEventPublisherPluginConfig
+	config.Enabled = true
+
+	plugin := eventpublisher.New(poolsUseCase, tokensUseCase, routerRepository, sink, config, USDC, &log.NoOpLogger{})
+
+	// First block: all pools are created and all taker fees are published.
+	err = plugin.ProcessEndBlock(context.TODO(), defaultHeight, domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{UOSMO: {}, USDC: {}},
+		PoolIDs:       map[uint64]struct{}{2: {}, 1: {}},
+	})
+	s.Require().NoError(err)
+
+	// Second block: pool 2 is changed, pool 3 is created, taker fees are unchanged.
+	err = plugin.ProcessEndBlock(context.TODO(), defaultHeight+1, domain.BlockPoolMetadata{
+		PoolIDs: map[uint64]struct{}{2: {}, 3: {}},
+	})
+	s.Require().NoError(err)
+
+	// Third block: only the taker fee changes.
+	routerRepository.SetTakerFee(UOSMO, USDC, osmomath.MustNewDecFromStr("0.002"))
+	err = plugin.ProcessEndBlock(context.TODO(), defaultHeight+2, domain.BlockPoolMetadata{})
+	s.Require().NoError(err)
+
+	s.Require().NoError(sink.Close())
+
+	data, err := os.ReadFile(filePath)
+	s.Require().NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	events := make([]publishedEvent, 0, len(lines))
+	for _, line := range lines {
+		var event publishedEvent
+		s.Require().NoError(json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+
+	type expectedEvent struct {
+		height        uint64
+		eventType     eventpublisherdomain.EventType
+		liquidityCaps []string
+		takerFee      string
+	}
+
+	expected := []expectedEvent{
+		{height: defaultHeight, eventType: eventpublisherdomain.PoolsCreatedEventType, liquidityCaps: []string{"1000", "2000"}},
+		{height: defaultHeight, eventType: eventpublisherdomain.PricesUpdatedEventType},
+		{height: defaultHeight, eventType: eventpublisherdomain.TakerFeesUpdatedEventType, takerFee: "0.001000000000000000"},
+		{height: defaultHeight + 1, eventType: eventpublisherdomain.PoolsCreatedEventType, liquidityCaps: []string{"3000"}},
+		{height: defaultHeight + 1, eventType: eventpublisherdomain.PoolsChangedEventType, liquidityCaps: []string{"2000"}},
+		{height: defaultHeight + 2, eventType: eventpublisherdomain.TakerFeesUpdatedEventType, takerFee: "0.002000000000000000"},
+	}
+
+	s.Require().Len(events, len(expected))
+	for i, expectedEvent := range expected {
+		event := events[i]
+
+		s.Require().Equal(expectedEvent.height, event.Height)
+		s.Require().Equal(expectedEvent.eventType, event.Type)
+
+		liquidityCaps := make([]string, 0, len(event.Pools))
+		for _, pool := range event.Pools {
+			liquidityCaps = append(liquidityCaps, pool.LiquidityCap)
+		}
+		if expectedEvent.liquidityCaps == nil {
+			s.Require().Empty(liquidityCaps)
+		} else {
+			s.Require().Equal(expectedEvent.liquidityCaps, liquidityCaps)
+		}
+
+		switch event.Type {
+		case eventpublisherdomain.PricesUpdatedEventType:
+			s.Require().Equal(osmomath.NewBigDec(2).String(), event.Prices[UOSMO][USDC])
+		case eventpublisherdomain.TakerFeesUpdatedEventType:
+			s.Require().Len(event.TakerFees, 1)
+			s.Require().Equal(expectedEvent.takerFee, event.TakerFees[0].TakerFee.String())
+		}
+	}
+}
+
+// Tests that the overlapping processing of blocks publishes the events in height order
+// and every created pool at most once.
+func (s *EventPublisherTestSuite) TestProcessEndBlock_Concurrent() {
+	filePath := filepath.Join(s.T().TempDir(), "events.ndjson")
+
+	sink, err := eventpublisher.NewFileSink(filePath)
+	s.Require().NoError(err)
+
+	plugin := s.newPoolsPlugin(sink)
+
+	const numBlocks = 20
+
+	var wg sync.WaitGroup
+	for i := uint64(0); i < numBlocks; i++ {
+		wg.Add(1)
+		go func(height uint64) {
+			defer wg.Done()
+
+			err := plugin.ProcessEndBlock(context.TODO(), height, domain.BlockPoolMetadata{
+				PoolIDs: map[uint64]struct{}{1: {}, height: {}},
+			})
+			s.Require().NoError(err)
+		}(defaultHeight + i)
+	}
+	wg.Wait()
+
+	s.Require().NoError(sink.Close())
+
+	createdCounts := map[string]int{}
+	var lastHeight uint64
+	for _, event := range s.readEvents(filePath) {
+		s.Require().GreaterOrEqual(event.Height, lastHeight, "event of height %d published after height %d", event.Height, lastHeight)
+		lastHeight = event.Height
+
+		if event.Type != eventpublisherdomain.PoolsCreatedEventType {
+			continue
+		}
+
+		for _, pool := range event.Pools {
+			createdCounts[pool.LiquidityCap]++
+		}
+	}
+
+	// Pool 1 is created by whichever block is processed first.
+	s.Require().Equal(1, createdCounts["1000"])
+	for liquidityCap, count := range createdCounts {
+		s.Require().Equal(1, count, "pool with liquidity cap %s created more than once", liquidityCap)
+	}
+}
+
+// Tests that a block at or below the last processed height is dropped.
+func (s *EventPublisherTestSuite) TestProcessEndBlock_OutOfOrder() {
+	filePath := filepath.Join(s.T().TempDir(), "events.ndjson")
+
+	sink, err := eventpublisher.NewFileSink(filePath)
+	s.Require().NoError(err)
+
+	plugin := s.newPoolsPlugin(sink)
+
+	for _, height := range []uint64{defaultHeight + 1, defaultHeight, defaultHeight + 1, defaultHeight + 2} {
+		err := plugin.ProcessEndBlock(context.TODO(), height, domain.BlockPoolMetadata{
+			PoolIDs: map[uint64]struct{}{height: {}},
+		})
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(sink.Close())
+
+	heights := make([]uint64, 0)
+	for _, event := range s.readEvents(filePath) {
+		if event.Type == eventpublisherdomain.PoolsCreatedEventType {
+			heights = append(heights, event.Height)
+		}
+	}
+
+	s.Require().Equal([]uint64{defaultHeight + 1, defaultHeight + 2}, heights)
+}
+
+// newPoolsPlugin returns a new event publisher plugin publishing to the given sink
+// the pools with the IDs updated in the block, with liquidity capitalization set to 1000 * pool ID.
+func (s *EventPublisherTestSuite) newPoolsPlugin(sink eventpublisherdomain.Sink) domain.EndBlockProcessPlugin {
+	poolsUseCase := &mocks.PoolsUsecaseMock{
+		GetPoolsFunc: func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error) {
+			options := domain.PoolsOptions{}
+			for _, opt := range opts {
+				opt(&options)
+			}
+
+			pools := make([]sqsdomain.PoolI, 0, len(options.PoolIDFilter))
+			for _, poolID := range options.PoolIDFilter {
+				pools = append(pools, &mocks.MockRoutablePool{ID: poolID, PoolLiquidityCap: osmomath.NewIntFromUint64(poolID * 1000)})
+			}
+			return pools, nil
+		},
+	}
+
+	routerRepository := routerrepo.New(&log.NoOpLogger{})
+	routerRepository.SetTakerFee(UOSMO, USDC, osmomath.MustNewDecFromStr("0.001"))
+
+	config := domain.DefaultEventPublisherPluginConfig
+	config.Enabled = true
+
+	return eventpublisher.New(poolsUseCase, &mocks.TokensUsecaseMock{}, routerRepository, sink, config, USDC, &log.NoOpLogger{})
+}
+
+// readEvents returns the events published to the file sink at the given path in order.
+func (s *EventPublisherTestSuite) readEvents(filePath string) []publishedEvent {
+	data, err := os.ReadFile(filePath)
+	s.Require().NoError(err)
+
+	events := make([]publishedEvent, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event publishedEvent
+		s.Require().NoError(json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func (s *EventPublisherTestSuite) TestNATSSink_Publish() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	type publishedMessage struct {
+		subject string
+		payload string
+	}
+
+	const (
+		deniedSubject = "sqs.events.denied"
+		slowSubject   = "sqs.events.slow"
+	)
+
+	messages := make(chan publishedMessage, 1)
+	serverErrs := make(chan error, 1)
+
+	// Mock NATS server rejecting the messages published to the denied subject.
+	// The connections are served one at a time since the sink reconnects after a rejection.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			if err := serveNATSConn(conn, deniedSubject, slowSubject, func(subject, payload string) {
+				messages <- publishedMessage{subject: subject, payload: payload}
+			}); err != nil {
+				serverErrs <- err
+				return
+			}
+		}
+	}()
+
+	sink, err := eventpublisher.NewNATSSink("nats://" + listener.Addr().String())
+	s.Require().NoError(err)
+	defer sink.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = sink.Publish(ctx, "sqs.events.pools-changed", []byte(`{"height":1}`))
+	s.Require().NoError(err)
+
+	select {
+	case message := <-messages:
+		s.Require().Equal("sqs.events.pools-changed", message.subject)
+		s.Require().Equal(`{"height":1}`, message.payload)
+	case err := <-serverErrs:
+		s.FailNow(err.Error())
+	case <-time.After(5 * time.Second):
+		s.FailNow("message was not published")
+	}
+
+	// Subjects with whitespace are rejected.
+	err = sink.Publish(ctx, "invalid subject", []byte(`{}`))
+	s.Require().Error(err)
+
+	// Server errors are returned.
+	err = sink.Publish(ctx, deniedSubject, []byte(`{"height":2}`))
+	s.Require().ErrorContains(err, "Permissions Violation")
+
+	// The sink reconnects after the error.
+	err = sink.Publish(ctx, "sqs.events.pools-changed", []byte(`{"height":3}`))
+	s.Require().NoError(err)
+
+	select {
+	case message := <-messages:
+		s.Require().Equal(`{"height":3}`, message.payload)
+	case err := <-serverErrs:
+		s.FailNow(err.Error())
+	case <-time.After(5 * time.Second):
+		s.FailNow("message was not published")
+	}
+
+	// A publish that is not confirmed in time fails.
+	slowCtx, slowCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer slowCancel()
+	err = sink.Publish(slowCtx, slowSubject, []byte(`{"height":4}`))
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+	<-messages
+
+	// The late reply to the slow publish does not confirm the next publish.
+	err = sink.Publish(ctx, deniedSubject, []byte(`{"height":5}`))
+	s.Require().ErrorContains(err, "Permissions Violation")
+}
+
+// serveNATSConn serves the connection as a NATS server until the client disconnects,
+// passing the published messages to the callback.
+// The messages published to the denied subject are rejected with a permissions violation.
+// The PING following a message published to the slow subject is replied to late.
+func serveNATSConn(conn net.Conn, deniedSubject, slowSubject string, onMessage func(subject, payload string)) error {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	isSlow := false
+
+	if _, err := conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n")); err != nil {
+		return err
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			if isSlow {
+				isSlow = false
+				time.AfterFunc(200*time.Millisecond, func() {
+					_, _ = conn.Write([]byte("PONG\r\n"))
+				})
+				continue
+			}
+
+			if _, err := conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case "PUB":
+			payload, err := reader.ReadString('\n')
+			if err != nil {
+				return err
+			}
+
+			if fields[1] == deniedSubject {
+				if _, err := conn.Write([]byte("-ERR 'Permissions Violation for Publish to " + deniedSubject + "'\r\n")); err != nil {
+					return err
+				}
+				continue
+			}
+
+			isSlow = fields[1] == slowSubject
+			onMessage(fields[1], strings.TrimSuffix(payload, "\r\n"))
+		}
+	}
+}
+
+func (s *EventPublisherTestSuite) TestNewSink_Invalid() {
+	config := domain.DefaultEventPublisherPluginConfig
+
+	config.Sink = "kafka"
+	_, err := eventpublisher.NewSink(config)
+	s.Require().Error(err)
+
+	config.Sink = eventpublisherdomain.NATSSinkType
+	config.NATSURL = "http://localhost:4222"
+	_, err = eventpublisher.NewSink(config)
+	s.Require().Error(err)
+}
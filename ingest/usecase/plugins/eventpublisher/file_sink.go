@@ -0,0 +1,53 @@
+package eventpublisher
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+)
+
+// fileSink appends every published payload as a line to a local file,
+// producing newline-delimited JSON. The subject is not recorded since
+// events carry their type. Intended for local testing.
+type fileSink struct {
+	mx   sync.Mutex
+	file *os.File
+}
+
+var _ eventpublisherdomain.Sink = &fileSink{}
+
+// NewFileSink returns a new file sink appending to the file at the given path.
+// The file is created if it does not exist.
+func NewFileSink(path string) (*fileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{
+		file: file,
+	}, nil
+}
+
+// Publish implements eventpublisherdomain.Sink.
+func (f *fileSink) Publish(ctx context.Context, subject string, data []byte) error {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	line := make([]byte, 0, len(data)+1)
+	line = append(line, data...)
+	line = append(line, '\n')
+
+	_, err := f.file.Write(line)
+	return err
+}
+
+// Close implements eventpublisherdomain.Sink.
+func (f *fileSink) Close() error {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	return f.file.Close()
+}
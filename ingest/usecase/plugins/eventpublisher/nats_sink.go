@@ -0,0 +1,341 @@
+package eventpublisher
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+)
+
+// natsSink publishes events to a NATS server over the NATS client protocol.
+//
+// It implements the subset of the protocol needed for publishing: the handshake,
+// PUB and keep-alive PING/PONG. Every PUB is followed by a PING so that the
+// server errors, such as permission violations, are returned by the publish
+// rather than ignored. The connection is established lazily and re-established
+// on the next publish after a failure.
+type natsSink struct {
+	address string
+
+	// mx serializes the publishes and guards the connection.
+	mx   sync.Mutex
+	conn *natsConn
+}
+
+// natsConn is an established NATS connection.
+//
+// The server replies to the PINGs in order, so the replies are matched to the publishes
+// by sequence: the n-th PONG confirms the publish followed by the n-th PING, and a -ERR
+// is attributed to the oldest unconfirmed PING. As a result, a late reply to a publish
+// that was not waited for is never taken as the confirmation of a later publish.
+type natsConn struct {
+	conn net.Conn
+
+	// writeMx guards the writer and pings, shared by the publishes and the keep-alive responses.
+	writeMx sync.Mutex
+	writer  *bufio.Writer
+	// pings is the number of PINGs written after the handshake.
+	pings uint64
+
+	// replyMx guards pongs and errs.
+	replyMx sync.Mutex
+	// pongs is the number of PONGs received after the handshake.
+	pongs uint64
+	// errs are the server errors by the sequence of the PING they were received before.
+	errs map[uint64]error
+	// replied is signaled on every reply.
+	replied chan struct{}
+	// done is closed once reading from the connection fails.
+	done chan struct{}
+}
+
+var _ eventpublisherdomain.Sink = &natsSink{}
+
+const (
+	natsDefaultPort    = "4222"
+	natsConnectTimeout = 5 * time.Second
+	natsReplyTimeout   = 5 * time.Second
+	natsConnectOptions = `{"verbose":false,"pedantic":false,"name":"sqs","lang":"go"}`
+)
+
+// NewNATSSink returns a new NATS sink for the server at the given URL.
+// Returns error if the URL is invalid.
+func NewNATSSink(natsURL string) (*natsSink, error) {
+	parsedURL, err := url.Parse(natsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsedURL.Scheme != "nats" || parsedURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS url %q, expected nats://host:port", natsURL)
+	}
+
+	port := parsedURL.Port()
+	if port == "" {
+		port = natsDefaultPort
+	}
+
+	return &natsSink{
+		address: net.JoinHostPort(parsedURL.Hostname(), port),
+	}, nil
+}
+
+// Publish implements eventpublisherdomain.Sink.
+// Returns error if the server rejects the message or does not confirm it in time.
+// The connection is closed on any error so that the next publish reconnects.
+func (n *natsSink) Publish(ctx context.Context, subject string, data []byte) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := n.conn.publish(ctx, subject, data); err != nil {
+		n.closeConn()
+		return err
+	}
+
+	return nil
+}
+
+// Close implements eventpublisherdomain.Sink.
+func (n *natsSink) Close() error {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	n.conn.writeMx.Lock()
+	err := n.conn.writer.Flush()
+	n.conn.writeMx.Unlock()
+
+	n.closeConn()
+	return err
+}
+
+// connect establishes the connection and performs the handshake.
+// On success, starts a goroutine reading the server replies and keep-alives.
+// CONTRACT: the caller holds the lock.
+func (n *natsSink) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: natsConnectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.address)
+	if err != nil {
+		return err
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(natsConnectTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	// The server greets with its INFO.
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	if !strings.HasPrefix(line, "INFO") {
+		conn.Close()
+		return fmt.Errorf("unexpected NATS greeting %q", strings.TrimSpace(line))
+	}
+
+	// The PING round trip confirms that the CONNECT was accepted.
+	writer.WriteString("CONNECT " + natsConnectOptions + "\r\nPING\r\n")
+	if err := writer.Flush(); err != nil {
+		conn.Close()
+		return err
+	}
+
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return fmt.Errorf("NATS handshake failed: %s", line)
+		}
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return err
+	}
+
+	n.conn = &natsConn{
+		conn:    conn,
+		writer:  writer,
+		errs:    make(map[uint64]error),
+		replied: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	go n.conn.readLoop(reader)
+
+	return nil
+}
+
+// closeConn closes the current connection.
+// CONTRACT: the caller holds the lock.
+func (n *natsSink) closeConn() {
+	if n.conn != nil {
+		n.conn.conn.Close()
+	}
+
+	n.conn = nil
+}
+
+// publish writes the message followed by a PING and waits for the server reply to that PING.
+// Returns the server error if the server replies with -ERR.
+func (c *natsConn) publish(ctx context.Context, subject string, data []byte) error {
+	var seq uint64
+	if err := c.write(ctx, func(w *bufio.Writer) {
+		fmt.Fprintf(w, "PUB %s %d\r\n", subject, len(data))
+		w.Write(data)
+		w.WriteString("\r\nPING\r\n")
+
+		c.pings++
+		seq = c.pings
+	}); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(natsReplyTimeout)
+	defer timer.Stop()
+
+	for {
+		if confirmed, err := c.confirmation(seq); confirmed {
+			return err
+		}
+
+		select {
+		case <-c.replied:
+		case <-c.done:
+			return errors.New("NATS connection closed before the publish was confirmed")
+		case <-timer.C:
+			return errors.New("NATS publish was not confirmed in time")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// confirmation returns true if the PING of the given sequence has been replied to,
+// along with the server error received before its PONG, if any.
+func (c *natsConn) confirmation(seq uint64) (bool, error) {
+	c.replyMx.Lock()
+	defer c.replyMx.Unlock()
+
+	if c.pongs < seq {
+		return false, nil
+	}
+
+	err := c.errs[seq]
+	delete(c.errs, seq)
+
+	return true, err
+}
+
+// write writes to the connection with the context deadline, if any.
+func (c *natsConn) write(ctx context.Context, writeFn func(w *bufio.Writer)) error {
+	c.writeMx.Lock()
+	defer c.writeMx.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	writeFn(c.writer)
+
+	if err := c.writer.Flush(); err != nil {
+		return err
+	}
+
+	// Clear the deadline so that it does not apply to the keep-alive responses.
+	return c.conn.SetWriteDeadline(time.Time{})
+}
+
+// readLoop responds to the server PINGs and forwards the replies to the publish PINGs
+// until reading from the connection fails.
+func (c *natsConn) readLoop(reader *bufio.Reader) {
+	defer close(c.done)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			c.conn.Close()
+			return
+		}
+
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PING":
+			if err := c.write(context.Background(), func(w *bufio.Writer) {
+				w.WriteString("PONG\r\n")
+			}); err != nil {
+				c.conn.Close()
+				return
+			}
+		case line == "PONG":
+			c.reply(func() {
+				c.pongs++
+				// Drop the errors of PINGs that were not waited for.
+				for seq := range c.errs {
+					if seq < c.pongs {
+						delete(c.errs, seq)
+					}
+				}
+			})
+		case strings.HasPrefix(line, "-ERR"):
+			err := fmt.Errorf("NATS publish failed: %s", line)
+			c.reply(func() {
+				// The first error before the PONG is kept.
+				if _, ok := c.errs[c.pongs+1]; !ok {
+					c.errs[c.pongs+1] = err
+				}
+			})
+		}
+	}
+}
+
+// reply records the reply under the lock and signals the waiting publish, if any.
+func (c *natsConn) reply(recordFn func()) {
+	c.replyMx.Lock()
+	recordFn()
+	c.replyMx.Unlock()
+
+	select {
+	case c.replied <- struct{}{}:
+	default:
+	}
+}
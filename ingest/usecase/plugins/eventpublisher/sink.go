@@ -0,0 +1,21 @@
+package eventpublisher
+
+import (
+	"fmt"
+
+	"github.com/osmosis-labs/sqs/domain"
+	eventpublisherdomain "github.com/osmosis-labs/sqs/domain/eventpublisher"
+)
+
+// NewSink returns a new sink of the configured type.
+// Returns error if the sink type is unsupported or the sink fails to be created.
+func NewSink(config domain.EventPublisherPluginConfig) (eventpublisherdomain.Sink, error) {
+	switch config.Sink {
+	case eventpublisherdomain.FileSinkType:
+		return NewFileSink(config.FilePath)
+	case eventpublisherdomain.NATSSinkType:
+		return NewNATSSink(config.NATSURL)
+	default:
+		return nil, fmt.Errorf("unsupported event publisher sink type %q", config.Sink)
+	}
+}
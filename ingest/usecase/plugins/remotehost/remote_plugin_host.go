@@ -0,0 +1,328 @@
+package remotehost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	"github.com/osmosis-labs/sqs/log"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+)
+
+// remotePlugin is an out-of-process plugin registered with the host.
+type remotePlugin struct {
+	name    string
+	address string
+
+	conn   *grpc.ClientConn
+	client prototypes.SQSPluginClient
+
+	// inFlight is true while the plugin is processing a block.
+	inFlight atomic.Bool
+	// consecutiveFailures is the number of consecutive failed end block calls.
+	consecutiveFailures atomic.Int64
+}
+
+// remotePluginHost is an end block plugin that forwards the end block notifications
+// to the out-of-process plugins registered over gRPC.
+//
+// Each plugin is called concurrently with a timeout, in the background. A plugin that
+// is still processing the previous block is skipped for the current one. As a result,
+// a slow plugin can neither delay block processing nor the other plugins.
+type remotePluginHost struct {
+	poolsUseCase mvc.PoolsUsecase
+	codec        codec.Codec
+
+	config domain.RemotePluginHostConfig
+
+	// plugins maps plugin names to *remotePlugin.
+	plugins    sync.Map
+	numPlugins atomic.Int64
+
+	logger log.Logger
+}
+
+var (
+	_ domain.EndBlockProcessPlugin  = &remotePluginHost{}
+	_ remoteplugindomain.PluginHost = &remotePluginHost{}
+)
+
+const (
+	tracerName = "sqs-remote-plugin-host"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+)
+
+// New returns a new out-of-process plugin host.
+func New(poolsUseCase mvc.PoolsUsecase, codec codec.Codec, config domain.RemotePluginHostConfig, logger log.Logger) *remotePluginHost {
+	return &remotePluginHost{
+		poolsUseCase: poolsUseCase,
+		codec:        codec,
+
+		config: config,
+
+		plugins: sync.Map{},
+
+		logger: logger,
+	}
+}
+
+// ProcessEndBlock implements domain.EndBlockProcessPlugin.
+// It returns as soon as the calls to the registered plugins are dispatched.
+func (h *remotePluginHost) ProcessEndBlock(ctx context.Context, blockHeight uint64, metadata domain.BlockPoolMetadata) error {
+	_, span := tracer.Start(ctx, "remotePluginHost.ProcessEndBlock")
+	defer span.End()
+
+	if h.numPlugins.Load() == 0 {
+		return nil
+	}
+
+	req := &prototypes.ProcessEndBlockRequest{
+		BlockHeight: blockHeight,
+		Metadata:    convertBlockPoolMetadata(metadata),
+		Pools:       make([]*prototypes.PoolData, 0, len(metadata.PoolIDs)),
+	}
+
+	for _, poolID := range req.Metadata.PoolIds {
+		poolData, err := h.GetPoolData([]uint64{poolID})
+		if err != nil {
+			h.logger.Error("failed to get pool data for remote plugins", zap.Uint64("pool_id", poolID), zap.Error(err))
+			continue
+		}
+
+		req.Pools = append(req.Pools, poolData...)
+	}
+
+	timeout := time.Duration(h.config.ProcessEndBlockTimeoutMs) * time.Millisecond
+
+	h.plugins.Range(func(_, value any) bool {
+		plugin, ok := value.(*remotePlugin)
+		if !ok {
+			return true
+		}
+
+		// Skip the plugin if it is still processing the previous block.
+		if !plugin.inFlight.CompareAndSwap(false, true) {
+			domain.SQSRemotePluginSkippedCounter.WithLabelValues(plugin.name).Inc()
+			h.logger.Info("remote plugin is still processing previous block", zap.String("plugin", plugin.name), zap.Uint64("block_height", blockHeight))
+			return true
+		}
+
+		go h.processEndBlock(plugin, req, timeout)
+
+		return true
+	})
+
+	return nil
+}
+
+// processEndBlock calls the end block processing of the given plugin with the given timeout.
+// Deregisters the plugin if it exceeds the configured number of consecutive failures.
+func (h *remotePluginHost) processEndBlock(plugin *remotePlugin, req *prototypes.ProcessEndBlockRequest, timeout time.Duration) {
+	defer plugin.inFlight.Store(false)
+
+	// Note that a new background context is used since the plugin calls are
+	// detached from the block processing.
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if _, err := plugin.client.ProcessEndBlock(ctx, req); err != nil {
+		domain.SQSRemotePluginProcessEndBlockErrorCounter.WithLabelValues(plugin.name).Inc()
+
+		failures := plugin.consecutiveFailures.Add(1)
+		h.logger.Error("remote plugin failed to process end block", zap.String("plugin", plugin.name), zap.Uint64("block_height", req.BlockHeight), zap.Int64("consecutive_failures", failures), zap.Error(err))
+
+		if h.config.MaxConsecutiveFailures > 0 && failures >= int64(h.config.MaxConsecutiveFailures) {
+			h.logger.Info("deregistering remote plugin due to consecutive failures", zap.String("plugin", plugin.name))
+			h.deregisterPlugin(plugin)
+		}
+
+		return
+	}
+
+	plugin.consecutiveFailures.Store(0)
+}
+
+// RegisterPlugin implements remoteplugindomain.PluginHost.
+func (h *remotePluginHost) RegisterPlugin(name, address string) error {
+	if name == "" {
+		return errors.New("plugin name cannot be empty")
+	}
+
+	if address == "" {
+		return errors.New("plugin address cannot be empty")
+	}
+
+	if len(h.config.AllowedPluginAddresses) > 0 && !slices.Contains(h.config.AllowedPluginAddresses, address) {
+		return remoteplugindomain.PluginAddressNotAllowedError{Address: address}
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+
+	plugin := &remotePlugin{
+		name:    name,
+		address: address,
+		conn:    conn,
+		client:  prototypes.NewSQSPluginClient(conn),
+	}
+
+	if previous, loaded := h.plugins.Swap(name, plugin); loaded {
+		h.closePlugin(previous.(*remotePlugin))
+	} else {
+		h.numPlugins.Add(1)
+	}
+
+	domain.SQSRemotePluginRegisteredGauge.Set(float64(h.numPlugins.Load()))
+
+	h.logger.Info("registered remote plugin", zap.String("plugin", name), zap.String("address", address))
+
+	return nil
+}
+
+// DeregisterPlugin implements remoteplugindomain.PluginHost.
+func (h *remotePluginHost) DeregisterPlugin(name string) error {
+	value, ok := h.plugins.Load(name)
+	if !ok {
+		return fmt.Errorf("plugin %s is not registered", name)
+	}
+
+	h.deregisterPlugin(value.(*remotePlugin))
+
+	h.logger.Info("deregistered remote plugin", zap.String("plugin", name))
+
+	return nil
+}
+
+// deregisterPlugin removes the given plugin, unless it has been replaced
+// by a new registration with the same name in the meantime.
+func (h *remotePluginHost) deregisterPlugin(plugin *remotePlugin) {
+	if !h.plugins.CompareAndDelete(plugin.name, plugin) {
+		return
+	}
+
+	h.numPlugins.Add(-1)
+	domain.SQSRemotePluginRegisteredGauge.Set(float64(h.numPlugins.Load()))
+
+	h.closePlugin(plugin)
+}
+
+// closePlugin closes the connection to the given plugin.
+func (h *remotePluginHost) closePlugin(plugin *remotePlugin) {
+	if err := plugin.conn.Close(); err != nil {
+		h.logger.Error("failed to close remote plugin connection", zap.String("plugin", plugin.name), zap.Error(err))
+	}
+}
+
+// GetPoolData implements remoteplugindomain.PluginHost.
+func (h *remotePluginHost) GetPoolData(poolIDs []uint64) ([]*prototypes.PoolData, error) {
+	poolData := make([]*prototypes.PoolData, 0, len(poolIDs))
+
+	for _, poolID := range poolIDs {
+		pool, err := h.poolsUseCase.GetPool(poolID)
+		if err != nil {
+			return nil, err
+		}
+
+		chainModel, err := h.codec.MarshalInterfaceJSON(pool.GetUnderlyingPool())
+		if err != nil {
+			return nil, err
+		}
+
+		sqsModel, err := json.Marshal(pool.GetSQSPoolModel())
+		if err != nil {
+			return nil, err
+		}
+
+		var tickModel []byte
+		if pool.GetType() == poolmanagertypes.Concentrated {
+			model, err := pool.GetTickModel()
+			if err != nil {
+				return nil, err
+			}
+
+			tickModel, err = json.Marshal(model)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		poolData = append(poolData, &prototypes.PoolData{
+			ChainModel: chainModel,
+			SqsModel:   sqsModel,
+			TickModel:  tickModel,
+		})
+	}
+
+	return poolData, nil
+}
+
+// convertBlockPoolMetadata converts the block pool metadata to its proto representation.
+// Denoms and pool IDs are sorted for deterministic output.
+func convertBlockPoolMetadata(metadata domain.BlockPoolMetadata) *prototypes.BlockPoolMetadata {
+	result := &prototypes.BlockPoolMetadata{
+		UpdatedDenoms:      make([]string, 0, len(metadata.UpdatedDenoms)),
+		PoolIds:            make([]uint64, 0, len(metadata.PoolIDs)),
+		DenomPoolLiquidity: make([]*prototypes.DenomPoolLiquidity, 0, len(metadata.DenomPoolLiquidityMap)),
+	}
+
+	for denom := range metadata.UpdatedDenoms {
+		result.UpdatedDenoms = append(result.UpdatedDenoms, denom)
+	}
+	sort.Strings(result.UpdatedDenoms)
+
+	for poolID := range metadata.PoolIDs {
+		result.PoolIds = append(result.PoolIds, poolID)
+	}
+	sort.Slice(result.PoolIds, func(i, j int) bool {
+		return result.PoolIds[i] < result.PoolIds[j]
+	})
+
+	for denom, liquidityData := range metadata.DenomPoolLiquidityMap {
+		denomPoolLiquidity := &prototypes.DenomPoolLiquidity{
+			Denom:          denom,
+			TotalLiquidity: liquidityData.TotalLiquidity.String(),
+			Pools:          make([]*prototypes.PoolLiquidity, 0, len(liquidityData.Pools)),
+		}
+
+		for poolID, liquidity := range liquidityData.Pools {
+			denomPoolLiquidity.Pools = append(denomPoolLiquidity.Pools, &prototypes.PoolLiquidity{
+				PoolId:    poolID,
+				Liquidity: liquidity.String(),
+			})
+		}
+
+		sort.Slice(denomPoolLiquidity.Pools, func(i, j int) bool {
+			return denomPoolLiquidity.Pools[i].PoolId < denomPoolLiquidity.Pools[j].PoolId
+		})
+
+		result.DenomPoolLiquidity = append(result.DenomPoolLiquidity, denomPoolLiquidity)
+	}
+
+	sort.Slice(result.DenomPoolLiquidity, func(i, j int) bool {
+		return result.DenomPoolLiquidity[i].Denom < result.DenomPoolLiquidity[j].Denom
+	})
+
+	return result
+}
@@ -0,0 +1,195 @@
+package remotehost_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	remoteplugindomain "github.com/osmosis-labs/sqs/domain/remoteplugin"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/remotehost"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+type RemotePluginHostTestSuite struct {
+	suite.Suite
+}
+
+const (
+	defaultPluginName        = "test-plugin"
+	defaultHeight     uint64 = 100
+	waitTimeout              = 5 * time.Second
+)
+
+var (
+	defaultMetadata = domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{"uosmo": {}, "uatom": {}},
+		PoolIDs:       map[uint64]struct{}{2: {}, 1: {}},
+		DenomPoolLiquidityMap: domain.DenomPoolLiquidityMap{
+			"uosmo": domain.DenomPoolLiquidityData{
+				TotalLiquidity: osmomath.NewInt(300),
+				Pools: map[uint64]osmomath.Int{
+					2: osmomath.NewInt(200),
+					1: osmomath.NewInt(100),
+				},
+			},
+		},
+	}
+)
+
+func TestRemotePluginHostTestSuite(t *testing.T) {
+	suite.Run(t, new(RemotePluginHostTestSuite))
+}
+
+// mockPluginServer is a SQSPlugin server that forwards the received requests
+// to a channel after the configured delay.
+type mockPluginServer struct {
+	prototypes.UnimplementedSQSPluginServer
+
+	delay    time.Duration
+	requests chan *prototypes.ProcessEndBlockRequest
+}
+
+// ProcessEndBlock implements types.SQSPluginServer.
+func (m *mockPluginServer) ProcessEndBlock(ctx context.Context, req *prototypes.ProcessEndBlockRequest) (*prototypes.ProcessEndBlockReply, error) {
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	m.requests <- req
+
+	return &prototypes.ProcessEndBlockReply{}, nil
+}
+
+// startPluginServer starts a mock plugin server and returns its address.
+func (s *RemotePluginHostTestSuite) startPluginServer(plugin *mockPluginServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	server := grpc.NewServer()
+	prototypes.RegisterSQSPluginServer(server, plugin)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	s.T().Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+// Validates that the registered plugin receives the end block metadata.
+func (s *RemotePluginHostTestSuite) TestProcessEndBlock() {
+	plugin := &mockPluginServer{requests: make(chan *prototypes.ProcessEndBlockRequest, 1)}
+	address := s.startPluginServer(plugin)
+
+	host := remotehost.New(&mocks.PoolsUsecaseMock{
+		GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+			return nil, errors.New("pool not found")
+		},
+	}, nil, domain.DefaultRemotePluginHostConfig, &log.NoOpLogger{})
+
+	s.Require().NoError(host.RegisterPlugin(defaultPluginName, address))
+
+	err := host.ProcessEndBlock(context.Background(), defaultHeight, defaultMetadata)
+	s.Require().NoError(err)
+
+	select {
+	case req := <-plugin.requests:
+		s.Require().Equal(defaultHeight, req.BlockHeight)
+		s.Require().Equal([]string{"uatom", "uosmo"}, req.Metadata.UpdatedDenoms)
+		s.Require().Equal([]uint64{1, 2}, req.Metadata.PoolIds)
+		s.Require().Len(req.Metadata.DenomPoolLiquidity, 1)
+
+		denomPoolLiquidity := req.Metadata.DenomPoolLiquidity[0]
+		s.Require().Equal("uosmo", denomPoolLiquidity.Denom)
+		s.Require().Equal("300", denomPoolLiquidity.TotalLiquidity)
+		s.Require().Len(denomPoolLiquidity.Pools, 2)
+		s.Require().Equal(uint64(1), denomPoolLiquidity.Pools[0].PoolId)
+		s.Require().Equal("100", denomPoolLiquidity.Pools[0].Liquidity)
+
+		// Pools failing to be retrieved are omitted.
+		s.Require().Empty(req.Pools)
+	case <-time.After(waitTimeout):
+		s.FailNow("plugin did not receive end block request")
+	}
+
+	// Deregistered plugins are not notified.
+	s.Require().NoError(host.DeregisterPlugin(defaultPluginName))
+	s.Require().Error(host.DeregisterPlugin(defaultPluginName))
+
+	err = host.ProcessEndBlock(context.Background(), defaultHeight+1, defaultMetadata)
+	s.Require().NoError(err)
+
+	select {
+	case <-plugin.requests:
+		s.FailNow("deregistered plugin received end block request")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// Validates that a slow plugin neither blocks the end block processing
+// nor receives blocks while still processing the previous one, and that it is
+// deregistered after exceeding the consecutive failure limit.
+func (s *RemotePluginHostTestSuite) TestProcessEndBlock_SlowPlugin() {
+	plugin := &mockPluginServer{delay: time.Hour, requests: make(chan *prototypes.ProcessEndBlockRequest, 1)}
+	address := s.startPluginServer(plugin)
+
+	config := domain.DefaultRemotePluginHostConfig
+	config.ProcessEndBlockTimeoutMs = 200
+	config.MaxConsecutiveFailures = 1
+
+	host := remotehost.New(&mocks.PoolsUsecaseMock{}, nil, config, &log.NoOpLogger{})
+	s.Require().NoError(host.RegisterPlugin(defaultPluginName, address))
+
+	skippedBefore := testutil.ToFloat64(domain.SQSRemotePluginSkippedCounter.WithLabelValues(defaultPluginName))
+
+	// Returns immediately despite the plugin never responding.
+	start := time.Now()
+	s.Require().NoError(host.ProcessEndBlock(context.Background(), defaultHeight, domain.BlockPoolMetadata{}))
+	s.Require().NoError(host.ProcessEndBlock(context.Background(), defaultHeight+1, domain.BlockPoolMetadata{}))
+	s.Require().Less(time.Since(start), time.Duration(config.ProcessEndBlockTimeoutMs)*time.Millisecond)
+
+	// The second block is skipped since the first one is still in flight.
+	s.Require().Equal(skippedBefore+1, testutil.ToFloat64(domain.SQSRemotePluginSkippedCounter.WithLabelValues(defaultPluginName)))
+
+	// The plugin is deregistered after timing out.
+	s.Require().Eventually(func() bool {
+		return testutil.ToFloat64(domain.SQSRemotePluginRegisteredGauge) == 0
+	}, waitTimeout, 50*time.Millisecond)
+	s.Require().Error(host.DeregisterPlugin(defaultPluginName))
+}
+
+// Validates registration input.
+func (s *RemotePluginHostTestSuite) TestRegisterPlugin_Invalid() {
+	host := remotehost.New(&mocks.PoolsUsecaseMock{}, nil, domain.DefaultRemotePluginHostConfig, &log.NoOpLogger{})
+
+	s.Require().Error(host.RegisterPlugin("", "localhost:1234"))
+	s.Require().Error(host.RegisterPlugin(defaultPluginName, ""))
+}
+
+// Validates that only the allowed plugin addresses can be registered.
+func (s *RemotePluginHostTestSuite) TestRegisterPlugin_NotAllowed() {
+	config := domain.DefaultRemotePluginHostConfig
+	config.AllowedPluginAddresses = []string{"localhost:1234"}
+
+	host := remotehost.New(&mocks.PoolsUsecaseMock{}, nil, config, &log.NoOpLogger{})
+
+	err := host.RegisterPlugin(defaultPluginName, "10.0.0.1:1234")
+	s.Require().ErrorAs(err, &remoteplugindomain.PluginAddressNotAllowedError{})
+
+	s.Require().NoError(host.RegisterPlugin(defaultPluginName, "localhost:1234"))
+	s.Require().NoError(host.DeregisterPlugin(defaultPluginName))
+}
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"sort"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+var oneHundred = osmomath.NewDec(100)
+
+// parsedRule is a configured rule with its threshold parsed at construction.
+type parsedRule struct {
+	webhookdomain.Rule
+	threshold osmomath.Dec
+}
+
+// newParsedRule returns the rule with its threshold parsed.
+// Returns an error if the threshold cannot be represented as a decimal.
+func newParsedRule(rule webhookdomain.Rule) (parsedRule, error) {
+	threshold, err := rule.Threshold()
+	if err != nil {
+		return parsedRule{}, err
+	}
+
+	return parsedRule{
+		Rule:      rule,
+		threshold: threshold,
+	}, nil
+}
+
+// blockState is the state that the rules are evaluated against.
+// It only contains the values updated in a block.
+type blockState struct {
+	prices             map[string]osmomath.BigDec
+	poolLiquidityCaps  map[uint64]osmomath.Int
+	poolDenoms         map[uint64][]string
+	denomLiquidityCaps map[string]osmomath.Int
+	takerFees          sqsdomain.TakerFeeMap
+}
+
+// newBlockState returns a new empty block state.
+func newBlockState() blockState {
+	return blockState{
+		prices:             make(map[string]osmomath.BigDec),
+		poolLiquidityCaps:  make(map[uint64]osmomath.Int),
+		poolDenoms:         make(map[uint64][]string),
+		denomLiquidityCaps: make(map[string]osmomath.Int),
+		takerFees:          sqsdomain.TakerFeeMap{},
+	}
+}
+
+// update updates the state with the values from the given block state.
+// Taker fees are replaced since they are always reported in full.
+func (s *blockState) update(current blockState) {
+	for denom, price := range current.prices {
+		s.prices[denom] = price
+	}
+
+	for poolID, liquidityCap := range current.poolLiquidityCaps {
+		s.poolLiquidityCaps[poolID] = liquidityCap
+		s.poolDenoms[poolID] = current.poolDenoms[poolID]
+	}
+
+	for denom, liquidityCap := range current.denomLiquidityCaps {
+		s.denomLiquidityCaps[denom] = liquidityCap
+	}
+
+	if len(current.takerFees) > 0 {
+		s.takerFees = current.takerFees
+	}
+}
+
+// evaluateRule evaluates the rule against the previous and current state.
+// Returns the fired alerts sorted by denom and pool ID.
+func evaluateRule(rule parsedRule, previous, current blockState) []webhookdomain.Alert {
+	denomFilter := make(map[string]struct{}, len(rule.Denoms))
+	for _, denom := range rule.Denoms {
+		denomFilter[denom] = struct{}{}
+	}
+
+	matchesDenoms := func(denoms ...string) bool {
+		if len(denomFilter) == 0 {
+			return true
+		}
+
+		for _, denom := range denoms {
+			if _, ok := denomFilter[denom]; ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	threshold := rule.threshold
+
+	alerts := make([]webhookdomain.Alert, 0)
+
+	switch rule.Type {
+	case webhookdomain.PoolLiquidityCapDropRuleType:
+		for poolID, currentCap := range current.poolLiquidityCaps {
+			previousCap, ok := previous.poolLiquidityCaps[poolID]
+			if !ok || !matchesDenoms(current.poolDenoms[poolID]...) {
+				continue
+			}
+
+			if changePercent, isDrop := computeDropPercent(previousCap, currentCap); isDrop && changePercent.Neg().GTE(threshold) {
+				alerts = append(alerts, newAlert(rule.Rule, "", poolID, previousCap.String(), currentCap.String(), changePercent))
+			}
+		}
+	case webhookdomain.DenomLiquidityCapDropRuleType:
+		for denom, currentCap := range current.denomLiquidityCaps {
+			previousCap, ok := previous.denomLiquidityCaps[denom]
+			if !ok || !matchesDenoms(denom) {
+				continue
+			}
+
+			if changePercent, isDrop := computeDropPercent(previousCap, currentCap); isDrop && changePercent.Neg().GTE(threshold) {
+				alerts = append(alerts, newAlert(rule.Rule, denom, 0, previousCap.String(), currentCap.String(), changePercent))
+			}
+		}
+	case webhookdomain.PriceMoveRuleType:
+		for denom, currentPrice := range current.prices {
+			previousPrice, ok := previous.prices[denom]
+			if !ok || previousPrice.IsZero() || currentPrice.IsZero() || !matchesDenoms(denom) {
+				continue
+			}
+
+			changePercent := currentPrice.Sub(previousPrice).QuoMut(previousPrice).MulMut(osmomath.BigDecFromDec(oneHundred)).Dec()
+			if changePercent.Abs().GTE(threshold) {
+				alerts = append(alerts, newAlert(rule.Rule, denom, 0, previousPrice.String(), currentPrice.String(), changePercent))
+			}
+		}
+	case webhookdomain.TakerFeeChangeRuleType:
+		for denomPair, currentFee := range current.takerFees {
+			previousFee, ok := previous.takerFees[denomPair]
+			if !ok || previousFee.Equal(currentFee) || !matchesDenoms(denomPair.Denom0, denomPair.Denom1) {
+				continue
+			}
+
+			changePercent := osmomath.ZeroDec()
+			if previousFee.IsPositive() {
+				changePercent = currentFee.Sub(previousFee).Quo(previousFee).MulMut(oneHundred)
+			}
+
+			alerts = append(alerts, newAlert(rule.Rule, denomPair.Denom0+"/"+denomPair.Denom1, 0, previousFee.String(), currentFee.String(), changePercent))
+		}
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Denom != alerts[j].Denom {
+			return alerts[i].Denom < alerts[j].Denom
+		}
+		return alerts[i].PoolID < alerts[j].PoolID
+	})
+
+	return alerts
+}
+
+// computeDropPercent returns the signed change in percent from previous to current
+// and true if the value dropped. Returns false if the previous value is not positive.
+func computeDropPercent(previous, current osmomath.Int) (osmomath.Dec, bool) {
+	if !previous.IsPositive() || current.GTE(previous) {
+		return osmomath.ZeroDec(), false
+	}
+
+	return current.Sub(previous).ToLegacyDec().QuoMut(previous.ToLegacyDec()).MulMut(oneHundred), true
+}
+
+// newAlert returns a new alert for the given rule.
+func newAlert(rule webhookdomain.Rule, denom string, poolID uint64, previous, current string, changePercent osmomath.Dec) webhookdomain.Alert {
+	return webhookdomain.Alert{
+		Rule:          rule.Name,
+		Type:          rule.Type,
+		Denom:         denom,
+		PoolID:        poolID,
+		Previous:      previous,
+		Current:       current,
+		ChangePercent: changePercent.String(),
+	}
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
+)
+
+// sender delivers notifications to the configured webhook URL.
+type sender struct {
+	client *http.Client
+
+	url          string
+	secret       string
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// retryableError is returned when the delivery attempt may succeed if retried.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+// newSender returns a new webhook sender.
+func newSender(url, secret string, timeout time.Duration, maxRetries int, retryBackoff time.Duration) *sender {
+	return &sender{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+
+		url:          url,
+		secret:       secret,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// send delivers the notification, retrying with exponential backoff on network errors,
+// 429 and 5xx responses. Other 4xx responses are not retried.
+// Returns error if the notification could not be delivered.
+func (s *sender) send(ctx context.Context, notification webhookdomain.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	signature := sign(s.secret, body)
+
+	backoff := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err = s.post(ctx, body, signature)
+		if err == nil {
+			return nil
+		}
+
+		if _, ok := err.(retryableError); !ok || attempt >= s.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery attempt.
+func (s *sender) post(ctx context.Context, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(webhookdomain.SignatureHeader, signature)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return retryableError{err: err}
+	}
+
+	return err
+}
+
+// sign returns the hex-encoded HMAC-SHA256 signature of the body keyed by the secret.
+// Returns empty string if the secret is empty.
+func sign(secret string, body []byte) string {
+	if secret == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
+	"github.com/osmosis-labs/sqs/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// webhookIngestPlugin is a plugin that evaluates the configured rules at the end of every block
+// and delivers the fired alerts to the configured webhook.
+//
+// Rules compare the values updated in the block against the last observed values.
+// The first observation of a value never fires an alert.
+type webhookIngestPlugin struct {
+	poolsUseCase     mvc.PoolsUsecase
+	tokensUseCase    mvc.TokensUsecase
+	routerRepository mvc.RouterRepository
+
+	config            domain.WebhookPluginConfig
+	rules             []parsedRule
+	defaultQuoteDenom string
+
+	sender *sender
+
+	// stateMx serializes the evaluation of blocks since end block plugins are run
+	// in their own goroutine per block.
+	stateMx sync.Mutex
+	state   blockState
+	// lastEvaluatedHeight is the height of the latest evaluated block.
+	// Blocks at or below it are dropped so that the state is never rolled back.
+	lastEvaluatedHeight uint64
+
+	logger log.Logger
+}
+
+var _ domain.EndBlockProcessPlugin = &webhookIngestPlugin{}
+
+const (
+	tracerName = "sqs-webhook"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+)
+
+// New returns a new webhook notification plugin.
+// Returns error if the URL or any of the rules are invalid.
+func New(poolsUseCase mvc.PoolsUsecase, tokensUseCase mvc.TokensUsecase, routerRepository mvc.RouterRepository, config domain.WebhookPluginConfig, defaultQuoteDenom string, logger log.Logger) (*webhookIngestPlugin, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := make([]parsedRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		parsed, err := newParsedRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, parsed)
+	}
+
+	return &webhookIngestPlugin{
+		poolsUseCase:     poolsUseCase,
+		tokensUseCase:    tokensUseCase,
+		routerRepository: routerRepository,
+
+		config:            config,
+		rules:             rules,
+		defaultQuoteDenom: defaultQuoteDenom,
+
+		sender: newSender(config.URL, config.Secret, time.Duration(config.TimeoutMs)*time.Millisecond, config.MaxRetries, time.Duration(config.RetryBackoffMs)*time.Millisecond),
+
+		state: newBlockState(),
+
+		logger: logger,
+	}, nil
+}
+
+// ProcessEndBlock implements domain.EndBlockProcessPlugin.
+func (w *webhookIngestPlugin) ProcessEndBlock(ctx context.Context, blockHeight uint64, metadata domain.BlockPoolMetadata) error {
+	ctx, span := tracer.Start(ctx, "webhookIngestPlugin.ProcessEndBlock")
+	defer span.End()
+
+	// Collect the block state under the lock so that the values are observed in the order the blocks are evaluated.
+	w.stateMx.Lock()
+	if lastEvaluatedHeight := w.lastEvaluatedHeight; blockHeight <= lastEvaluatedHeight {
+		w.stateMx.Unlock()
+		w.logger.Debug("dropping webhook evaluation of out of order block", zap.Uint64("block_height", blockHeight), zap.Uint64("last_evaluated_height", lastEvaluatedHeight))
+		return nil
+	}
+
+	current, err := w.collectBlockState(ctx, metadata)
+	if err != nil {
+		w.stateMx.Unlock()
+		w.logger.Error("failed to collect webhook block state", zap.Uint64("block_height", blockHeight), zap.Error(err))
+		return err
+	}
+
+	alerts := make([]webhookdomain.Alert, 0)
+	for _, rule := range w.rules {
+		alerts = append(alerts, evaluateRule(rule, w.state, current)...)
+	}
+	w.state.update(current)
+	w.lastEvaluatedHeight = blockHeight
+	w.stateMx.Unlock()
+
+	span.SetAttributes(attribute.Int("alerts", len(alerts)))
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	for _, alert := range alerts {
+		domain.SQSWebhookAlertsCounter.WithLabelValues(string(alert.Type)).Inc()
+	}
+
+	notification := webhookdomain.Notification{
+		Height: blockHeight,
+		Alerts: alerts,
+	}
+
+	// Deliver asynchronously so that a slow webhook does not block ingestion.
+	go func() {
+		if err := w.sender.send(context.Background(), notification); err != nil {
+			domain.SQSWebhookDeliveryErrorCounter.Inc()
+			w.logger.Error("failed to deliver webhook notification", zap.Uint64("block_height", blockHeight), zap.Int("alerts", len(alerts)), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// collectBlockState collects the values updated in the block that the rules are evaluated against.
+func (w *webhookIngestPlugin) collectBlockState(ctx context.Context, metadata domain.BlockPoolMetadata) (blockState, error) {
+	current := newBlockState()
+
+	updatedDenoms := make([]string, 0, len(metadata.UpdatedDenoms))
+	for denom := range metadata.UpdatedDenoms {
+		updatedDenoms = append(updatedDenoms, denom)
+	}
+
+	if w.hasRuleType(webhookdomain.PriceMoveRuleType) && len(updatedDenoms) > 0 {
+		prices, err := w.tokensUseCase.GetPrices(ctx, updatedDenoms, []string{w.defaultQuoteDenom}, domain.ChainPricingSourceType)
+		if err != nil {
+			return blockState{}, err
+		}
+
+		for denom, quotePrices := range prices {
+			if price, ok := quotePrices[w.defaultQuoteDenom]; ok {
+				current.prices[denom] = price
+			}
+		}
+	}
+
+	if w.hasRuleType(webhookdomain.DenomLiquidityCapDropRuleType) && len(updatedDenoms) > 0 {
+		for denom, denomMetadata := range w.tokensUseCase.GetPoolDenomsMetadata(updatedDenoms) {
+			current.denomLiquidityCaps[denom] = denomMetadata.TotalLiquidityCap
+		}
+	}
+
+	if w.hasRuleType(webhookdomain.PoolLiquidityCapDropRuleType) {
+		for poolID := range metadata.PoolIDs {
+			pool, err := w.poolsUseCase.GetPool(poolID)
+			if err != nil {
+				w.logger.Debug("failed to get pool for webhook rules", zap.Uint64("pool_id", poolID), zap.Error(err))
+				continue
+			}
+
+			current.poolLiquidityCaps[poolID] = pool.GetLiquidityCap()
+			current.poolDenoms[poolID] = pool.GetPoolDenoms()
+		}
+	}
+
+	if w.hasRuleType(webhookdomain.TakerFeeChangeRuleType) {
+		current.takerFees = w.routerRepository.GetAllTakerFees()
+	}
+
+	return current, nil
+}
+
+// hasRuleType returns true if any of the configured rules is of the given type.
+func (w *webhookIngestPlugin) hasRuleType(ruleType webhookdomain.RuleType) bool {
+	for _, rule := range w.config.Rules {
+		if rule.Type == ruleType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,492 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	webhookdomain "github.com/osmosis-labs/sqs/domain/webhook"
+	"github.com/osmosis-labs/sqs/ingest/usecase/plugins/webhook"
+	"github.com/osmosis-labs/sqs/log"
+	routerrepo "github.com/osmosis-labs/sqs/router/repository"
+	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+}
+
+const (
+	defaultPoolID uint64 = 1
+	defaultHeight uint64 = 100
+	testSecret           = "secret"
+)
+
+var (
+	UOSMO = routertesting.UOSMO
+	USDC  = routertesting.USDC
+	ATOM  = routertesting.ATOM
+)
+
+func TestWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+// blockValues are the values observed by the plugin at a block.
+type blockValues struct {
+	price             osmomath.BigDec
+	poolLiquidityCap  osmomath.Int
+	denomLiquidityCap osmomath.Int
+	takerFee          osmomath.Dec
+}
+
+// webhookServer is a test webhook server that records the received notifications.
+type webhookServer struct {
+	*httptest.Server
+
+	attempts      atomic.Int32
+	notifications chan webhookdomain.Notification
+}
+
+// newWebhookServer returns a new test webhook server that responds with the given
+// status codes in order and with 200 once they are exhausted.
+// Notifications with an invalid signature are rejected.
+func (s *WebhookTestSuite) newWebhookServer(statusCodes ...int) *webhookServer {
+	server := &webhookServer{
+		notifications: make(chan webhookdomain.Notification, 10),
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(server.attempts.Add(1))
+
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write(body)
+		if r.Header.Get(webhookdomain.SignatureHeader) != hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if attempt <= len(statusCodes) {
+			w.WriteHeader(statusCodes[attempt-1])
+			return
+		}
+
+		var notification webhookdomain.Notification
+		s.Require().NoError(json.Unmarshal(body, &notification))
+		server.notifications <- notification
+	}))
+
+	return server
+}
+
+// newPlugin returns a new webhook plugin backed by mocks returning the values
+// pointed to by current.
+func (s *WebhookTestSuite) newPlugin(url string, rules []webhookdomain.Rule, current *blockValues) domain.EndBlockProcessPlugin {
+	pool := &mocks.MockRoutablePool{
+		ID:     defaultPoolID,
+		Denoms: []string{UOSMO, USDC},
+	}
+
+	poolsUseCase := &mocks.PoolsUsecaseMock{
+		GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+			pool.PoolLiquidityCap = current.poolLiquidityCap
+			return pool, nil
+		},
+	}
+
+	tokensUseCase := &mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			return domain.PricesResult{
+				UOSMO: {USDC: current.price},
+			}, nil
+		},
+		GetPoolDenomsMetadataFunc: func(chainDenoms []string) domain.PoolDenomMetaDataMap {
+			return domain.PoolDenomMetaDataMap{
+				UOSMO: {TotalLiquidityCap: current.denomLiquidityCap},
+			}
+		},
+	}
+
+	routerRepository := routerrepo.New(&log.NoOpLogger{})
+
+	config := domain.DefaultWebhookPluginConfig
+	config.Enabled = true
+	config.URL = url
+	config.Secret = testSecret
+	config.RetryBackoffMs = 1
+	config.Rules = rules
+
+	plugin, err := webhook.New(poolsUseCase, tokensUseCase, &takerFeeRepository{RouterRepository: routerRepository, current: current}, config, USDC, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	return plugin
+}
+
+// takerFeeRepository wraps the router repository to return the taker fee pointed to by current.
+type takerFeeRepository struct {
+	routerrepo.RouterRepository
+
+	current *blockValues
+}
+
+func (r *takerFeeRepository) GetAllTakerFees() sqsdomain.TakerFeeMap {
+	return sqsdomain.TakerFeeMap{
+		sqsdomain.DenomPair{Denom0: UOSMO, Denom1: USDC}: r.current.takerFee,
+	}
+}
+
+func (s *WebhookTestSuite) TestProcessEndBlock() {
+	defaultValues := blockValues{
+		price:             osmomath.NewBigDec(10),
+		poolLiquidityCap:  osmomath.NewInt(1_000),
+		denomLiquidityCap: osmomath.NewInt(2_000),
+		takerFee:          osmomath.MustNewDecFromStr("0.001"),
+	}
+
+	tests := []struct {
+		name string
+
+		rule    webhookdomain.Rule
+		current blockValues
+
+		expectedAlert *webhookdomain.Alert
+	}{
+		{
+			name: "price move above threshold fires",
+			rule: webhookdomain.Rule{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 5},
+			current: func() blockValues {
+				v := defaultValues
+				v.price = osmomath.NewBigDec(9)
+				return v
+			}(),
+
+			expectedAlert: &webhookdomain.Alert{
+				Rule:          "osmo-price",
+				Type:          webhookdomain.PriceMoveRuleType,
+				Denom:         UOSMO,
+				Previous:      osmomath.NewBigDec(10).String(),
+				Current:       osmomath.NewBigDec(9).String(),
+				ChangePercent: osmomath.NewDec(-10).String(),
+			},
+		},
+		{
+			name: "price move below threshold does not fire",
+			rule: webhookdomain.Rule{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 15},
+			current: func() blockValues {
+				v := defaultValues
+				v.price = osmomath.NewBigDec(11)
+				return v
+			}(),
+		},
+		{
+			name: "price move below a threshold with more than six decimals does not fire",
+			rule: webhookdomain.Rule{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 0.0000002},
+			current: func() blockValues {
+				v := defaultValues
+				// 0.0000001% move
+				v.price = osmomath.NewBigDecWithPrec(1_000_000_001, 8)
+				return v
+			}(),
+		},
+		{
+			name: "price move for filtered out denom does not fire",
+			rule: webhookdomain.Rule{Name: "atom-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 5, Denoms: []string{ATOM}},
+			current: func() blockValues {
+				v := defaultValues
+				v.price = osmomath.NewBigDec(20)
+				return v
+			}(),
+		},
+		{
+			name: "pool liquidity cap drop fires",
+			rule: webhookdomain.Rule{Name: "pool-drop", Type: webhookdomain.PoolLiquidityCapDropRuleType, ThresholdPercent: 50, Denoms: []string{USDC}},
+			current: func() blockValues {
+				v := defaultValues
+				v.poolLiquidityCap = osmomath.NewInt(400)
+				return v
+			}(),
+
+			expectedAlert: &webhookdomain.Alert{
+				Rule:          "pool-drop",
+				Type:          webhookdomain.PoolLiquidityCapDropRuleType,
+				PoolID:        defaultPoolID,
+				Previous:      "1000",
+				Current:       "400",
+				ChangePercent: osmomath.NewDec(-60).String(),
+			},
+		},
+		{
+			name: "pool liquidity cap increase does not fire",
+			rule: webhookdomain.Rule{Name: "pool-drop", Type: webhookdomain.PoolLiquidityCapDropRuleType, ThresholdPercent: 50},
+			current: func() blockValues {
+				v := defaultValues
+				v.poolLiquidityCap = osmomath.NewInt(10_000)
+				return v
+			}(),
+		},
+		{
+			name: "denom liquidity cap drop fires",
+			rule: webhookdomain.Rule{Name: "denom-drop", Type: webhookdomain.DenomLiquidityCapDropRuleType, ThresholdPercent: 25},
+			current: func() blockValues {
+				v := defaultValues
+				v.denomLiquidityCap = osmomath.NewInt(1_500)
+				return v
+			}(),
+
+			expectedAlert: &webhookdomain.Alert{
+				Rule:          "denom-drop",
+				Type:          webhookdomain.DenomLiquidityCapDropRuleType,
+				Denom:         UOSMO,
+				Previous:      "2000",
+				Current:       "1500",
+				ChangePercent: osmomath.NewDec(-25).String(),
+			},
+		},
+		{
+			name: "taker fee change fires",
+			rule: webhookdomain.Rule{Name: "taker-fee", Type: webhookdomain.TakerFeeChangeRuleType},
+			current: func() blockValues {
+				v := defaultValues
+				v.takerFee = osmomath.MustNewDecFromStr("0.002")
+				return v
+			}(),
+
+			expectedAlert: &webhookdomain.Alert{
+				Rule:          "taker-fee",
+				Type:          webhookdomain.TakerFeeChangeRuleType,
+				Denom:         UOSMO + "/" + USDC,
+				Previous:      osmomath.MustNewDecFromStr("0.001").String(),
+				Current:       osmomath.MustNewDecFromStr("0.002").String(),
+				ChangePercent: osmomath.NewDec(100).String(),
+			},
+		},
+		{
+			name:    "unchanged taker fee does not fire",
+			rule:    webhookdomain.Rule{Name: "taker-fee", Type: webhookdomain.TakerFeeChangeRuleType},
+			current: defaultValues,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			server := s.newWebhookServer()
+			defer server.Close()
+
+			values := defaultValues
+			plugin := s.newPlugin(server.URL, []webhookdomain.Rule{tc.rule}, &values)
+
+			metadata := domain.BlockPoolMetadata{
+				UpdatedDenoms: map[string]struct{}{UOSMO: {}},
+				PoolIDs:       map[uint64]struct{}{defaultPoolID: {}},
+			}
+
+			alertsBefore := testutil.ToFloat64(domain.SQSWebhookAlertsCounter.WithLabelValues(string(tc.rule.Type)))
+
+			// The first observation never fires.
+			err := plugin.ProcessEndBlock(context.TODO(), defaultHeight, metadata)
+			s.Require().NoError(err)
+
+			values = tc.current
+
+			err = plugin.ProcessEndBlock(context.TODO(), defaultHeight+1, metadata)
+			s.Require().NoError(err)
+
+			alertsAfter := testutil.ToFloat64(domain.SQSWebhookAlertsCounter.WithLabelValues(string(tc.rule.Type)))
+
+			if tc.expectedAlert == nil {
+				s.Require().Equal(alertsBefore, alertsAfter)
+				return
+			}
+
+			s.Require().Equal(alertsBefore+1, alertsAfter)
+
+			select {
+			case notification := <-server.notifications:
+				s.Require().Equal(defaultHeight+1, notification.Height)
+				s.Require().Equal([]webhookdomain.Alert{*tc.expectedAlert}, notification.Alerts)
+			case <-time.After(5 * time.Second):
+				s.FailNow("notification was not delivered")
+			}
+		})
+	}
+}
+
+// Tests that blocks at or below the last evaluated height are dropped
+// so that a delayed block does not roll the observed values back.
+func (s *WebhookTestSuite) TestProcessEndBlock_OutOfOrder() {
+	server := s.newWebhookServer()
+	defer server.Close()
+
+	values := blockValues{price: osmomath.NewBigDec(10)}
+	plugin := s.newPlugin(server.URL, []webhookdomain.Rule{{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 1}}, &values)
+
+	metadata := domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{UOSMO: {}},
+	}
+
+	alertsBefore := testutil.ToFloat64(domain.SQSWebhookAlertsCounter.WithLabelValues(string(webhookdomain.PriceMoveRuleType)))
+
+	s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight+1, metadata))
+
+	// Neither the delayed block nor the same block again is evaluated.
+	values.price = osmomath.NewBigDec(20)
+	s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight, metadata))
+	s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight+1, metadata))
+	s.Require().Equal(alertsBefore, testutil.ToFloat64(domain.SQSWebhookAlertsCounter.WithLabelValues(string(webhookdomain.PriceMoveRuleType))))
+
+	// The next block is evaluated against the values of the last evaluated block.
+	values.price = osmomath.NewBigDec(30)
+	s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight+2, metadata))
+	s.Require().Equal(alertsBefore+1, testutil.ToFloat64(domain.SQSWebhookAlertsCounter.WithLabelValues(string(webhookdomain.PriceMoveRuleType))))
+
+	select {
+	case notification := <-server.notifications:
+		s.Require().Equal(defaultHeight+2, notification.Height)
+		s.Require().Len(notification.Alerts, 1)
+		s.Require().Equal(osmomath.NewBigDec(10).String(), notification.Alerts[0].Previous)
+		s.Require().Equal(osmomath.NewBigDec(30).String(), notification.Alerts[0].Current)
+	case <-time.After(5 * time.Second):
+		s.FailNow("notification was not delivered")
+	}
+}
+
+func (s *WebhookTestSuite) TestProcessEndBlock_Delivery() {
+	tests := []struct {
+		name string
+
+		statusCodes []int
+
+		expectedAttempts  int32
+		expectedDelivered bool
+	}{
+		{
+			name: "delivered on first attempt",
+
+			expectedAttempts:  1,
+			expectedDelivered: true,
+		},
+		{
+			name:        "retried on 5xx and 429",
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+
+			expectedAttempts:  3,
+			expectedDelivered: true,
+		},
+		{
+			name:        "not retried on 4xx",
+			statusCodes: []int{http.StatusBadRequest},
+
+			expectedAttempts: 1,
+		},
+		{
+			name:        "gives up after max retries",
+			statusCodes: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+
+			// 1 attempt + 3 retries by default.
+			expectedAttempts: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			server := s.newWebhookServer(tc.statusCodes...)
+			defer server.Close()
+
+			values := blockValues{price: osmomath.NewBigDec(10)}
+			plugin := s.newPlugin(server.URL, []webhookdomain.Rule{{Name: "osmo-price", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 1}}, &values)
+
+			metadata := domain.BlockPoolMetadata{
+				UpdatedDenoms: map[string]struct{}{UOSMO: {}},
+			}
+
+			deliveryErrorsBefore := testutil.ToFloat64(domain.SQSWebhookDeliveryErrorCounter)
+
+			s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight, metadata))
+			values.price = osmomath.NewBigDec(20)
+			s.Require().NoError(plugin.ProcessEndBlock(context.TODO(), defaultHeight+1, metadata))
+
+			if tc.expectedDelivered {
+				select {
+				case <-server.notifications:
+				case <-time.After(5 * time.Second):
+					s.FailNow("notification was not delivered")
+				}
+			} else {
+				s.Require().Eventually(func() bool {
+					return testutil.ToFloat64(domain.SQSWebhookDeliveryErrorCounter) == deliveryErrorsBefore+1
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+
+			s.Require().Equal(tc.expectedAttempts, server.attempts.Load())
+		})
+	}
+}
+
+func (s *WebhookTestSuite) TestNew_Invalid() {
+	tests := []struct {
+		name string
+
+		url   string
+		rules []webhookdomain.Rule
+	}{
+		{
+			name: "invalid url",
+			url:  "not a url",
+		},
+		{
+			name:  "unsupported rule type",
+			url:   "http://localhost",
+			rules: []webhookdomain.Rule{{Name: "rule", Type: "unknown"}},
+		},
+		{
+			name:  "non-positive threshold",
+			url:   "http://localhost",
+			rules: []webhookdomain.Rule{{Name: "rule", Type: webhookdomain.PriceMoveRuleType}},
+		},
+		{
+			name:  "threshold with more decimals than supported",
+			url:   "http://localhost",
+			rules: []webhookdomain.Rule{{Name: "rule", Type: webhookdomain.PriceMoveRuleType, ThresholdPercent: 1e-19}},
+		},
+		{
+			name: "duplicate rule name",
+			url:  "http://localhost",
+			rules: []webhookdomain.Rule{
+				{Name: "rule", Type: webhookdomain.TakerFeeChangeRuleType},
+				{Name: "rule", Type: webhookdomain.TakerFeeChangeRuleType},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			config := domain.DefaultWebhookPluginConfig
+			config.URL = tc.url
+			config.Rules = tc.rules
+
+			_, err := webhook.New(&mocks.PoolsUsecaseMock{}, &mocks.TokensUsecaseMock{}, routerrepo.New(&log.NoOpLogger{}), config, USDC, &log.NoOpLogger{})
+			s.Require().Error(err)
+		})
+	}
+}
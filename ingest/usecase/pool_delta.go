@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
+	"github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+// tickRange identifies a tick range of a concentrated pool.
+type tickRange struct {
+	lowerTick int64
+	upperTick int64
+}
+
+// ApplyPoolDeltas implements mvc.IngestUsecase.
+func (p *ingestUseCase) ApplyPoolDeltas(req *types.ProcessBlockRequest) (map[uint64]sqsdomain.PoolI, error) {
+	version := req.Version
+	if version == 0 {
+		version = sqsdomain.IngestProtocolVersionFull
+	}
+
+	if version > sqsdomain.LatestIngestProtocolVersion {
+		return nil, domain.UnsupportedIngestProtocolVersionError{Version: version, LatestVersion: sqsdomain.LatestIngestProtocolVersion}
+	}
+
+	if version < sqsdomain.IngestProtocolVersionDelta && (len(req.PoolDeltas) > 0 || len(req.RemovedPoolIds) > 0) {
+		return nil, fmt.Errorf("pool deltas require ingest protocol version (%d), got (%d)", sqsdomain.IngestProtocolVersionDelta, version)
+	}
+
+	pools, fullPoolData := p.parsePools(req.Pools)
+
+	p.poolDataMx.Lock()
+	defer p.poolDataMx.Unlock()
+
+	// The encoded pool data is only needed to apply the deltas onto.
+	// Nodes sending the full pools do not need it to be retained.
+	if version < sqsdomain.IngestProtocolVersionDelta {
+		if len(p.poolData) > 0 {
+			p.poolData = make(map[uint64]*types.PoolData)
+		}
+
+		return pools, nil
+	}
+
+	// Stage the updates so that the stored pool data is left untouched if any delta fails to apply.
+	updatedPoolData := fullPoolData
+	deltaPoolData := make([]*types.PoolData, 0, len(req.PoolDeltas))
+
+	for _, delta := range req.PoolDeltas {
+		previousPoolData, ok := updatedPoolData[delta.PoolId]
+		if !ok {
+			previousPoolData, ok = p.poolData[delta.PoolId]
+			if !ok {
+				return nil, domain.PoolDeltaUnknownPoolError{PoolID: delta.PoolId}
+			}
+		}
+
+		poolData, err := applyPoolDelta(previousPoolData, delta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply delta to pool (%d): %w", delta.PoolId, err)
+		}
+
+		checksum := sqsdomain.PoolDataChecksum(poolData.ChainModel, poolData.SqsModel, poolData.TickModel)
+		if !bytes.Equal(checksum, delta.Checksum) {
+			return nil, domain.PoolDeltaChecksumMismatchError{
+				PoolID:           delta.PoolId,
+				ExpectedChecksum: hex.EncodeToString(delta.Checksum),
+				ActualChecksum:   hex.EncodeToString(checksum),
+			}
+		}
+
+		updatedPoolData[delta.PoolId] = poolData
+		deltaPoolData = append(deltaPoolData, poolData)
+
+		// Superseded by the pool resulting from the delta.
+		delete(pools, delta.PoolId)
+	}
+
+	// Parse the pools resulting from the deltas.
+	deltaPools, _ := p.parsePools(deltaPoolData)
+	for poolID, pool := range deltaPools {
+		pools[poolID] = pool
+	}
+
+	// Commit the updates.
+	for poolID, poolData := range updatedPoolData {
+		p.poolData[poolID] = poolData
+	}
+
+	for _, poolID := range req.RemovedPoolIds {
+		delete(p.poolData, poolID)
+	}
+
+	return pools, nil
+}
+
+// applyPoolDelta returns the pool data resulting from applying the delta onto the given pool data.
+// The given pool data is not mutated.
+func applyPoolDelta(poolData *types.PoolData, delta *types.PoolDelta) (*types.PoolData, error) {
+	result := &types.PoolData{
+		ChainModel: poolData.ChainModel,
+		SqsModel:   poolData.SqsModel,
+		TickModel:  poolData.TickModel,
+	}
+
+	if len(delta.ChainModel) > 0 {
+		result.ChainModel = delta.ChainModel
+	}
+
+	if len(delta.Balances) > 0 || delta.PoolLiquidityCap != "" {
+		sqsModel, err := applySQSModelDelta(poolData.SqsModel, delta)
+		if err != nil {
+			return nil, err
+		}
+
+		result.SqsModel = sqsModel
+	}
+
+	if len(delta.TickUpdates) > 0 || delta.HasTickState {
+		tickModel, err := applyTickModelDelta(poolData.TickModel, delta)
+		if err != nil {
+			return nil, err
+		}
+
+		result.TickModel = tickModel
+	}
+
+	return result, nil
+}
+
+// applySQSModelDelta applies the balances and liquidity capitalization updates of the delta
+// onto the encoded SQS model and returns the encoded result.
+func applySQSModelDelta(sqsModelBz []byte, delta *types.PoolDelta) ([]byte, error) {
+	var sqsModel sqsdomain.SQSPool
+	if err := json.Unmarshal(sqsModelBz, &sqsModel); err != nil {
+		return nil, err
+	}
+
+	if len(delta.Balances) > 0 {
+		sqsModel.Balances = nil
+		if err := json.Unmarshal(delta.Balances, &sqsModel.Balances); err != nil {
+			return nil, err
+		}
+	}
+
+	if delta.PoolLiquidityCap != "" {
+		poolLiquidityCap, ok := osmomath.NewIntFromString(delta.PoolLiquidityCap)
+		if !ok {
+			return nil, fmt.Errorf("invalid pool liquidity cap (%s)", delta.PoolLiquidityCap)
+		}
+
+		sqsModel.PoolLiquidityCap = poolLiquidityCap
+		sqsModel.PoolLiquidityCapError = delta.PoolLiquidityCapError
+	}
+
+	return json.Marshal(sqsModel)
+}
+
+// applyTickModelDelta applies the tick updates and tick state of the delta
+// onto the encoded tick model and returns the encoded result.
+// Updated ranges overwrite the liquidity of the existing ranges with the same bounds
+// or are inserted if absent. Ranges with empty liquidity are removed.
+func applyTickModelDelta(tickModelBz []byte, delta *types.PoolDelta) ([]byte, error) {
+	tickModel := sqsdomain.TickModel{}
+	if len(tickModelBz) > 0 {
+		if err := json.Unmarshal(tickModelBz, &tickModel); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(delta.TickUpdates) > 0 {
+		ticks := make(map[tickRange]sqsdomain.LiquidityDepthsWithRange, len(tickModel.Ticks)+len(delta.TickUpdates))
+		for _, tick := range tickModel.Ticks {
+			ticks[tickRange{lowerTick: tick.LowerTick, upperTick: tick.UpperTick}] = tick
+		}
+
+		for _, update := range delta.TickUpdates {
+			key := tickRange{lowerTick: update.LowerTick, upperTick: update.UpperTick}
+
+			if update.LiquidityAmount == "" {
+				delete(ticks, key)
+				continue
+			}
+
+			liquidityAmount, err := osmomath.NewDecFromStr(update.LiquidityAmount)
+			if err != nil {
+				return nil, fmt.Errorf("invalid liquidity amount (%s) for tick range [%d, %d): %w", update.LiquidityAmount, update.LowerTick, update.UpperTick, err)
+			}
+
+			ticks[key] = sqsdomain.LiquidityDepthsWithRange{
+				LowerTick:       update.LowerTick,
+				UpperTick:       update.UpperTick,
+				LiquidityAmount: liquidityAmount,
+			}
+		}
+
+		tickModel.Ticks = make([]sqsdomain.LiquidityDepthsWithRange, 0, len(ticks))
+		for _, tick := range ticks {
+			tickModel.Ticks = append(tickModel.Ticks, tick)
+		}
+
+		// Ticks are ordered by their range as returned by the node.
+		sort.Slice(tickModel.Ticks, func(i, j int) bool {
+			if tickModel.Ticks[i].LowerTick != tickModel.Ticks[j].LowerTick {
+				return tickModel.Ticks[i].LowerTick < tickModel.Ticks[j].LowerTick
+			}
+			return tickModel.Ticks[i].UpperTick < tickModel.Ticks[j].UpperTick
+		})
+	}
+
+	if delta.HasTickState {
+		tickModel.CurrentTickIndex = delta.CurrentTickIndex
+		tickModel.HasNoLiquidity = delta.HasNoLiquidity
+	}
+
+	return json.Marshal(tickModel)
+}
@@ -0,0 +1,454 @@
+package usecase_test
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v25/app"
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
+	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/ingest/usecase"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
+	"github.com/osmosis-labs/sqs/sqsdomain/proto/types"
+)
+
+const (
+	balancerPoolID     uint64 = 1
+	concentratedPoolID uint64 = 2
+)
+
+var (
+	encodingConfig = app.MakeEncodingConfig()
+
+	defaultTickModel = &sqsdomain.TickModel{
+		Ticks: []sqsdomain.LiquidityDepthsWithRange{
+			{LowerTick: -100, UpperTick: 0, LiquidityAmount: osmomath.NewDec(10)},
+			{LowerTick: 0, UpperTick: 100, LiquidityAmount: osmomath.NewDec(20)},
+		},
+		CurrentTickIndex: 1,
+	}
+)
+
+// Tests that pool deltas are applied onto the pool data ingested previously
+// and that the stored pool data is left untouched on failure.
+func (s *IngestUseCaseTestSuite) TestApplyPoolDeltas() {
+	balancerPool := s.newBalancerPool()
+	concentratedPool := s.newConcentratedPool()
+
+	defaultSQSModel := sqsdomain.SQSPool{
+		PoolLiquidityCap: osmomath.NewInt(1_000),
+		Balances:         sdk.NewCoins(defaultUOSMOBalance, defaultUSDCBalance),
+		PoolDenoms:       []string{UOSMO, USDC},
+		SpreadFactor:     osmomath.ZeroDec(),
+	}
+
+	updatedBalances := sdk.NewCoins(defaultUOSMOBalance.AddAmount(oneInt), defaultUSDCBalance)
+	updatedSQSModel := defaultSQSModel
+	updatedSQSModel.Balances = updatedBalances
+	updatedSQSModel.PoolLiquidityCap = osmomath.NewInt(2_000)
+	updatedSQSModel.PoolLiquidityCapError = "price not found"
+
+	updatedTickModel := &sqsdomain.TickModel{
+		Ticks: []sqsdomain.LiquidityDepthsWithRange{
+			{LowerTick: -200, UpperTick: -100, LiquidityAmount: osmomath.NewDec(5)},
+			{LowerTick: 0, UpperTick: 100, LiquidityAmount: osmomath.NewDec(25)},
+		},
+		CurrentTickIndex: -150,
+	}
+
+	initialBalancerData := s.encodePoolData(balancerPool, defaultSQSModel, nil)
+	initialConcentratedData := s.encodePoolData(concentratedPool, defaultSQSModel, defaultTickModel)
+
+	updatedBalancerData := s.encodePoolData(balancerPool, updatedSQSModel, nil)
+	updatedConcentratedData := s.encodePoolData(concentratedPool, defaultSQSModel, updatedTickModel)
+
+	balancesDelta := &types.PoolDelta{
+		PoolId:                balancerPoolID,
+		Balances:              s.marshal(updatedBalances),
+		PoolLiquidityCap:      updatedSQSModel.PoolLiquidityCap.String(),
+		PoolLiquidityCapError: updatedSQSModel.PoolLiquidityCapError,
+		Checksum:              checksum(updatedBalancerData),
+	}
+
+	tickDelta := &types.PoolDelta{
+		PoolId: concentratedPoolID,
+		TickUpdates: []*types.TickRangeUpdate{
+			// Removed.
+			{LowerTick: -100, UpperTick: 0},
+			// Updated.
+			{LowerTick: 0, UpperTick: 100, LiquidityAmount: "25"},
+			// Inserted.
+			{LowerTick: -200, UpperTick: -100, LiquidityAmount: "5"},
+		},
+		CurrentTickIndex: -150,
+		HasTickState:     true,
+		Checksum:         checksum(updatedConcentratedData),
+	}
+
+	fullBlock := &types.ProcessBlockRequest{
+		BlockHeight: 1,
+		Pools:       []*types.PoolData{initialBalancerData, initialConcentratedData},
+	}
+
+	initialBlock := &types.ProcessBlockRequest{
+		BlockHeight: 1,
+		Pools:       []*types.PoolData{initialBalancerData, initialConcentratedData},
+		Version:     sqsdomain.IngestProtocolVersionDelta,
+	}
+
+	tests := []struct {
+		name string
+
+		requests []*types.ProcessBlockRequest
+
+		expectedPoolData map[uint64]*types.PoolData
+		expectedErr      error
+	}{
+		{
+			name: "version 1, full pools are parsed",
+
+			requests: []*types.ProcessBlockRequest{fullBlock},
+
+			expectedPoolData: map[uint64]*types.PoolData{balancerPoolID: initialBalancerData, concentratedPoolID: initialConcentratedData},
+		},
+		{
+			name: "version 1, pool data is not retained",
+
+			requests: []*types.ProcessBlockRequest{
+				fullBlock,
+				{
+					BlockHeight: 2,
+					PoolDeltas:  []*types.PoolDelta{balancesDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedErr: domain.PoolDeltaUnknownPoolError{PoolID: balancerPoolID},
+		},
+		{
+			name: "version 2, full pools are parsed",
+
+			requests: []*types.ProcessBlockRequest{initialBlock},
+
+			expectedPoolData: map[uint64]*types.PoolData{balancerPoolID: initialBalancerData, concentratedPoolID: initialConcentratedData},
+		},
+		{
+			name: "balances and liquidity cap delta",
+
+			requests: []*types.ProcessBlockRequest{
+				initialBlock,
+				{
+					BlockHeight: 2,
+					PoolDeltas:  []*types.PoolDelta{balancesDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedPoolData: map[uint64]*types.PoolData{balancerPoolID: updatedBalancerData},
+		},
+		{
+			name: "tick updates delta",
+
+			requests: []*types.ProcessBlockRequest{
+				initialBlock,
+				{
+					BlockHeight: 2,
+					PoolDeltas:  []*types.PoolDelta{tickDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedPoolData: map[uint64]*types.PoolData{concentratedPoolID: updatedConcentratedData},
+		},
+		{
+			name: "delta of a pool created in the same block",
+
+			requests: []*types.ProcessBlockRequest{
+				{
+					BlockHeight: 1,
+					Pools:       []*types.PoolData{initialBalancerData},
+					PoolDeltas:  []*types.PoolDelta{balancesDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedPoolData: map[uint64]*types.PoolData{balancerPoolID: updatedBalancerData},
+		},
+		{
+			name: "checksum mismatch, stored pool data is left untouched",
+
+			requests: []*types.ProcessBlockRequest{
+				initialBlock,
+				{
+					BlockHeight: 2,
+					PoolDeltas: []*types.PoolDelta{
+						tickDelta,
+						{
+							PoolId:   balancerPoolID,
+							Balances: s.marshal(updatedBalances),
+							Checksum: checksum(updatedBalancerData),
+						},
+					},
+					Version: sqsdomain.IngestProtocolVersionDelta,
+				},
+				{
+					BlockHeight: 2,
+					PoolDeltas:  []*types.PoolDelta{tickDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedPoolData: map[uint64]*types.PoolData{concentratedPoolID: updatedConcentratedData},
+		},
+		{
+			name: "delta of an unknown pool",
+
+			requests: []*types.ProcessBlockRequest{
+				{
+					BlockHeight: 1,
+					PoolDeltas:  []*types.PoolDelta{balancesDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedErr: domain.PoolDeltaUnknownPoolError{PoolID: balancerPoolID},
+		},
+		{
+			name: "delta of a removed pool",
+
+			requests: []*types.ProcessBlockRequest{
+				initialBlock,
+				{
+					BlockHeight:    2,
+					RemovedPoolIds: []uint64{balancerPoolID},
+					Version:        sqsdomain.IngestProtocolVersionDelta,
+				},
+				{
+					BlockHeight: 3,
+					PoolDeltas:  []*types.PoolDelta{balancesDelta},
+					Version:     sqsdomain.IngestProtocolVersionDelta,
+				},
+			},
+
+			expectedErr: domain.PoolDeltaUnknownPoolError{PoolID: balancerPoolID},
+		},
+		{
+			name: "unsupported version",
+
+			requests: []*types.ProcessBlockRequest{
+				{
+					BlockHeight: 1,
+					Version:     sqsdomain.LatestIngestProtocolVersion + 1,
+				},
+			},
+
+			expectedErr: domain.UnsupportedIngestProtocolVersionError{
+				Version:       sqsdomain.LatestIngestProtocolVersion + 1,
+				LatestVersion: sqsdomain.LatestIngestProtocolVersion,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.Run(tt.name, func() {
+			ingester := s.newDeltaIngester()
+
+			var (
+				pools map[uint64]sqsdomain.PoolI
+				err   error
+			)
+
+			// Requests prior to the last one may fail as part of the setup.
+			for _, req := range tt.requests {
+				pools, err = ingester.ApplyPoolDeltas(req)
+			}
+
+			if tt.expectedErr != nil {
+				s.Require().Error(err)
+				s.Require().ErrorIs(err, tt.expectedErr)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(s.parsePools(ingester, tt.expectedPoolData), pools)
+		})
+	}
+}
+
+// Tests that a checksum mismatch is reported with the pool ID.
+func (s *IngestUseCaseTestSuite) TestApplyPoolDeltas_ChecksumMismatch() {
+	ingester := s.newDeltaIngester()
+
+	_, err := ingester.ApplyPoolDeltas(&types.ProcessBlockRequest{
+		BlockHeight: 1,
+		Pools: []*types.PoolData{
+			s.encodePoolData(s.newBalancerPool(), sqsdomain.SQSPool{PoolLiquidityCap: osmomath.NewInt(1_000)}, nil),
+		},
+		Version: sqsdomain.IngestProtocolVersionDelta,
+	})
+	s.Require().NoError(err)
+
+	_, err = ingester.ApplyPoolDeltas(&types.ProcessBlockRequest{
+		BlockHeight: 2,
+		PoolDeltas: []*types.PoolDelta{
+			{
+				PoolId:           balancerPoolID,
+				PoolLiquidityCap: "2000",
+				Checksum:         []byte{1},
+			},
+		},
+		Version: sqsdomain.IngestProtocolVersionDelta,
+	})
+	s.Require().Error(err)
+
+	var mismatchErr domain.PoolDeltaChecksumMismatchError
+	s.Require().ErrorAs(err, &mismatchErr)
+	s.Require().Equal(balancerPoolID, mismatchErr.PoolID)
+	s.Require().Equal("01", mismatchErr.ExpectedChecksum)
+}
+
+// Validates removePoolsFromDenomLiquidityMap per the spec.
+func (s *IngestUseCaseTestSuite) TestRemovePoolsFromDenomLiquidityMap() {
+	denomLiquidityMap := domain.DenomPoolLiquidityMap{
+		UOSMO: domain.DenomPoolLiquidityData{
+			TotalLiquidity: defaultAmount.Add(defaultAmount),
+			Pools: map[uint64]osmomath.Int{
+				defaultPoolID:     defaultAmount,
+				defaultPoolID + 1: defaultAmount,
+			},
+		},
+		USDC: domain.DenomPoolLiquidityData{
+			TotalLiquidity: defaultAmount,
+			Pools: map[uint64]osmomath.Int{
+				defaultPoolID: defaultAmount,
+			},
+		},
+	}
+
+	result := usecase.RemovePoolsFromDenomLiquidityMap(denomLiquidityMap, []uint64{defaultPoolID, defaultPoolID + 2})
+
+	s.Require().Equal(domain.DenomPoolLiquidityMap{
+		UOSMO: domain.DenomPoolLiquidityData{
+			TotalLiquidity: defaultAmount,
+			Pools: map[uint64]osmomath.Int{
+				defaultPoolID + 1: defaultAmount,
+			},
+		},
+	}, result)
+}
+
+// newDeltaIngester returns an ingest usecase that is only able to apply pool deltas.
+func (s *IngestUseCaseTestSuite) newDeltaIngester() *usecase.IngestUseCaseImpl {
+	ingestUseCase, err := usecase.NewIngestUsecase(nil, nil, nil, nil, nil, encodingConfig.Marshaler, nil, nil, nil, noOpLogger)
+	s.Require().NoError(err)
+	ingester, ok := ingestUseCase.(*usecase.IngestUseCaseImpl)
+	s.Require().True(ok)
+	return ingester
+}
+
+// parsePools parses the given pool data by pool ID.
+func (s *IngestUseCaseTestSuite) parsePools(ingester *usecase.IngestUseCaseImpl, poolData map[uint64]*types.PoolData) map[uint64]sqsdomain.PoolI {
+	pools := make(map[uint64]sqsdomain.PoolI, len(poolData))
+	for poolID, data := range poolData {
+		pool, err := ingester.ParsePool(data)
+		s.Require().NoError(err)
+		pools[poolID] = pool
+	}
+	return pools
+}
+
+func (s *IngestUseCaseTestSuite) newBalancerPool() poolmanagertypes.PoolI {
+	pool, err := balancer.NewBalancerPool(
+		balancerPoolID,
+		balancer.PoolParams{SwapFee: osmomath.ZeroDec(), ExitFee: osmomath.ZeroDec()},
+		[]balancer.PoolAsset{
+			{Token: defaultUOSMOBalance, Weight: osmomath.NewInt(1)},
+			{Token: defaultUSDCBalance, Weight: osmomath.NewInt(1)},
+		},
+		"",
+		time.Unix(0, 0).UTC(),
+	)
+	s.Require().NoError(err)
+	return &pool
+}
+
+func (s *IngestUseCaseTestSuite) newConcentratedPool() poolmanagertypes.PoolI {
+	pool, err := concentratedmodel.NewConcentratedLiquidityPool(concentratedPoolID, UOSMO, USDC, 100, osmomath.ZeroDec())
+	s.Require().NoError(err)
+	return &pool
+}
+
+// encodePoolData encodes the pool the same way the node does.
+func (s *IngestUseCaseTestSuite) encodePoolData(chainModel poolmanagertypes.PoolI, sqsModel sqsdomain.SQSPool, tickModel *sqsdomain.TickModel) *types.PoolData {
+	chainModelBz, err := encodingConfig.Marshaler.MarshalInterfaceJSON(chainModel)
+	s.Require().NoError(err)
+
+	poolData := &types.PoolData{
+		ChainModel: chainModelBz,
+		SqsModel:   s.marshal(sqsModel),
+	}
+
+	if tickModel != nil {
+		poolData.TickModel = s.marshal(tickModel)
+	}
+
+	return poolData
+}
+
+func (s *IngestUseCaseTestSuite) marshal(v any) []byte {
+	bz, err := json.Marshal(v)
+	s.Require().NoError(err)
+	return bz
+}
+
+func checksum(poolData *types.PoolData) []byte {
+	return sqsdomain.PoolDataChecksum(poolData.ChainModel, poolData.SqsModel, poolData.TickModel)
+}
+
+// Tests that the concentrated pools of the state snapshot built at the end of the block carry
+// their tick models since the spot prices served from a pinned snapshot do not retrieve them.
+func (s *IngestUseCaseTestSuite) TestStoreStateSnapshot_TickModels() {
+	const height uint64 = 100
+
+	ingester := s.newDeltaIngester()
+
+	stateSnapshotHolder := domain.NewStateSnapshotHolder()
+	ingester.RegisterStateSnapshotHolder(stateSnapshotHolder, &mocks.CandidateRouteSearchDataHolderMock{})
+
+	sqsModel := sqsdomain.SQSPool{
+		PoolLiquidityCap: osmomath.NewInt(1_000),
+		Balances:         sdk.NewCoins(defaultUOSMOBalance, defaultUSDCBalance),
+		PoolDenoms:       []string{UOSMO, USDC},
+		SpreadFactor:     osmomath.ZeroDec(),
+	}
+
+	pools := s.parsePools(ingester, map[uint64]*types.PoolData{
+		balancerPoolID:     s.encodePoolData(s.newBalancerPool(), sqsModel, nil),
+		concentratedPoolID: s.encodePoolData(s.newConcentratedPool(), sqsModel, defaultTickModel),
+	})
+
+	ingester.StoreStateSnapshot(height, sqsdomain.TakerFeeMap{}, []sqsdomain.PoolI{pools[balancerPoolID], pools[concentratedPoolID]}, nil)
+
+	snapshot, ok := domain.GetStateSnapshot(domain.ContextWithStateSnapshot(context.Background(), stateSnapshotHolder.Load()))
+	s.Require().True(ok)
+	s.Require().Equal(height, snapshot.GetHeight())
+
+	concentratedPool, err := snapshot.GetPool(concentratedPoolID)
+	s.Require().NoError(err)
+
+	tickModel, err := concentratedPool.GetTickModel()
+	s.Require().NoError(err)
+	s.Require().Equal(defaultTickModel, tickModel)
+
+	balancerPool, err := snapshot.GetPool(balancerPoolID)
+	s.Require().NoError(err)
+	s.Require().Equal(poolmanagertypes.Balancer, balancerPool.GetType())
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
+	cosmwasmpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// validatePool checks the invariants of the given pool that routing relies on.
+// Returns the first violated invariant, in which case the pool must be quarantined from routing.
+//
+// Note that pools without liquidity or with fewer than two denoms are valid.
+// They are filtered out of routing when sorting pools instead.
+func validatePool(pool sqsdomain.PoolI, cosmWasmPoolConfig domain.CosmWasmPoolRouterConfig) error {
+	poolType := pool.GetType()
+
+	if poolType != poolmanagertypes.CosmWasm && pool.GetSQSPoolModel().CosmWasmPoolModel != nil {
+		return domain.UnexpectedCosmWasmPoolModelError{
+			PoolID:   pool.GetId(),
+			PoolType: poolmanagertypes.PoolType_name[int32(poolType)],
+		}
+	}
+
+	switch poolType {
+	case poolmanagertypes.Balancer, poolmanagertypes.Stableswap:
+		return validateCFMMPool(pool)
+	case poolmanagertypes.Concentrated:
+		return validateConcentratedPool(pool)
+	case poolmanagertypes.CosmWasm:
+		return validateCosmWasmPool(pool, cosmWasmPoolConfig)
+	default:
+		return domain.InvalidPoolTypeError{PoolType: int32(poolType)}
+	}
+}
+
+// validateCFMMPool validates that the balancer or stableswap pool has non-zero balances
+// since its swap math is undefined otherwise.
+func validateCFMMPool(pool sqsdomain.PoolI) error {
+	if pool.GetSQSPoolModel().Balances.IsZero() {
+		return domain.PoolZeroBalancesError{PoolID: pool.GetId()}
+	}
+
+	return nil
+}
+
+// validateConcentratedPool validates that the tick model of the concentrated pool is set,
+// that its tick ranges are ordered and non-overlapping and that the current tick
+// is within the bucket at the current tick index.
+// Pools flagged as having no liquidity are valid regardless of their tick model.
+func validateConcentratedPool(pool sqsdomain.PoolI) error {
+	poolID := pool.GetId()
+
+	concentratedPool, ok := pool.GetUnderlyingPool().(*concentratedmodel.Pool)
+	if !ok {
+		return domain.FailedToCastPoolModelError{
+			ExpectedModel: poolmanagertypes.PoolType_name[int32(poolmanagertypes.Concentrated)],
+			ActualModel:   poolmanagertypes.PoolType_name[int32(pool.GetType())],
+		}
+	}
+
+	// Errors if the tick model is not set.
+	tickModel, err := pool.GetTickModel()
+	if err != nil {
+		return err
+	}
+
+	if tickModel.HasNoLiquidity {
+		return nil
+	}
+
+	for i, tick := range tickModel.Ticks {
+		if tick.LowerTick >= tick.UpperTick || (i > 0 && tick.LowerTick < tickModel.Ticks[i-1].UpperTick) {
+			return domain.ConcentratedTicksNotOrderedError{PoolID: poolID, Index: i}
+		}
+	}
+
+	currentBucketIndex := tickModel.CurrentTickIndex
+	if currentBucketIndex < 0 || currentBucketIndex >= int64(len(tickModel.Ticks)) {
+		return domain.ConcentratedCurrentTickNotWithinBucketError{
+			PoolId:             poolID,
+			CurrentBucketIndex: currentBucketIndex,
+			TotalBuckets:       int64(len(tickModel.Ticks)),
+		}
+	}
+
+	currentBucket := tickModel.Ticks[currentBucketIndex]
+	if !concentratedPool.IsCurrentTickInRange(currentBucket.LowerTick, currentBucket.UpperTick) {
+		return domain.ConcentratedCurrentTickAndBucketMismatchError{
+			PoolID:      poolID,
+			CurrentTick: concentratedPool.CurrentTick,
+			LowerTick:   currentBucket.LowerTick,
+			UpperTick:   currentBucket.UpperTick,
+		}
+	}
+
+	if concentratedPool.GetCurrentSqrtPrice().IsZero() {
+		return domain.ConcentratedZeroCurrentSqrtPriceError{PoolId: poolID}
+	}
+
+	return nil
+}
+
+// validateCosmWasmPool validates that the CosmWasm pool model of the pool matches
+// the pool type configured for its code ID and carries the data of that pool type.
+// Pools with code IDs that are not configured are not routed and are not validated further.
+func validateCosmWasmPool(pool sqsdomain.PoolI, cosmWasmPoolConfig domain.CosmWasmPoolRouterConfig) error {
+	poolID := pool.GetId()
+
+	cosmWasmPool, ok := pool.GetUnderlyingPool().(*cosmwasmpoolmodel.CosmWasmPool)
+	if !ok {
+		return domain.FailedToCastPoolModelError{
+			ExpectedModel: poolmanagertypes.PoolType_name[int32(poolmanagertypes.CosmWasm)],
+			ActualModel:   poolmanagertypes.PoolType_name[int32(pool.GetType())],
+		}
+	}
+
+	model := pool.GetSQSPoolModel().CosmWasmPoolModel
+
+	if _, isAlloyedTransmuterCodeID := cosmWasmPoolConfig.AlloyedTransmuterCodeIDs[cosmWasmPool.CodeId]; isAlloyedTransmuterCodeID {
+		if model == nil || !model.IsAlloyTransmuter() {
+			return domain.UnsupportedCosmWasmPoolError{PoolId: poolID}
+		}
+
+		if model.Data.AlloyTransmuter == nil {
+			return domain.CosmWasmPoolDataMissingError{PoolId: poolID, CosmWasmPoolType: domain.CosmWasmPoolAlloyTransmuter}
+		}
+	}
+
+	if _, isOrderbookCodeID := cosmWasmPoolConfig.OrderbookCodeIDs[cosmWasmPool.CodeId]; isOrderbookCodeID {
+		if model == nil || !model.IsOrderbook() {
+			return domain.UnsupportedCosmWasmPoolError{PoolId: poolID}
+		}
+
+		if model.Data.Orderbook == nil {
+			return domain.CosmWasmPoolDataMissingError{PoolId: poolID, CosmWasmPoolType: domain.CosmWasmPoolOrderbook}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package usecase_test
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
+	cosmwasmpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/ingest/usecase"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+const (
+	orderbookCodeID         uint64 = 10
+	alloyedTransmuterCodeID uint64 = 11
+)
+
+var (
+	validationCosmWasmPoolConfig = domain.CosmWasmPoolRouterConfig{
+		OrderbookCodeIDs:         map[uint64]struct{}{orderbookCodeID: {}},
+		AlloyedTransmuterCodeIDs: map[uint64]struct{}{alloyedTransmuterCodeID: {}},
+	}
+
+	orderbookModel = cosmwasmpool.NewCWPoolModel(cosmwasmpool.ORDERBOOK_CONTRACT_NAME, cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION, cosmwasmpool.CosmWasmPoolData{
+		Orderbook: &cosmwasmpool.OrderbookData{BaseDenom: UOSMO, QuoteDenom: USDC},
+	})
+)
+
+// Tests the invariants checked per pool type.
+func (s *IngestUseCaseTestSuite) TestValidatePool() {
+	var (
+		balances = sdk.NewCoins(defaultUOSMOBalance, defaultUSDCBalance)
+
+		noLiquidityTickModel = &sqsdomain.TickModel{HasNoLiquidity: true}
+
+		overlappingTickModel = &sqsdomain.TickModel{
+			Ticks: []sqsdomain.LiquidityDepthsWithRange{
+				{LowerTick: -100, UpperTick: 50, LiquidityAmount: osmomath.NewDec(10)},
+				{LowerTick: 0, UpperTick: 100, LiquidityAmount: osmomath.NewDec(20)},
+			},
+			CurrentTickIndex: 1,
+		}
+
+		outOfRangeTickModel = &sqsdomain.TickModel{
+			Ticks:            defaultTickModel.Ticks,
+			CurrentTickIndex: 2,
+		}
+
+		mismatchedTickModel = &sqsdomain.TickModel{
+			Ticks:            defaultTickModel.Ticks,
+			CurrentTickIndex: 0,
+		}
+	)
+
+	tests := []struct {
+		name string
+
+		pool sqsdomain.PoolI
+
+		expectedErr error
+	}{
+		{
+			name: "valid balancer pool",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newBalancerPool(),
+				SQSModel:   sqsdomain.SQSPool{Balances: balances},
+			},
+		},
+		{
+			name: "balancer pool with zero balances",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newBalancerPool(),
+				SQSModel:   sqsdomain.SQSPool{Balances: sdk.Coins{sdk.NewCoin(UOSMO, zeroInt)}},
+			},
+
+			expectedErr: domain.PoolZeroBalancesError{PoolID: balancerPoolID},
+		},
+		{
+			name: "balancer pool with cosmwasm pool model",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newBalancerPool(),
+				SQSModel:   sqsdomain.SQSPool{Balances: balances, CosmWasmPoolModel: orderbookModel},
+			},
+
+			expectedErr: domain.UnexpectedCosmWasmPoolModelError{PoolID: balancerPoolID, PoolType: "Balancer"},
+		},
+		{
+			name: "valid concentrated pool",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newPricedConcentratedPool(),
+				TickModel:  defaultTickModel,
+			},
+		},
+		{
+			name: "concentrated pool without liquidity",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newConcentratedPool(),
+				TickModel:  noLiquidityTickModel,
+			},
+		},
+		{
+			name: "concentrated pool without tick model",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newPricedConcentratedPool(),
+			},
+
+			expectedErr: sqsdomain.ConcentratedPoolNoTickModelError{PoolId: concentratedPoolID},
+		},
+		{
+			name: "concentrated pool with overlapping ticks",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newPricedConcentratedPool(),
+				TickModel:  overlappingTickModel,
+			},
+
+			expectedErr: domain.ConcentratedTicksNotOrderedError{PoolID: concentratedPoolID, Index: 1},
+		},
+		{
+			name: "concentrated pool with current tick index out of range",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newPricedConcentratedPool(),
+				TickModel:  outOfRangeTickModel,
+			},
+
+			expectedErr: domain.ConcentratedCurrentTickNotWithinBucketError{PoolId: concentratedPoolID, CurrentBucketIndex: 2, TotalBuckets: 2},
+		},
+		{
+			name: "concentrated pool with current tick outside of current bucket",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newPricedConcentratedPool(),
+				TickModel:  mismatchedTickModel,
+			},
+
+			expectedErr: domain.ConcentratedCurrentTickAndBucketMismatchError{PoolID: concentratedPoolID, CurrentTick: 0, LowerTick: -100, UpperTick: 0},
+		},
+		{
+			name: "concentrated pool with zero sqrt price",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: s.newConcentratedPool(),
+				TickModel:  defaultTickModel,
+			},
+
+			expectedErr: domain.ConcentratedZeroCurrentSqrtPriceError{PoolId: concentratedPoolID},
+		},
+		{
+			name: "valid orderbook pool",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: &cosmwasmpoolmodel.CosmWasmPool{PoolId: defaultPoolID, CodeId: orderbookCodeID},
+				SQSModel:   sqsdomain.SQSPool{CosmWasmPoolModel: orderbookModel},
+			},
+		},
+		{
+			name: "orderbook pool without orderbook data",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: &cosmwasmpoolmodel.CosmWasmPool{PoolId: defaultPoolID, CodeId: orderbookCodeID},
+				SQSModel: sqsdomain.SQSPool{
+					CosmWasmPoolModel: cosmwasmpool.NewCWPoolModel(cosmwasmpool.ORDERBOOK_CONTRACT_NAME, cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION, cosmwasmpool.CosmWasmPoolData{}),
+				},
+			},
+
+			expectedErr: domain.CosmWasmPoolDataMissingError{PoolId: defaultPoolID, CosmWasmPoolType: domain.CosmWasmPoolOrderbook},
+		},
+		{
+			name: "alloyed transmuter pool with orderbook model",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: &cosmwasmpoolmodel.CosmWasmPool{PoolId: defaultPoolID, CodeId: alloyedTransmuterCodeID},
+				SQSModel:   sqsdomain.SQSPool{CosmWasmPoolModel: orderbookModel},
+			},
+
+			expectedErr: domain.UnsupportedCosmWasmPoolError{PoolId: defaultPoolID},
+		},
+		{
+			name: "alloyed transmuter pool without cosmwasm pool model",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: &cosmwasmpoolmodel.CosmWasmPool{PoolId: defaultPoolID, CodeId: alloyedTransmuterCodeID},
+			},
+
+			expectedErr: domain.UnsupportedCosmWasmPoolError{PoolId: defaultPoolID},
+		},
+		{
+			name: "cosmwasm pool with code ID that is not configured",
+			pool: &sqsdomain.PoolWrapper{
+				ChainModel: &cosmwasmpoolmodel.CosmWasmPool{PoolId: defaultPoolID, CodeId: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.Run(tt.name, func() {
+			err := usecase.ValidatePool(tt.pool, validationCosmWasmPoolConfig)
+
+			if tt.expectedErr != nil {
+				s.Require().Error(err)
+				s.Require().Equal(tt.expectedErr, err)
+				return
+			}
+
+			s.Require().NoError(err)
+		})
+	}
+}
+
+// Tests that the pools failing validation are quarantined and removed from the denom liquidity map,
+// and that they are released once a later block passes validation.
+func (s *IngestUseCaseTestSuite) TestValidatePools_Quarantine() {
+	const height uint64 = 100
+
+	quarantinedPools := map[uint64]domain.QuarantinedPool{}
+
+	poolsUseCase := &mocks.PoolsUsecaseMock{
+		QuarantinePoolsFunc: func(pools []domain.QuarantinedPool) {
+			for _, pool := range pools {
+				quarantinedPools[pool.PoolID] = pool
+			}
+		},
+		ReleaseQuarantinedPoolsFunc: func(poolIDs []uint64) []uint64 {
+			releasedPoolIDs := []uint64{}
+			for _, poolID := range poolIDs {
+				if _, ok := quarantinedPools[poolID]; ok {
+					delete(quarantinedPools, poolID)
+					releasedPoolIDs = append(releasedPoolIDs, poolID)
+				}
+			}
+			return releasedPoolIDs
+		},
+		GetQuarantinedPoolsFunc: func() []domain.QuarantinedPool {
+			pools := make([]domain.QuarantinedPool, 0, len(quarantinedPools))
+			for _, pool := range quarantinedPools {
+				pools = append(pools, pool)
+			}
+			return pools
+		},
+	}
+
+	ingestUseCase, err := usecase.NewIngestUsecase(poolsUseCase, nil, nil, nil, nil, encodingConfig.Marshaler, nil, nil, nil, noOpLogger)
+	s.Require().NoError(err)
+	ingester, ok := ingestUseCase.(*usecase.IngestUseCaseImpl)
+	s.Require().True(ok)
+
+	sqsModel := sqsdomain.SQSPool{
+		PoolLiquidityCap: osmomath.NewInt(1_000),
+		Balances:         sdk.NewCoins(defaultUOSMOBalance, defaultUSDCBalance),
+		PoolDenoms:       []string{UOSMO, USDC},
+		SpreadFactor:     osmomath.ZeroDec(),
+	}
+
+	balancerPool, err := ingester.ParsePool(s.encodePoolData(s.newBalancerPool(), sqsModel, nil))
+	s.Require().NoError(err)
+	validConcentratedPool, err := ingester.ParsePool(s.encodePoolData(s.newPricedConcentratedPool(), sqsModel, defaultTickModel))
+	s.Require().NoError(err)
+	invalidConcentratedPool, err := ingester.ParsePool(s.encodePoolData(s.newPricedConcentratedPool(), sqsModel, &sqsdomain.TickModel{
+		Ticks:            defaultTickModel.Ticks,
+		CurrentTickIndex: 2,
+	}))
+	s.Require().NoError(err)
+
+	// The concentrated pool contributes liquidity before failing validation.
+	_, _, err = ingester.ValidatePools(context.TODO(), height, map[uint64]sqsdomain.PoolI{
+		balancerPoolID:     balancerPool,
+		concentratedPoolID: validConcentratedPool,
+	})
+	s.Require().NoError(err)
+
+	pools, metadata, err := ingester.ValidatePools(context.TODO(), height+1, map[uint64]sqsdomain.PoolI{
+		concentratedPoolID: invalidConcentratedPool,
+	})
+	s.Require().NoError(err)
+
+	// The quarantined pool is still returned to be stored.
+	s.Require().Len(pools, 1)
+
+	s.Require().Equal(map[uint64]domain.QuarantinedPool{
+		concentratedPoolID: {
+			PoolID:             concentratedPoolID,
+			PoolType:           "Concentrated",
+			Reason:             domain.ConcentratedCurrentTickNotWithinBucketError{PoolId: concentratedPoolID, CurrentBucketIndex: 2, TotalBuckets: 2}.Error(),
+			QuarantinedHeight:  height + 1,
+			LatestFailedHeight: height + 1,
+		},
+	}, quarantinedPools)
+
+	// Only the balancer pool remains routable.
+	s.Require().Empty(metadata.PoolIDs)
+	s.Require().Equal(map[string]struct{}{UOSMO: {}, USDC: {}}, metadata.UpdatedDenoms)
+	for _, denom := range []string{UOSMO, USDC} {
+		s.Require().Equal([]uint64{balancerPoolID}, domain.KeysFromMap(metadata.DenomPoolLiquidityMap[denom].Pools))
+	}
+
+	// The pool is released once it passes validation.
+	_, metadata, err = ingester.ValidatePools(context.TODO(), height+2, map[uint64]sqsdomain.PoolI{
+		concentratedPoolID: validConcentratedPool,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Empty(quarantinedPools)
+	s.Require().Contains(metadata.PoolIDs, concentratedPoolID)
+	s.Require().Contains(metadata.DenomPoolLiquidityMap[UOSMO].Pools, concentratedPoolID)
+}
+
+// newPricedConcentratedPool returns a concentrated pool with the current tick
+// at zero and the corresponding current sqrt price.
+func (s *IngestUseCaseTestSuite) newPricedConcentratedPool() *concentratedmodel.Pool {
+	pool, ok := s.newConcentratedPool().(*concentratedmodel.Pool)
+	s.Require().True(ok)
+
+	pool.CurrentSqrtPrice = osmomath.OneBigDec()
+
+	return pool
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// DegradedModeMiddleware applies the configured degraded mode action to quote requests
+// while the detected chain status is not healthy:
+// - "warn" adds a warning header to the response.
+// - "widen-slippage" adds a warning header and widens the reported price impact by the configured factor.
+// - "refuse" fails the request with 503 Service Unavailable.
+// Meant to be applied to the quote routes only.
+func (m *GoMiddleware) DegradedModeMiddleware(chainStatusGetter domain.ChainStatusGetter, config domain.DegradedModeConfig) echo.MiddlewareFunc {
+	slippageWideningFactor := osmomath.MustNewDecFromStr(strconv.FormatFloat(config.SlippageWideningFactor, 'f', -1, 64))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Action == domain.DegradedModeActionNone {
+				return next(c)
+			}
+
+			chainStatus := chainStatusGetter.GetChainStatus()
+			if chainStatus.Status == domain.ChainStatusHealthy {
+				return next(c)
+			}
+
+			domain.SQSDegradedModeQuotesCounter.WithLabelValues(config.Action, string(chainStatus.Status)).Inc()
+
+			warning := fmt.Sprintf("%s: %s", chainStatus.Status, chainStatus.Message)
+
+			switch config.Action {
+			case domain.DegradedModeActionRefuse:
+				c.Response().Header().Set(echo.HeaderRetryAfter, retryAfterSecs)
+				return c.JSON(http.StatusServiceUnavailable, domain.ResponseError{Message: "quotes are unavailable in degraded mode, " + warning})
+			case domain.DegradedModeActionWidenSlippage:
+				request := c.Request()
+				c.SetRequest(request.WithContext(domain.ContextWithSlippageWideningFactor(request.Context(), slippageWideningFactor)))
+			}
+
+			c.Response().Header().Set(domain.DegradedModeWarningHeader, warning)
+
+			return next(c)
+		}
+	}
+}
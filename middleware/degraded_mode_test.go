@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/middleware"
+)
+
+type staticChainStatusGetter domain.ChainStatusReport
+
+// GetChainStatus implements domain.ChainStatusGetter.
+func (g staticChainStatusGetter) GetChainStatus() domain.ChainStatusReport {
+	return domain.ChainStatusReport(g)
+}
+
+// This test validates that the degraded mode action is applied to requests while the chain status is not healthy.
+func TestDegradedModeMiddleware(t *testing.T) {
+	chainHalt := domain.ChainStatusReport{Status: domain.ChainStatusChainHalt, Message: "no block produced"}
+
+	tests := []struct {
+		name string
+
+		action      string
+		chainStatus domain.ChainStatusReport
+
+		expectedStatusCode             int
+		expectedWarning                string
+		expectedSlippageWideningFactor string
+	}{
+		{
+			name:               "healthy",
+			action:             domain.DegradedModeActionRefuse,
+			chainStatus:        domain.ChainStatusReport{Status: domain.ChainStatusHealthy},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "none",
+			action:             domain.DegradedModeActionNone,
+			chainStatus:        chainHalt,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "warn",
+			action:             domain.DegradedModeActionWarn,
+			chainStatus:        chainHalt,
+			expectedStatusCode: http.StatusOK,
+			expectedWarning:    "chain_halt: no block produced",
+		},
+		{
+			name:                           "widen slippage",
+			action:                         domain.DegradedModeActionWidenSlippage,
+			chainStatus:                    chainHalt,
+			expectedStatusCode:             http.StatusOK,
+			expectedWarning:                "chain_halt: no block produced",
+			expectedSlippageWideningFactor: "2.5",
+		},
+		{
+			name:               "refuse",
+			action:             domain.DegradedModeActionRefuse,
+			chainStatus:        chainHalt,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := domain.DegradedModeConfig{
+				Action:                 tc.action,
+				SlippageWideningFactor: 2.5,
+			}
+
+			m := middleware.InitMiddleware(&domain.CORSConfig{}, &domain.FlightRecordConfig{}, &log.NoOpLogger{})
+
+			var (
+				isHandlerCalled        bool
+				slippageWideningFactor osmomath.Dec
+				isSlippageWidened      bool
+			)
+
+			e := echo.New()
+			e.GET("/router/quote", func(c echo.Context) error {
+				isHandlerCalled = true
+				slippageWideningFactor, isSlippageWidened = domain.GetSlippageWideningFactor(c.Request().Context())
+				return c.JSON(http.StatusOK, map[string]string{"amount": "1"})
+			}, m.DegradedModeMiddleware(staticChainStatusGetter(tc.chainStatus), config))
+
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/router/quote", nil))
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			require.Equal(t, tc.expectedWarning, rec.Header().Get(domain.DegradedModeWarningHeader))
+			require.Equal(t, tc.expectedStatusCode == http.StatusOK, isHandlerCalled)
+
+			require.Equal(t, tc.expectedSlippageWideningFactor != "", isSlippageWidened)
+			if isSlippageWidened {
+				require.Equal(t, osmomath.MustNewDecFromStr(tc.expectedSlippageWideningFactor), slippageWideningFactor)
+			}
+		})
+	}
+}
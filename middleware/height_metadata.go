@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+)
+
+const (
+	// minHeightQueryParam is the min height that the client requires the response to be served from.
+	minHeightQueryParam = "minHeight"
+	// withHeightMetadataQueryParam requests the response to be wrapped in an envelope with the height metadata.
+	withHeightMetadataQueryParam = "withHeightMetadata"
+
+	// retryAfterSecs is the number of seconds after which the client may retry a request that the server
+	// cannot serve yet.
+	retryAfterSecs = "1"
+)
+
+// heightMetadataEnvelope wraps the response data with its height metadata.
+type heightMetadataEnvelope struct {
+	Data           json.RawMessage       `json:"data"`
+	HeightMetadata domain.HeightMetadata `json:"height_metadata"`
+}
+
+// bufferedResponseWriter buffers the status code and body of the response
+// so that they can be wrapped before writing.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *bufferedResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+// Write implements http.ResponseWriter.
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// HeightMetadataMiddleware attaches the height metadata of the response as headers.
+// If the minHeight query parameter is set and the response would be served from an older height, it fails the request
+// with 409 Conflict, or with 503 Service Unavailable if no block has been ingested yet.
+// If the withHeightMetadata query parameter is true, successful JSON responses are wrapped in an envelope
+// with the height metadata.
+// Must be used after the state snapshot middleware so that the metadata reflects the pinned snapshot.
+func (m *GoMiddleware) HeightMetadataMiddleware(chainInfoUsecase mvc.ChainInfoUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			heightMetadata := chainInfoUsecase.GetHeightMetadata(c.Request().Context())
+
+			header := c.Response().Header()
+			header.Set(domain.IngestHeightHeader, strconv.FormatUint(heightMetadata.IngestHeight, 10))
+			header.Set(domain.PricingHeightHeader, strconv.FormatUint(heightMetadata.PricingHeight, 10))
+			header.Set(domain.SnapshotAgeHeader, strconv.FormatInt(heightMetadata.SnapshotAgeMs, 10))
+
+			if minHeightStr := c.QueryParam(minHeightQueryParam); minHeightStr != "" {
+				minHeight, err := strconv.ParseUint(minHeightStr, 10, 64)
+				if err != nil {
+					return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: "invalid minHeight: " + err.Error()})
+				}
+
+				if heightMetadata.SnapshotHeight < minHeight {
+					err := domain.MinHeightNotReachedError{MinHeight: minHeight, Height: heightMetadata.SnapshotHeight}
+
+					if heightMetadata.SnapshotHeight == 0 {
+						header.Set(echo.HeaderRetryAfter, retryAfterSecs)
+						return c.JSON(http.StatusServiceUnavailable, domain.ResponseError{Message: err.Error()})
+					}
+
+					return c.JSON(http.StatusConflict, domain.ResponseError{Message: err.Error()})
+				}
+			}
+
+			withHeightMetadata, err := domain.ParseBooleanQueryParam(c, withHeightMetadataQueryParam)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+			}
+
+			if !withHeightMetadata {
+				return next(c)
+			}
+
+			return writeHeightMetadataEnvelope(c, next, heightMetadata)
+		}
+	}
+}
+
+// writeHeightMetadataEnvelope calls the next handler, buffering its response, and writes the response
+// wrapped in an envelope with the height metadata. Non-JSON and unsuccessful responses are written as is.
+func writeHeightMetadataEnvelope(c echo.Context, next echo.HandlerFunc, heightMetadata domain.HeightMetadata) error {
+	response := c.Response()
+
+	originalWriter := response.Writer
+	bufferedWriter := &bufferedResponseWriter{ResponseWriter: originalWriter, statusCode: http.StatusOK}
+	response.Writer = bufferedWriter
+
+	handlerErr := next(c)
+
+	response.Writer = originalWriter
+
+	// The handler returned an error without writing a response. Leave it to the error handler.
+	if !response.Committed {
+		return handlerErr
+	}
+
+	body := bufferedWriter.body.Bytes()
+
+	data := bytes.TrimSpace(body)
+
+	isSuccess := bufferedWriter.statusCode >= http.StatusOK && bufferedWriter.statusCode < http.StatusMultipleChoices
+	isJSON := strings.HasPrefix(response.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	if isSuccess && isJSON && len(data) > 0 {
+		envelope, err := json.Marshal(heightMetadataEnvelope{
+			Data:           data,
+			HeightMetadata: heightMetadata,
+		})
+		if err != nil {
+			return err
+		}
+
+		body = envelope
+		response.Header().Del(echo.HeaderContentLength)
+	}
+
+	originalWriter.WriteHeader(bufferedWriter.statusCode)
+	if _, err := originalWriter.Write(body); err != nil {
+		return err
+	}
+
+	return handlerErr
+}
@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/middleware"
+)
+
+// This test validates the height metadata headers, the min height check and the height metadata envelope.
+func TestHeightMetadataMiddleware(t *testing.T) {
+	heightMetadata := domain.HeightMetadata{
+		IngestHeight:   101,
+		PricingHeight:  99,
+		SnapshotHeight: 100,
+		SnapshotAgeMs:  250,
+	}
+
+	tests := []struct {
+		name string
+
+		heightMetadata domain.HeightMetadata
+		query          string
+
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "no query params",
+			heightMetadata:     heightMetadata,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"amount":"1"}`,
+		},
+		{
+			name:               "min height reached",
+			heightMetadata:     heightMetadata,
+			query:              "?minHeight=100",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"amount":"1"}`,
+		},
+		{
+			name:               "min height not reached",
+			heightMetadata:     heightMetadata,
+			query:              "?minHeight=101",
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"message":"height (100) is behind the requested min height (101)"}`,
+		},
+		{
+			name:               "min height before first block",
+			query:              "?minHeight=1",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       `{"message":"height (0) is behind the requested min height (1)"}`,
+		},
+		{
+			name:               "invalid min height",
+			heightMetadata:     heightMetadata,
+			query:              "?minHeight=-1",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "with height metadata envelope",
+			heightMetadata:     heightMetadata,
+			query:              "?withHeightMetadata=true",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"amount":"1"},"height_metadata":{"ingest_height":101,"pricing_height":99,"snapshot_height":100,"snapshot_age_ms":250}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chainInfoUsecase := &mocks.ChainInfoUsecaseMock{
+				GetHeightMetadataFunc: func(ctx context.Context) domain.HeightMetadata {
+					return tc.heightMetadata
+				},
+			}
+
+			m := middleware.InitMiddleware(&domain.CORSConfig{}, &domain.FlightRecordConfig{}, &log.NoOpLogger{})
+
+			e := echo.New()
+			e.Use(m.HeightMetadataMiddleware(chainInfoUsecase))
+			e.GET("/router/quote", func(c echo.Context) error {
+				return c.JSON(http.StatusOK, map[string]string{"amount": "1"})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/router/quote"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			require.Equal(t, strconv.FormatUint(tc.heightMetadata.IngestHeight, 10), rec.Header().Get(domain.IngestHeightHeader))
+			require.Equal(t, strconv.FormatUint(tc.heightMetadata.PricingHeight, 10), rec.Header().Get(domain.PricingHeightHeader))
+			require.Equal(t, strconv.FormatInt(tc.heightMetadata.SnapshotAgeMs, 10), rec.Header().Get(domain.SnapshotAgeHeader))
+
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
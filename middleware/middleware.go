@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 
 	"time"
@@ -125,3 +126,24 @@ func (m *GoMiddleware) TraceWithParamsMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// StateSnapshotMiddleware pins the latest state snapshot to the request context so that the request
+// observes the state of a single block for its lifetime. The snapshot height is reported in the response header.
+// No-op until the first block is ingested.
+func (m *GoMiddleware) StateSnapshotMiddleware(stateSnapshotHolder *domain.StateSnapshotHolder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			snapshot := stateSnapshotHolder.Load()
+			if snapshot == nil {
+				return next(c)
+			}
+
+			request := c.Request()
+			c.SetRequest(request.WithContext(domain.ContextWithStateSnapshot(request.Context(), snapshot)))
+
+			c.Response().Header().Set(domain.StateSnapshotHeightHeader, strconv.FormatUint(snapshot.GetHeight(), 10))
+
+			return next(c)
+		}
+	}
+}
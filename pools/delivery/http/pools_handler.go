@@ -4,17 +4,12 @@ import (
 	"net/http"
 	"strconv"
 
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
-	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
 	"github.com/osmosis-labs/sqs/sqsdomain"
-
-	"github.com/osmosis-labs/osmosis/osmomath"
-	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
 )
 
 // ResponseError represent the response error struct
@@ -27,21 +22,10 @@ type PoolsHandler struct {
 	PUsecase mvc.PoolsUsecase
 }
 
-// PoolsResponse is a structure for serializing pool result returned to clients.
-type PoolResponse struct {
-	ChainModel poolmanagertypes.PoolI    `json:"chain_model"`
-	Balances   sdk.Coins                 `json:"balances"`
-	Type       poolmanagertypes.PoolType `json:"type"`
-	// In some cases, spread factor might be duplicated in the chain model.
-	// However, we duplicate it here for client convinience to be able to always
-	// rely on it being present.
-	SpreadFactor      osmomath.Dec `json:"spread_factor"`
-	LiquidityCap      osmomath.Int `json:"liquidity_cap"`
-	LiquidityCapError string       `json:"liquidity_cap_error"`
-
-	APRData  passthroughdomain.PoolAPRDataStatusWrap  `json:"apr_data,omitempty"`
-	FeesData passthroughdomain.PoolFeesDataStatusWrap `json:"fees_data,omitempty"`
-}
+// PoolResponse is a structure for serializing pool result returned to clients.
+//
+// Deprecated: use domain.PoolResponse.
+type PoolResponse = domain.PoolResponse
 
 const resourcePrefix = "/pools"
 
@@ -58,6 +42,8 @@ func NewPoolsHandler(e *echo.Echo, us mvc.PoolsUsecase) {
 	e.GET(formatPoolsResource("/ticks/:id"), handler.GetConcentratedPoolTicks)
 	e.GET(formatPoolsResource("/canonical-orderbook"), handler.GetCanonicalOrderbook)
 	e.GET(formatPoolsResource("/canonical-orderbooks"), handler.GetCanonicalOrderbooks)
+	e.GET(formatPoolsResource("/quarantined"), handler.GetQuarantinedPools)
+	e.GET(formatPoolsResource("/alloyed-transmuter-capacity"), handler.GetAlloyTransmuterCapacities)
 	e.GET(formatPoolsResource(""), handler.GetPools)
 }
 
@@ -118,7 +104,7 @@ func (a *PoolsHandler) GetPools(c echo.Context) error {
 	}
 
 	// Convert pools to the appropriate format
-	resultPools := convertPoolsToResponse(pools)
+	resultPools := domain.NewPoolsResponse(pools)
 
 	return c.JSON(http.StatusOK, resultPools)
 }
@@ -208,25 +194,22 @@ func (a *PoolsHandler) GetCanonicalOrderbooks(c echo.Context) error {
 	return c.JSON(http.StatusOK, orderbookData)
 }
 
-// convertPoolToResponse convertes a given pool to the appropriate response type.
-func convertPoolToResponse(pool sqsdomain.PoolI) PoolResponse {
-	return PoolResponse{
-		ChainModel:        pool.GetUnderlyingPool(),
-		Balances:          pool.GetSQSPoolModel().Balances,
-		Type:              pool.GetType(),
-		SpreadFactor:      pool.GetSQSPoolModel().SpreadFactor,
-		LiquidityCap:      pool.GetLiquidityCap(),
-		LiquidityCapError: pool.GetLiquidityCapError(),
-		APRData:           pool.GetAPRData(),
-		FeesData:          pool.GetFeesData(),
-	}
+// @Summary Get pools quarantined from routing.
+// @Description Returns the pools that failed ingest-time validation and are excluded from routing
+// @Description with the reason of the latest failure. Pools are released once a later block passes validation.
+// @Produce  json
+// @Success 200  {array}  domain.QuarantinedPool  "List of quarantined pools sorted by pool ID"
+// @Router /pools/quarantined [get]
+func (a *PoolsHandler) GetQuarantinedPools(c echo.Context) error {
+	return c.JSON(http.StatusOK, a.PUsecase.GetQuarantinedPools())
 }
 
-// convertPoolsToResponse converts the given pools to the appropriate response type.
-func convertPoolsToResponse(pools []sqsdomain.PoolI) []PoolResponse {
-	resultPools := make([]PoolResponse, 0, len(pools))
-	for _, pool := range pools {
-		resultPools = append(resultPools, convertPoolToResponse(pool))
-	}
-	return resultPools
+// @Summary Get rate limiter capacity of alloyed transmuter pools.
+// @Description Returns for each alloyed transmuter pool and asset the current weight, the static upper limit,
+// @Description the change limiter window state and the maximum amount that can be swapped in before a limiter trips.
+// @Produce  json
+// @Success 200  {array}  domain.AlloyTransmuterCapacity  "List of alloyed transmuter pool capacities sorted by pool ID"
+// @Router /pools/alloyed-transmuter-capacity [get]
+func (a *PoolsHandler) GetAlloyTransmuterCapacities(c echo.Context) error {
+	return c.JSON(http.StatusOK, a.PUsecase.GetAlloyTransmuterCapacities())
 }
@@ -52,6 +52,13 @@ type poolsUseCase struct {
 	canonicalOrderBookForBaseQuoteDenom sync.Map
 	canonicalOrderbookPoolIDs           sync.Map
 
+	// quarantinedPools are the pools excluded from routing, keyed by pool ID.
+	quarantinedPools sync.Map
+
+	// poolCircuitBreaker excludes pools with repeated quote failures from routing.
+	// Nil if disabled.
+	poolCircuitBreaker domain.PoolCircuitBreaker
+
 	cosmWasmPoolsParams cosmwasmdomain.CosmWasmPoolsParams
 
 	aprPrefetcher      datafetchers.MapFetcher[uint64, passthroughdomain.PoolAPR]
@@ -94,22 +101,39 @@ func NewPoolsUsecase(poolsConfig *domain.PoolsConfig, chainGRPCGatewayEndpoint s
 		return nil, err
 	}
 
+	cosmWasmPoolRouterConfig := domain.CosmWasmPoolRouterConfig{
+		TransmuterCodeIDs:        transmuterCodeIDsMap,
+		AlloyedTransmuterCodeIDs: alloyedTransmuterCodeIDsMap,
+		OrderbookCodeIDs:         orderbookCodeIDsMap,
+		GeneralCosmWasmCodeIDs:   generalizedCosmWasmCodeIDsMap,
+		CustomCosmWasmCodeIDs:    make(map[uint64]struct{}),
+		ChainGRPCGatewayEndpoint: chainGRPCGatewayEndpoint,
+	}
+
+	cosmWasmPoolRegistry, err := pools.NewDefaultCosmWasmPoolRegistry(cosmWasmPoolRouterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryCache *cosmwasmdomain.QueryCache
+	if poolsConfig.GeneralCosmWasmQueryCache.Enabled {
+		queryCache = cosmwasmdomain.NewQueryCache(wasmClient, poolsConfig.GeneralCosmWasmQueryCache)
+	}
+
 	return &poolsUseCase{
 		pools:            sync.Map{},
 		routerRepository: routerRepository,
 
 		cosmWasmPoolsParams: cosmwasmdomain.CosmWasmPoolsParams{
-			Config: domain.CosmWasmPoolRouterConfig{
-				TransmuterCodeIDs:        transmuterCodeIDsMap,
-				AlloyedTransmuterCodeIDs: alloyedTransmuterCodeIDsMap,
-				OrderbookCodeIDs:         orderbookCodeIDsMap,
-				GeneralCosmWasmCodeIDs:   generalizedCosmWasmCodeIDsMap,
-				ChainGRPCGatewayEndpoint: chainGRPCGatewayEndpoint,
-			},
+			Config: cosmWasmPoolRouterConfig,
 
 			WasmClient: wasmClient,
 
 			ScalingFactorGetterCb: scalingFactorGetterCb,
+
+			QueryCache: queryCache,
+
+			Registry: cosmWasmPoolRegistry,
 		},
 
 		logger: logger,
@@ -133,7 +157,9 @@ func (p *poolsUseCase) GetAllPools() (pools []sqsdomain.PoolI, err error) {
 }
 
 // GetRoutesFromCandidates implements mvc.PoolsUsecase.
-func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error) {
+func (p *poolsUseCase) GetRoutesFromCandidates(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error) {
+	snapshot, isSnapshotPinned := domain.GetStateSnapshot(ctx)
+
 	// We track whether a route contains a generalized cosmwasm pool
 	// so that we can exclude it from split quote logic.
 	// The reason for this is that making network requests to chain is expensive.
@@ -152,13 +178,38 @@ func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.Candida
 		skipErrorRoute := false
 
 		for _, candidatePool := range candidateRoute.Pools {
-			pool, err := p.GetPool(candidatePool.ID)
+			// Skip routes over quarantined pools that might still be cached.
+			if p.IsPoolQuarantined(candidatePool.ID) {
+				skipErrorRoute = true
+				break
+			}
+
+			// Skip routes over pools with an open circuit breaker. These are not excluded
+			// from the candidate route search so that the cached routes retain them
+			// and they are routed again once the breaker is half-open.
+			if p.poolCircuitBreaker != nil && p.poolCircuitBreaker.IsPoolOpen(candidatePool.ID) {
+				skipErrorRoute = true
+				break
+			}
+
+			// Use the pool and taker fee of the pinned state snapshot, if any.
+			var (
+				pool     sqsdomain.PoolI
+				takerFee osmomath.Dec
+				exists   bool
+				err      error
+			)
+			if isSnapshotPinned {
+				pool, err = snapshot.GetPool(candidatePool.ID)
+				takerFee, exists = snapshot.GetTakerFee(previousTokenOutDenom, candidatePool.TokenOutDenom)
+			} else {
+				pool, err = p.GetPool(candidatePool.ID)
+				takerFee, exists = p.routerRepository.GetTakerFee(previousTokenOutDenom, candidatePool.TokenOutDenom)
+			}
 			if err != nil {
 				return nil, err
 			}
 
-			// Get taker fee
-			takerFee, exists := p.routerRepository.GetTakerFee(previousTokenOutDenom, candidatePool.TokenOutDenom)
 			if !exists {
 				takerFee = sqsdomain.DefaultTakerFee
 			}
@@ -236,14 +287,25 @@ func (p *poolsUseCase) GetPool(poolID uint64) (sqsdomain.PoolI, error) {
 
 // GetPoolSpotPrice implements mvc.PoolsUsecase.
 func (p *poolsUseCase) GetPoolSpotPrice(ctx context.Context, poolID uint64, takerFee math.LegacyDec, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
-	pool, err := p.GetPool(poolID)
-	if err != nil {
-		return osmomath.BigDec{}, err
-	}
+	var pool sqsdomain.PoolI
+	if snapshot, ok := domain.GetStateSnapshot(ctx); ok {
+		// The pools of the pinned state snapshot carry their tick models and must not be mutated.
+		var err error
+		pool, err = snapshot.GetPool(poolID)
+		if err != nil {
+			return osmomath.BigDec{}, err
+		}
+	} else {
+		var err error
+		pool, err = p.GetPool(poolID)
+		if err != nil {
+			return osmomath.BigDec{}, err
+		}
 
-	// Instrument pool with tick model data if concentrated
-	if err := p.getTicksAndSetTickModelIfConcentrated(pool); err != nil {
-		return osmomath.BigDec{}, err
+		// Instrument pool with tick model data if concentrated
+		if err := p.getTicksAndSetTickModelIfConcentrated(pool); err != nil {
+			return osmomath.BigDec{}, err
+		}
 	}
 
 	// N.B.: Empty string for token out denom because it is irrelevant for calculating spot price.
@@ -389,6 +451,149 @@ func (p *poolsUseCase) StorePools(pools []sqsdomain.PoolI) error {
 	return nil
 }
 
+// DeletePools implements mvc.PoolsUsecase.
+func (p *poolsUseCase) DeletePools(poolIDs []uint64) {
+	for _, poolID := range poolIDs {
+		p.pools.Delete(poolID)
+		p.quarantinedPools.Delete(poolID)
+
+		// If the pool was a canonical orderbook, remove it so that
+		// it is replaced by the next orderbook stored for the same base and quote denom.
+		if _, isCanonical := p.canonicalOrderbookPoolIDs.LoadAndDelete(poolID); isCanonical {
+			p.canonicalOrderBookForBaseQuoteDenom.Range(func(key, value any) bool {
+				if entry, ok := value.(orderBookEntry); ok && entry.PoolID == poolID {
+					p.canonicalOrderBookForBaseQuoteDenom.Delete(key)
+					return false
+				}
+				return true
+			})
+		}
+	}
+
+	removedPoolIDs := make(map[uint64]struct{}, len(poolIDs))
+	for _, poolID := range poolIDs {
+		removedPoolIDs[poolID] = struct{}{}
+	}
+	p.InvalidateCosmWasmPoolQueries(removedPoolIDs)
+}
+
+// QuarantinePools implements mvc.PoolsUsecase.
+func (p *poolsUseCase) QuarantinePools(quarantinedPools []domain.QuarantinedPool) {
+	for _, quarantinedPool := range quarantinedPools {
+		if previous, ok := p.quarantinedPools.Load(quarantinedPool.PoolID); ok {
+			if previousQuarantinedPool, ok := previous.(domain.QuarantinedPool); ok {
+				quarantinedPool.QuarantinedHeight = previousQuarantinedPool.QuarantinedHeight
+			}
+		}
+
+		p.quarantinedPools.Store(quarantinedPool.PoolID, quarantinedPool)
+	}
+}
+
+// ReleaseQuarantinedPools implements mvc.PoolsUsecase.
+func (p *poolsUseCase) ReleaseQuarantinedPools(poolIDs []uint64) []uint64 {
+	releasedPoolIDs := make([]uint64, 0)
+	for _, poolID := range poolIDs {
+		if _, ok := p.quarantinedPools.LoadAndDelete(poolID); ok {
+			releasedPoolIDs = append(releasedPoolIDs, poolID)
+		}
+	}
+
+	return releasedPoolIDs
+}
+
+// GetQuarantinedPools implements mvc.PoolsUsecase.
+func (p *poolsUseCase) GetQuarantinedPools() []domain.QuarantinedPool {
+	quarantinedPools := make([]domain.QuarantinedPool, 0)
+	p.quarantinedPools.Range(func(_, value any) bool {
+		if quarantinedPool, ok := value.(domain.QuarantinedPool); ok {
+			quarantinedPools = append(quarantinedPools, quarantinedPool)
+		}
+		return true
+	})
+
+	sort.Slice(quarantinedPools, func(i, j int) bool {
+		return quarantinedPools[i].PoolID < quarantinedPools[j].PoolID
+	})
+
+	return quarantinedPools
+}
+
+// GetAlloyTransmuterCapacities implements mvc.PoolsUsecase.
+func (p *poolsUseCase) GetAlloyTransmuterCapacities() []domain.AlloyTransmuterCapacity {
+	capacities := make([]domain.AlloyTransmuterCapacity, 0)
+	p.pools.Range(func(_, value any) bool {
+		pool, ok := value.(sqsdomain.PoolI)
+		if !ok || pool.GetType() != poolmanagertypes.CosmWasm {
+			return true
+		}
+
+		cosmWasmPool, ok := pool.GetUnderlyingPool().(*cosmwasmpoolmodel.CosmWasmPool)
+		if !ok {
+			return true
+		}
+
+		if _, isAlloyedTransmuterCodeID := p.cosmWasmPoolsParams.Config.AlloyedTransmuterCodeIDs[cosmWasmPool.CodeId]; !isAlloyedTransmuterCodeID {
+			return true
+		}
+
+		sqsPoolModel := pool.GetSQSPoolModel()
+		model := sqsPoolModel.CosmWasmPoolModel
+		if model == nil || !model.IsAlloyTransmuter() || model.Data.AlloyTransmuter == nil {
+			return true
+		}
+
+		capacity, err := pools.ComputeAlloyTransmuterCapacity(pool.GetId(), sqsPoolModel.Balances, model.Data.AlloyTransmuter)
+		if err != nil {
+			p.logger.Error("failed to compute alloyed transmuter capacity", zap.Uint64("pool_id", pool.GetId()), zap.Error(err))
+			return true
+		}
+
+		capacities = append(capacities, capacity)
+		return true
+	})
+
+	sort.Slice(capacities, func(i, j int) bool {
+		return capacities[i].PoolID < capacities[j].PoolID
+	})
+
+	return capacities
+}
+
+// IsPoolQuarantined implements mvc.PoolsUsecase.
+func (p *poolsUseCase) IsPoolQuarantined(poolID uint64) bool {
+	_, ok := p.quarantinedPools.Load(poolID)
+	return ok
+}
+
+// RegisterPoolCircuitBreaker implements mvc.PoolsUsecase.
+func (p *poolsUseCase) RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker) {
+	p.poolCircuitBreaker = poolCircuitBreaker
+}
+
+// InvalidateCosmWasmPoolQueries implements mvc.PoolsUsecase.
+func (p *poolsUseCase) InvalidateCosmWasmPoolQueries(poolIDs map[uint64]struct{}) {
+	if p.cosmWasmPoolsParams.QueryCache == nil {
+		return
+	}
+
+	p.cosmWasmPoolsParams.QueryCache.Invalidate(poolIDs)
+}
+
+// RegisterCosmWasmPool implements mvc.PoolsUsecase.
+func (p *poolsUseCase) RegisterCosmWasmPool(registration cosmwasmdomain.PoolRegistration) error {
+	if err := p.cosmWasmPoolsParams.Registry.Register(registration); err != nil {
+		return err
+	}
+
+	// Whitelist the code IDs of the registered pool type for routing.
+	for codeID := range registration.CodeIDs {
+		p.cosmWasmPoolsParams.Config.CustomCosmWasmCodeIDs[codeID] = struct{}{}
+	}
+
+	return nil
+}
+
 // processOrderbookPoolIDForBaseQuote processes the orderbook pool ID for the base and quote denom and pool liquidity
 // capitalization. If the current pool has higher liquidity capitalization than the top liquidity pool, update the top liquidity pool
 // for the given base and quote denom.
@@ -638,13 +843,14 @@ func (p *poolsUseCase) retainPoolIfMatchesOptions(poolsToUpdate []sqsdomain.Pool
 	return poolsToUpdate
 }
 
-// setPoolAPRAndFeeDataIfConfigured sets the APR and fee data for the pool if the options are configured.
+// setPoolAPRAndFeeDataIfConfigured sets the APR and fee data for the pool if the options are configured
+// and the APR and pool fees fetchers are registered.
 // No-op otherwise.
 // Logs an error if fails to get APR or pool fee data.
 // The input pool parameter is mutated.
 // The input options parameter is used to determine whether to set APR and fee data.
 func (p *poolsUseCase) setPoolAPRAndFeeDataIfConfigured(pool sqsdomain.PoolI, options domain.PoolsOptions) {
-	if options.WithMarketIncentives {
+	if options.WithMarketIncentives && p.aprPrefetcher != nil && p.poolFeesPrefetcher != nil {
 		poolID := pool.GetId()
 
 		// Get APR data
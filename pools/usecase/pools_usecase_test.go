@@ -13,6 +13,7 @@ import (
 	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
 	"github.com/stretchr/testify/suite"
 
+	concentratedmodel "github.com/osmosis-labs/osmosis/v25/x/concentrated-liquidity/model"
 	cosmwasmpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
 	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v25/x/gamm/pool-models/stableswap"
@@ -25,6 +26,7 @@ import (
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/pools/usecase"
 	routerrepo "github.com/osmosis-labs/sqs/router/repository"
+	"github.com/osmosis-labs/sqs/router/usecase/circuitbreaker"
 	"github.com/osmosis-labs/sqs/router/usecase/pools"
 	"github.com/osmosis-labs/sqs/router/usecase/route"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
@@ -133,11 +135,13 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 	tests := []struct {
 		name string
 
-		pools           []sqsdomain.PoolI
-		candidateRoutes sqsdomain.CandidateRoutes
-		takerFeeMap     sqsdomain.TakerFeeMap
-		tokenInDenom    string
-		tokenOutDenom   string
+		pools                []sqsdomain.PoolI
+		quarantinedPoolIDs   []uint64
+		circuitBrokenPoolIDs []uint64
+		candidateRoutes      sqsdomain.CandidateRoutes
+		takerFeeMap          sqsdomain.TakerFeeMap
+		tokenInDenom         string
+		tokenOutDenom        string
 
 		expectedError error
 
@@ -215,6 +219,32 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 				},
 			},
 		},
+		{
+			name:               "quarantined pool is skipped",
+			pools:              validPools,
+			quarantinedPoolIDs: []uint64{defaultPoolID},
+
+			candidateRoutes: validCandidateRoutes,
+			takerFeeMap:     validTakerFeeMap,
+
+			tokenInDenom:  denomOne,
+			tokenOutDenom: denomTwo,
+
+			expectedRoutes: []route.RouteImpl{},
+		},
+		{
+			name:                 "pool with open circuit breaker is skipped",
+			pools:                validPools,
+			circuitBrokenPoolIDs: []uint64{defaultPoolID},
+
+			candidateRoutes: validCandidateRoutes,
+			takerFeeMap:     validTakerFeeMap,
+
+			tokenInDenom:  denomOne,
+			tokenOutDenom: denomTwo,
+
+			expectedRoutes: []route.RouteImpl{},
+		},
 
 		// TODO:
 		// Valid conversion of single multi-hop route
@@ -235,8 +265,23 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 
 			poolsUsecase.StorePools(tc.pools)
 
+			for _, poolID := range tc.quarantinedPoolIDs {
+				poolsUsecase.QuarantinePools([]domain.QuarantinedPool{{PoolID: poolID}})
+			}
+
+			poolCircuitBreaker := circuitbreaker.New(domain.PoolCircuitBreakerConfig{
+				Enabled:               true,
+				FailureThreshold:      1,
+				InitialBackoffSeconds: 60,
+				MaxBackoffSeconds:     60,
+			})
+			poolsUsecase.RegisterPoolCircuitBreaker(poolCircuitBreaker)
+			for _, poolID := range tc.circuitBrokenPoolIDs {
+				poolCircuitBreaker.RecordQuoteFailure(poolID, fmt.Errorf("quote failed"))
+			}
+
 			// System under test
-			actualRoutes, err := poolsUsecase.GetRoutesFromCandidates(tc.candidateRoutes, tc.tokenInDenom, tc.tokenOutDenom)
+			actualRoutes, err := poolsUsecase.GetRoutesFromCandidates(context.Background(), tc.candidateRoutes, tc.tokenInDenom, tc.tokenOutDenom)
 
 			if tc.expectedError != nil {
 				s.Require().Error(err)
@@ -973,6 +1018,35 @@ func (s *PoolsUsecaseTestSuite) newRoutablePool(pool sqsdomain.PoolI, tokenOutDe
 	return routablePool
 }
 
+// Tests that quarantined pools retain their quarantine height until released or deleted.
+func (s *PoolsUsecaseTestSuite) TestQuarantinePools() {
+	poolsUsecase := s.newDefaultPoolsUseCase()
+
+	poolsUsecase.QuarantinePools([]domain.QuarantinedPool{
+		{PoolID: 2, Reason: "first", QuarantinedHeight: 10, LatestFailedHeight: 10},
+		{PoolID: 1, Reason: "first", QuarantinedHeight: 10, LatestFailedHeight: 10},
+	})
+
+	// Quarantined again at a later height.
+	poolsUsecase.QuarantinePools([]domain.QuarantinedPool{
+		{PoolID: 2, Reason: "second", QuarantinedHeight: 11, LatestFailedHeight: 11},
+	})
+
+	s.Require().Equal([]domain.QuarantinedPool{
+		{PoolID: 1, Reason: "first", QuarantinedHeight: 10, LatestFailedHeight: 10},
+		{PoolID: 2, Reason: "second", QuarantinedHeight: 10, LatestFailedHeight: 11},
+	}, poolsUsecase.GetQuarantinedPools())
+	s.Require().True(poolsUsecase.IsPoolQuarantined(1))
+
+	// Only the quarantined pools are reported as released.
+	s.Require().Equal([]uint64{1}, poolsUsecase.ReleaseQuarantinedPools([]uint64{1, 3}))
+	s.Require().False(poolsUsecase.IsPoolQuarantined(1))
+
+	// Deleted pools are released.
+	poolsUsecase.DeletePools([]uint64{2})
+	s.Require().Empty(poolsUsecase.GetQuarantinedPools())
+}
+
 func (s *PoolsUsecaseTestSuite) TestetPoolAPRAndFeeDataIfConfigured() {
 
 }
@@ -1021,3 +1095,36 @@ func getMockFeesFetcher(shouldForceFeesFetcherError, isFeeDataStale bool) *mocks
 		},
 	}
 }
+
+// This test validates that the spot price of a concentrated pool is computed from the pinned state snapshot
+// without retrieving its tick model, relying on the snapshot pools carrying their tick models.
+func (s *PoolsUsecaseTestSuite) TestGetPoolSpotPrice_PinnedStateSnapshot() {
+	concentratedPool, err := concentratedmodel.NewConcentratedLiquidityPool(defaultPoolID, denomOne, denomTwo, 100, osmomath.ZeroDec())
+	s.Require().NoError(err)
+	concentratedPool.CurrentSqrtPrice = osmomath.NewBigDec(2)
+
+	tickModel := &sqsdomain.TickModel{
+		Ticks: []sqsdomain.LiquidityDepthsWithRange{
+			{LowerTick: -100, UpperTick: 100, LiquidityAmount: osmomath.NewDec(10)},
+		},
+	}
+
+	snapshot := (*domain.StateSnapshot)(nil).Update(1, []sqsdomain.PoolI{&sqsdomain.PoolWrapper{
+		ChainModel: &concentratedPool,
+		SQSModel:   sqsdomain.SQSPool{PoolLiquidityCap: defaultPoolLiquidityCap, PoolDenoms: []string{denomOne, denomTwo}},
+		TickModel:  tickModel,
+	}}, nil, nil, nil)
+
+	// The latest state is empty so that the pool can only be served from the snapshot.
+	poolsUsecase := s.newDefaultPoolsUseCase()
+
+	ctx := domain.ContextWithStateSnapshot(context.Background(), snapshot)
+
+	spotPrice, err := poolsUsecase.GetPoolSpotPrice(ctx, defaultPoolID, defaultTakerFee, denomTwo, denomOne)
+	s.Require().NoError(err)
+	s.Require().Equal(osmomath.NewBigDec(4), spotPrice)
+
+	// Without a pinned snapshot, the latest state is used.
+	_, err = poolsUsecase.GetPoolSpotPrice(context.Background(), defaultPoolID, defaultTakerFee, denomTwo, denomOne)
+	s.Require().ErrorIs(err, domain.PoolNotFoundError{PoolID: defaultPoolID})
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// PoolCircuitBreakerHandler is the http handler for the pool circuit breaker
+type PoolCircuitBreakerHandler struct {
+	PoolCircuitBreaker domain.PoolCircuitBreaker
+}
+
+// NewPoolCircuitBreakerHandler will initialize the router/pool-circuit-breakers resource endpoint
+func NewPoolCircuitBreakerHandler(e *echo.Echo, poolCircuitBreaker domain.PoolCircuitBreaker) {
+	handler := &PoolCircuitBreakerHandler{
+		PoolCircuitBreaker: poolCircuitBreaker,
+	}
+
+	e.GET(formatRouterResource("/pool-circuit-breakers"), handler.GetPoolCircuitBreakers)
+}
+
+// @Summary Returns the circuit breaker states of pools with recent quote failures.
+// @Description Pools with an open breaker are excluded from routing until their backoff expires.
+// Pools that quote successfully are not returned.
+//
+// @Produce  json
+// @Success 200  {array}  domain.PoolCircuitBreakerState  "Pool circuit breaker states sorted by pool ID"
+// @Router /router/pool-circuit-breakers [get]
+func (h *PoolCircuitBreakerHandler) GetPoolCircuitBreakers(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.PoolCircuitBreaker.GetStates())
+}
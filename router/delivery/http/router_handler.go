@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"go.opentelemetry.io/otel/attribute"
@@ -35,17 +36,18 @@ func formatRouterResource(resource string) string {
 }
 
 // NewRouterHandler will initialize the pools/ resources endpoint
-func NewRouterHandler(e *echo.Echo, us mvc.RouterUsecase, tu mvc.TokensUsecase, logger log.Logger) {
+// The given quote middleware is applied to the quote endpoints only.
+func NewRouterHandler(e *echo.Echo, us mvc.RouterUsecase, tu mvc.TokensUsecase, logger log.Logger, quoteMiddleware ...echo.MiddlewareFunc) {
 	handler := &RouterHandler{
 		RUsecase: us,
 		TUsecase: tu,
 		logger:   logger,
 	}
-	e.GET(formatRouterResource("/quote"), handler.GetOptimalQuote)
+	e.GET(formatRouterResource("/quote"), handler.GetOptimalQuote, quoteMiddleware...)
 	e.GET(formatRouterResource("/routes"), handler.GetCandidateRoutes)
 	e.GET(formatRouterResource("/cached-routes"), handler.GetCachedCandidateRoutes)
 	e.GET(formatRouterResource("/spot-price-pool/:id"), handler.GetSpotPriceForPool)
-	e.GET(formatRouterResource("/custom-direct-quote"), handler.GetDirectCustomQuote)
+	e.GET(formatRouterResource("/custom-direct-quote"), handler.GetDirectCustomQuote, quoteMiddleware...)
 	e.GET(formatRouterResource("/taker-fee-pool/:id"), handler.GetTakerFee)
 	e.POST(formatRouterResource("/store-state"), handler.StoreRouterStateInFiles)
 	e.GET(formatRouterResource("/state"), handler.GetRouterState)
@@ -68,6 +70,11 @@ func NewRouterHandler(e *echo.Echo, us mvc.RouterUsecase, tu mvc.TokensUsecase,
 // @Param  singleRoute     query  bool    false  "Boolean flag indicating whether to return single routes (no splits). False (splits enabled) by default."
 // @Param  humanDenoms     query  bool    true "Boolean flag indicating whether the given denoms are human readable or not. Human denoms get converted to chain internally"
 // @Param  applyExponents  query  bool    false  "Boolean flag indicating whether to apply exponents to the spot price. False by default."
+// @Param  allowedIntermediateDenoms    query  string  false  "Comma-separated denoms. If set, routes may only go through these intermediate denoms."
+// @Param  forbiddenIntermediateDenoms  query  string  false  "Comma-separated denoms that routes must not go through."
+// @Param  mustIncludeDenom             query  string  false  "Denom that every route must go through."
+// @Param  excludeUnlistedTokens        query  bool    false  "Boolean flag indicating whether to exclude routes going through unlisted tokens. False by default."
+// @Param  timeBudgetMs                 query  int     false  "Time budget of computing the quote in milliseconds, overriding the configured one. Once exhausted, the best quote found so far is returned with is_partial set."
 // @Success 200  {object}  domain.Quote  "The computed best route quote"
 // @Router /router/quote [get]
 func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
@@ -90,7 +97,7 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 	}
 
 	// Validate the request
-	if err := req.Validate(); err != nil {
+	if err := req.Validate(uint64(a.RUsecase.GetConfig().MaxQuoteTimeBudgetMs)); err != nil {
 		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
 	}
 
@@ -105,7 +112,7 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 		tokenIn, tokenOutDenom = req.TokenOut, req.TokenInDenom
 	}
 
-	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, []string{tokenIn.Denom, tokenOutDenom})
+	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, append([]string{tokenIn.Denom, tokenOutDenom}, req.Denoms()...))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
 	}
@@ -119,6 +126,14 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 		routerOpts = append(routerOpts, domain.WithMaxSplitRoutes(domain.DisableSplitRoutes))
 	}
 
+	if denomConstraints := req.ToDomain(chainDenoms[2:]); !denomConstraints.IsEmpty() {
+		routerOpts = append(routerOpts, domain.WithCandidateRouteDenomConstraints(denomConstraints))
+	}
+
+	if req.TimeBudgetMs > 0 {
+		routerOpts = append(routerOpts, domain.WithQuoteTimeBudget(time.Duration(req.TimeBudgetMs)*time.Millisecond))
+	}
+
 	var quote domain.Quote
 	if req.SwapMethod() == domain.TokenSwapMethodExactIn {
 		quote, err = a.RUsecase.GetOptimalQuote(ctx, *tokenIn, tokenOutDenom, routerOpts...)
@@ -240,6 +255,10 @@ func (a *RouterHandler) GetDirectCustomQuote(c echo.Context) (err error) {
 // @Param  tokenIn  query  string  true  "The string representation of the denom of the token in"
 // @Param  tokenOutDenom  query  string  true  "The string representation of the denom of the token out"
 // @Param humanDenoms query bool true "Boolean flag indicating whether the given denoms are human readable or not. Human denoms get converted to chain internally"
+// @Param  allowedIntermediateDenoms    query  string  false  "Comma-separated denoms. If set, routes may only go through these intermediate denoms."
+// @Param  forbiddenIntermediateDenoms  query  string  false  "Comma-separated denoms that routes must not go through."
+// @Param  mustIncludeDenom             query  string  false  "Denom that every route must go through."
+// @Param  excludeUnlistedTokens        query  bool    false  "Boolean flag indicating whether to exclude routes going through unlisted tokens. False by default."
 // @Success 200  {array}  sqsdomain.CandidateRoutes  "An array of possible routing options"
 // @Router /router/routes [get]
 func (a *RouterHandler) GetCandidateRoutes(c echo.Context) error {
@@ -250,7 +269,12 @@ func (a *RouterHandler) GetCandidateRoutes(c echo.Context) error {
 		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
 	}
 
-	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, []string{tokenIn, tokenOutDenom})
+	var denomConstraintsReq types.CandidateRouteDenomConstraintsRequest
+	if err := denomConstraintsReq.UnmarshalHTTPRequest(c); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, append([]string{tokenIn, tokenOutDenom}, denomConstraintsReq.Denoms()...))
 	if err != nil {
 		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
 	}
@@ -259,7 +283,12 @@ func (a *RouterHandler) GetCandidateRoutes(c echo.Context) error {
 	tokenIn = chainDenoms[0]
 	tokenOutDenom = chainDenoms[1]
 
-	routes, err := a.RUsecase.GetCandidateRoutes(ctx, sdk.NewCoin(tokenIn, osmomath.OneInt()), tokenOutDenom)
+	var routerOpts []domain.RouterOption
+	if denomConstraints := denomConstraintsReq.ToDomain(chainDenoms[2:]); !denomConstraints.IsEmpty() {
+		routerOpts = append(routerOpts, domain.WithCandidateRouteDenomConstraints(denomConstraints))
+	}
+
+	routes, err := a.RUsecase.GetCandidateRoutes(ctx, sdk.NewCoin(tokenIn, osmomath.OneInt()), tokenOutDenom, routerOpts...)
 	if err != nil {
 		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
 	}
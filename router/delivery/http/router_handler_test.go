@@ -99,10 +99,31 @@ func (s *RouterHandlerSuite) TestGetOptimalQuote() {
 				"singleRoute":    "true",
 				"applyExponents": "true",
 			},
+			handler: &routerdelivery.RouterHandler{
+				RUsecase: &mocks.RouterUsecaseMock{},
+			},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   `{"message": "swap method is invalid - must be either swap exact amount in or swap exact amount out"}`,
 			expectedError:      true,
 		},
+		{
+			name: "time budget above max",
+			queryParams: map[string]string{
+				"tokenIn":       "1000ust",
+				"tokenOutDenom": "usdc",
+				"timeBudgetMs":  "18446744073709551615",
+			},
+			handler: &routerdelivery.RouterHandler{
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetConfigFunc: func() domain.RouterConfig {
+						return domain.RouterConfig{MaxQuoteTimeBudgetMs: 1000}
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "timeBudgetMs is invalid - must not exceed the max quote time budget"}`,
+			expectedError:      true,
+		},
 		{
 			name: "invalid tokenIn format",
 			queryParams: map[string]string{
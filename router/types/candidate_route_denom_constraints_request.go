@@ -0,0 +1,104 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// CandidateRouteDenomConstraintsRequest represents the candidate route denom constraints
+// of the /router/quote and /router/routes endpoints.
+type CandidateRouteDenomConstraintsRequest struct {
+	AllowedIntermediateDenoms   []string
+	ForbiddenIntermediateDenoms []string
+	MustIncludeDenom            string
+	ExcludeUnlistedTokens       bool
+}
+
+// UnmarshalHTTPRequest unmarshals the HTTP request to CandidateRouteDenomConstraintsRequest.
+// It returns an error if the request is invalid.
+func (r *CandidateRouteDenomConstraintsRequest) UnmarshalHTTPRequest(c echo.Context) error {
+	var err error
+	r.ExcludeUnlistedTokens, err = domain.ParseBooleanQueryParam(c, "excludeUnlistedTokens")
+	if err != nil {
+		return err
+	}
+
+	r.AllowedIntermediateDenoms = parseDenomsQueryParam(c, "allowedIntermediateDenoms")
+	r.ForbiddenIntermediateDenoms = parseDenomsQueryParam(c, "forbiddenIntermediateDenoms")
+	r.MustIncludeDenom = strings.TrimSpace(c.QueryParam("mustIncludeDenom"))
+
+	return nil
+}
+
+// UnmarshalGRPCRequest sets the candidate route denom constraints from the gRPC request fields.
+func (r *CandidateRouteDenomConstraintsRequest) UnmarshalGRPCRequest(allowedIntermediateDenoms, forbiddenIntermediateDenoms []string, mustIncludeDenom string, excludeUnlistedTokens bool) {
+	r.ExcludeUnlistedTokens = excludeUnlistedTokens
+	r.AllowedIntermediateDenoms = trimDenoms(allowedIntermediateDenoms)
+	r.ForbiddenIntermediateDenoms = trimDenoms(forbiddenIntermediateDenoms)
+	r.MustIncludeDenom = strings.TrimSpace(mustIncludeDenom)
+}
+
+// Denoms returns all denoms of the request, in order: allowed, forbidden and must-include.
+// Used for translating human denoms to chain denoms.
+func (r *CandidateRouteDenomConstraintsRequest) Denoms() []string {
+	denoms := make([]string, 0, len(r.AllowedIntermediateDenoms)+len(r.ForbiddenIntermediateDenoms)+1)
+	denoms = append(denoms, r.AllowedIntermediateDenoms...)
+	denoms = append(denoms, r.ForbiddenIntermediateDenoms...)
+	if r.MustIncludeDenom != "" {
+		denoms = append(denoms, r.MustIncludeDenom)
+	}
+	return denoms
+}
+
+// ToDomain converts the request to domain.CandidateRouteDenomConstraints, replacing
+// the request denoms with the given chain denoms. chainDenoms must be in the order returned by Denoms.
+func (r *CandidateRouteDenomConstraintsRequest) ToDomain(chainDenoms []string) domain.CandidateRouteDenomConstraints {
+	constraints := domain.CandidateRouteDenomConstraints{
+		ExcludeUnlistedTokens: r.ExcludeUnlistedTokens,
+	}
+
+	if len(r.AllowedIntermediateDenoms) > 0 {
+		constraints.AllowedIntermediateDenoms = toDenomSet(chainDenoms[:len(r.AllowedIntermediateDenoms)])
+	}
+	chainDenoms = chainDenoms[len(r.AllowedIntermediateDenoms):]
+
+	if len(r.ForbiddenIntermediateDenoms) > 0 {
+		constraints.ForbiddenIntermediateDenoms = toDenomSet(chainDenoms[:len(r.ForbiddenIntermediateDenoms)])
+	}
+	chainDenoms = chainDenoms[len(r.ForbiddenIntermediateDenoms):]
+
+	if r.MustIncludeDenom != "" {
+		constraints.MustIncludeDenom = chainDenoms[0]
+	}
+
+	return constraints
+}
+
+// parseDenomsQueryParam parses the comma-separated denoms of the given query param.
+// Returns nil if the param is empty.
+func parseDenomsQueryParam(c echo.Context, param string) []string {
+	return trimDenoms(strings.Split(c.QueryParam(param), ","))
+}
+
+// trimDenoms trims the given denoms and drops the empty ones.
+// Returns nil if no denoms remain.
+func trimDenoms(rawDenoms []string) []string {
+	var denoms []string
+	for _, denom := range rawDenoms {
+		if denom = strings.TrimSpace(denom); denom != "" {
+			denoms = append(denoms, denom)
+		}
+	}
+	return denoms
+}
+
+func toDenomSet(denoms []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(denoms))
+	for _, denom := range denoms {
+		set[denom] = struct{}{}
+	}
+	return set
+}
@@ -13,7 +13,10 @@ var (
 	ErrTokenInNotSpecified             = errors.New("tokenIn is required")
 	ErrSwapMethodNotValid              = errors.New("swap method is invalid - must be either swap exact amount in or swap exact amount out")
 	ErrPoolIDNotValid                  = errors.New("pool ID must be integer")
+	ErrPoolIDNotSpecified              = errors.New("pool ID is required")
 	ErrNumOfTokenOutDenomPoolsMismatch = errors.New("number of tokenOutDenom must be equal to number of pool IDs")
 	ErrNumOfTokenInDenomPoolsMismatch  = errors.New("number of tokenInDenom must be equal to number of pool IDs")
 	ErrInvalidRouteType                = errors.New("invalid route type")
+	ErrTimeBudgetNotValid              = errors.New("timeBudgetMs is invalid - must be a non-negative integer")
+	ErrTimeBudgetTooLarge              = errors.New("timeBudgetMs is invalid - must not exceed the max quote time budget")
 )
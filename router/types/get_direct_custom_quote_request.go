@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/osmosis-labs/sqs/domain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
@@ -18,6 +19,7 @@ type GetDirectCustomQuoteRequest struct {
 	TokenOut       *sdk.Coin
 	TokenInDenom   []string
 	PoolID         []uint64 // list of the pool ID
+	HumanDenoms    bool     // Boolean flag indicating whether the given denoms are human readable or not.
 	ApplyExponents bool     // Boolean flag indicating whether to apply exponents to the spot price. False by default.
 }
 
@@ -66,6 +68,39 @@ func (r *GetDirectCustomQuoteRequest) UnmarshalHTTPRequest(c echo.Context) error
 	return nil
 }
 
+// UnmarshalGRPCRequest unmarshals the gRPC request to GetDirectCustomQuoteRequest.
+// It returns an error if the request is invalid.
+func (r *GetDirectCustomQuoteRequest) UnmarshalGRPCRequest(req *prototypes.QueryCustomDirectQuoteRequest) error {
+	r.HumanDenoms = req.HumanDenoms
+	r.ApplyExponents = req.ApplyExponents
+
+	if req.TokenIn != "" {
+		tokenInCoin, err := sdk.ParseCoinNormalized(req.TokenIn)
+		if err != nil {
+			return ErrTokenInNotValid
+		}
+		r.TokenIn = &tokenInCoin
+	}
+
+	if req.TokenOut != "" {
+		tokenOutCoin, err := sdk.ParseCoinNormalized(req.TokenOut)
+		if err != nil {
+			return ErrTokenOutNotValid
+		}
+		r.TokenOut = &tokenOutCoin
+	}
+
+	r.TokenInDenom = req.TokenInDenoms
+	r.TokenOutDenom = req.TokenOutDenoms
+
+	if len(req.PoolIds) == 0 {
+		return ErrPoolIDNotSpecified
+	}
+	r.PoolID = req.PoolIds
+
+	return nil
+}
+
 // SwapMethod returns the swap method of the request.
 // Request may contain data for both swap methods, only one of them should be specified, otherwise it's invalid.
 func (r *GetDirectCustomQuoteRequest) SwapMethod() domain.TokenSwapMethod {
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/router/types"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -107,6 +108,66 @@ func TestGetDirectCustomQuoteRequestUnmarshal(t *testing.T) {
 	}
 }
 
+// TestGetDirectCustomQuoteRequestUnmarshalGRPC tests the UnmarshalGRPCRequest method of GetDirectCustomQuoteRequest.
+func TestGetDirectCustomQuoteRequestUnmarshalGRPC(t *testing.T) {
+	testcases := []struct {
+		name           string
+		request        *prototypes.QueryCustomDirectQuoteRequest
+		expectedResult *types.GetDirectCustomQuoteRequest
+		expectedError  error
+	}{
+		{
+			name: "valid request",
+			request: &prototypes.QueryCustomDirectQuoteRequest{
+				TokenIn:        "1000ust",
+				TokenOutDenoms: []string{"uosmo", "usdc"},
+				PoolIds:        []uint64{1, 2},
+				HumanDenoms:    true,
+				ApplyExponents: true,
+			},
+			expectedResult: &types.GetDirectCustomQuoteRequest{
+				TokenIn:        &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom:  []string{"uosmo", "usdc"},
+				PoolID:         []uint64{1, 2},
+				HumanDenoms:    true,
+				ApplyExponents: true,
+			},
+		},
+		{
+			name: "invalid tokenIn",
+			request: &prototypes.QueryCustomDirectQuoteRequest{
+				TokenIn:        "invalid_token",
+				TokenOutDenoms: []string{"usdc"},
+				PoolIds:        []uint64{1},
+			},
+			expectedError: types.ErrTokenInNotValid,
+		},
+		{
+			name: "missing pool IDs",
+			request: &prototypes.QueryCustomDirectQuoteRequest{
+				TokenIn:        "1000ust",
+				TokenOutDenoms: []string{"usdc"},
+			},
+			expectedError: types.ErrPoolIDNotSpecified,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result types.GetDirectCustomQuoteRequest
+			err := (&result).UnmarshalGRPCRequest(tc.request)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, &result)
+		})
+	}
+}
+
 // TestGetDirectCustomQuoteRequestSwapMethod tests the SwapMethod method of GetDirectCustomQuoteRequest.
 func TestGetDirectCustomQuoteRequestSwapMethod(t *testing.T) {
 	testcases := []struct {
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"strconv"
+
 	"github.com/osmosis-labs/sqs/domain"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
@@ -16,6 +19,10 @@ type GetQuoteRequest struct {
 	SingleRoute    bool
 	HumanDenoms    bool
 	ApplyExponents bool
+	// TimeBudgetMs overrides the configured quote time budget in milliseconds if non-zero.
+	TimeBudgetMs uint64
+
+	CandidateRouteDenomConstraintsRequest
 }
 
 // UnmarshalHTTPRequest unmarshals the HTTP request to GetQuoteRequest.
@@ -48,9 +55,53 @@ func (r *GetQuoteRequest) UnmarshalHTTPRequest(c echo.Context) error {
 		r.TokenOut = &tokenOutCoin
 	}
 
+	if timeBudgetMs := c.QueryParam("timeBudgetMs"); timeBudgetMs != "" {
+		r.TimeBudgetMs, err = strconv.ParseUint(timeBudgetMs, 10, 64)
+		if err != nil {
+			return ErrTimeBudgetNotValid
+		}
+	}
+
 	r.TokenInDenom = c.QueryParam("tokenInDenom")
 	r.TokenOutDenom = c.QueryParam("tokenOutDenom")
 
+	return r.CandidateRouteDenomConstraintsRequest.UnmarshalHTTPRequest(c)
+}
+
+// UnmarshalGRPCRequest unmarshals the gRPC request to GetQuoteRequest.
+// It returns an error if the request is invalid.
+func (r *GetQuoteRequest) UnmarshalGRPCRequest(req *prototypes.QueryQuoteRequest) error {
+	r.SingleRoute = req.SingleRoute
+	r.HumanDenoms = req.HumanDenoms
+	r.ApplyExponents = req.ApplyExponents
+	r.TimeBudgetMs = req.TimeBudgetMs
+
+	if req.TokenIn != "" {
+		tokenInCoin, err := sdk.ParseCoinNormalized(req.TokenIn)
+		if err != nil {
+			return ErrTokenInNotValid
+		}
+		r.TokenIn = &tokenInCoin
+	}
+
+	if req.TokenOut != "" {
+		tokenOutCoin, err := sdk.ParseCoinNormalized(req.TokenOut)
+		if err != nil {
+			return ErrTokenOutNotValid
+		}
+		r.TokenOut = &tokenOutCoin
+	}
+
+	r.TokenInDenom = req.TokenInDenom
+	r.TokenOutDenom = req.TokenOutDenom
+
+	r.CandidateRouteDenomConstraintsRequest.UnmarshalGRPCRequest(
+		req.AllowedIntermediateDenoms,
+		req.ForbiddenIntermediateDenoms,
+		req.MustIncludeDenom,
+		req.ExcludeUnlistedTokens,
+	)
+
 	return nil
 }
 
@@ -76,12 +127,17 @@ func (r *GetQuoteRequest) SwapMethod() domain.TokenSwapMethod {
 }
 
 // Validate validates the GetQuoteRequest.
-func (r *GetQuoteRequest) Validate() error {
+// maxTimeBudgetMs is the maximum time budget in milliseconds the request may set.
+func (r *GetQuoteRequest) Validate(maxTimeBudgetMs uint64) error {
 	method := r.SwapMethod()
 	if method == domain.TokenSwapMethodInvalid {
 		return ErrSwapMethodNotValid
 	}
 
+	if r.TimeBudgetMs > maxTimeBudgetMs {
+		return ErrTimeBudgetTooLarge
+	}
+
 	// token denoms
 	var a, b string
 
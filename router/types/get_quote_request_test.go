@@ -1,12 +1,14 @@
 package types_test
 
 import (
+	"math"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/router/types"
+	prototypes "github.com/osmosis-labs/sqs/sqsdomain/proto/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -40,6 +42,60 @@ func TestGetQuoteRequestUnmarshal(t *testing.T) {
 				ApplyExponents: true,
 			},
 		},
+		{
+			name: "valid request with candidate route denom constraints",
+			queryParams: map[string]string{
+				"tokenIn":                     "1000ust",
+				"tokenOutDenom":               "usdc",
+				"allowedIntermediateDenoms":   "uosmo, atom",
+				"forbiddenIntermediateDenoms": "weth",
+				"mustIncludeDenom":            "uosmo",
+				"excludeUnlistedTokens":       "true",
+			},
+			expectedResult: &types.GetQuoteRequest{
+				TokenIn:       &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom: "usdc",
+				CandidateRouteDenomConstraintsRequest: types.CandidateRouteDenomConstraintsRequest{
+					AllowedIntermediateDenoms:   []string{"uosmo", "atom"},
+					ForbiddenIntermediateDenoms: []string{"weth"},
+					MustIncludeDenom:            "uosmo",
+					ExcludeUnlistedTokens:       true,
+				},
+			},
+		},
+		{
+			name: "valid request with time budget",
+			queryParams: map[string]string{
+				"tokenIn":       "1000ust",
+				"tokenOutDenom": "usdc",
+				"timeBudgetMs":  "150",
+			},
+			expectedResult: &types.GetQuoteRequest{
+				TokenIn:       &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom: "usdc",
+				TimeBudgetMs:  150,
+			},
+		},
+		{
+			name: "invalid timeBudgetMs param",
+			queryParams: map[string]string{
+				"tokenIn":       "1000ust",
+				"tokenOutDenom": "usdc",
+				"timeBudgetMs":  "-1",
+			},
+			expectedResult: nil,
+			expectedError:  true,
+		},
+		{
+			name: "invalid excludeUnlistedTokens param",
+			queryParams: map[string]string{
+				"tokenIn":               "1000ust",
+				"tokenOutDenom":         "usdc",
+				"excludeUnlistedTokens": "invalid",
+			},
+			expectedResult: nil,
+			expectedError:  true,
+		},
 		{
 			name: "invalid singleRoute param",
 			queryParams: map[string]string{
@@ -114,6 +170,110 @@ func TestGetQuoteRequestUnmarshal(t *testing.T) {
 	}
 }
 
+// TestGetQuoteRequestUnmarshalGRPC tests the UnmarshalGRPCRequest method of GetQuoteRequest.
+func TestGetQuoteRequestUnmarshalGRPC(t *testing.T) {
+	testcases := []struct {
+		name           string
+		request        *prototypes.QueryQuoteRequest
+		expectedResult *types.GetQuoteRequest
+		expectedError  error
+	}{
+		{
+			name: "valid request with candidate route denom constraints",
+			request: &prototypes.QueryQuoteRequest{
+				TokenIn:                     "1000ust",
+				TokenOutDenom:               "usdc",
+				SingleRoute:                 true,
+				HumanDenoms:                 true,
+				TimeBudgetMs:                150,
+				AllowedIntermediateDenoms:   []string{"uosmo", " atom", ""},
+				ForbiddenIntermediateDenoms: []string{"weth"},
+				MustIncludeDenom:            " uosmo",
+				ExcludeUnlistedTokens:       true,
+			},
+			expectedResult: &types.GetQuoteRequest{
+				TokenIn:       &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom: "usdc",
+				SingleRoute:   true,
+				HumanDenoms:   true,
+				TimeBudgetMs:  150,
+				CandidateRouteDenomConstraintsRequest: types.CandidateRouteDenomConstraintsRequest{
+					AllowedIntermediateDenoms:   []string{"uosmo", "atom"},
+					ForbiddenIntermediateDenoms: []string{"weth"},
+					MustIncludeDenom:            "uosmo",
+					ExcludeUnlistedTokens:       true,
+				},
+			},
+		},
+		{
+			name: "valid exact amount out request",
+			request: &prototypes.QueryQuoteRequest{
+				TokenOut:     "1000usdc",
+				TokenInDenom: "atom",
+			},
+			expectedResult: &types.GetQuoteRequest{
+				TokenOut:     &sdk.Coin{Denom: "usdc", Amount: sdk.NewInt(1000)},
+				TokenInDenom: "atom",
+			},
+		},
+		{
+			name: "invalid tokenIn",
+			request: &prototypes.QueryQuoteRequest{
+				TokenIn:       "invalid_token",
+				TokenOutDenom: "usdc",
+			},
+			expectedError: types.ErrTokenInNotValid,
+		},
+		{
+			name: "invalid tokenOut",
+			request: &prototypes.QueryQuoteRequest{
+				TokenOut:     "invalid_token",
+				TokenInDenom: "atom",
+			},
+			expectedError: types.ErrTokenOutNotValid,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result types.GetQuoteRequest
+			err := (&result).UnmarshalGRPCRequest(tc.request)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, &result)
+		})
+	}
+}
+
+// TestCandidateRouteDenomConstraintsRequestToDomain tests the conversion of the request denoms to the domain constraints.
+func TestCandidateRouteDenomConstraintsRequestToDomain(t *testing.T) {
+	req := types.CandidateRouteDenomConstraintsRequest{
+		AllowedIntermediateDenoms:   []string{"osmo", "atom"},
+		ForbiddenIntermediateDenoms: []string{"eth"},
+		MustIncludeDenom:            "osmo",
+		ExcludeUnlistedTokens:       true,
+	}
+
+	assert.Equal(t, []string{"osmo", "atom", "eth", "osmo"}, req.Denoms())
+
+	constraints := req.ToDomain([]string{"uosmo", "uatom", "weth", "uosmo"})
+
+	assert.Equal(t, domain.CandidateRouteDenomConstraints{
+		AllowedIntermediateDenoms:   map[string]struct{}{"uosmo": {}, "uatom": {}},
+		ForbiddenIntermediateDenoms: map[string]struct{}{"weth": {}},
+		MustIncludeDenom:            "uosmo",
+		ExcludeUnlistedTokens:       true,
+	}, constraints)
+
+	// Empty request converts to empty constraints.
+	assert.True(t, (&types.CandidateRouteDenomConstraintsRequest{}).ToDomain(nil).IsEmpty())
+}
+
 // TestGetQuoteRequestSwapMethod tests the SwapMethod method of GetQuoteRequest.
 func TestGetQuoteRequestSwapMethod(t *testing.T) {
 	testcases := []struct {
@@ -178,6 +338,8 @@ func TestGetQuoteRequestSwapMethod(t *testing.T) {
 
 // TestGetQuoteRequestValidate tests the Validate method of GetQuoteRequest.
 func TestGetQuoteRequestValidate(t *testing.T) {
+	const maxTimeBudgetMs = 1000
+
 	testcases := []struct {
 		name          string
 		request       *types.GetQuoteRequest
@@ -231,11 +393,29 @@ func TestGetQuoteRequestValidate(t *testing.T) {
 				DenomB: "usdt",
 			},
 		},
+		{
+			name: "valid request with max time budget",
+			request: &types.GetQuoteRequest{
+				TokenIn:       &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom: "usdc",
+				TimeBudgetMs:  maxTimeBudgetMs,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "invalid request with time budget above max",
+			request: &types.GetQuoteRequest{
+				TokenIn:       &sdk.Coin{Denom: "ust", Amount: sdk.NewInt(1000)},
+				TokenOutDenom: "usdc",
+				TimeBudgetMs:  math.MaxUint64,
+			},
+			expectedError: types.ErrTimeBudgetTooLarge,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.request.Validate()
+			err := tc.request.Validate(maxTimeBudgetMs)
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedError, err)
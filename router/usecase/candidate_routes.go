@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
@@ -22,8 +24,14 @@ type candidateRouteWrapper struct {
 	IsCanonicalOrderboolRoute bool
 }
 
+// candidateRouteDenomDataGetter returns the candidate route search data of a denom.
+// Implemented by both the candidate route search data holder and the state snapshot.
+type candidateRouteDenomDataGetter interface {
+	GetDenomData(denom string) (domain.CandidateRouteDenomData, error)
+}
+
 type candidateRouteFinder struct {
-	candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder
+	candidateRouteDataHolder candidateRouteDenomDataGetter
 	logger                   log.Logger
 }
 
@@ -37,7 +45,10 @@ func NewCandidateRouteFinder(candidateRouteDataHolder mvc.CandidateRouteSearchDa
 }
 
 // FindCandidateRoutes implements domain.CandidateRouteFinder.
-func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
+func (c candidateRouteFinder) FindCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
+	// Search over the data of the pinned state snapshot, if any. c is a copy.
+	c.candidateRouteDataHolder = getCandidateRouteDenomDataGetter(ctx, c.candidateRouteDataHolder)
+
 	routes := make([]candidateRouteWrapper, 0, options.MaxRoutes)
 
 	// Preallocate constant visited map size to avoid reallocations.
@@ -55,41 +66,16 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 		return sqsdomain.CandidateRoutes{}, err
 	}
 
-	if len(denomData.CanonicalOrderbooks) > 0 {
-		canonicalOrderbook, ok := denomData.CanonicalOrderbooks[tokenOutDenom]
-		if ok {
-			shouldSkipCanonicalOrderbook := false
-			// Filter the canonical orderbook pool using the pool filters.
-			for _, filter := range options.PoolFiltersAnyOf {
-				// nolint: forcetypeassert
-				canonicalOrderbookPoolWrapper := (canonicalOrderbook).(*sqsdomain.PoolWrapper)
-				if filter(canonicalOrderbookPoolWrapper) {
-					shouldSkipCanonicalOrderbook = true
-					break
-				}
-			}
-
-			if !shouldSkipCanonicalOrderbook {
-				// Add the canonical orderbook as a route.
-				routes = append(routes, candidateRouteWrapper{
-					IsCanonicalOrderboolRoute: true,
-					Pools: []candidatePoolWrapper{
-						{
-							CandidatePool: sqsdomain.CandidatePool{
-								ID:            canonicalOrderbook.GetId(),
-								TokenOutDenom: tokenOutDenom,
-							},
-							PoolDenoms: canonicalOrderbook.GetSQSPoolModel().PoolDenoms,
-						},
-					},
-				})
-			}
-
-			visited[canonicalOrderbook.GetId()] = struct{}{}
+	if canonicalOrderbook, canonicalOrderbookRoute := getCanonicalOrderbookRoute(denomData, tokenIn.Denom, tokenOutDenom, options); canonicalOrderbook != nil {
+		if canonicalOrderbookRoute != nil {
+			routes = append(routes, *canonicalOrderbookRoute)
 		}
+
+		visited[canonicalOrderbook.GetId()] = struct{}{}
 	}
 
-	for len(queue) > 0 && len(routes) < options.MaxRoutes {
+	// Stop the search once the context is done, keeping the routes found so far.
+	for len(queue) > 0 && len(routes) < options.MaxRoutes && ctx.Err() == nil {
 		currentRoute := queue[0]
 		queue[0] = nil // Clear the slice to avoid holding onto references
 		queue = queue[1:]
@@ -164,20 +150,10 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 			}
 
 			// Microptimization for the first pool in the route.
-			if len(currentRoute) == 0 {
-				currentTokenInAmount := pool.SQSModel.Balances.AmountOf(currenTokenInDenom)
-
-				// HACK: alloyed LP share is not contained in balances.
-				// TODO: remove the hack and ingest the LP share balance on the Osmosis side.
-				// https://linear.app/osmosis/issue/DATA-236/bug-alloyed-lp-share-is-not-present-in-balances
-				cosmwasmModel := pool.SQSModel.CosmWasmPoolModel
-				isAlloyed := cosmwasmModel != nil && cosmwasmModel.IsAlloyTransmuter()
-
-				if currentTokenInAmount.LT(tokenIn.Amount) && !isAlloyed {
-					visited[poolID] = struct{}{}
-					// Not enough tokenIn to swap.
-					continue
-				}
+			if len(currentRoute) == 0 && !hasEnoughTokenIn(pool, tokenIn) {
+				visited[poolID] = struct{}{}
+				// Not enough tokenIn to swap.
+				continue
 			}
 
 			currentPoolID := poolID
@@ -215,12 +191,16 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 
 					if len(newPath) <= options.MaxPoolsPerRoute {
 						if hasTokenOut {
+							if !options.IncludesRequiredDenom(tokenIn.Denom, tokenOutDenom, getIntermediateDenoms(currentRoute)...) {
+								break
+							}
+
 							routes = append(routes, candidateRouteWrapper{
 								Pools:                     newPath,
 								IsCanonicalOrderboolRoute: false,
 							})
 							break
-						} else {
+						} else if !options.ShouldSkipIntermediateDenom(denom) {
 							queue = append(queue, newPath)
 						}
 					}
@@ -236,9 +216,77 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 	return validateAndFilterRoutes(routes, tokenIn.Denom, c.logger)
 }
 
+// getCanonicalOrderbookRoute returns the canonical orderbook between token in and token out
+// from the token in denom data and the direct route through it.
+// Returns nil orderbook if there is no canonical orderbook for the pair.
+// Returns nil route if the orderbook is excluded by the options.
+func getCanonicalOrderbookRoute(tokenInDenomData domain.CandidateRouteDenomData, tokenInDenom, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.PoolI, *candidateRouteWrapper) {
+	canonicalOrderbook, ok := tokenInDenomData.CanonicalOrderbooks[tokenOutDenom]
+	if !ok {
+		return nil, nil
+	}
+
+	// Filter the canonical orderbook pool using the pool filters.
+	// nolint: forcetypeassert
+	if options.ShouldSkipPool(canonicalOrderbook.(*sqsdomain.PoolWrapper)) {
+		return canonicalOrderbook, nil
+	}
+
+	// The direct route has no intermediate denoms.
+	if !options.IncludesRequiredDenom(tokenInDenom, tokenOutDenom) {
+		return canonicalOrderbook, nil
+	}
+
+	return canonicalOrderbook, &candidateRouteWrapper{
+		IsCanonicalOrderboolRoute: true,
+		Pools: []candidatePoolWrapper{
+			{
+				CandidatePool: sqsdomain.CandidatePool{
+					ID:            canonicalOrderbook.GetId(),
+					TokenOutDenom: tokenOutDenom,
+				},
+				PoolDenoms: canonicalOrderbook.GetSQSPoolModel().PoolDenoms,
+			},
+		},
+	}
+}
+
+// hasEnoughTokenIn returns true if the pool has enough token in balance to swap the token in.
+func hasEnoughTokenIn(pool *sqsdomain.PoolWrapper, tokenIn sdk.Coin) bool {
+	currentTokenInAmount := pool.SQSModel.Balances.AmountOf(tokenIn.Denom)
+
+	// HACK: alloyed LP share is not contained in balances.
+	// TODO: remove the hack and ingest the LP share balance on the Osmosis side.
+	// https://linear.app/osmosis/issue/DATA-236/bug-alloyed-lp-share-is-not-present-in-balances
+	cosmwasmModel := pool.SQSModel.CosmWasmPoolModel
+	isAlloyed := cosmwasmModel != nil && cosmwasmModel.IsAlloyTransmuter()
+
+	return currentTokenInAmount.GTE(tokenIn.Amount) || isAlloyed
+}
+
+// getIntermediateDenoms returns the denoms the given route goes through
+// i.e. the token out denoms of all of its pools.
+func getIntermediateDenoms(route []candidatePoolWrapper) []string {
+	denoms := make([]string, 0, len(route))
+	for _, pool := range route {
+		denoms = append(denoms, pool.TokenOutDenom)
+	}
+	return denoms
+}
+
 // Pool represents a pool in the decentralized exchange.
 type Pool struct {
 	ID       int
 	TokenIn  string
 	TokenOut string
 }
+
+// getCandidateRouteDenomDataGetter returns the state snapshot pinned to the context, if any,
+// so that the search observes the data of a single block. Otherwise, returns the given getter of the latest data.
+func getCandidateRouteDenomDataGetter(ctx context.Context, latest candidateRouteDenomDataGetter) candidateRouteDenomDataGetter {
+	if snapshot, ok := domain.GetStateSnapshot(ctx); ok {
+		return snapshot
+	}
+
+	return latest
+}
@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"context"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,6 +12,15 @@ import (
 
 // Microbenchmark for the GetSplitQuote function.
 func BenchmarkCandidateRouteSearcher(b *testing.B) {
+	benchmarkCandidateRouteSearcher(b, domain.CandidateRouteSearchAlgorithmBFS)
+}
+
+// Microbenchmark for the best-first candidate route search.
+func BenchmarkBestFirstCandidateRouteSearcher(b *testing.B) {
+	benchmarkCandidateRouteSearcher(b, domain.CandidateRouteSearchAlgorithmBestFirst)
+}
+
+func benchmarkCandidateRouteSearcher(b *testing.B, algorithm string) {
 	// This is a hack to be able to use test suite helpers with the benchmark.
 	// We need to set testing.T for assertings within the helpers. Otherwise, it would block
 	s := RouterTestSuite{}
@@ -18,7 +28,10 @@ func BenchmarkCandidateRouteSearcher(b *testing.B) {
 
 	mainnetState := s.SetupMainnetState()
 
-	usecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithLoggerDisabled())
+	routerConfig := routertesting.DefaultRouterConfig
+	routerConfig.CandidateRouteSearchAlgorithm = algorithm
+
+	usecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithLoggerDisabled(), routertesting.WithRouterConfig(routerConfig))
 
 	var (
 		amountIn      = osmomath.NewInt(1_000_000)
@@ -26,7 +39,7 @@ func BenchmarkCandidateRouteSearcher(b *testing.B) {
 		tokenOutDenom = ATOM
 	)
 
-	routerConfig := usecase.Router.GetConfig()
+	routerConfig = usecase.Router.GetConfig()
 	candidateRouteOptions := domain.CandidateRouteSearchOptions{
 		MaxRoutes:           routerConfig.MaxRoutes,
 		MaxPoolsPerRoute:    routerConfig.MaxPoolsPerRoute,
@@ -38,7 +51,7 @@ func BenchmarkCandidateRouteSearcher(b *testing.B) {
 	// Run the benchmark
 	for i := 0; i < b.N; i++ {
 		// System under test
-		_, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(tokenIn, tokenOutDenom, candidateRouteOptions)
+		_, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), tokenIn, tokenOutDenom, candidateRouteOptions)
 		s.Require().NoError(err)
 		if err != nil {
 			b.Errorf("FindCandidateRoutes returned an error: %v", err)
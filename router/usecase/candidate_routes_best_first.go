@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
+	"go.uber.org/zap"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// bestFirstCandidateRouteFinder searches candidate routes best-first.
+// Partial routes are scored by the estimated value of their output and the
+// highest scoring ones are expanded first. As a result, a deep route with a better
+// estimated output is found before shallow routes with a poor one.
+//
+// The output of a pool is estimated from its spot price, spread factor and a constant
+// product price impact on its token in balance. The value of a denom is estimated from the
+// liquidity capitalization and balance of its top ranked pool.
+// These heuristics are imperfect and subject to change.
+type bestFirstCandidateRouteFinder struct {
+	candidateRouteDataHolder candidateRouteDenomDataGetter
+	logger                   log.Logger
+}
+
+var _ domain.CandidateRouteSearcher = bestFirstCandidateRouteFinder{}
+
+// bestFirstRoute is a partial or complete route in the best-first search.
+type bestFirstRoute struct {
+	pools []candidatePoolWrapper
+	// denom is the token out denom of the last pool or the token in denom if there are no pools.
+	denom string
+	// amount is the estimated amount of denom out of the route.
+	amount float64
+	// score is the estimated value of amount.
+	score float64
+	// isComplete is true if the route ends in the token out denom.
+	isComplete bool
+}
+
+// bestFirstRouteQueue is a max-heap of routes by score.
+// Ties are broken by estimated amount and then by the number of pools.
+type bestFirstRouteQueue []*bestFirstRoute
+
+var _ heap.Interface = &bestFirstRouteQueue{}
+
+func (q bestFirstRouteQueue) Len() int { return len(q) }
+
+func (q bestFirstRouteQueue) Less(i, j int) bool {
+	if q[i].score != q[j].score {
+		return q[i].score > q[j].score
+	}
+	if q[i].amount != q[j].amount {
+		return q[i].amount > q[j].amount
+	}
+	return len(q[i].pools) < len(q[j].pools)
+}
+
+func (q bestFirstRouteQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *bestFirstRouteQueue) Push(x any) {
+	// nolint: forcetypeassert
+	*q = append(*q, x.(*bestFirstRoute))
+}
+
+func (q *bestFirstRouteQueue) Pop() any {
+	old := *q
+	n := len(old)
+	route := old[n-1]
+	old[n-1] = nil // Clear the pointer to avoid holding onto references
+	*q = old[:n-1]
+	return route
+}
+
+// NewBestFirstCandidateRouteFinder returns a new best-first candidate route finder.
+func NewBestFirstCandidateRouteFinder(candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder, logger log.Logger) bestFirstCandidateRouteFinder {
+	return bestFirstCandidateRouteFinder{
+		candidateRouteDataHolder: candidateRouteDataHolder,
+		logger:                   logger,
+	}
+}
+
+// NewCandidateRouteSearcher returns the candidate route searcher for the given algorithm.
+// Empty algorithm defaults to domain.CandidateRouteSearchAlgorithmBFS.
+// Returns error if the algorithm is not supported.
+func NewCandidateRouteSearcher(algorithm string, candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder, logger log.Logger) (domain.CandidateRouteSearcher, error) {
+	switch algorithm {
+	case "", domain.CandidateRouteSearchAlgorithmBFS:
+		return NewCandidateRouteFinder(candidateRouteDataHolder, logger), nil
+	case domain.CandidateRouteSearchAlgorithmBestFirst:
+		return NewBestFirstCandidateRouteFinder(candidateRouteDataHolder, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported candidate route search algorithm (%s)", algorithm)
+	}
+}
+
+// FindCandidateRoutes implements domain.CandidateRouteFinder.
+func (c bestFirstCandidateRouteFinder) FindCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
+	// Search over the data of the pinned state snapshot, if any. c is a copy.
+	c.candidateRouteDataHolder = getCandidateRouteDenomDataGetter(ctx, c.candidateRouteDataHolder)
+
+	routes := make([]candidateRouteWrapper, 0, options.MaxRoutes)
+
+	// Preallocate constant visited map size to avoid reallocations.
+	visited := make(map[uint64]struct{}, 100)
+
+	// Estimated denom values, computed lazily.
+	denomValues := make(map[string]float64)
+
+	denomData, err := c.candidateRouteDataHolder.GetDenomData(tokenIn.Denom)
+	if err != nil {
+		return sqsdomain.CandidateRoutes{}, err
+	}
+
+	if canonicalOrderbook, canonicalOrderbookRoute := getCanonicalOrderbookRoute(denomData, tokenIn.Denom, tokenOutDenom, options); canonicalOrderbook != nil {
+		if canonicalOrderbookRoute != nil {
+			routes = append(routes, *canonicalOrderbookRoute)
+		}
+
+		visited[canonicalOrderbook.GetId()] = struct{}{}
+	}
+
+	tokenInAmount, _ := tokenIn.Amount.BigIntMut().Float64()
+	tokenInValue, err := c.getDenomValue(tokenIn.Denom, denomValues)
+	if err != nil {
+		return sqsdomain.CandidateRoutes{}, err
+	}
+
+	// Preallocate constant queue size to avoid dynamic reallocations.
+	queue := make(bestFirstRouteQueue, 0, 100)
+	heap.Push(&queue, &bestFirstRoute{
+		pools:  make([]candidatePoolWrapper, 0, options.MaxPoolsPerRoute),
+		denom:  tokenIn.Denom,
+		amount: tokenInAmount,
+		score:  tokenInAmount * tokenInValue,
+	})
+
+	// Stop the search once the context is done, keeping the routes found so far.
+	for queue.Len() > 0 && len(routes) < options.MaxRoutes && ctx.Err() == nil {
+		// nolint: forcetypeassert
+		currentRoute := heap.Pop(&queue).(*bestFirstRoute)
+
+		if currentRoute.isComplete {
+			routes = append(routes, candidateRouteWrapper{
+				Pools:                     currentRoute.pools,
+				IsCanonicalOrderboolRoute: false,
+			})
+			continue
+		}
+
+		if len(currentRoute.pools) >= options.MaxPoolsPerRoute {
+			continue
+		}
+
+		denomData, err := c.candidateRouteDataHolder.GetDenomData(currentRoute.denom)
+		if err != nil {
+			return sqsdomain.CandidateRoutes{}, err
+		}
+
+		rankedPools := denomData.SortedPools
+		if len(rankedPools) == 0 {
+			c.logger.Debug("no pools found for denom in candidate route search", zap.String("denom", currentRoute.denom))
+		}
+
+		for i := 0; i < len(rankedPools); i++ {
+			// Unsafe cast for performance reasons.
+			// nolint: forcetypeassert
+			pool := (rankedPools[i]).(*sqsdomain.PoolWrapper)
+			poolID := pool.ChainModel.GetId()
+
+			if _, ok := visited[poolID]; ok {
+				continue
+			}
+
+			// Avoid swapping through the same pool twice in a row.
+			if len(currentRoute.pools) > 0 && currentRoute.pools[len(currentRoute.pools)-1].ID == poolID {
+				continue
+			}
+
+			// If the option is configured to skip a given pool
+			// We mark it as visited and continue.
+			if options.ShouldSkipPool(pool) {
+				visited[poolID] = struct{}{}
+				continue
+			}
+
+			if pool.GetLiquidityCap().Uint64() < options.MinPoolLiquidityCap {
+				visited[poolID] = struct{}{}
+				// Skip pools that have less liquidity than the minimum required.
+				continue
+			}
+
+			poolDenoms := pool.SQSModel.PoolDenoms
+			hasTokenIn := false
+			hasTokenOut := false
+			shouldSkipPool := false
+			for _, denom := range poolDenoms {
+				if denom == currentRoute.denom {
+					hasTokenIn = true
+				}
+				if denom == tokenOutDenom {
+					hasTokenOut = true
+				}
+
+				// Avoid going through pools that has the initial token in denom twice.
+				if len(currentRoute.pools) > 0 && denom == tokenIn.Denom {
+					shouldSkipPool = true
+					break
+				}
+			}
+
+			if shouldSkipPool || !hasTokenIn {
+				continue
+			}
+
+			if len(currentRoute.pools) == 0 && !hasEnoughTokenIn(pool, tokenIn) {
+				visited[poolID] = struct{}{}
+				// Not enough tokenIn to swap.
+				continue
+			}
+
+			for _, denom := range poolDenoms {
+				if denom == currentRoute.denom {
+					continue
+				}
+				if hasTokenOut && denom != tokenOutDenom {
+					continue
+				}
+
+				if hasTokenOut {
+					if !options.IncludesRequiredDenom(tokenIn.Denom, tokenOutDenom, getIntermediateDenoms(currentRoute.pools)...) {
+						continue
+					}
+				} else {
+					// Routes that cannot reach the token out within the max pools per route are not worth exploring.
+					if len(currentRoute.pools)+1 >= options.MaxPoolsPerRoute {
+						continue
+					}
+
+					if options.ShouldSkipIntermediateDenom(denom) || containsDenom(currentRoute.pools, denom) {
+						continue
+					}
+				}
+
+				amount := estimatePoolAmountOut(pool, currentRoute.denom, denom, currentRoute.amount)
+				if amount <= 0 {
+					continue
+				}
+
+				value, err := c.getDenomValue(denom, denomValues)
+				if err != nil {
+					return sqsdomain.CandidateRoutes{}, err
+				}
+
+				newPath := make([]candidatePoolWrapper, len(currentRoute.pools), len(currentRoute.pools)+1)
+				copy(newPath, currentRoute.pools)
+				newPath = append(newPath, candidatePoolWrapper{
+					CandidatePool: sqsdomain.CandidatePool{
+						ID:            poolID,
+						TokenOutDenom: denom,
+					},
+					PoolDenoms: poolDenoms,
+				})
+
+				heap.Push(&queue, &bestFirstRoute{
+					pools:      newPath,
+					denom:      denom,
+					amount:     amount,
+					score:      amount * value,
+					isComplete: hasTokenOut,
+				})
+			}
+		}
+
+		for _, pool := range currentRoute.pools {
+			visited[pool.ID] = struct{}{}
+		}
+	}
+
+	return validateAndFilterRoutes(routes, tokenIn.Denom, c.logger)
+}
+
+// getDenomValue returns the estimated value of one unit of the given denom, memoizing it in denomValues.
+// The value is estimated from the first ranked pool with the denom that has a liquidity capitalization and
+// a balance of the denom, assuming that the liquidity capitalization is equally split across the pool denoms.
+// Returns zero if the value cannot be estimated.
+func (c bestFirstCandidateRouteFinder) getDenomValue(denom string, denomValues map[string]float64) (float64, error) {
+	if value, ok := denomValues[denom]; ok {
+		return value, nil
+	}
+
+	denomData, err := c.candidateRouteDataHolder.GetDenomData(denom)
+	if err != nil {
+		return 0, err
+	}
+
+	value := float64(0)
+	for _, pool := range denomData.SortedPools {
+		sqsModel := pool.GetSQSPoolModel()
+		if len(sqsModel.PoolDenoms) == 0 {
+			continue
+		}
+
+		liquidityCap, _ := pool.GetLiquidityCap().BigIntMut().Float64()
+		balance, _ := sqsModel.Balances.AmountOf(denom).BigIntMut().Float64()
+		if liquidityCap <= 0 || balance <= 0 {
+			continue
+		}
+
+		value = liquidityCap / float64(len(sqsModel.PoolDenoms)) / balance
+		break
+	}
+
+	denomValues[denom] = value
+
+	return value, nil
+}
+
+// estimatePoolAmountOut estimates the amount of denomOut out of the pool for the given amount of denomIn.
+// The amount is the spot price quote less the spread factor and the constant product price impact
+// on the pool's denomIn balance. The price impact is ignored if the balance is unknown.
+func estimatePoolAmountOut(pool *sqsdomain.PoolWrapper, denomIn, denomOut string, amountIn float64) float64 {
+	spotPrice := estimateSpotPrice(pool, denomIn, denomOut)
+
+	amountOut := amountIn * spotPrice
+
+	if spreadFactor := pool.SQSModel.SpreadFactor; !spreadFactor.IsNil() {
+		spreadFactorFloat, _ := spreadFactor.Float64()
+		amountOut *= 1 - spreadFactorFloat
+	}
+
+	if balanceIn, _ := pool.SQSModel.Balances.AmountOf(denomIn).BigIntMut().Float64(); balanceIn > 0 {
+		amountOut *= balanceIn / (balanceIn + amountIn)
+	}
+
+	return amountOut
+}
+
+// estimateSpotPrice returns the spot price of denomIn in terms of denomOut.
+// Uses the chain model for pools that do not need chain queries to compute it.
+// Otherwise, falls back to the ratio of the pool balances or one if the balances are unknown.
+func estimateSpotPrice(pool *sqsdomain.PoolWrapper, denomIn, denomOut string) float64 {
+	switch pool.ChainModel.GetType() {
+	case poolmanagertypes.Balancer, poolmanagertypes.Stableswap, poolmanagertypes.Concentrated:
+		spotPrice, err := pool.ChainModel.SpotPrice(sdk.Context{}, denomOut, denomIn)
+		if err == nil {
+			if spotPriceFloat, err := spotPrice.Float64(); err == nil {
+				return spotPriceFloat
+			}
+		}
+	}
+
+	balanceIn, _ := pool.SQSModel.Balances.AmountOf(denomIn).BigIntMut().Float64()
+	balanceOut, _ := pool.SQSModel.Balances.AmountOf(denomOut).BigIntMut().Float64()
+	if balanceIn <= 0 || balanceOut <= 0 {
+		return 1
+	}
+
+	return balanceOut / balanceIn
+}
+
+// containsDenom returns true if the given route goes through the given denom.
+func containsDenom(route []candidatePoolWrapper, denom string) bool {
+	for _, pool := range route {
+		if pool.TokenOutDenom == denom {
+			return true
+		}
+	}
+	return false
+}
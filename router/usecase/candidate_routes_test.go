@@ -1,13 +1,17 @@
 package usecase_test
 
 import (
+	"context"
 	"slices"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
 	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/usecase"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 )
@@ -71,7 +75,7 @@ func (s *RouterTestSuite) TestCandidateRouteSearcher_HappyPath() {
 			expectedMinPoolLiquidityCapInt := osmomath.NewInt(int64(routerConfig.MinPoolLiquidityCap))
 
 			// System under test
-			candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(tc.tokenIn, tc.tokenOutDenom, candidateRouteOptions)
+			candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), tc.tokenIn, tc.tokenOutDenom, candidateRouteOptions)
 			s.Require().NoError(err)
 
 			// Validate that at least one route found
@@ -146,7 +150,7 @@ func (s *RouterTestSuite) TestCandidateRouteSearcher_SkipPoolOption() {
 	const expectedPoolID = uint64(1)
 
 	// System under test #1
-	candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, candidateRouteOptions)
+	candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), oneOSMOIn, ATOM, candidateRouteOptions)
 	s.Require().NoError(err)
 
 	// Contains default pool ID
@@ -166,13 +170,190 @@ func (s *RouterTestSuite) TestCandidateRouteSearcher_SkipPoolOption() {
 	}
 
 	// System under test #2
-	candidateRoutes, err = usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, candidateRouteOptions)
+	candidateRoutes, err = usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), oneOSMOIn, ATOM, candidateRouteOptions)
 	s.Require().NoError(err)
 
 	didFindExpectedPoolID = foundExpectedPoolID(expectedPoolID, candidateRoutes.Routes)
 	s.Require().False(didFindExpectedPoolID)
 }
 
+// This test validates that the candidate route denom constraints work as intended
+// by setting up a test between OSMO and ATOM and constraining the routes to go through USDC
+// and, separately, to never go through USDC.
+func (s *RouterTestSuite) TestCandidateRouteSearcher_DenomConstraintsOption() {
+	mainnetState := s.SetupMainnetState()
+
+	usecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	oneOSMOIn := sdk.NewCoin(UOSMO, defaultAmount)
+
+	routerConfig := usecase.Router.GetConfig()
+	candidateRouteOptions := domain.CandidateRouteSearchOptions{
+		MaxRoutes:           routerConfig.MaxRoutes,
+		MaxPoolsPerRoute:    routerConfig.MaxPoolsPerRoute,
+		MinPoolLiquidityCap: routerConfig.MinPoolLiquidityCap,
+		CandidateRouteDenomConstraints: domain.CandidateRouteDenomConstraints{
+			MustIncludeDenom: USDC,
+		},
+	}
+
+	// System under test #1
+	candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), oneOSMOIn, ATOM, candidateRouteOptions)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(candidateRoutes.Routes)
+
+	for _, route := range candidateRoutes.Routes {
+		s.Require().True(containsIntermediateDenom(route, USDC))
+	}
+
+	candidateRouteOptions.CandidateRouteDenomConstraints = domain.CandidateRouteDenomConstraints{
+		ForbiddenIntermediateDenoms: map[string]struct{}{USDC: {}},
+	}
+
+	// System under test #2
+	candidateRoutes, err = usecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), oneOSMOIn, ATOM, candidateRouteOptions)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(candidateRoutes.Routes)
+
+	for _, route := range candidateRoutes.Routes {
+		s.Require().False(containsIntermediateDenom(route, USDC))
+	}
+}
+
+// containsIntermediateDenom returns true if the given route goes through the given denom.
+func containsIntermediateDenom(route sqsdomain.CandidateRoute, denom string) bool {
+	for _, pool := range route.Pools[:len(route.Pools)-1] {
+		if pool.TokenOutDenom == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// This test validates that the best-first candidate route search prefers a deep route with
+// a better estimated output over a shallow one with a poor output while BFS returns the shallow one.
+//
+// Setup:
+// - pool 1: A/D with low liquidity, the direct route suffers high price impact.
+// - pools 2, 3 and 4: A/B, B/C and C/D with high liquidity.
+func (s *RouterTestSuite) TestCandidateRouteSearcher_BestFirst() {
+	const (
+		denomA = "denomA"
+		denomB = "denomB"
+		denomC = "denomC"
+		denomD = "denomD"
+	)
+
+	var (
+		lowLiquidity  = osmomath.NewInt(1_000)
+		highLiquidity = osmomath.NewInt(1_000_000)
+
+		newPool = func(id uint64, liquidity osmomath.Int, denoms ...string) *sqsdomain.PoolWrapper {
+			balances := sdk.NewCoins()
+			for _, denom := range denoms {
+				balances = balances.Add(sdk.NewCoin(denom, liquidity))
+			}
+			return &sqsdomain.PoolWrapper{
+				// CosmWasm pool type so that the spot price is estimated from balances.
+				ChainModel: &mocks.ChainPoolMock{ID: id, Type: poolmanagertypes.CosmWasm},
+				SQSModel: sqsdomain.SQSPool{
+					PoolLiquidityCap: liquidity,
+					Balances:         balances,
+					PoolDenoms:       denoms,
+					SpreadFactor:     osmomath.ZeroDec(),
+				},
+			}
+		}
+
+		directPool = newPool(1, lowLiquidity, denomA, denomD)
+		poolAB     = newPool(2, highLiquidity, denomA, denomB)
+		poolBC     = newPool(3, highLiquidity, denomB, denomC)
+		poolCD     = newPool(4, highLiquidity, denomC, denomD)
+
+		dataHolder = &mocks.CandidateRouteSearchDataHolderMock{
+			CandidateRouteSearchData: map[string]domain.CandidateRouteDenomData{
+				denomA: {SortedPools: []sqsdomain.PoolI{poolAB, directPool}},
+				denomB: {SortedPools: []sqsdomain.PoolI{poolAB, poolBC}},
+				denomC: {SortedPools: []sqsdomain.PoolI{poolBC, poolCD}},
+				denomD: {SortedPools: []sqsdomain.PoolI{poolCD, directPool}},
+			},
+		}
+
+		options = domain.CandidateRouteSearchOptions{
+			MaxRoutes:        1,
+			MaxPoolsPerRoute: 3,
+		}
+
+		tokenIn = sdk.NewCoin(denomA, lowLiquidity)
+	)
+
+	tests := []struct {
+		name string
+
+		algorithm string
+
+		expectedPoolIDs []uint64
+	}{
+		{
+			name:            "bfs - shallow route",
+			algorithm:       domain.CandidateRouteSearchAlgorithmBFS,
+			expectedPoolIDs: []uint64{1},
+		},
+		{
+			name:            "best-first - deep route with better output",
+			algorithm:       domain.CandidateRouteSearchAlgorithmBestFirst,
+			expectedPoolIDs: []uint64{2, 3, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			searcher, err := usecase.NewCandidateRouteSearcher(tc.algorithm, dataHolder, noOpLogger)
+			s.Require().NoError(err)
+
+			// System under test
+			candidateRoutes, err := searcher.FindCandidateRoutes(context.Background(), tokenIn, denomD, options)
+			s.Require().NoError(err)
+
+			s.Require().Len(candidateRoutes.Routes, 1)
+
+			actualPoolIDs := make([]uint64, 0, len(candidateRoutes.Routes[0].Pools))
+			for _, pool := range candidateRoutes.Routes[0].Pools {
+				actualPoolIDs = append(actualPoolIDs, pool.ID)
+			}
+			s.Require().Equal(tc.expectedPoolIDs, actualPoolIDs)
+		})
+	}
+
+	// Canceled context stops the search before any route is found.
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, algorithm := range []string{domain.CandidateRouteSearchAlgorithmBFS, domain.CandidateRouteSearchAlgorithmBestFirst} {
+		searcher, err := usecase.NewCandidateRouteSearcher(algorithm, dataHolder, noOpLogger)
+		s.Require().NoError(err)
+
+		candidateRoutes, err := searcher.FindCandidateRoutes(canceledCtx, tokenIn, denomD, options)
+		s.Require().NoError(err)
+		s.Require().Empty(candidateRoutes.Routes)
+	}
+
+	// Pinned state snapshot is searched instead of the latest search data.
+	var snapshot *domain.StateSnapshot
+	snapshotCtx := domain.ContextWithStateSnapshot(context.Background(), snapshot.Update(1, nil, nil, nil, dataHolder.CandidateRouteSearchData))
+	for _, algorithm := range []string{domain.CandidateRouteSearchAlgorithmBFS, domain.CandidateRouteSearchAlgorithmBestFirst} {
+		searcher, err := usecase.NewCandidateRouteSearcher(algorithm, &mocks.CandidateRouteSearchDataHolderMock{}, noOpLogger)
+		s.Require().NoError(err)
+
+		candidateRoutes, err := searcher.FindCandidateRoutes(snapshotCtx, tokenIn, denomD, options)
+		s.Require().NoError(err)
+		s.Require().Len(candidateRoutes.Routes, 1)
+	}
+
+	// Unsupported algorithm
+	_, err := usecase.NewCandidateRouteSearcher("unsupported", dataHolder, noOpLogger)
+	s.Require().Error(err)
+}
+
 func (s *RouterTestSuite) validateExpectedPoolIDOneHopRoute(route sqsdomain.CandidateRoute, expectedPoolID uint64) {
 	routePools := route.Pools
 	s.Require().Equal(1, len(routePools))
@@ -0,0 +1,12 @@
+package circuitbreaker
+
+import (
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// SetNow overrides the clock of the given pool circuit breaker.
+func SetNow(breaker domain.PoolCircuitBreaker, now func() time.Time) {
+	breaker.(*poolCircuitBreaker).now = now
+}
@@ -0,0 +1,198 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+var _ domain.PoolCircuitBreaker = &poolCircuitBreaker{}
+
+// poolCircuitBreaker implements domain.PoolCircuitBreaker.
+// Only pools with failures since their latest success are tracked.
+type poolCircuitBreaker struct {
+	config domain.PoolCircuitBreakerConfig
+
+	mu    sync.RWMutex
+	pools map[uint64]*poolState
+
+	// now returns the current time. Overridden in tests.
+	now func() time.Time
+}
+
+// poolState is the breaker state of a single pool.
+type poolState struct {
+	consecutiveFailures int
+	trips               int
+	lastError           string
+	// openUntil is zero until the breaker opens for the first time.
+	openUntil time.Time
+}
+
+// New returns a new pool circuit breaker with the given config.
+func New(config domain.PoolCircuitBreakerConfig) domain.PoolCircuitBreaker {
+	return &poolCircuitBreaker{
+		config: config,
+		pools:  make(map[uint64]*poolState),
+		now:    time.Now,
+	}
+}
+
+// RecordQuoteSuccess implements domain.PoolCircuitBreaker.
+func (b *poolCircuitBreaker) RecordQuoteSuccess(poolID uint64) {
+	// Successes are recorded for every pool of every quoted route.
+	// Avoid taking the write lock for pools without failures.
+	b.mu.RLock()
+	_, ok := b.pools[poolID]
+	b.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.pools[poolID]
+	if !ok {
+		return
+	}
+
+	if state.trips > 0 {
+		// Successes of requests that were in flight when the breaker opened
+		// must not close it. Only a success in half-open state closes it.
+		if b.now().Before(state.openUntil) {
+			return
+		}
+
+		domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge.Dec()
+	}
+
+	delete(b.pools, poolID)
+}
+
+// RecordQuoteFailure implements domain.PoolCircuitBreaker.
+func (b *poolCircuitBreaker) RecordQuoteFailure(poolID uint64, err error) {
+	if isExpectedQuoteError(err) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.pools[poolID]
+	if !ok {
+		state = &poolState{}
+		b.pools[poolID] = state
+	}
+
+	state.consecutiveFailures++
+	state.lastError = err.Error()
+
+	now := b.now()
+
+	if state.trips > 0 {
+		// Failures of requests that were in flight when the breaker opened
+		// must not extend the backoff. Only a failure in half-open state reopens it.
+		if now.Before(state.openUntil) {
+			return
+		}
+
+		b.trip(poolID, state, now)
+		return
+	}
+
+	if state.consecutiveFailures >= b.config.FailureThreshold {
+		domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge.Inc()
+
+		b.trip(poolID, state, now)
+	}
+}
+
+// trip opens the breaker of the pool for the initial backoff doubled with every previous trip,
+// capped at the max backoff.
+// CONTRACT: b.mu is held.
+func (b *poolCircuitBreaker) trip(poolID uint64, state *poolState, now time.Time) {
+	backoff := time.Duration(b.config.MaxBackoffSeconds) * time.Second
+
+	// Avoid overflowing the shift for pools that keep failing.
+	if state.trips < 32 {
+		if exponentialBackoff := time.Duration(b.config.InitialBackoffSeconds) * time.Second << state.trips; exponentialBackoff < backoff {
+			backoff = exponentialBackoff
+		}
+	}
+
+	state.trips++
+	state.openUntil = now.Add(backoff)
+
+	domain.SQSRouterPoolCircuitBreakerTripsCounter.WithLabelValues(strconv.FormatUint(poolID, 10)).Inc()
+}
+
+// IsPoolOpen implements domain.PoolCircuitBreaker.
+func (b *poolCircuitBreaker) IsPoolOpen(poolID uint64) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	state, ok := b.pools[poolID]
+	if !ok {
+		return false
+	}
+
+	return b.now().Before(state.openUntil)
+}
+
+// GetStates implements domain.PoolCircuitBreaker.
+func (b *poolCircuitBreaker) GetStates() []domain.PoolCircuitBreakerState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := b.now()
+
+	states := make([]domain.PoolCircuitBreakerState, 0, len(b.pools))
+	for poolID, state := range b.pools {
+		breakerState := domain.PoolCircuitBreakerClosed
+		if state.trips > 0 {
+			breakerState = domain.PoolCircuitBreakerHalfOpen
+			if now.Before(state.openUntil) {
+				breakerState = domain.PoolCircuitBreakerOpen
+			}
+		}
+
+		states = append(states, domain.PoolCircuitBreakerState{
+			PoolID:              poolID,
+			State:               breakerState,
+			ConsecutiveFailures: state.consecutiveFailures,
+			Trips:               state.trips,
+			LastError:           state.lastError,
+			OpenUntil:           state.openUntil,
+		})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].PoolID < states[j].PoolID
+	})
+
+	return states
+}
+
+// isExpectedQuoteError returns true if the error is expected for healthy pools
+// and must not count towards opening the breaker.
+func isExpectedQuoteError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var (
+		concentratedErr domain.ConcentratedNotEnoughLiquidityToCompleteSwapError
+		orderbookErr    domain.OrderbookNotEnoughLiquidityToCompleteSwapError
+		transmuterErr   domain.TransmuterInsufficientBalanceError
+		staticLimitErr  domain.StaticRateLimiterInvalidUpperLimitError
+	)
+
+	return errors.As(err, &concentratedErr) || errors.As(err, &orderbookErr) || errors.As(err, &transmuterErr) ||
+		errors.As(err, &staticLimitErr)
+}
@@ -0,0 +1,164 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/router/usecase/circuitbreaker"
+)
+
+type PoolCircuitBreakerTestSuite struct {
+	suite.Suite
+
+	now time.Time
+}
+
+const (
+	defaultPoolID = uint64(1)
+	otherPoolID   = uint64(2)
+)
+
+var (
+	defaultConfig = domain.PoolCircuitBreakerConfig{
+		Enabled:               true,
+		FailureThreshold:      3,
+		InitialBackoffSeconds: 10,
+		MaxBackoffSeconds:     30,
+	}
+
+	errContractQuery = errors.New("contract query failed")
+)
+
+func TestPoolCircuitBreakerTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolCircuitBreakerTestSuite))
+}
+
+func (s *PoolCircuitBreakerTestSuite) SetupTest() {
+	s.now = time.Unix(1_700_000_000, 0)
+}
+
+// newBreaker returns a breaker with the default config using the suite clock.
+func (s *PoolCircuitBreakerTestSuite) newBreaker() domain.PoolCircuitBreaker {
+	breaker := circuitbreaker.New(defaultConfig)
+	circuitbreaker.SetNow(breaker, func() time.Time { return s.now })
+	return breaker
+}
+
+// recordFailures records the given number of failures of the pool.
+func recordFailures(breaker domain.PoolCircuitBreaker, poolID uint64, count int) {
+	for i := 0; i < count; i++ {
+		breaker.RecordQuoteFailure(poolID, errContractQuery)
+	}
+}
+
+// Tests that the breaker opens after the threshold of consecutive failures,
+// backs off exponentially up to the max backoff and closes on success.
+func (s *PoolCircuitBreakerTestSuite) TestStateTransitions() {
+	breaker := s.newBreaker()
+
+	trippedBefore := testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge)
+	tripsBefore := testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTripsCounter.WithLabelValues(fmt.Sprint(defaultPoolID)))
+
+	// Below the threshold.
+	recordFailures(breaker, defaultPoolID, defaultConfig.FailureThreshold-1)
+	s.Require().False(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Equal([]domain.PoolCircuitBreakerState{{
+		PoolID:              defaultPoolID,
+		State:               domain.PoolCircuitBreakerClosed,
+		ConsecutiveFailures: defaultConfig.FailureThreshold - 1,
+		LastError:           errContractQuery.Error(),
+	}}, breaker.GetStates())
+
+	// Threshold reached, opens for the initial backoff.
+	recordFailures(breaker, defaultPoolID, 1)
+	s.Require().True(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().False(breaker.IsPoolOpen(otherPoolID))
+	s.Require().Equal([]domain.PoolCircuitBreakerState{{
+		PoolID:              defaultPoolID,
+		State:               domain.PoolCircuitBreakerOpen,
+		ConsecutiveFailures: defaultConfig.FailureThreshold,
+		Trips:               1,
+		LastError:           errContractQuery.Error(),
+		OpenUntil:           s.now.Add(10 * time.Second),
+	}}, breaker.GetStates())
+	s.Require().Equal(trippedBefore+1, testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge))
+
+	// Failures of requests in flight while open do not extend the backoff.
+	recordFailures(breaker, defaultPoolID, 1)
+	s.Require().Equal(s.now.Add(10*time.Second), breaker.GetStates()[0].OpenUntil)
+
+	// Successes of requests in flight while open do not close the breaker.
+	breaker.RecordQuoteSuccess(defaultPoolID)
+	s.Require().True(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Equal(1, breaker.GetStates()[0].Trips)
+	s.Require().Equal(trippedBefore+1, testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge))
+
+	// Backoff expires, half-open.
+	s.now = s.now.Add(10 * time.Second)
+	s.Require().False(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Equal(domain.PoolCircuitBreakerHalfOpen, breaker.GetStates()[0].State)
+
+	// Failure in half-open state reopens with doubled backoff.
+	recordFailures(breaker, defaultPoolID, 1)
+	s.Require().True(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Equal(s.now.Add(20*time.Second), breaker.GetStates()[0].OpenUntil)
+
+	// The next backoff is capped at the max backoff.
+	s.now = s.now.Add(20 * time.Second)
+	recordFailures(breaker, defaultPoolID, 1)
+	s.Require().Equal(s.now.Add(30*time.Second), breaker.GetStates()[0].OpenUntil)
+	s.Require().Equal(3, breaker.GetStates()[0].Trips)
+	s.Require().Equal(tripsBefore+3, testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTripsCounter.WithLabelValues(fmt.Sprint(defaultPoolID))))
+
+	// Success in half-open state closes the breaker and resets the pool.
+	s.now = s.now.Add(30 * time.Second)
+	breaker.RecordQuoteSuccess(defaultPoolID)
+	s.Require().False(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Empty(breaker.GetStates())
+	s.Require().Equal(trippedBefore, testutil.ToFloat64(domain.SQSRouterPoolCircuitBreakerTrippedPoolsGauge))
+
+	// The backoff restarts from the initial backoff after a success.
+	recordFailures(breaker, defaultPoolID, defaultConfig.FailureThreshold)
+	s.Require().Equal(s.now.Add(10*time.Second), breaker.GetStates()[0].OpenUntil)
+}
+
+// Tests that a success resets the consecutive failures of a closed breaker.
+func (s *PoolCircuitBreakerTestSuite) TestSuccessResetsFailures() {
+	breaker := s.newBreaker()
+
+	recordFailures(breaker, defaultPoolID, defaultConfig.FailureThreshold-1)
+	breaker.RecordQuoteSuccess(defaultPoolID)
+	recordFailures(breaker, defaultPoolID, defaultConfig.FailureThreshold-1)
+
+	s.Require().False(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Equal(defaultConfig.FailureThreshold-1, breaker.GetStates()[0].ConsecutiveFailures)
+}
+
+// Tests that failures expected for healthy pools are not counted.
+func (s *PoolCircuitBreakerTestSuite) TestExpectedFailuresIgnored() {
+	expectedErrors := []error{
+		context.Canceled,
+		fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+		domain.ConcentratedNotEnoughLiquidityToCompleteSwapError{PoolId: defaultPoolID},
+		domain.OrderbookNotEnoughLiquidityToCompleteSwapError{PoolId: defaultPoolID},
+		domain.TransmuterInsufficientBalanceError{},
+	}
+
+	breaker := s.newBreaker()
+
+	for i := 0; i < defaultConfig.FailureThreshold; i++ {
+		for _, err := range expectedErrors {
+			breaker.RecordQuoteFailure(defaultPoolID, err)
+		}
+	}
+
+	s.Require().False(breaker.IsPoolOpen(defaultPoolID))
+	s.Require().Empty(breaker.GetStates())
+}
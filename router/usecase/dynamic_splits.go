@@ -24,6 +24,7 @@ const totalIncrements = uint8(10)
 // The algorithm is based on the knapsack problem.
 // The time complexity is O(n * m), where n is the number of routes and m is the totalIncrements.
 // The space complexity is O(n * m).
+// Returns the context error if the context is done before the split is found.
 func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin) (domain.Quote, error) {
 	// Routes must be non-empty
 	if len(routes) == 0 {
@@ -76,13 +77,20 @@ func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 	// callback with caching capabilities.
 	computeAndCacheOutAmountCb := getComputeAndCacheOutAmountCb(ctx, inAmountDec, tokenIn.Denom, routes)
 
+	// allocations of capacity limited routes are capped at their capacity.
+	maxRouteIncrements := getMaxRouteIncrements(routes, tokenIn)
+
 	// Step 2: fill the tables
 	for x := uint8(1); x <= totalIncrements; x++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		for j := 1; j <= len(routes); j++ {
 			dp[x][j] = dp[x][j-1] // Not using the j-th route
 			proportions[x][j] = 0 // Default increment (0% of the token)
 
-			for p := uint8(0); p <= x; p++ {
+			for p := uint8(0); p <= x && p <= maxRouteIncrements[j-1]; p++ {
 				// Consider two scenarios:
 				// 1) Not using the j-th route at all, which would yield an output of dp[x][j-1].
 				// 2) Using the j-th route with a certain proportion p of the input.
@@ -179,6 +187,30 @@ func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 	return quote, nil
 }
 
+// getMaxRouteIncrements returns the maximum number of increments that can be allocated to each route.
+// For routes that are capacity limited (e.g. by alloyed transmuter rate limiters), this is the maximum
+// number of increments whose in amount is within the route capacity. For all other routes, it is totalIncrements.
+func getMaxRouteIncrements(routes []route.RouteImpl, tokenIn sdk.Coin) []uint8 {
+	computeAndCacheInAmountIncrementCb := getComputeAndCacheInAmountIncrementCb(tokenIn.Amount.ToLegacyDec())
+
+	maxRouteIncrements := make([]uint8, len(routes))
+	for i := range routes {
+		maxRouteIncrements[i] = totalIncrements
+
+		// On error, the route is not capped and fails on estimation instead.
+		maxTokenInAmount, isLimited, err := routes[i].GetMaxTokenInAmount(tokenIn.Denom)
+		if err != nil || !isLimited {
+			continue
+		}
+
+		for maxRouteIncrements[i] > 0 && computeAndCacheInAmountIncrementCb(maxRouteIncrements[i]).GT(maxTokenInAmount) {
+			maxRouteIncrements[i]--
+		}
+	}
+
+	return maxRouteIncrements
+}
+
 // This function computes the inAmountIncrement for a given proportion p.
 // It caches the result on the stack to avoid recomputing it.
 func getComputeAndCacheInAmountIncrementCb(totalInAmountDec osmomath.Dec) func(p uint8) osmomath.Int {
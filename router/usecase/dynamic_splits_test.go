@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/router/usecase"
 	"github.com/osmosis-labs/sqs/router/usecase/route"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
@@ -27,6 +28,40 @@ func (s *RouterTestSuite) TestGetSplitQuote() {
 	s.Require().NoError(err)
 }
 
+// capacityLimitedPoolMock is a routable pool mock limiting the amount that can be swapped in.
+type capacityLimitedPoolMock struct {
+	*mocks.MockRoutablePool
+
+	maxTokenInAmount osmomath.Int
+}
+
+var _ domain.CapacityLimitedPool = &capacityLimitedPoolMock{}
+
+// GetMaxTokenInAmount implements domain.CapacityLimitedPool.
+func (p *capacityLimitedPoolMock) GetMaxTokenInAmount(tokenInDenom string) (osmomath.Int, bool, error) {
+	return p.maxTokenInAmount, true, nil
+}
+
+// Validates that the allocations of capacity limited routes are capped at their capacity.
+func (s *RouterTestSuite) TestGetMaxRouteIncrements() {
+	tokenIn := sdk.NewCoin(UOSMO, osmomath.NewInt(1_000))
+
+	routes := []route.RouteImpl{
+		// not limited
+		{Pools: []domain.RoutablePool{&mocks.MockRoutablePool{ID: 1, TakerFee: osmomath.ZeroDec()}}},
+		// limited to 25% of the amount
+		{Pools: []domain.RoutablePool{&capacityLimitedPoolMock{MockRoutablePool: &mocks.MockRoutablePool{ID: 2, TakerFee: osmomath.ZeroDec()}, maxTokenInAmount: osmomath.NewInt(250)}}},
+		// limited to 50% of the amount before the taker fee
+		{Pools: []domain.RoutablePool{&capacityLimitedPoolMock{MockRoutablePool: &mocks.MockRoutablePool{ID: 3, TakerFee: osmomath.NewDecWithPrec(5, 1)}, maxTokenInAmount: osmomath.NewInt(250)}}},
+		// no capacity left
+		{Pools: []domain.RoutablePool{&capacityLimitedPoolMock{MockRoutablePool: &mocks.MockRoutablePool{ID: 4, TakerFee: osmomath.ZeroDec()}, maxTokenInAmount: osmomath.ZeroInt()}}},
+	}
+
+	maxRouteIncrements := usecase.GetMaxRouteIncrements(routes, tokenIn)
+
+	s.Require().Equal([]uint8{10, 2, 5, 0}, maxRouteIncrements)
+}
+
 // setupSplitsMainnetTestCase sets up the test case for GetSplitQuote using mainnet state.
 // Calls all the relevant functions as if we were estimating the quote up until starting the
 // splits computation.
@@ -55,7 +90,7 @@ func (s *RouterTestSuite) setupSplitsMainnetTestCase(displayDenomIn string, amou
 		MinPoolLiquidityCap: config.MinPoolLiquidityCap,
 	}
 	// Get candidate routes
-	candidateRoutes, err := useCases.CandidateRouteSearcher.FindCandidateRoutes(tokenIn, chainDenomOut, options)
+	candidateRoutes, err := useCases.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), tokenIn, chainDenomOut, options)
 	s.Require().NoError(err)
 
 	// TODO: consider moving to interface.
@@ -57,6 +57,10 @@ func FormatCandidateRouteCacheKey(tokenInDenom string, tokenOutDenom string) str
 	return formatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom)
 }
 
+func (r *routerUseCaseImpl) GetCandidateRouteCacheKey(tokenIn sdk.Coin, tokenOutDenom string) string {
+	return r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom)
+}
+
 func SortPools(pools []sqsdomain.PoolI, transmuterCodeIDs map[uint64]struct{}, totalTVL osmomath.Int, preferredPoolIDsMap map[uint64]struct{}, logger log.Logger) []sqsdomain.PoolI {
 	return sortPools(pools, transmuterCodeIDs, totalTVL, preferredPoolIDsMap, logger)
 }
@@ -65,6 +69,10 @@ func GetSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 	return getSplitQuote(ctx, routes, tokenIn)
 }
 
+func GetMaxRouteIncrements(routes []route.RouteImpl, tokenIn sdk.Coin) []uint8 {
+	return getMaxRouteIncrements(routes, tokenIn)
+}
+
 func (r *routerUseCaseImpl) RankRoutesByDirectQuote(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenIn sdk.Coin, tokenOutDenom string, maxRoutes int) (domain.Quote, []route.RouteImpl, error) {
 	return r.rankRoutesByDirectQuote(ctx, candidateRoutes, tokenIn, tokenOutDenom, maxRoutes)
 }
@@ -89,3 +97,11 @@ func (r *routerUseCaseImpl) SetRankedRouteCacheToMock(tokenInDenom, tokenOutDeno
 		}}, 0)
 
 }
+
+func FormatQuoteCoalescingKey(swapMethod domain.TokenSwapMethod, tokenIn sdk.Coin, tokenOutDenom string, options domain.RouterOptions, height uint64) (string, bool) {
+	return formatQuoteCoalescingKey(swapMethod, tokenIn, tokenOutDenom, options, height)
+}
+
+func CopyQuote(quote domain.Quote) domain.Quote {
+	return copyQuote(quote)
+}
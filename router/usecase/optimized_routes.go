@@ -18,6 +18,8 @@ import (
 )
 
 // Returns best quote as well as all routes sorted by amount out and error if any.
+// Once the context is done, only the routes estimated so far are ranked. If the quote time budget
+// is exhausted, at least one route taking the full amount is estimated if possible.
 // CONTRACT: router repository must be set on the router.
 // CONTRACT: pools reporitory must be set on the router
 func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin, logger log.Logger) (quote domain.Quote, sortedRoutesByAmtOut []RouteWithOutAmount, err error) {
@@ -29,23 +31,64 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 
 	errors := []error{}
 
+	hasFullAmountRoute := false
 	for _, route := range routes {
-		directRouteTokenOut, err := route.CalculateTokenOutByTokenIn(ctx, tokenIn)
+		// Once the context is done, stop estimating. If the quote time budget is exhausted,
+		// the estimation continues until there is a route to quote with.
+		if ctx.Err() != nil && (hasFullAmountRoute || !isQuoteTimeBudgetExhausted(ctx)) {
+			break
+		}
+
+		// Routes that cannot take the full amount due to the capacity of the first pool
+		// (e.g. alloyed transmuter rate limiters) are estimated at their capacity.
+		// These are kept for split routing where their allocation is capped.
+		routeTokenIn := tokenIn
+		if maxTokenInAmount, isLimited, err := route.GetMaxTokenInAmount(tokenIn.Denom); err == nil && isLimited && maxTokenInAmount.IsPositive() && maxTokenInAmount.LT(tokenIn.Amount) {
+			routeTokenIn = sdk.NewCoin(tokenIn.Denom, maxTokenInAmount)
+		}
+
+		directRouteTokenOut, failedPool, err := route.CalculateTokenOutByTokenInWithFailedPool(ctx, routeTokenIn)
 		if err != nil {
 			logger.Debug("skipping single route due to error in estimate", zap.Error(err))
 			errors = append(errors, err)
+
+			// Failures due to the context being done are not attributed to the pool.
+			if r.poolCircuitBreaker != nil && failedPool != nil && ctx.Err() == nil {
+				r.poolCircuitBreaker.RecordQuoteFailure(failedPool.GetId(), err)
+			}
 			continue
 		}
 
+		if r.poolCircuitBreaker != nil {
+			for _, pool := range route.GetPools() {
+				r.poolCircuitBreaker.RecordQuoteSuccess(pool.GetId())
+			}
+		}
+
 		if directRouteTokenOut.Amount.IsNil() {
 			directRouteTokenOut.Amount = osmomath.ZeroInt()
 		}
 
 		routesWithAmountOut = append(routesWithAmountOut, RouteWithOutAmount{
 			RouteImpl: route,
-			InAmount:  tokenIn.Amount,
+			InAmount:  routeTokenIn.Amount,
 			OutAmount: directRouteTokenOut.Amount,
 		})
+
+		// The best single route must be able to take the full amount.
+		if routeTokenIn.Amount.Equal(tokenIn.Amount) {
+			hasFullAmountRoute = true
+		}
+	}
+
+	// Stopped before estimating any route.
+	if len(routesWithAmountOut) == 0 && len(errors) == 0 {
+		return nil, nil, ctx.Err()
+	}
+
+	if !hasFullAmountRoute && len(routesWithAmountOut) > 0 {
+		errors = append(errors, fmt.Errorf("no route can take the full amount (%s) due to pool capacity", tokenIn))
+		routesWithAmountOut = routesWithAmountOut[:0]
 	}
 
 	// If we skipped all routes due to errors, return the first error
@@ -56,10 +99,15 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 		// If the original routes were computed only through the zero liquidity token, they will be recomputed
 		// through another token due to changed order.
 
+		// Routes failing due to the context being done do not warrant recomputation.
+		if ctx.Err() != nil {
+			return nil, nil, errors[0]
+		}
+
 		// Note: the zero length check occurred at the start of function.
 		tokenOutDenom := routes[0].GetTokenOutDenom()
 
-		r.candidateRouteCache.Delete(formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom))
+		r.candidateRouteCache.Delete(r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom))
 		tokenInOrderOfMagnitude := GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
 		r.rankedRouteCache.Delete(formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude))
 
@@ -71,6 +119,15 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 		return routesWithAmountOut[i].OutAmount.GT(routesWithAmountOut[j].OutAmount)
 	})
 
+	// Move the best route taking the full amount to the front.
+	for i, route := range routesWithAmountOut {
+		if route.InAmount.Equal(tokenIn.Amount) {
+			copy(routesWithAmountOut[1:i+1], routesWithAmountOut[:i])
+			routesWithAmountOut[0] = route
+			break
+		}
+	}
+
 	bestRoute := routesWithAmountOut[0]
 
 	finalQuote := &quoteExactAmountIn{
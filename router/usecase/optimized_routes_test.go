@@ -123,9 +123,10 @@ func (s *RouterTestSuite) TestGetBestSplitRoutesQuote() {
 	tests := map[string]struct {
 		maxSplitIterations int
 
-		routes      []route.RouteImpl
-		tokenIn     sdk.Coin
-		expectError error
+		routes            []route.RouteImpl
+		tokenIn           sdk.Coin
+		isContextCanceled bool
+		expectError       error
 
 		expectedTokenOutDenom string
 
@@ -195,6 +196,26 @@ func (s *RouterTestSuite) TestGetBestSplitRoutesQuote() {
 			expectedProportionInOrder: []int{2, 0, 1},
 		},
 
+		"context canceled": {
+			routes: []route.RouteImpl{
+				// Route 1
+				WithRoutePools(route.RouteImpl{}, []domain.RoutablePool{
+					mocks.WithChainPoolModel(mocks.WithTokenOutDenom(DefaultMockPool, DenomOne), defaultBalancerPool),
+				}),
+
+				// Route 2
+				WithRoutePools(route.RouteImpl{}, []domain.RoutablePool{
+					mocks.WithPoolID(mocks.WithChainPoolModel(mocks.WithTokenOutDenom(DefaultMockPool, DenomOne), secondBalancerPoolSameDenoms), 2),
+				}),
+			},
+
+			tokenIn: sdk.NewCoin(DenomTwo, sdk.NewInt(5_000_000)),
+
+			isContextCanceled: true,
+
+			expectError: context.Canceled,
+		},
+
 		// TODO: cover error cases
 		// TODO: multi route multi hop
 		// TODO: assert that split ratios are correct
@@ -202,7 +223,13 @@ func (s *RouterTestSuite) TestGetBestSplitRoutesQuote() {
 
 	for name, tc := range tests {
 		s.Run(name, func() {
-			quote, err := routerusecase.GetSplitQuote(context.TODO(), tc.routes, tc.tokenIn)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.isContextCanceled {
+				cancel()
+			}
+
+			quote, err := routerusecase.GetSplitQuote(ctx, tc.routes, tc.tokenIn)
 
 			if tc.expectError != nil {
 				s.Require().Error(err)
@@ -0,0 +1,130 @@
+package pools
+
+import (
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cwpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+
+	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+const (
+	TransmuterPoolRegistrationName          = "transmuter"
+	GeneralizedCosmWasmPoolRegistrationName = "generalized-cosmwasm"
+	AlloyedTransmuterPoolRegistrationName   = "alloyed-transmuter"
+	OrderbookPoolRegistrationName           = "orderbook"
+)
+
+// DefaultCosmWasmPoolRegistrations returns the registrations of the built-in CosmWasm pool
+// implementations for the code IDs in the given config.
+// The registrations are returned in the order in which they must be matched.
+func DefaultCosmWasmPoolRegistrations(config domain.CosmWasmPoolRouterConfig) []cosmwasmdomain.PoolRegistration {
+	return []cosmwasmdomain.PoolRegistration{
+		{
+			// Transmuter has a custom implementation since it does not need to interact with the chain.
+			Name:            TransmuterPoolRegistrationName,
+			CodeIDs:         config.TransmuterCodeIDs,
+			NewRoutablePool: newRoutableTransmuterPool,
+		},
+		{
+			// for most other CosmWasm pools, interaction with the chain will
+			// be required. As a result, we have a custom implementation.
+			Name:            GeneralizedCosmWasmPoolRegistrationName,
+			CodeIDs:         config.GeneralCosmWasmCodeIDs,
+			NewRoutablePool: newRoutableGeneralizedCosmWasmPool,
+		},
+		{
+			// since v2, we introduce concept of alloyed assets but not yet actively used
+			// since v3, we introduce concept of normalization factor
+			// `routableAlloyTransmuterPoolImpl` is v3 compatible
+			Name:              AlloyedTransmuterPoolRegistrationName,
+			Contract:          cosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME,
+			VersionConstraint: cosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_VERSION_CONSTRAINT,
+			CodeIDs:           config.AlloyedTransmuterCodeIDs,
+			NewRoutablePool:   newRoutableAlloyTransmuterPool,
+		},
+		{
+			Name:              OrderbookPoolRegistrationName,
+			Contract:          cosmwasmpool.ORDERBOOK_CONTRACT_NAME,
+			VersionConstraint: cosmwasmpool.ORDERBOOK_CONTRACT_VERSION_CONSTRAINT,
+			CodeIDs:           config.OrderbookCodeIDs,
+			NewRoutablePool:   newRoutableOrderbookPool,
+		},
+	}
+}
+
+// NewDefaultCosmWasmPoolRegistry returns a CosmWasm pool registry with the built-in
+// pool implementations registered for the code IDs in the given config.
+func NewDefaultCosmWasmPoolRegistry(config domain.CosmWasmPoolRouterConfig) (*cosmwasmdomain.PoolRegistry, error) {
+	registry := cosmwasmdomain.NewPoolRegistry()
+	for _, registration := range DefaultCosmWasmPoolRegistrations(config) {
+		if err := registry.Register(registration); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func newRoutableTransmuterPool(pool sqsdomain.PoolI, cosmwasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, _ cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+	sqsPoolModel := pool.GetSQSPoolModel()
+
+	return &routableTransmuterPoolImpl{
+		ChainPool:     cosmwasmPool,
+		Balances:      sqsPoolModel.Balances,
+		TokenOutDenom: tokenOutDenom,
+		TakerFee:      takerFee,
+		SpreadFactor:  sqsPoolModel.SpreadFactor,
+	}, nil
+}
+
+func newRoutableGeneralizedCosmWasmPool(pool sqsdomain.PoolI, cosmwasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, cosmWasmPoolsParams cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+	sqsPoolModel := pool.GetSQSPoolModel()
+
+	return NewRoutableCosmWasmPool(cosmwasmPool, sqsPoolModel.Balances, tokenOutDenom, takerFee, sqsPoolModel.SpreadFactor, cosmWasmPoolsParams), nil
+}
+
+// newRoutableAlloyTransmuterPool errors if the pool model does not have the alloyed transmuter data.
+func newRoutableAlloyTransmuterPool(pool sqsdomain.PoolI, cosmwasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, _ cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+	sqsPoolModel := pool.GetSQSPoolModel()
+
+	model := sqsPoolModel.CosmWasmPoolModel
+	if model == nil || model.Data.AlloyTransmuter == nil {
+		return nil, domain.CosmWasmPoolDataMissingError{
+			CosmWasmPoolType: domain.CosmWasmPoolAlloyTransmuter,
+			PoolId:           pool.GetId(),
+		}
+	}
+
+	return &routableAlloyTransmuterPoolImpl{
+		ChainPool:           cosmwasmPool,
+		AlloyTransmuterData: model.Data.AlloyTransmuter,
+		Balances:            sqsPoolModel.Balances,
+		TokenOutDenom:       tokenOutDenom,
+		TakerFee:            takerFee,
+		SpreadFactor:        sqsPoolModel.SpreadFactor,
+	}, nil
+}
+
+// newRoutableOrderbookPool errors if the pool model does not have the orderbook data.
+func newRoutableOrderbookPool(pool sqsdomain.PoolI, cosmwasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, _ cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+	sqsPoolModel := pool.GetSQSPoolModel()
+
+	model := sqsPoolModel.CosmWasmPoolModel
+	if model == nil || model.Data.Orderbook == nil {
+		return nil, domain.CosmWasmPoolDataMissingError{
+			CosmWasmPoolType: domain.CosmWasmPoolOrderbook,
+			PoolId:           pool.GetId(),
+		}
+	}
+
+	return &routableOrderbookPoolImpl{
+		ChainPool:     cosmwasmPool,
+		Balances:      sqsPoolModel.Balances,
+		TokenOutDenom: tokenOutDenom,
+		TakerFee:      takerFee,
+		SpreadFactor:  sqsPoolModel.SpreadFactor,
+		OrderbookData: model.Data.Orderbook,
+	}, nil
+}
@@ -25,7 +25,7 @@ func NewRoutableCosmWasmPoolWithCustomModel(
 	tokenOutDenom string,
 	takerFee osmomath.Dec,
 ) (domain.RoutablePool, error) {
-	return newRoutableCosmWasmPoolWithCustomModel(pool, cosmwasmPool, cosmWasmPoolsParams, tokenOutDenom, takerFee)
+	return newRoutableCosmWasmPoolFromRegistry(pool, cosmwasmPool, cosmWasmPoolsParams, tokenOutDenom, takerFee)
 }
 
 func (r *routableAlloyTransmuterPoolImpl) CheckStaticRateLimiter(tokenInCoin sdk.Coin) error {
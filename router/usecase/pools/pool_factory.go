@@ -89,7 +89,7 @@ func NewRoutablePool(pool sqsdomain.PoolI, tokenOutDenom string, takerFee osmoma
 }
 
 // newRoutableCosmWasmPool creates a new RoutablePool for CosmWasm pools.
-// Panics if the given pool is not a cosmwasm pool or if the
+// Returns error if the given pool is not a cosmwasm pool or if no registered implementation matches the pool.
 func newRoutableCosmWasmPool(pool sqsdomain.PoolI, tokenOutDenom string, takerFee osmomath.Dec, cosmWasmPoolsParams cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
 	chainPool := pool.GetUnderlyingPool()
 	poolType := pool.GetType()
@@ -102,97 +102,37 @@ func newRoutableCosmWasmPool(pool sqsdomain.PoolI, tokenOutDenom string, takerFe
 		}
 	}
 
-	balances := pool.GetSQSPoolModel().Balances
-
-	// Check if the pool is a transmuter pool
-	_, isTransmuter := cosmWasmPoolsParams.Config.TransmuterCodeIDs[cosmwasmPool.CodeId]
-	if isTransmuter {
-		spreadFactor := pool.GetSQSPoolModel().SpreadFactor
-
-		// Transmuter has a custom implementation since it does not need to interact with the chain.
-		return &routableTransmuterPoolImpl{
-			ChainPool:     cosmwasmPool,
-			Balances:      balances,
-			TokenOutDenom: tokenOutDenom,
-			TakerFee:      takerFee,
-			SpreadFactor:  spreadFactor,
-		}, nil
-	}
-
-	_, isGeneralizedCosmWasmPool := cosmWasmPoolsParams.Config.GeneralCosmWasmCodeIDs[cosmwasmPool.CodeId]
-	if isGeneralizedCosmWasmPool {
-		spreadFactor := pool.GetSQSPoolModel().SpreadFactor
-
-		// for most other CosmWasm pools, interaction with the chain will
-		// be required. As a result, we have a custom implementation.
-		return NewRoutableCosmWasmPool(cosmwasmPool, balances, tokenOutDenom, takerFee, spreadFactor, cosmWasmPoolsParams), nil
-	}
-
-	return newRoutableCosmWasmPoolWithCustomModel(pool, cosmwasmPool, cosmWasmPoolsParams, tokenOutDenom, takerFee)
+	return newRoutableCosmWasmPoolFromRegistry(pool, cosmwasmPool, cosmWasmPoolsParams, tokenOutDenom, takerFee)
 }
 
-// newRoutableCosmWasmPoolWithCustomModel creates a new RoutablePool for CosmWasm pools that require a custom CosmWasmPoolModel.
+// newRoutableCosmWasmPoolFromRegistry creates a new RoutablePool for the given CosmWasm pool
+// using the first matching implementation in the registry.
+// If the registry is not set, the built-in implementations for the configured code IDs are used.
 // errors if:
-// - the pool matched criteria for a custom model, but the model does not have the required data.
-// - the pool's `CosmWasmPoolModel` is nil
-// returns a routable pool constructed with custom model otherwise
-func newRoutableCosmWasmPoolWithCustomModel(
+// - no registered implementation matches the pool's code ID and contract info.
+// - the matched implementation fails to construct the pool (e.g. the pool model is missing required data).
+func newRoutableCosmWasmPoolFromRegistry(
 	pool sqsdomain.PoolI,
 	cosmwasmPool *cwpoolmodel.CosmWasmPool,
 	cosmWasmPoolsParams cosmwasmdomain.CosmWasmPoolsParams,
 	tokenOutDenom string,
 	takerFee osmomath.Dec,
 ) (domain.RoutablePool, error) {
-	sqsPoolModel := pool.GetSQSPoolModel()
-
-	// Check if the pool is a transmuter pool with alloyed assets
-	model := sqsPoolModel.CosmWasmPoolModel
-	balances := sqsPoolModel.Balances
-	spreadFactor := sqsPoolModel.SpreadFactor
-	if model != nil {
-		// since v2, we introduce concept of alloyed assets but not yet actively used
-		// since v3, we introduce concept of normalization factor
-		// `routableAlloyTransmuterPoolImpl` is v3 compatible
-		_, isAlloyedTransmuterCodeId := cosmWasmPoolsParams.Config.AlloyedTransmuterCodeIDs[cosmwasmPool.CodeId]
-		if isAlloyedTransmuterCodeId && model.IsAlloyTransmuter() {
-			if model.Data.AlloyTransmuter == nil {
-				return nil, domain.CosmWasmPoolDataMissingError{
-					CosmWasmPoolType: domain.CosmWasmPoolAlloyTransmuter,
-					PoolId:           pool.GetId(),
-				}
-			}
-
-			return &routableAlloyTransmuterPoolImpl{
-				ChainPool:           cosmwasmPool,
-				AlloyTransmuterData: model.Data.AlloyTransmuter,
-				Balances:            balances,
-				TokenOutDenom:       tokenOutDenom,
-				TakerFee:            takerFee,
-				SpreadFactor:        spreadFactor,
-			}, nil
+	registry := cosmWasmPoolsParams.Registry
+	if registry == nil {
+		var err error
+		registry, err = NewDefaultCosmWasmPoolRegistry(cosmWasmPoolsParams.Config)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		_, isOrderbookCodeId := cosmWasmPoolsParams.Config.OrderbookCodeIDs[cosmwasmPool.CodeId]
-		if isOrderbookCodeId && model.IsOrderbook() {
-			if model.Data.Orderbook == nil {
-				return nil, domain.CosmWasmPoolDataMissingError{
-					CosmWasmPoolType: domain.CosmWasmPoolOrderbook,
-					PoolId:           pool.GetId(),
-				}
-			}
-
-			return &routableOrderbookPoolImpl{
-				ChainPool:     cosmwasmPool,
-				Balances:      balances,
-				TokenOutDenom: tokenOutDenom,
-				TakerFee:      takerFee,
-				SpreadFactor:  spreadFactor,
-				OrderbookData: model.Data.Orderbook,
-			}, nil
+	registration, ok := registry.Match(cosmwasmPool.CodeId, pool.GetSQSPoolModel().CosmWasmPoolModel)
+	if !ok {
+		return nil, domain.UnsupportedCosmWasmPoolError{
+			PoolId: cosmwasmPool.PoolId,
 		}
 	}
 
-	return nil, domain.UnsupportedCosmWasmPoolError{
-		PoolId: cosmwasmPool.PoolId,
-	}
+	return registration.NewRoutablePool(pool, cosmwasmPool, tokenOutDenom, takerFee, cosmWasmPoolsParams)
 }
@@ -204,3 +204,34 @@ func TestNewRoutableCosmWasmPoolWithCustomModel(t *testing.T) {
 		})
 	}
 }
+
+// Validates that a pool implementation registered in the cosmwasm pool registry
+// is used for the pools matching its registration.
+func TestNewRoutableCosmWasmPool_CustomRegistration(t *testing.T) {
+	const customContractName = "crates.io:custom-pool"
+
+	customCosmWasmPool := cwpoolmodel.CosmWasmPool{CodeId: 5, PoolId: 200}
+	customPool := &mocks.MockRoutablePool{
+		ID:                customCosmWasmPool.PoolId,
+		CosmWasmPoolModel: cosmwasmpool.NewCWPoolModel(customContractName, "1.0.0", cosmwasmpool.CosmWasmPoolData{}),
+	}
+	expectedRoutablePool := &mocks.MockRoutablePool{ID: customCosmWasmPool.PoolId}
+
+	registry, err := pools.NewDefaultCosmWasmPoolRegistry(domain.CosmWasmPoolRouterConfig{})
+	require.NoError(t, err)
+
+	err = registry.Register(cosmwasmdomain.PoolRegistration{
+		Name:              "custom",
+		Contract:          customContractName,
+		VersionConstraint: ">= 1.0.0",
+		CodeIDs:           map[uint64]struct{}{customCosmWasmPool.CodeId: {}},
+		NewRoutablePool: func(pool sqsdomain.PoolI, cosmWasmPool *cwpoolmodel.CosmWasmPool, tokenOutDenom string, takerFee osmomath.Dec, params cosmwasmdomain.CosmWasmPoolsParams) (domain.RoutablePool, error) {
+			return expectedRoutablePool, nil
+		},
+	})
+	require.NoError(t, err)
+
+	routablePool, err := pools.NewRoutableCosmWasmPoolWithCustomModel(customPool, &customCosmWasmPool, cosmwasmdomain.CosmWasmPoolsParams{Registry: registry}, "token", osmomath.ZeroDec())
+	require.NoError(t, err)
+	require.Equal(t, expectedRoutablePool, routablePool)
+}
@@ -0,0 +1,323 @@
+package pools
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+var _ domain.CapacityLimitedPool = &routableAlloyTransmuterPoolImpl{}
+
+// GetMaxTokenInAmount implements domain.CapacityLimitedPool.
+// Returns the maximum amount of the given denom that can be swapped in before the static rate limiter trips.
+// The change rate limiter is not considered since its moving average is approximated as of the latest
+// division update rather than evaluated at the block time of the swap. See ComputeAlloyTransmuterCapacity.
+func (r *routableAlloyTransmuterPoolImpl) GetMaxTokenInAmount(tokenInDenom string) (osmomath.Int, bool, error) {
+	upperLimit, isLimited, err := getStaticWeightUpperLimit(r.AlloyTransmuterData.RateLimiterConfig, tokenInDenom)
+	if err != nil || !isLimited {
+		return osmomath.Int{}, false, err
+	}
+
+	normalizedBalances, normalizedTotal, err := computeNormalizedBalances(r.GetId(), r.Balances, r.AlloyTransmuterData)
+	if err != nil {
+		return osmomath.Int{}, false, err
+	}
+
+	return computeMaxTokenInAmount(r.GetId(), r.AlloyTransmuterData, normalizedBalances, normalizedTotal, tokenInDenom, upperLimit)
+}
+
+// ComputeAlloyTransmuterCapacity returns the rate limiter state and the maximum swap in amount
+// of each asset in the alloyed transmuter pool with the given balances.
+// The maximum swap in amount accounts for the change rate limiter, making it an estimate.
+// See computeChangeLimiterState. The alloyed LP share is skipped.
+// Returns error if the pool data is malformed.
+func ComputeAlloyTransmuterCapacity(poolID uint64, balances sdk.Coins, data *cosmwasmpool.AlloyTransmuterData) (domain.AlloyTransmuterCapacity, error) {
+	normalizedBalances, normalizedTotal, err := computeNormalizedBalances(poolID, balances, data)
+	if err != nil {
+		return domain.AlloyTransmuterCapacity{}, err
+	}
+
+	capacity := domain.AlloyTransmuterCapacity{
+		PoolID:       poolID,
+		AlloyedDenom: data.AlloyedDenom,
+		Assets:       make([]domain.AlloyTransmuterAssetCapacity, 0, len(data.AssetConfigs)),
+	}
+
+	for _, assetConfig := range data.AssetConfigs {
+		denom := assetConfig.Denom
+
+		// Skip if the asset is alloyed LP share
+		if strings.Contains(denom, alloyedLPShareDenomComponent) {
+			continue
+		}
+
+		assetCapacity := domain.AlloyTransmuterAssetCapacity{
+			Denom:  denom,
+			Weight: osmomath.ZeroDec(),
+		}
+
+		if normalizedTotal.IsPositive() {
+			assetCapacity.Weight = normalizedBalances[denom].ToLegacyDec().Quo(normalizedTotal.ToLegacyDec())
+		}
+
+		if staticLimiter, ok := data.RateLimiterConfig.GetStaticLimiter(denom); ok {
+			staticUpperLimit, err := osmomath.NewDecFromStr(staticLimiter.UpperLimit)
+			if err != nil {
+				return domain.AlloyTransmuterCapacity{}, err
+			}
+			assetCapacity.StaticUpperLimit = &staticUpperLimit
+		}
+
+		if changeLimiter, ok := data.RateLimiterConfig.GetChangeLimiter(denom); ok {
+			state, isSet, err := computeChangeLimiterState(changeLimiter)
+			if err != nil {
+				return domain.AlloyTransmuterCapacity{}, err
+			}
+			if isSet {
+				assetCapacity.ChangeLimiter = &state
+			}
+		}
+
+		upperLimit, isLimited, err := getWeightUpperLimit(data.RateLimiterConfig, denom)
+		if err != nil {
+			return domain.AlloyTransmuterCapacity{}, err
+		}
+
+		if isLimited {
+			maxSwapInAmount, isLimited, err := computeMaxTokenInAmount(poolID, data, normalizedBalances, normalizedTotal, denom, upperLimit)
+			if err != nil {
+				return domain.AlloyTransmuterCapacity{}, err
+			}
+			if isLimited {
+				assetCapacity.MaxSwapInAmount = &maxSwapInAmount
+			}
+		}
+
+		capacity.Assets = append(capacity.Assets, assetCapacity)
+	}
+
+	return capacity, nil
+}
+
+// computeNormalizedBalances returns the normalized balances of the pool assets
+// excluding the alloyed LP share as well as their total.
+func computeNormalizedBalances(poolID uint64, balances sdk.Coins, data *cosmwasmpool.AlloyTransmuterData) (map[string]osmomath.Int, osmomath.Int, error) {
+	normalizationFactors := data.PreComputedData.NormalizationScalingFactors
+
+	normalizedBalances := make(map[string]osmomath.Int, len(data.AssetConfigs))
+	normalizedTotal := osmomath.ZeroInt()
+
+	for _, assetConfig := range data.AssetConfigs {
+		assetDenom := assetConfig.Denom
+
+		// Skip if the asset is alloyed LP share
+		if strings.Contains(assetDenom, alloyedLPShareDenomComponent) {
+			continue
+		}
+
+		normalizationScalingFactor, ok := normalizationFactors[assetDenom]
+		if !ok {
+			return nil, osmomath.Int{}, fmt.Errorf("normalization scaling factor not found for asset %s, pool id %d", assetDenom, poolID)
+		}
+
+		normalizedBalance := balances.AmountOf(assetDenom).Mul(normalizationScalingFactor)
+
+		normalizedBalances[assetDenom] = normalizedBalance
+		normalizedTotal = normalizedTotal.Add(normalizedBalance)
+	}
+
+	return normalizedBalances, normalizedTotal, nil
+}
+
+// computeWeightAfterSwapIn returns the weight of the token in denom after swapping in the given coin.
+func computeWeightAfterSwapIn(poolID uint64, data *cosmwasmpool.AlloyTransmuterData, normalizedBalances map[string]osmomath.Int, normalizedTotal osmomath.Int, tokenIn sdk.Coin) (osmomath.Dec, error) {
+	normalizationScalingFactor, ok := data.PreComputedData.NormalizationScalingFactors[tokenIn.Denom]
+	if !ok {
+		return osmomath.Dec{}, fmt.Errorf("normalization scaling factor not found for asset %s, pool id %d", tokenIn.Denom, poolID)
+	}
+
+	normalizedTokenIn := tokenIn.Amount.Mul(normalizationScalingFactor)
+
+	normalizedTotal = normalizedTotal.Add(normalizedTokenIn)
+	if normalizedTotal.IsZero() {
+		return osmomath.ZeroDec(), nil
+	}
+
+	tokenInNormalizedBalance, ok := normalizedBalances[tokenIn.Denom]
+	if !ok {
+		tokenInNormalizedBalance = osmomath.ZeroInt()
+	}
+
+	return tokenInNormalizedBalance.Add(normalizedTokenIn).ToLegacyDec().Quo(normalizedTotal.ToLegacyDec()), nil
+}
+
+// getWeightUpperLimit returns the most restrictive weight upper limit of the static and the change
+// rate limiters for the given denom.
+// Returns false if neither limiter is set.
+func getWeightUpperLimit(rateLimiter cosmwasmpool.AlloyedRateLimiter, denom string) (osmomath.Dec, bool, error) {
+	upperLimit, isLimited, err := getStaticWeightUpperLimit(rateLimiter, denom)
+	if err != nil {
+		return osmomath.Dec{}, false, err
+	}
+
+	if changeLimiter, ok := rateLimiter.GetChangeLimiter(denom); ok {
+		state, isSet, err := computeChangeLimiterState(changeLimiter)
+		if err != nil {
+			return osmomath.Dec{}, false, err
+		}
+
+		if isSet && (!isLimited || state.UpperLimit.LT(upperLimit)) {
+			upperLimit, isLimited = state.UpperLimit, true
+		}
+	}
+
+	return upperLimit, isLimited, nil
+}
+
+// getStaticWeightUpperLimit returns the weight upper limit of the static rate limiter for the given denom.
+// Returns false if the limiter is not set.
+func getStaticWeightUpperLimit(rateLimiter cosmwasmpool.AlloyedRateLimiter, denom string) (osmomath.Dec, bool, error) {
+	staticLimiter, ok := rateLimiter.GetStaticLimiter(denom)
+	if !ok {
+		return osmomath.Dec{}, false, nil
+	}
+
+	staticUpperLimit, err := osmomath.NewDecFromStr(staticLimiter.UpperLimit)
+	if err != nil {
+		return osmomath.Dec{}, false, err
+	}
+
+	return staticUpperLimit, true, nil
+}
+
+// computeMaxTokenInAmount returns the maximum amount of the token in denom that can be swapped in
+// for its weight to stay within the given upper limit.
+//
+// (n + x * s) / (N + x * s) <= U
+// x <= (U * N - n) / (s * (1 - U))
+//
+// where n is the normalized token in balance, N is the normalized total, s is the
+// normalization scaling factor of the token in and U is the upper limit.
+// Returns false if the amount is not limited (U >= 1).
+func computeMaxTokenInAmount(poolID uint64, data *cosmwasmpool.AlloyTransmuterData, normalizedBalances map[string]osmomath.Int, normalizedTotal osmomath.Int, tokenInDenom string, upperLimit osmomath.Dec) (osmomath.Int, bool, error) {
+	if upperLimit.GTE(osmomath.OneDec()) {
+		return osmomath.Int{}, false, nil
+	}
+
+	normalizationScalingFactor, ok := data.PreComputedData.NormalizationScalingFactors[tokenInDenom]
+	if !ok {
+		return osmomath.Int{}, false, fmt.Errorf("normalization scaling factor not found for asset %s, pool id %d", tokenInDenom, poolID)
+	}
+
+	if !normalizationScalingFactor.IsPositive() {
+		return osmomath.Int{}, false, domain.ZeroNormalizationFactorError{Denom: tokenInDenom, PoolId: poolID}
+	}
+
+	tokenInNormalizedBalance, ok := normalizedBalances[tokenInDenom]
+	if !ok {
+		tokenInNormalizedBalance = osmomath.ZeroInt()
+	}
+
+	numerator := upperLimit.MulInt(normalizedTotal).Sub(tokenInNormalizedBalance.ToLegacyDec())
+	if !numerator.IsPositive() {
+		return osmomath.ZeroInt(), true, nil
+	}
+
+	denominator := osmomath.OneDec().Sub(upperLimit).MulInt(normalizationScalingFactor)
+
+	maxTokenInAmount := numerator.Quo(denominator).TruncateInt()
+
+	// Account for the rounding of the weight computation, backing off with an increasing step.
+	step := osmomath.OneInt()
+	for maxTokenInAmount.IsPositive() {
+		weight, err := computeWeightAfterSwapIn(poolID, data, normalizedBalances, normalizedTotal, sdk.Coin{Denom: tokenInDenom, Amount: maxTokenInAmount})
+		if err != nil {
+			return osmomath.Int{}, false, err
+		}
+
+		if weight.LTE(upperLimit) {
+			break
+		}
+
+		maxTokenInAmount = osmomath.MaxInt(maxTokenInAmount.Sub(step), osmomath.ZeroInt())
+		step = step.MulRaw(2)
+	}
+
+	return maxTokenInAmount, true, nil
+}
+
+// computeChangeLimiterState returns the window state of the change limiter.
+//
+// The moving average of the weight is computed over the divisions within the window ending
+// at the latest division update, extending each division integral with its latest value until
+// the start of the next division. The limiter is evaluated as of the latest update since the
+// current block time is not ingested. As a result, the state approximates the one that the contract
+// evaluates swaps against and is only used for reporting capacity rather than rejecting swaps.
+// Returns false if the limiter has no divisions.
+func computeChangeLimiterState(changeLimiter cosmwasmpool.ChangeLimiter) (domain.AlloyTransmuterChangeLimiterState, bool, error) {
+	divisions := changeLimiter.Divisions
+	if len(divisions) == 0 {
+		return domain.AlloyTransmuterChangeLimiterState{}, false, nil
+	}
+
+	boundaryOffset, err := osmomath.NewDecFromStr(changeLimiter.BoundaryOffset)
+	if err != nil {
+		return domain.AlloyTransmuterChangeLimiterState{}, false, err
+	}
+
+	windowEnd := divisions[len(divisions)-1].UpdatedAt
+	windowStart := windowEnd - int64(changeLimiter.WindowConfig.WindowSize)
+
+	integralSum := osmomath.ZeroDec()
+	elapsedSum := int64(0)
+
+	var latestValue osmomath.Dec
+	for i, division := range divisions {
+		divisionEnd := windowEnd
+		if i+1 < len(divisions) {
+			divisionEnd = divisions[i+1].StartedAt
+		}
+
+		latestValue, err = osmomath.NewDecFromStr(division.LatestValue)
+		if err != nil {
+			return domain.AlloyTransmuterChangeLimiterState{}, false, err
+		}
+
+		// Skip divisions outside of the window.
+		if divisionEnd <= windowStart {
+			continue
+		}
+
+		integral, err := osmomath.NewDecFromStr(division.Integral)
+		if err != nil {
+			return domain.AlloyTransmuterChangeLimiterState{}, false, err
+		}
+
+		if divisionEnd > division.UpdatedAt {
+			integral = integral.Add(latestValue.MulInt64(divisionEnd - division.UpdatedAt))
+		}
+
+		integralSum = integralSum.Add(integral)
+		elapsedSum += divisionEnd - division.StartedAt
+	}
+
+	// No time elapsed within the window, the average is the latest value.
+	movingAverage := latestValue
+	if elapsedSum > 0 {
+		movingAverage = integralSum.QuoInt64(elapsedSum)
+	}
+
+	return domain.AlloyTransmuterChangeLimiterState{
+		WindowSize:     changeLimiter.WindowConfig.WindowSize,
+		DivisionCount:  changeLimiter.WindowConfig.DivisionCount,
+		MovingAverage:  movingAverage,
+		BoundaryOffset: boundaryOffset,
+		UpperLimit:     movingAverage.Add(boundaryOffset),
+	}, true, nil
+}
@@ -0,0 +1,182 @@
+package pools_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cwpoolmodel "github.com/osmosis-labs/osmosis/v25/x/cosmwasmpool/model"
+
+	"github.com/osmosis-labs/sqs/router/usecase/pools"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+)
+
+var (
+	defaultRateLimiterScalingFactors = map[string]osmomath.Int{
+		USDC:               osmomath.NewInt(1),
+		USDT:               osmomath.NewInt(1),
+		OVERLY_PRECISE_USD: osmomath.NewInt(1),
+		NO_PRECISION_USD:   osmomath.NewInt(1),
+	}
+
+	defaultRateLimiterBalances = sdk.NewCoins(
+		sdk.NewCoin(USDC, osmomath.NewInt(1_000_000)),
+		sdk.NewCoin(USDT, osmomath.NewInt(2_000_000)),
+	)
+
+	// moving average of 0.3 over the window with 0.1 boundary offset.
+	defaultChangeLimiter = cosmwasmpool.ChangeLimiter{
+		Divisions: []cosmwasmpool.Division{
+			{StartedAt: 0, UpdatedAt: 50, LatestValue: "0.3", Integral: "15"},
+			{StartedAt: 100, UpdatedAt: 100, LatestValue: "0.3", Integral: "0"},
+		},
+		LatestValue:    "0.3",
+		WindowConfig:   cosmwasmpool.WindowConfig{WindowSize: 1000, DivisionCount: 10},
+		BoundaryOffset: "0.1",
+	}
+)
+
+// newRateLimitedAlloyTransmuterPool returns an alloyed transmuter pool over USDC and USDT
+// with the default balances and the given rate limiter.
+func newRateLimitedAlloyTransmuterPool(rateLimiter cosmwasmpool.AlloyedRateLimiter) *pools.RoutableAlloyTransmuterPoolImpl {
+	return &pools.RoutableAlloyTransmuterPoolImpl{
+		ChainPool: &cwpoolmodel.CosmWasmPool{PoolId: defaultPoolID},
+		AlloyTransmuterData: &cosmwasmpool.AlloyTransmuterData{
+			AlloyedDenom: ALLUSD,
+			AssetConfigs: []cosmwasmpool.TransmuterAssetConfig{
+				{Denom: USDC, NormalizationFactor: osmomath.NewInt(1)},
+				{Denom: USDT, NormalizationFactor: osmomath.NewInt(1)},
+				{Denom: ALLUSD, NormalizationFactor: osmomath.NewInt(1)},
+			},
+			RateLimiterConfig: rateLimiter,
+			PreComputedData: cosmwasmpool.PrecomputedData{
+				StdNormFactor:               osmomath.OneInt(),
+				NormalizationScalingFactors: defaultRateLimiterScalingFactors,
+			},
+		},
+		Balances:      defaultRateLimiterBalances,
+		TokenOutDenom: USDT,
+		TakerFee:      osmomath.ZeroDec(),
+		SpreadFactor:  osmomath.ZeroDec(),
+	}
+}
+
+// Tests the maximum token in amount before the rate limiters trip.
+func (s *RoutablePoolTestSuite) TestGetMaxTokenInAmount_AlloyTransmuter() {
+	tests := map[string]struct {
+		rateLimiter cosmwasmpool.AlloyedRateLimiter
+
+		expectedIsLimited bool
+		expectedAmount    osmomath.Int
+	}{
+		"no limiters": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{},
+		},
+		"static limiter": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{
+				StaticLimiterByDenomMap: map[string]cosmwasmpool.StaticLimiter{USDC: {UpperLimit: "0.5"}},
+			},
+			expectedIsLimited: true,
+			// (0.5 * 3_000_000 - 1_000_000) / 0.5
+			expectedAmount: osmomath.NewInt(1_000_000),
+		},
+		"static limiter already exceeded": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{
+				StaticLimiterByDenomMap: map[string]cosmwasmpool.StaticLimiter{USDC: {UpperLimit: "0.2"}},
+			},
+			expectedIsLimited: true,
+			expectedAmount:    osmomath.ZeroInt(),
+		},
+		"static limiter of one is unlimited": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{
+				StaticLimiterByDenomMap: map[string]cosmwasmpool.StaticLimiter{USDC: {UpperLimit: "1"}},
+			},
+		},
+		"change limiter more restrictive than static is not considered": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{
+				StaticLimiterByDenomMap: map[string]cosmwasmpool.StaticLimiter{USDC: {UpperLimit: "0.5"}},
+				ChangeLimiterByDenomMap: map[string]cosmwasmpool.ChangeLimiter{USDC: defaultChangeLimiter},
+			},
+			expectedIsLimited: true,
+			// (0.5 * 3_000_000 - 1_000_000) / 0.5
+			expectedAmount: osmomath.NewInt(1_000_000),
+		},
+		"change limiter only is not limited": {
+			rateLimiter: cosmwasmpool.AlloyedRateLimiter{
+				ChangeLimiterByDenomMap: map[string]cosmwasmpool.ChangeLimiter{USDC: defaultChangeLimiter},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			r := newRateLimitedAlloyTransmuterPool(tc.rateLimiter)
+
+			// System under test
+			amount, isLimited, err := r.GetMaxTokenInAmount(USDC)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedIsLimited, isLimited)
+
+			if !tc.expectedIsLimited {
+				return
+			}
+
+			s.Require().Equal(tc.expectedAmount.String(), amount.String())
+
+			// The maximum amount passes the limiters and one more unit trips them.
+			if amount.IsPositive() {
+				_, err = r.CalcTokenOutAmt(sdk.NewCoin(USDC, amount), USDT)
+				s.Require().NoError(err)
+			}
+
+			_, err = r.CalcTokenOutAmt(sdk.NewCoin(USDC, amount.AddRaw(1)), USDT)
+			s.Require().Error(err)
+		})
+	}
+}
+
+// Tests that swaps exceeding the approximated change rate limiter upper limit are not rejected.
+func (s *RoutablePoolTestSuite) TestCalcTokenOutAmt_ChangeRateLimiter() {
+	r := newRateLimitedAlloyTransmuterPool(cosmwasmpool.AlloyedRateLimiter{
+		ChangeLimiterByDenomMap: map[string]cosmwasmpool.ChangeLimiter{USDC: defaultChangeLimiter},
+	})
+
+	// 1_500_000 / 3_500_000 = 0.428571428571428571 exceeds the upper limit of 0.4
+	tokenOut, err := r.CalcTokenOutAmt(sdk.NewCoin(USDC, osmomath.NewInt(500_000)), USDT)
+	s.Require().NoError(err)
+	s.Require().Equal(osmomath.NewBigDec(500_000), tokenOut)
+}
+
+// Tests the capacity reported for each asset of the pool.
+func (s *RoutablePoolTestSuite) TestComputeAlloyTransmuterCapacity() {
+	data := newRateLimitedAlloyTransmuterPool(cosmwasmpool.AlloyedRateLimiter{
+		StaticLimiterByDenomMap: map[string]cosmwasmpool.StaticLimiter{USDC: {UpperLimit: "0.5"}},
+		ChangeLimiterByDenomMap: map[string]cosmwasmpool.ChangeLimiter{USDC: defaultChangeLimiter},
+	}).AlloyTransmuterData
+
+	// System under test
+	capacity, err := pools.ComputeAlloyTransmuterCapacity(defaultPoolID, defaultRateLimiterBalances, data)
+	s.Require().NoError(err)
+
+	s.Require().Equal(defaultPoolID, capacity.PoolID)
+	s.Require().Equal(ALLUSD, capacity.AlloyedDenom)
+
+	// Alloyed LP share is skipped
+	s.Require().Len(capacity.Assets, 2)
+
+	usdcCapacity := capacity.Assets[0]
+	s.Require().Equal(USDC, usdcCapacity.Denom)
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.333333333333333333"), usdcCapacity.Weight)
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.5"), *usdcCapacity.StaticUpperLimit)
+	s.Require().NotNil(usdcCapacity.ChangeLimiter)
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.3"), usdcCapacity.ChangeLimiter.MovingAverage)
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.4"), usdcCapacity.ChangeLimiter.UpperLimit)
+	// The change limiter is more restrictive than the static one.
+	// (0.4 * 3_000_000 - 1_000_000) / 0.6
+	s.Require().Equal(osmomath.NewInt(333_333), *usdcCapacity.MaxSwapInAmount)
+
+	usdtCapacity := capacity.Assets[1]
+	s.Require().Equal(USDT, usdtCapacity.Denom)
+	s.Require().Nil(usdtCapacity.StaticUpperLimit)
+	s.Require().Nil(usdtCapacity.ChangeLimiter)
+	s.Require().Nil(usdtCapacity.MaxSwapInAmount)
+}
@@ -36,6 +36,7 @@ type routableCosmWasmPoolImpl struct {
 	TakerFee                 osmomath.Dec                    "json:\"taker_fee\""
 	SpreadFactor             osmomath.Dec                    "json:\"spread_factor\""
 	wasmClient               wasmtypes.QueryClient           "json:\"-\""
+	queryCache               *cosmwasmdomain.QueryCache      "json:\"-\""
 	spotPriceQuoteCalculator domain.SpotPriceQuoteCalculator "json:\"-\""
 }
 
@@ -49,6 +50,7 @@ func NewRoutableCosmWasmPool(pool *cwpoolmodel.CosmWasmPool, balances sdk.Coins,
 		TakerFee:      takerFee,
 		SpreadFactor:  spreadFactor,
 		wasmClient:    cosmWasmPoolsParams.WasmClient,
+		queryCache:    cosmWasmPoolsParams.QueryCache,
 
 		// Note, that there is no calculator set
 		// since we need to wire quote calculation callback to it.
@@ -107,7 +109,7 @@ func (r *routableCosmWasmPoolImpl) calculateTokenOutByTokenIn(ctx context.Contex
 	calcMessage := msg.NewCalcOutAmtGivenInRequest(tokenIn, tokenOutDenom, r.SpreadFactor)
 
 	calcOutAmtGivenInResponse := msg.CalcOutAmtGivenInResponse{}
-	if err := cosmwasmdomain.QueryCosmwasmContract(ctx, r.wasmClient, r.ChainPool.ContractAddress, &calcMessage, &calcOutAmtGivenInResponse); err != nil {
+	if err := r.queryContract(ctx, &calcMessage, &calcOutAmtGivenInResponse); err != nil {
 		return sdk.Coin{}, err
 	}
 
@@ -116,6 +118,20 @@ func (r *routableCosmWasmPoolImpl) calculateTokenOutByTokenIn(ctx context.Contex
 	return calcOutAmtGivenInResponse.TokenOut, nil
 }
 
+// queryContract queries the pool contract via the query cache if configured.
+// Queries to chain consume the query budget of the context, if any.
+func (r *routableCosmWasmPoolImpl) queryContract(ctx context.Context, request any, response any) error {
+	if r.queryCache != nil {
+		return r.queryCache.Query(ctx, r.ChainPool.PoolId, r.ChainPool.ContractAddress, request, response)
+	}
+
+	if err := cosmwasmdomain.ConsumeQueryBudget(ctx); err != nil {
+		return err
+	}
+
+	return cosmwasmdomain.QueryCosmwasmContract(ctx, r.wasmClient, r.ChainPool.ContractAddress, request, response)
+}
+
 // SetTokenInDenom implements domain.RoutablePool.
 func (r *routableCosmWasmPoolImpl) SetTokenInDenom(tokenInDenom string) {
 	r.TokenInDenom = tokenInDenom
@@ -181,7 +197,7 @@ func (r *routableCosmWasmPoolImpl) CalcSpotPrice(ctx context.Context, baseDenom
 	}
 
 	response := &msg.SpotPriceQueryMsgResponse{}
-	if err := cosmwasmdomain.QueryCosmwasmContract(ctx, r.wasmClient, r.ChainPool.ContractAddress, &request, response); err != nil {
+	if err := r.queryContract(ctx, &request, response); err != nil {
 		return osmomath.BigDec{}, err
 	}
 
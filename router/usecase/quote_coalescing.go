@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// coalesceQuote computes the quote with getQuote, sharing the result between identical concurrent quotes
+// within the same block. Each caller receives its own copy of the quote so that it can be prepared independently.
+// Computes the quote without coalescing if request coalescing is disabled or the options cannot be keyed.
+func (r *routerUseCaseImpl) coalesceQuote(ctx context.Context, swapMethod domain.TokenSwapMethod, tokenIn sdk.Coin, tokenOutDenom string, options domain.RouterOptions, getQuote func(ctx context.Context) (domain.Quote, error)) (domain.Quote, error) {
+	if r.latestHeightGetter == nil {
+		return getQuote(ctx)
+	}
+
+	key, ok := formatQuoteCoalescingKey(swapMethod, tokenIn, tokenOutDenom, options, domain.GetRequestHeight(ctx, r.latestHeightGetter))
+	if !ok {
+		return getQuote(ctx)
+	}
+
+	quote, err := domain.CoalesceRequest(ctx, &r.quoteGroup, key, domain.CoalescedQuoteRequest, getQuote)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyQuote(quote), nil
+}
+
+// formatQuoteCoalescingKey formats the key of a quote from the swap method, pair, amount, router options and height.
+// The amount is keyed exactly since quotes are amount-specific.
+// Returns false if the options contain custom candidate route pool filters that cannot be keyed.
+func formatQuoteCoalescingKey(swapMethod domain.TokenSwapMethod, tokenIn sdk.Coin, tokenOutDenom string, options domain.RouterOptions, height uint64) (string, bool) {
+	if len(options.CandidateRoutesPoolFiltersAnyOf) > 0 {
+		return "", false
+	}
+
+	constraints := options.CandidateRouteDenomConstraints
+
+	return fmt.Sprintf("%d%s%s%s%s%s%d%s%d%s%d%s%d%s%t%s%s%s%s%s%s%s%t%s%d%s%d",
+		swapMethod, denomSeparatorChar,
+		tokenIn, denomSeparatorChar,
+		tokenOutDenom, denomSeparatorChar,
+		options.MaxPoolsPerRoute, denomSeparatorChar,
+		options.MaxRoutes, denomSeparatorChar,
+		options.MaxSplitRoutes, denomSeparatorChar,
+		options.MinPoolLiquidityCap, denomSeparatorChar,
+		options.DisableCache, denomSeparatorChar,
+		formatDenomSet(constraints.AllowedIntermediateDenoms), denomSeparatorChar,
+		formatDenomSet(constraints.ForbiddenIntermediateDenoms), denomSeparatorChar,
+		constraints.MustIncludeDenom, denomSeparatorChar,
+		constraints.ExcludeUnlistedTokens, denomSeparatorChar,
+		options.QuoteTimeBudget, denomSeparatorChar,
+		height,
+	), true
+}
+
+// formatDenomSet formats the given set of denoms to a string in sorted order.
+func formatDenomSet(denoms map[string]struct{}) string {
+	sortedDenoms := make([]string, 0, len(denoms))
+	for denom := range denoms {
+		sortedDenoms = append(sortedDenoms, denom)
+	}
+	sort.Strings(sortedDenoms)
+
+	return strings.Join(sortedDenoms, ",")
+}
+
+// copyQuote returns a shallow copy of the quote.
+// This suffices for preparing the copies independently since PrepareResult replaces the fields of the quote
+// rather than mutating them.
+func copyQuote(quote domain.Quote) domain.Quote {
+	switch q := quote.(type) {
+	case *quoteExactAmountIn:
+		quoteCopy := *q
+		return &quoteCopy
+	case *quoteExactAmountOut:
+		quoteCopy := *q
+		if q.quoteExactAmountIn != nil {
+			quoteExactAmountInCopy := *q.quoteExactAmountIn
+			quoteCopy.quoteExactAmountIn = &quoteExactAmountInCopy
+		}
+		return &quoteCopy
+	default:
+		return quote
+	}
+}
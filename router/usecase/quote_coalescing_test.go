@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/router/usecase"
+)
+
+// This test validates that the quote coalescing key distinguishes every input
+// that affects the quote while being independent of the order of the denom constraints.
+func (s *RouterTestSuite) TestFormatQuoteCoalescingKey() {
+	var (
+		defaultTokenIn = sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000))
+		defaultHeight  = uint64(100)
+	)
+
+	newOptions := func() domain.RouterOptions {
+		return domain.RouterOptions{
+			MaxPoolsPerRoute: 4,
+			MaxRoutes:        20,
+			MaxSplitRoutes:   3,
+			CandidateRouteDenomConstraints: domain.CandidateRouteDenomConstraints{
+				AllowedIntermediateDenoms: map[string]struct{}{USDC: {}, ATOM: {}},
+			},
+		}
+	}
+
+	defaultKey, ok := usecase.FormatQuoteCoalescingKey(domain.TokenSwapMethodExactIn, defaultTokenIn, USDT, newOptions(), defaultHeight)
+	s.Require().True(ok)
+
+	// Same inputs produce the same key.
+	key, ok := usecase.FormatQuoteCoalescingKey(domain.TokenSwapMethodExactIn, defaultTokenIn, USDT, newOptions(), defaultHeight)
+	s.Require().True(ok)
+	s.Require().Equal(defaultKey, key)
+
+	tests := map[string]struct {
+		swapMethod    domain.TokenSwapMethod
+		tokenIn       sdk.Coin
+		tokenOutDenom string
+		updateOptions func(*domain.RouterOptions)
+		height        uint64
+	}{
+		"different swap method": {
+			swapMethod: domain.TokenSwapMethodExactOut,
+		},
+		"different amount": {
+			tokenIn: sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_001)),
+		},
+		"different token out": {
+			tokenOutDenom: USDC,
+		},
+		"different max split routes": {
+			updateOptions: func(o *domain.RouterOptions) { o.MaxSplitRoutes = domain.DisableSplitRoutes },
+		},
+		"different min pool liquidity cap": {
+			updateOptions: func(o *domain.RouterOptions) { o.MinPoolLiquidityCap = 1 },
+		},
+		"different denom constraints": {
+			updateOptions: func(o *domain.RouterOptions) { o.CandidateRouteDenomConstraints.MustIncludeDenom = USDC },
+		},
+		"different height": {
+			height: defaultHeight + 1,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		s.Run(name, func() {
+			swapMethod := domain.TokenSwapMethodExactIn
+			if tc.swapMethod != domain.TokenSwapMethodExactIn {
+				swapMethod = tc.swapMethod
+			}
+
+			tokenIn := defaultTokenIn
+			if !tc.tokenIn.IsNil() {
+				tokenIn = tc.tokenIn
+			}
+
+			tokenOutDenom := USDT
+			if tc.tokenOutDenom != "" {
+				tokenOutDenom = tc.tokenOutDenom
+			}
+
+			options := newOptions()
+			if tc.updateOptions != nil {
+				tc.updateOptions(&options)
+			}
+
+			height := defaultHeight
+			if tc.height != 0 {
+				height = tc.height
+			}
+
+			key, ok := usecase.FormatQuoteCoalescingKey(swapMethod, tokenIn, tokenOutDenom, options, height)
+			s.Require().True(ok)
+			s.Require().NotEqual(defaultKey, key)
+		})
+	}
+
+	// Custom pool filters cannot be keyed.
+	options := newOptions()
+	options.CandidateRoutesPoolFiltersAnyOf = []domain.CandidateRoutePoolFiltrerCb{domain.ShouldSkipOrderbookPool}
+	_, ok = usecase.FormatQuoteCoalescingKey(domain.TokenSwapMethodExactIn, defaultTokenIn, USDT, options, defaultHeight)
+	s.Require().False(ok)
+}
+
+// This test validates that preparing a copy of the quote does not affect the original.
+func (s *RouterTestSuite) TestCopyQuote() {
+	quote := &usecase.QuoteImpl{
+		AmountIn:  sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+		AmountOut: osmomath.NewInt(2_000_000),
+	}
+
+	quoteCopy, ok := usecase.CopyQuote(quote).(*usecase.QuoteImpl)
+	s.Require().True(ok)
+	s.Require().NotSame(quote, quoteCopy)
+	s.Require().Equal(*quote, *quoteCopy)
+
+	quoteCopy.EffectiveFee = osmomath.OneDec()
+	s.Require().True(quote.EffectiveFee.IsNil())
+
+	quoteOut := usecase.NewQuoteExactAmountOut(quote)
+	quoteOutCopy, ok := usecase.CopyQuote(quoteOut).(*usecase.QuoteExactAmountOut)
+	s.Require().True(ok)
+	s.Require().NotSame(quoteOut, quoteOutCopy)
+	s.Require().Equal(quote.AmountOut, quoteOutCopy.GetAmountOut())
+
+	quoteOutCopy.AmountIn = osmomath.OneInt()
+	s.Require().True(quoteOut.AmountIn.IsNil())
+}
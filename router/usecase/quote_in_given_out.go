@@ -26,6 +26,7 @@ type quoteExactAmountOut struct {
 	EffectiveFee            osmomath.Dec        "json:\"effective_fee\""
 	PriceImpact             osmomath.Dec        "json:\"price_impact\""
 	InBaseOutQuoteSpotPrice osmomath.Dec        "json:\"in_base_out_quote_spot_price\""
+	IsPartial               bool                "json:\"is_partial,omitempty\""
 }
 
 // PrepareResult implements domain.Quote.
@@ -49,6 +50,7 @@ func (q *quoteExactAmountOut) PrepareResult(ctx context.Context, scalingFactor o
 	q.EffectiveFee = q.quoteExactAmountIn.EffectiveFee
 	q.PriceImpact = q.quoteExactAmountIn.PriceImpact
 	q.InBaseOutQuoteSpotPrice = q.quoteExactAmountIn.InBaseOutQuoteSpotPrice
+	q.IsPartial = q.quoteExactAmountIn.IsPartial
 
 	for i, route := range q.Route {
 		route, ok := route.(*RouteWithOutAmount)
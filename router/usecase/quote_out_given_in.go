@@ -42,6 +42,8 @@ type quoteExactAmountIn struct {
 	EffectiveFee            osmomath.Dec        "json:\"effective_fee\""
 	PriceImpact             osmomath.Dec        "json:\"price_impact\""
 	InBaseOutQuoteSpotPrice osmomath.Dec        "json:\"in_base_out_quote_spot_price\""
+	// IsPartial is true if the quote is the best found before the quote time budget was exhausted.
+	IsPartial bool "json:\"is_partial,omitempty\""
 }
 
 // PrepareResult implements domain.Quote.
@@ -102,6 +104,11 @@ func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor os
 		q.PriceImpact = totalEffectiveSpotPriceInBaseOutQuote.Quo(totalSpotPriceInBaseOutQuote).SubMut(one)
 	}
 
+	// Widen the reported price impact in degraded mode.
+	if slippageWideningFactor, ok := domain.GetSlippageWideningFactor(ctx); ok && !q.PriceImpact.IsNil() {
+		q.PriceImpact = q.PriceImpact.Mul(slippageWideningFactor)
+	}
+
 	q.EffectiveFee = totalFeeAcrossRoutes
 	q.Route = resultRoutes
 	q.InBaseOutQuoteSpotPrice = totalSpotPriceInBaseOutQuote
@@ -151,3 +158,8 @@ func (q *quoteExactAmountIn) GetPriceImpact() osmomath.Dec {
 func (q *quoteExactAmountIn) GetInBaseOutQuoteSpotPrice() osmomath.Dec {
 	return q.InBaseOutQuoteSpotPrice
 }
+
+// GetIsPartial implements domain.Quote.
+func (q *quoteExactAmountIn) GetIsPartial() bool {
+	return q.IsPartial
+}
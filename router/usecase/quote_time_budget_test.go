@@ -0,0 +1,220 @@
+package usecase_test
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/route"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// This test validates that the optimal quote is computed within the quote time budget.
+// Once the budget is exhausted, the best quote found so far is returned marked as partial.
+// If the request is cancelled, an error is returned instead.
+//
+// The routes are served from the ranked route cache and consist of mock pools, some of which
+// are slow, blocking until the context is done.
+func (s *RouterTestSuite) TestGetOptimalQuote_TimeBudget() {
+	const (
+		tinyBudget  = 10 * time.Millisecond
+		largeBudget = time.Minute
+	)
+
+	var (
+		tokenIn       = sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000))
+		tokenOutDenom = USDC
+	)
+
+	newPool := func(id uint64, calculateTokenOut func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error)) *mocks.MockRoutablePool {
+		return &mocks.MockRoutablePool{
+			ID:                             id,
+			TakerFee:                       osmomath.ZeroDec(),
+			TokenOutDenom:                  tokenOutDenom,
+			CalculateTokenOutByTokenInFunc: calculateTokenOut,
+		}
+	}
+
+	// Returns the given amount out without delay.
+	newFastPool := func(id uint64, amountOut osmomath.Int) *mocks.MockRoutablePool {
+		return newPool(id, func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+			return sdk.NewCoin(tokenOutDenom, amountOut), nil
+		})
+	}
+
+	// Blocks until the context is done.
+	slowPool := newPool(3, func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+		<-ctx.Done()
+		return sdk.Coin{}, ctx.Err()
+	})
+
+	// Returns the given amount out without delay for the full amount in, blocking
+	// until the context is done otherwise. As a result, only the split computation is slow.
+	slowSplitPool := newPool(4, func(ctx context.Context, poolTokenIn sdk.Coin) (sdk.Coin, error) {
+		if poolTokenIn.Amount.Equal(tokenIn.Amount) {
+			return sdk.NewCoin(tokenOutDenom, osmomath.NewInt(400)), nil
+		}
+		<-ctx.Done()
+		return sdk.Coin{}, ctx.Err()
+	})
+
+	fastPool := newFastPool(1, osmomath.NewInt(500))
+	otherFastPool := newFastPool(2, osmomath.NewInt(300))
+
+	tests := []struct {
+		name string
+
+		routePools [][]domain.RoutablePool
+
+		budget         time.Duration
+		maxSplitRoutes int
+		// cancelOnSlowPool cancels the request once a slow pool is reached.
+		cancelOnSlowPool bool
+
+		expectedAmountOut osmomath.Int
+		expectedIsPartial bool
+		expectedErr       error
+		expectBudgetErr   bool
+	}{
+		{
+			name:       "fast routes within budget -> complete quote",
+			routePools: [][]domain.RoutablePool{{fastPool}, {otherFastPool}},
+			budget:     largeBudget,
+
+			expectedAmountOut: osmomath.NewInt(500),
+		},
+		{
+			name:       "no budget -> complete quote",
+			routePools: [][]domain.RoutablePool{{fastPool}, {otherFastPool}},
+
+			expectedAmountOut: osmomath.NewInt(500),
+		},
+		{
+			name:       "budget exhausted after estimating a route -> partial quote with the best route so far",
+			routePools: [][]domain.RoutablePool{{fastPool}, {slowPool}, {otherFastPool}},
+			budget:     tinyBudget,
+
+			expectedAmountOut: osmomath.NewInt(500),
+			expectedIsPartial: true,
+		},
+		{
+			name:           "budget exhausted during splits -> partial quote falling back to the best single route",
+			routePools:     [][]domain.RoutablePool{{fastPool}, {slowSplitPool}},
+			budget:         tinyBudget,
+			maxSplitRoutes: 3,
+
+			expectedAmountOut: osmomath.NewInt(500),
+			expectedIsPartial: true,
+		},
+		{
+			name:       "budget exhausted before estimating any route -> error",
+			routePools: [][]domain.RoutablePool{{slowPool}},
+			budget:     tinyBudget,
+
+			expectBudgetErr: true,
+		},
+		{
+			name:             "request cancelled after estimating a route -> cancellation error rather than partial quote",
+			routePools:       [][]domain.RoutablePool{{fastPool}, {slowPool}},
+			budget:           largeBudget,
+			cancelOnSlowPool: true,
+
+			expectedErr: context.Canceled,
+		},
+		{
+			name:             "request cancelled without budget -> cancellation error",
+			routePools:       [][]domain.RoutablePool{{fastPool}, {slowPool}},
+			cancelOnSlowPool: true,
+
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			routes := make([]route.RouteImpl, 0, len(tc.routePools))
+			for _, pools := range tc.routePools {
+				routePools := make([]domain.RoutablePool, 0, len(pools))
+				for _, pool := range pools {
+					// Cancel the request once the slow pool is reached.
+					if tc.cancelOnSlowPool && pool.GetId() == slowPool.GetId() {
+						pool = newPool(slowPool.GetId(), func(poolCtx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+							cancel()
+							return slowPool.CalculateTokenOutByTokenIn(poolCtx, tokenIn)
+						})
+					}
+					routePools = append(routePools, pool)
+				}
+				routes = append(routes, WithRoutePools(EmptyRoute, routePools))
+			}
+
+			routerUsecase := s.newTimeBudgetRouterUsecase(tokenIn, tokenOutDenom, routes, tc.maxSplitRoutes)
+
+			quote, err := routerUsecase.GetOptimalQuote(ctx, tokenIn, tokenOutDenom, domain.WithQuoteTimeBudget(tc.budget))
+
+			if tc.expectedErr != nil {
+				s.Require().ErrorIs(err, tc.expectedErr)
+				s.Require().Nil(quote)
+				return
+			}
+
+			if tc.expectBudgetErr {
+				s.Require().ErrorContains(err, "no quote found within the time budget")
+				s.Require().Nil(quote)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedAmountOut.String(), quote.GetAmountOut().String())
+
+			// The best single route is returned.
+			s.Require().Len(quote.GetRoute(), 1)
+
+			quoteImpl, ok := quote.(*usecase.QuoteImpl)
+			s.Require().True(ok)
+			s.Require().Equal(tc.expectedIsPartial, quoteImpl.IsPartial)
+		})
+	}
+}
+
+// newTimeBudgetRouterUsecase returns a router usecase serving the given routes for the pair from the ranked route cache.
+func (s *RouterTestSuite) newTimeBudgetRouterUsecase(tokenIn sdk.Coin, tokenOutDenom string, routes []route.RouteImpl, maxSplitRoutes int) mvc.RouterUsecase {
+	candidateRoutes := sqsdomain.CandidateRoutes{}
+	for _, r := range routes {
+		candidateRoute := sqsdomain.CandidateRoute{}
+		for _, pool := range r.GetPools() {
+			candidateRoute.Pools = append(candidateRoute.Pools, sqsdomain.CandidatePool{ID: pool.GetId(), TokenOutDenom: tokenOutDenom})
+		}
+		candidateRoutes.Routes = append(candidateRoutes.Routes, candidateRoute)
+	}
+
+	rankedRouteCache := cache.New()
+	rankedRouteCache.Set(usecase.FormatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, usecase.GetPrecomputeOrderOfMagnitude(tokenIn.Amount)), candidateRoutes, time.Hour)
+
+	poolsUsecase := &mocks.PoolsUsecaseMock{
+		GetRoutesFromCandidatesFunc: func(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error) {
+			return routes, nil
+		},
+	}
+
+	config := domain.RouterConfig{
+		MaxPoolsPerRoute:  4,
+		MaxRoutes:         20,
+		MaxSplitRoutes:    maxSplitRoutes,
+		RouteCacheEnabled: true,
+	}
+
+	return usecase.NewRouterUsecase(nil, poolsUsecase, nil, nil, config, domain.CosmWasmPoolRouterConfig{}, &log.NoOpLogger{}, rankedRouteCache, cache.New())
+}
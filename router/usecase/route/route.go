@@ -117,32 +117,74 @@ func (r *RouteImpl) GetPools() []domain.RoutablePool {
 
 // CalculateTokenOutByTokenIn implements Route.
 func (r *RouteImpl) CalculateTokenOutByTokenIn(ctx context.Context, tokenIn sdk.Coin) (tokenOut sdk.Coin, err error) {
+	tokenOut, _, err = r.CalculateTokenOutByTokenInWithFailedPool(ctx, tokenIn)
+	return tokenOut, err
+}
+
+// CalculateTokenOutByTokenInWithFailedPool is CalculateTokenOutByTokenIn that additionally
+// returns the pool that failed to estimate the quote on error.
+// This allows attributing quote failures to individual pools.
+func (r *RouteImpl) CalculateTokenOutByTokenInWithFailedPool(ctx context.Context, tokenIn sdk.Coin) (tokenOut sdk.Coin, failedPool domain.RoutablePool, err error) {
+	var currentPool domain.RoutablePool
+
 	defer func() {
 		// TODO: cover this by test
 		if r := recover(); r != nil {
 			tokenOut = sdk.Coin{}
+			failedPool = currentPool
 			err = fmt.Errorf("error when calculating out by in in route: %v", r)
 		}
 	}()
 
 	for _, pool := range r.Pools {
+		currentPool = pool
+
 		// Charge taker fee
 		tokenIn = pool.ChargeTakerFeeExactIn(tokenIn)
 		tokenInAmt := tokenIn.Amount.ToLegacyDec()
 
 		if tokenInAmt.IsNil() || tokenInAmt.IsZero() {
-			return sdk.Coin{}, nil
+			return sdk.Coin{}, nil, nil
 		}
 
 		tokenOut, err = pool.CalculateTokenOutByTokenIn(ctx, tokenIn)
 		if err != nil {
-			return sdk.Coin{}, err
+			return sdk.Coin{}, pool, err
 		}
 
 		tokenIn = tokenOut
 	}
 
-	return tokenOut, nil
+	return tokenOut, nil, nil
+}
+
+// GetMaxTokenInAmount returns the maximum amount of the given token in denom that can be swapped
+// over the route as limited by the first pool if it is capacity limited.
+// The capacity of the subsequent pools is not considered since their token in amounts depend on
+// the preceding pools.
+// Returns false if the amount is not limited.
+func (r *RouteImpl) GetMaxTokenInAmount(tokenInDenom string) (osmomath.Int, bool, error) {
+	if len(r.Pools) == 0 {
+		return osmomath.Int{}, false, nil
+	}
+
+	firstPool, ok := r.Pools[0].(domain.CapacityLimitedPool)
+	if !ok {
+		return osmomath.Int{}, false, nil
+	}
+
+	maxTokenInAmount, isLimited, err := firstPool.GetMaxTokenInAmount(tokenInDenom)
+	if err != nil || !isLimited {
+		return osmomath.Int{}, false, err
+	}
+
+	// Taker fee is charged before the token in reaches the pool.
+	takerFee := firstPool.GetTakerFee()
+	if !takerFee.IsNil() && takerFee.IsPositive() && takerFee.LT(osmomath.OneDec()) {
+		maxTokenInAmount = maxTokenInAmount.ToLegacyDec().Quo(osmomath.OneDec().Sub(takerFee)).TruncateInt()
+	}
+
+	return maxTokenInAmount, true, nil
 }
 
 // String implements domain.Route.
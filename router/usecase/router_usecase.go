@@ -9,12 +9,14 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/osmosis/osmoutils"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v25/x/poolmanager/types"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/cache"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/router/types"
@@ -44,11 +46,26 @@ type routerUseCaseImpl struct {
 	sortedPools   []sqsdomain.PoolI
 
 	candidateRouteCache *cache.Cache
+
+	// poolCircuitBreaker excludes pools with repeated quote failures from routing.
+	// Nil if disabled.
+	poolCircuitBreaker domain.PoolCircuitBreaker
+
+	// candidateRouteIndex holds the candidate routes precomputed for pairs of the top denoms.
+	// Nil if disabled.
+	candidateRouteIndex domain.CandidateRouteIndex
+
+	// latestHeightGetter keys coalesced quotes by height.
+	// Nil if request coalescing is disabled.
+	latestHeightGetter domain.LatestHeightGetter
+	// quoteGroup coalesces identical concurrent quotes.
+	quoteGroup singleflight.Group
 }
 
 const (
-	candidateRouteCacheLabel = "candidate_route"
-	rankedRouteCacheLabel    = "ranked_route"
+	candidateRouteCacheLabel      = "candidate_route"
+	candidateRouteIndexCacheLabel = "candidate_route_index"
+	rankedRouteCacheLabel         = "ranked_route"
 
 	denomSeparatorChar = "|"
 )
@@ -88,7 +105,17 @@ func NewRouterUsecase(tokensRepository mvc.RouterRepository, poolsUsecase mvc.Po
 // Returns error if:
 // - fails to estimate direct quotes for ranked routes
 // - fails to retrieve candidate routes
+// Identical concurrent quotes within the same block are coalesced if enabled. See EnableRequestCoalescing.
 func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+	options := r.getRouterOptions(opts...)
+
+	return r.coalesceQuote(ctx, domain.TokenSwapMethodExactIn, tokenIn, tokenOutDenom, options, func(ctx context.Context) (domain.Quote, error) {
+		return r.getOptimalQuote(ctx, tokenIn, tokenOutDenom, options)
+	})
+}
+
+// getRouterOptions returns the router options with the given options applied over the default config.
+func (r *routerUseCaseImpl) getRouterOptions(opts ...domain.RouterOption) domain.RouterOptions {
 	options := domain.RouterOptions{
 		MaxPoolsPerRoute:                 r.defaultConfig.MaxPoolsPerRoute,
 		MaxRoutes:                        r.defaultConfig.MaxRoutes,
@@ -98,12 +125,62 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		MaxSplitRoutes:                   r.defaultConfig.MaxSplitRoutes,
 		DisableCache:                     !r.defaultConfig.RouteCacheEnabled,
 		CandidateRoutesPoolFiltersAnyOf:  []domain.CandidateRoutePoolFiltrerCb{},
+		QuoteTimeBudget:                  time.Duration(r.defaultConfig.QuoteTimeBudgetMs) * time.Millisecond,
 	}
 	// Apply options
 	for _, opt := range opts {
 		opt(&options)
 	}
 
+	return options
+}
+
+// getOptimalQuote returns the optimal quote for the given router options. See GetOptimalQuote.
+// If the options have a time budget, the quote is computed within it. Once exhausted, the best quote
+// found so far is returned, marked as partial. If the context is done, the computation stops, returning an error.
+func (r *routerUseCaseImpl) getOptimalQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, options domain.RouterOptions) (domain.Quote, error) {
+	budgetCtx := ctx
+	if options.QuoteTimeBudget > 0 {
+		var cancel context.CancelFunc
+		budgetCtx, cancel = context.WithTimeoutCause(ctx, options.QuoteTimeBudget, errQuoteTimeBudgetExhausted)
+		defer cancel()
+	}
+
+	quote, err := r.computeOptimalQuote(budgetCtx, tokenIn, tokenOutDenom, options)
+
+	// Cancelled requests fail rather than returning a partial quote.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	isBudgetExhausted := isQuoteTimeBudgetExhausted(budgetCtx)
+
+	if err != nil {
+		if isBudgetExhausted {
+			return nil, fmt.Errorf("no quote found within the time budget (%s): %w", options.QuoteTimeBudget, err)
+		}
+		return nil, err
+	}
+
+	if isBudgetExhausted {
+		if q, ok := quote.(*quoteExactAmountIn); ok {
+			q.IsPartial = true
+		}
+
+		domain.SQSRouterPartialQuotesCounter.Inc()
+	}
+
+	return quote, nil
+}
+
+// computeOptimalQuote computes the optimal quote for the given router options.
+// Once the context is done, the best quote found so far is returned.
+func (r *routerUseCaseImpl) computeOptimalQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, options domain.RouterOptions) (domain.Quote, error) {
+	// Cached routes are computed without denom constraints.
+	if !options.CandidateRouteDenomConstraints.IsEmpty() {
+		options.DisableCache = true
+	}
+
 	var (
 		candidateRankedRoutes sqsdomain.CandidateRoutes
 		err                   error
@@ -118,6 +195,11 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		if err != nil {
 			return nil, err
 		}
+
+		// Recompute the ranked routes if the cached ones go through a pool excluded by the circuit breaker.
+		if r.containsCircuitBrokenPool(candidateRankedRoutes) {
+			candidateRankedRoutes = sqsdomain.CandidateRoutes{}
+		}
 	}
 
 	var (
@@ -153,16 +235,23 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		return topSingleRouteQuote, nil
 	}
 
-	// Filter out generalized cosmWasm pool routes
-	rankedRoutes = filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes)
+	splitCtx := ctx
+	if r.defaultConfig.GeneralCosmWasmSplitQueryBudget > 0 {
+		// Generalized cosmWasm pools participate in splits as long as their queries
+		// to chain fit within the budget. Memoized queries do not consume the budget.
+		splitCtx = cosmwasmdomain.WithQueryBudget(ctx, int64(r.defaultConfig.GeneralCosmWasmSplitQueryBudget))
+	} else {
+		// Filter out generalized cosmWasm pool routes
+		rankedRoutes = filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes)
 
-	// If filtering leads to a single route left, return it.
-	if len(rankedRoutes) == 1 {
-		return topSingleRouteQuote, nil
+		// If filtering leads to a single route left, return it.
+		if len(rankedRoutes) == 1 {
+			return topSingleRouteQuote, nil
+		}
 	}
 
 	// Compute split route quote
-	topSplitQuote, err := getSplitQuote(ctx, rankedRoutes, tokenIn)
+	topSplitQuote, err := getSplitQuote(splitCtx, rankedRoutes, tokenIn)
 	if err != nil {
 		// If error occurs in splits, return the single route quote
 		// rather than failing.
@@ -197,12 +286,15 @@ func (r *routerUseCaseImpl) GetOptimalQuoteInGivenOut(ctx context.Context, token
 	// The reason is that order-book contract does not implement the MsgSwapExactAmountOut API.
 	// The reason we disable cache is so that the exluded candidate routes do not interfere with the main
 	// "out given in" API.
-	opts = append(opts,
-		domain.WithDisableCache(),
-		domain.WithCandidateRoutesPoolFiltersAnyOf(domain.ShouldSkipOrderbookPool),
-	)
+	options := r.getRouterOptions(opts...)
 
-	quote, err := r.GetOptimalQuote(ctx, tokenIn, tokenOutDenom, opts...)
+	quote, err := r.coalesceQuote(ctx, domain.TokenSwapMethodExactOut, tokenIn, tokenOutDenom, options, func(ctx context.Context) (domain.Quote, error) {
+		outGivenInOptions := options
+		outGivenInOptions.DisableCache = true
+		outGivenInOptions.CandidateRoutesPoolFiltersAnyOf = append(outGivenInOptions.CandidateRoutesPoolFiltersAnyOf, domain.ShouldSkipOrderbookPool)
+
+		return r.getOptimalQuote(ctx, tokenIn, tokenOutDenom, outGivenInOptions)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -249,13 +341,13 @@ func (r *routerUseCaseImpl) GetSimpleQuote(ctx context.Context, tokenIn sdk.Coin
 		MaxPoolsPerRoute:    options.MaxPoolsPerRoute,
 		MinPoolLiquidityCap: options.MinPoolLiquidityCap,
 	}
-	candidateRoutes, err := r.candidateRouteSearcher.FindCandidateRoutes(tokenIn, tokenOutDenom, candidateRouteSearchOptions)
+	candidateRoutes, err := r.candidateRouteSearcher.FindCandidateRoutes(ctx, tokenIn, tokenOutDenom, candidateRouteSearchOptions)
 	if err != nil {
 		r.logger.Error("error getting candidate routes for pricing", zap.Error(err))
 		return nil, err
 	}
 
-	routes, err := r.poolsUsecase.GetRoutesFromCandidates(candidateRoutes, tokenIn.Denom, tokenOutDenom)
+	routes, err := r.poolsUsecase.GetRoutesFromCandidates(ctx, candidateRoutes, tokenIn.Denom, tokenOutDenom)
 	if err != nil {
 		r.logger.Error("error ranking routes for pricing", zap.Error(err))
 		return nil, err
@@ -333,7 +425,7 @@ func filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes []RouteWithOutAmou
 func (r *routerUseCaseImpl) rankRoutesByDirectQuote(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenIn sdk.Coin, tokenOutDenom string, maxSplitRoutes int) (domain.Quote, []route.RouteImpl, error) {
 	// Note that retrieving pools and taker fees is done in separate transactions.
 	// This is fine because taker fees don't change often.
-	routes, err := r.poolsUsecase.GetRoutesFromCandidates(candidateRoutes, tokenIn.Denom, tokenOutDenom)
+	routes, err := r.poolsUsecase.GetRoutesFromCandidates(ctx, candidateRoutes, tokenIn.Denom, tokenOutDenom)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -362,6 +454,9 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 		MinPoolLiquidityCap: routingOptions.MinPoolLiquidityCap,
 		DisableCache:        routingOptions.DisableCache,
 		PoolFiltersAnyOf:    routingOptions.CandidateRoutesPoolFiltersAnyOf,
+
+		CandidateRouteDenomConstraints: routingOptions.CandidateRouteDenomConstraints,
+		IsUnlistedDenomCb:              r.isUnlistedDenom,
 	}
 
 	// If top routes are not present in cache, retrieve unranked candidate routes
@@ -377,14 +472,17 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 		return nil, nil, err
 	}
 
-	if !routingOptions.DisableCache {
+	// Routes found before the context is done might be incomplete so they are not cached.
+	shouldCache := !routingOptions.DisableCache && ctx.Err() == nil
+
+	if shouldCache {
 		if len(candidateRoutes.Routes) > 0 {
 			domain.SQSRoutesCacheWritesCounter.WithLabelValues(requestURLPath, candidateRouteCacheLabel).Inc()
 
-			r.candidateRouteCache.Set(formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom), candidateRoutes, time.Duration(routingOptions.CandidateRouteCacheExpirySeconds)*time.Second)
+			r.candidateRouteCache.Set(r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom), candidateRoutes, time.Duration(routingOptions.CandidateRouteCacheExpirySeconds)*time.Second)
 		} else {
 			// If no candidate routes found, cache them for quarter of the duration
-			r.candidateRouteCache.Set(formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom), candidateRoutes, time.Duration(routingOptions.CandidateRouteCacheExpirySeconds/4)*time.Second)
+			r.candidateRouteCache.Set(r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom), candidateRoutes, time.Duration(routingOptions.CandidateRouteCacheExpirySeconds/4)*time.Second)
 
 			r.rankedRouteCache.Set(formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude), candidateRoutes, time.Duration(routingOptions.RankedRouteCacheExpirySeconds/4)*time.Second)
 
@@ -419,7 +517,9 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 			}
 		}
 
-		if !routingOptions.DisableCache {
+		// Routes over pools with an open circuit breaker were skipped when ranking.
+		// Caching the ranking would exclude these pools past their backoff.
+		if shouldCache && ctx.Err() == nil && !r.containsCircuitBrokenPool(candidateRoutes) {
 			domain.SQSRoutesCacheWritesCounter.WithLabelValues(requestURLPath, rankedRouteCacheLabel).Inc()
 			r.rankedRouteCache.Set(formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude), convertedCandidateRoutes, time.Duration(routingOptions.RankedRouteCacheExpirySeconds)*time.Second)
 		}
@@ -428,6 +528,14 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 	return topSingleRouteQuote, rankedRoutes, nil
 }
 
+// errQuoteTimeBudgetExhausted is the cause of the quote context being done due to the exhausted time budget.
+var errQuoteTimeBudgetExhausted = errors.New("quote time budget exhausted")
+
+// isQuoteTimeBudgetExhausted returns true if the context is done due to the exhausted quote time budget.
+func isQuoteTimeBudgetExhausted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errQuoteTimeBudgetExhausted)
+}
+
 var (
 	ErrTokenInDenomPoolNotFound  = fmt.Errorf("token in denom not found in pool")
 	ErrTokenOutDenomPoolNotFound = fmt.Errorf("token out denom not found in pool")
@@ -450,7 +558,7 @@ func (r *routerUseCaseImpl) GetCustomDirectQuote(ctx context.Context, tokenIn sd
 	}
 
 	// Retrieve taker fee for the pool
-	takerFee, ok := r.routerRepository.GetTakerFee(tokenIn.Denom, tokenOutDenom)
+	takerFee, ok := r.getTakerFee(ctx, tokenIn.Denom, tokenOutDenom)
 	if !ok {
 		return nil, fmt.Errorf("taker fee not found for pool %d, denom in (%s), denom out (%s)", poolID, tokenIn.Denom, tokenOutDenom)
 	}
@@ -463,7 +571,7 @@ func (r *routerUseCaseImpl) GetCustomDirectQuote(ctx context.Context, tokenIn sd
 	candidateRoutes := r.createCandidateRouteByPoolID(tokenOutDenom, poolID)
 
 	// Convert candidate route into a route with all the pool data
-	routes, err := r.poolsUsecase.GetRoutesFromCandidates(candidateRoutes, tokenIn.Denom, tokenOutDenom)
+	routes, err := r.poolsUsecase.GetRoutesFromCandidates(ctx, candidateRoutes, tokenIn.Denom, tokenOutDenom)
 	if err != nil {
 		return nil, err
 	}
@@ -556,11 +664,22 @@ func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.
 }
 
 // GetCandidateRoutes implements domain.RouterUsecase.
-func (r *routerUseCaseImpl) GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error) {
+// Only the candidate route denom constraints and disable cache are read from the given options.
+// Routes are not cached if denom constraints are set.
+func (r *routerUseCaseImpl) GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error) {
+	options := domain.RouterOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	candidateRouteSearchOptions := domain.CandidateRouteSearchOptions{
 		MaxRoutes:           r.defaultConfig.MaxRoutes,
 		MaxPoolsPerRoute:    r.defaultConfig.MaxPoolsPerRoute,
 		MinPoolLiquidityCap: r.defaultConfig.MinPoolLiquidityCap,
+		DisableCache:        options.DisableCache || !options.CandidateRouteDenomConstraints.IsEmpty(),
+
+		CandidateRouteDenomConstraints: options.CandidateRouteDenomConstraints,
+		IsUnlistedDenomCb:              r.isUnlistedDenom,
 	}
 
 	// Get the dynamic min pool liquidity cap for the given token in and token out denoms.
@@ -579,6 +698,15 @@ func (r *routerUseCaseImpl) GetCandidateRoutes(ctx context.Context, tokenIn sdk.
 	return candidateRoutes, nil
 }
 
+// isUnlistedDenom returns true if the given denom is unlisted or has no metadata.
+func (r *routerUseCaseImpl) isUnlistedDenom(denom string) bool {
+	token, err := r.tokenMetadataHolder.GetMetadataByChainDenom(denom)
+	if err != nil {
+		return true
+	}
+	return token.IsUnlisted
+}
+
 // GetTakerFee implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForPair, error) {
 	pool, err := r.poolsUsecase.GetPool(poolID)
@@ -613,6 +741,11 @@ func (r *routerUseCaseImpl) GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForP
 
 // GetCachedCandidateRoutes implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetCachedCandidateRoutes(ctx context.Context, tokenInDenom string, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool, error) {
+	return r.getCachedCandidateRoutes(ctx, formatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom))
+}
+
+// getCachedCandidateRoutes returns the candidate routes cached under the given key.
+func (r *routerUseCaseImpl) getCachedCandidateRoutes(ctx context.Context, cacheKey string) (sqsdomain.CandidateRoutes, bool, error) {
 	if !r.defaultConfig.RouteCacheEnabled {
 		return sqsdomain.CandidateRoutes{}, false, nil
 	}
@@ -623,7 +756,7 @@ func (r *routerUseCaseImpl) GetCachedCandidateRoutes(ctx context.Context, tokenI
 		return sqsdomain.CandidateRoutes{}, false, err
 	}
 
-	cachedCandidateRoutes, found := r.candidateRouteCache.Get(formatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom))
+	cachedCandidateRoutes, found := r.candidateRouteCache.Get(cacheKey)
 	if !found {
 		// Increase cache misses
 		domain.SQSRoutesCacheMissesCounter.WithLabelValues(requestURLPath, candidateRouteCacheLabel).Inc()
@@ -684,10 +817,14 @@ func (r *routerUseCaseImpl) GetCachedRankedRoutes(ctx context.Context, tokenInDe
 func (r *routerUseCaseImpl) handleCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, candidateRouteSearchOptions domain.CandidateRouteSearchOptions) (candidateRoutes sqsdomain.CandidateRoutes, err error) {
 	r.logger.Debug("getting routes")
 
-	// Check cache for routes if enabled
+	// Check the candidate route index and then the cache for routes if enabled
 	var isFoundCached bool
 	if !candidateRouteSearchOptions.DisableCache {
-		candidateRoutes, isFoundCached, err = r.GetCachedCandidateRoutes(ctx, tokenIn.Denom, tokenOutDenom)
+		if candidateRoutes, isFoundIndexed := r.getIndexedCandidateRoutes(ctx, tokenIn.Denom, tokenOutDenom); isFoundIndexed {
+			return candidateRoutes, nil
+		}
+
+		candidateRoutes, isFoundCached, err = r.getCachedCandidateRoutes(ctx, r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom))
 		if err != nil {
 			return sqsdomain.CandidateRoutes{}, err
 		}
@@ -699,7 +836,7 @@ func (r *routerUseCaseImpl) handleCandidateRoutes(ctx context.Context, tokenIn s
 	if !isFoundCached {
 		r.logger.Debug("calculating routes")
 
-		candidateRoutes, err = r.candidateRouteSearcher.FindCandidateRoutes(tokenIn, tokenOutDenom, candidateRouteSearchOptions)
+		candidateRoutes, err = r.candidateRouteSearcher.FindCandidateRoutes(ctx, tokenIn, tokenOutDenom, candidateRouteSearchOptions)
 		if err != nil {
 			r.logger.Error("error getting candidate routes for pricing", zap.Error(err))
 			return sqsdomain.CandidateRoutes{}, err
@@ -707,8 +844,8 @@ func (r *routerUseCaseImpl) handleCandidateRoutes(ctx context.Context, tokenIn s
 
 		r.logger.Info("calculated routes", zap.Int("num_routes", len(candidateRoutes.Routes)))
 
-		// Persist routes
-		if !candidateRouteSearchOptions.DisableCache {
+		// Persist routes unless the search stopped early due to the context being done.
+		if !candidateRouteSearchOptions.DisableCache && ctx.Err() == nil {
 			cacheDurationSeconds := r.defaultConfig.CandidateRouteCacheExpirySeconds
 			if len(candidateRoutes.Routes) == 0 {
 				// If there are no routes, we want to cache the result for a shorter duration
@@ -717,7 +854,7 @@ func (r *routerUseCaseImpl) handleCandidateRoutes(ctx context.Context, tokenIn s
 			}
 
 			r.logger.Debug("persisting routes", zap.Int("num_routes", len(candidateRoutes.Routes)))
-			r.candidateRouteCache.Set(formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom), candidateRoutes, time.Duration(cacheDurationSeconds)*time.Second)
+			r.candidateRouteCache.Set(r.getCandidateRouteCacheKey(tokenIn, tokenOutDenom), candidateRoutes, time.Duration(cacheDurationSeconds)*time.Second)
 		}
 	}
 
@@ -800,6 +937,18 @@ func formatCandidateRouteCacheKey(tokenInDenom string, tokenOutDenom string) str
 	return fmt.Sprintf("cr%s", formatRouteCacheKey(tokenInDenom, tokenOutDenom))
 }
 
+// getCandidateRouteCacheKey returns the candidate route cache key of the given token in and token out denom.
+// Best-first search ranks the routes by their estimated output for the token in amount, so its
+// candidate routes are cached per order of magnitude of the token in amount, similar to the ranked routes.
+// Breadth-first search does not depend on the amount, so its candidate routes are cached per denom pair.
+func (r *routerUseCaseImpl) getCandidateRouteCacheKey(tokenIn sdk.Coin, tokenOutDenom string) string {
+	if r.defaultConfig.CandidateRouteSearchAlgorithm == domain.CandidateRouteSearchAlgorithmBestFirst {
+		return fmt.Sprintf("cr%s", formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, GetPrecomputeOrderOfMagnitude(tokenIn.Amount)))
+	}
+
+	return formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom)
+}
+
 // convertRankedToCandidateRoutes converts the given ranked routes to candidate routes.
 // The primary use case for this is to keep minimal data for caching.
 func convertRankedToCandidateRoutes(rankedRoutes []route.RouteImpl) sqsdomain.CandidateRoutes {
@@ -880,9 +1029,19 @@ func (r *routerUseCaseImpl) GetMinPoolLiquidityCapFilter(tokenInDenom, tokenOutD
 	return minPoolLiquidityCapFilter, nil
 }
 
+// getTakerFee returns the taker fee for the given pair of denominations from the state snapshot
+// pinned to the context, if any. Otherwise, returns the latest taker fee.
+func (r *routerUseCaseImpl) getTakerFee(ctx context.Context, denom0, denom1 string) (osmomath.Dec, bool) {
+	if snapshot, ok := domain.GetStateSnapshot(ctx); ok {
+		return snapshot.GetTakerFee(denom0, denom1)
+	}
+
+	return r.routerRepository.GetTakerFee(denom0, denom1)
+}
+
 // GetPoolSpotPrice implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetPoolSpotPrice(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
-	poolTakerFee, ok := r.routerRepository.GetTakerFee(quoteAsset, baseAsset)
+	poolTakerFee, ok := r.getTakerFee(ctx, quoteAsset, baseAsset)
 	if !ok {
 		return osmomath.BigDec{}, fmt.Errorf("taker fee not found for pool %d, denom in (%s), denom out (%s)", poolID, quoteAsset, baseAsset)
 	}
@@ -918,6 +1077,14 @@ func (r *routerUseCaseImpl) GetConfig() domain.RouterConfig {
 	return r.defaultConfig
 }
 
+// GetRouteCacheStats implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetRouteCacheStats() domain.RouteCacheStats {
+	return domain.RouteCacheStats{
+		CandidateRoutes: r.candidateRouteCache.Len(),
+		RankedRoutes:    r.rankedRouteCache.Len(),
+	}
+}
+
 // filterOutGeneralizedCosmWasmPoolRoutes filters out routes that contain generalized cosm wasm pool.
 // The reason for this is that making network requests to chain is expensive. Generalized cosmwasm pools
 // make such network requests.
@@ -959,3 +1126,53 @@ func (r *routerUseCaseImpl) createCandidateRouteByPoolID(tokenOutDenom string, p
 		},
 	}
 }
+
+// RegisterPoolCircuitBreaker implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) RegisterPoolCircuitBreaker(poolCircuitBreaker domain.PoolCircuitBreaker) {
+	r.poolCircuitBreaker = poolCircuitBreaker
+}
+
+// RegisterCandidateRouteIndex implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) RegisterCandidateRouteIndex(candidateRouteIndex domain.CandidateRouteIndex) {
+	r.candidateRouteIndex = candidateRouteIndex
+}
+
+// EnableRequestCoalescing implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter) {
+	r.latestHeightGetter = latestHeightGetter
+}
+
+// getIndexedCandidateRoutes returns the candidate routes from the candidate route index.
+// Returns false if the index is disabled or the pair is not indexed.
+func (r *routerUseCaseImpl) getIndexedCandidateRoutes(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool) {
+	if r.candidateRouteIndex == nil {
+		return sqsdomain.CandidateRoutes{}, false
+	}
+
+	candidateRoutes, ok := r.candidateRouteIndex.GetIndexedCandidateRoutes(tokenInDenom, tokenOutDenom)
+	if !ok {
+		return sqsdomain.CandidateRoutes{}, false
+	}
+
+	if requestURLPath, err := domain.GetURLPathFromContext(ctx); err == nil {
+		domain.SQSRoutesCacheHitsCounter.WithLabelValues(requestURLPath, candidateRouteIndexCacheLabel).Inc()
+	}
+
+	return candidateRoutes, true
+}
+
+// containsCircuitBrokenPool returns true if any of the given routes goes through a pool
+// excluded from routing by the circuit breaker. False if the circuit breaker is disabled.
+func (r *routerUseCaseImpl) containsCircuitBrokenPool(routes sqsdomain.CandidateRoutes) bool {
+	if r.poolCircuitBreaker == nil {
+		return false
+	}
+
+	for poolID := range routes.UniquePoolIDs {
+		if r.poolCircuitBreaker.IsPoolOpen(poolID) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -892,7 +892,7 @@ func (s *RouterTestSuite) TestGetCandidateRoutes_Chain_FindUnsupportedRoutes() {
 			MaxPoolsPerRoute:    config.Router.MaxPoolsPerRoute,
 		}
 
-		routes, err := mainnetUsecase.CandidateRouteSearcher.FindCandidateRoutes(sdk.NewCoin(chainDenom, one), USDC, options)
+		routes, err := mainnetUsecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), sdk.NewCoin(chainDenom, one), USDC, options)
 		if err != nil {
 			fmt.Printf("Error for %s  -- %s -- %v\n", chainDenom, tokenMeta.HumanDenom, err)
 			errorCounter++
@@ -932,7 +932,7 @@ func (s *RouterTestSuite) TestGetCandidateRoutes_Chain_FindUnsupportedRoutes() {
 			continue
 		}
 
-		routes, err := mainnetUsecase.CandidateRouteSearcher.FindCandidateRoutes(sdk.NewCoin(chainDenom, one), USDC, options)
+		routes, err := mainnetUsecase.CandidateRouteSearcher.FindCandidateRoutes(context.Background(), sdk.NewCoin(chainDenom, one), USDC, options)
 		if err != nil {
 			fmt.Printf("Error for %s  -- %s -- %v\n", chainDenom, tokenMeta.HumanDenom, err)
 			errorCounter++
@@ -1145,6 +1145,48 @@ func (s *RouterTestSuite) TestConvertMinTokensPoolLiquidityCapToFilter() {
 	}
 }
 
+// Tests that the candidate routes are cached per order of magnitude of the token in amount
+// with best-first search and per denom pair otherwise.
+func (s *RouterTestSuite) TestGetCandidateRouteCacheKey() {
+	tests := []struct {
+		name string
+
+		algorithm string
+
+		expectedSmallAmountKey string
+		expectedLargeAmountKey string
+	}{
+		{
+			name:      "bfs -> same key for all amounts",
+			algorithm: domain.CandidateRouteSearchAlgorithmBFS,
+
+			expectedSmallAmountKey: usecase.FormatCandidateRouteCacheKey(UOSMO, USDC),
+			expectedLargeAmountKey: usecase.FormatCandidateRouteCacheKey(UOSMO, USDC),
+		},
+		{
+			name:      "best-first -> key per order of magnitude",
+			algorithm: domain.CandidateRouteSearchAlgorithmBestFirst,
+
+			expectedSmallAmountKey: "cr" + usecase.FormatRankedRouteCacheKey(UOSMO, USDC, 2),
+			expectedLargeAmountKey: "cr" + usecase.FormatRankedRouteCacheKey(UOSMO, USDC, 9),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		s.Run(tc.name, func() {
+			config := routertesting.DefaultRouterConfig
+			config.CandidateRouteSearchAlgorithm = tc.algorithm
+
+			routerUsecase, ok := usecase.NewRouterUsecase(nil, nil, nil, nil, config, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New()).(*usecase.RouterUseCaseImpl)
+			s.Require().True(ok)
+
+			s.Require().Equal(tc.expectedSmallAmountKey, routerUsecase.GetCandidateRouteCacheKey(sdk.NewCoin(UOSMO, osmomath.NewInt(100)), USDC))
+			s.Require().Equal(tc.expectedLargeAmountKey, routerUsecase.GetCandidateRouteCacheKey(sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000)), USDC))
+		})
+	}
+}
+
 // This test runs tests against GetCustomDirectQuotes to ensure that the method correctly calculates
 // quote across multi pool route.
 func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotes_Mainnet_UOSMOUSDC() {
@@ -0,0 +1,258 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"go.uber.org/zap"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// denomPair is a directed pair of denoms.
+type denomPair struct {
+	tokenInDenom  string
+	tokenOutDenom string
+}
+
+// blockUpdate is the union of the denoms and pools updated within the blocks
+// that the index has not been updated for yet.
+type blockUpdate struct {
+	updatedDenoms map[string]struct{}
+	poolIDs       map[uint64]struct{}
+}
+
+// candidateRouteIndexWorker precomputes candidate routes for all pairings of the top denoms
+// by liquidity capitalization on candidate route search data updates.
+// On every update, only the routes of pairs touched by the updated denoms and pools are recomputed.
+// The routes are computed in the background, not blocking the caller of the update.
+type candidateRouteIndexWorker struct {
+	routerUsecase mvc.RouterUsecase
+	tokensUsecase mvc.TokensUsecase
+	numTopDenoms  int
+	logger        log.Logger
+
+	// mu protects the index.
+	mu sync.RWMutex
+	// routes are the indexed candidate routes by pair.
+	routes map[denomPair]sqsdomain.CandidateRoutes
+	// poolPairs are the pairs whose indexed routes go through a pool, by pool ID.
+	poolPairs map[uint64]map[denomPair]struct{}
+
+	// pendingMu protects the pending update and isUpdating.
+	pendingMu sync.Mutex
+	// pending is the update that the index is yet to apply. Nil if none.
+	pending *blockUpdate
+	// isUpdating is true if the index is being updated in the background.
+	isUpdating bool
+}
+
+var (
+	_ domain.CandidateRouteSearchDataUpdateListener = &candidateRouteIndexWorker{}
+	_ domain.CandidateRouteIndex                    = &candidateRouteIndexWorker{}
+)
+
+// NewCandidateRouteIndexWorker returns a new candidate route index worker indexing the pairings of the
+// given number of top denoms. The routes are computed with the router usecase.
+func NewCandidateRouteIndexWorker(routerUsecase mvc.RouterUsecase, tokensUsecase mvc.TokensUsecase, numTopDenoms int, logger log.Logger) *candidateRouteIndexWorker {
+	return &candidateRouteIndexWorker{
+		routerUsecase: routerUsecase,
+		tokensUsecase: tokensUsecase,
+		numTopDenoms:  numTopDenoms,
+		logger:        logger,
+
+		routes:    map[denomPair]sqsdomain.CandidateRoutes{},
+		poolPairs: map[uint64]map[denomPair]struct{}{},
+	}
+}
+
+// OnSearchDataUpdate implements domain.CandidateRouteSearchDataUpdateListener.
+// Schedules the index update in the background. If an update is already in progress,
+// the given block update is merged into the pending one and applied after.
+func (c *candidateRouteIndexWorker) OnSearchDataUpdate(ctx context.Context, height uint64, blockPoolMetaData domain.BlockPoolMetadata) error {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	if c.pending == nil {
+		c.pending = &blockUpdate{
+			updatedDenoms: make(map[string]struct{}, len(blockPoolMetaData.UpdatedDenoms)),
+			poolIDs:       make(map[uint64]struct{}, len(blockPoolMetaData.PoolIDs)),
+		}
+	}
+
+	for denom := range blockPoolMetaData.UpdatedDenoms {
+		c.pending.updatedDenoms[denom] = struct{}{}
+	}
+
+	for poolID := range blockPoolMetaData.PoolIDs {
+		c.pending.poolIDs[poolID] = struct{}{}
+	}
+
+	if c.isUpdating {
+		return nil
+	}
+
+	c.isUpdating = true
+
+	// The update outlives the block processing that triggered it.
+	go c.updateLoop(context.WithoutCancel(ctx))
+
+	return nil
+}
+
+// GetIndexedCandidateRoutes implements domain.CandidateRouteIndex.
+func (c *candidateRouteIndexWorker) GetIndexedCandidateRoutes(tokenInDenom string, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	routes, ok := c.routes[denomPair{tokenInDenom: tokenInDenom, tokenOutDenom: tokenOutDenom}]
+	return routes, ok
+}
+
+// updateLoop applies the pending updates until there are none left.
+func (c *candidateRouteIndexWorker) updateLoop(ctx context.Context) {
+	for {
+		c.pendingMu.Lock()
+		pending := c.pending
+		c.pending = nil
+		if pending == nil {
+			c.isUpdating = false
+			c.pendingMu.Unlock()
+			return
+		}
+		c.pendingMu.Unlock()
+
+		c.update(ctx, *pending)
+	}
+}
+
+// update recomputes the routes of the pairings of the current top denoms that are either not indexed yet,
+// have the token in or token out denom updated or have routes through an updated pool.
+// Pairs that are no longer between top denoms are dropped from the index.
+func (c *candidateRouteIndexWorker) update(ctx context.Context, pending blockUpdate) {
+	topDenoms := c.getTopDenoms()
+
+	pairs := make([]denomPair, 0, len(topDenoms)*len(topDenoms))
+	for _, tokenInDenom := range topDenoms {
+		for _, tokenOutDenom := range topDenoms {
+			if tokenInDenom == tokenOutDenom {
+				continue
+			}
+			pairs = append(pairs, denomPair{tokenInDenom: tokenInDenom, tokenOutDenom: tokenOutDenom})
+		}
+	}
+
+	pairsToRecompute := c.getPairsToRecompute(pairs, pending)
+
+	recomputedRoutes := make(map[denomPair]sqsdomain.CandidateRoutes, len(pairsToRecompute))
+	for pair := range pairsToRecompute {
+		routes, err := c.routerUsecase.GetCandidateRoutes(ctx, sdk.NewCoin(pair.tokenInDenom, osmomath.OneInt()), pair.tokenOutDenom, domain.WithDisableCache())
+		if err != nil {
+			// The pair is dropped from the index, falling back to the search.
+			c.logger.Error("failed to compute candidate routes in candidate route index worker", zap.String("token_in_denom", pair.tokenInDenom), zap.String("token_out_denom", pair.tokenOutDenom), zap.Error(err))
+			continue
+		}
+
+		recomputedRoutes[pair] = routes
+	}
+
+	domain.SQSRouterCandidateRouteIndexRecomputedPairsCounter.Add(float64(len(pairsToRecompute)))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	routes := make(map[denomPair]sqsdomain.CandidateRoutes, len(pairs))
+	poolPairs := make(map[uint64]map[denomPair]struct{})
+	for _, pair := range pairs {
+		pairRoutes, ok := recomputedRoutes[pair]
+		if !ok {
+			if _, shouldRecompute := pairsToRecompute[pair]; shouldRecompute {
+				continue
+			}
+
+			pairRoutes, ok = c.routes[pair]
+			if !ok {
+				continue
+			}
+		}
+
+		routes[pair] = pairRoutes
+
+		for _, route := range pairRoutes.Routes {
+			for _, pool := range route.Pools {
+				if _, ok := poolPairs[pool.ID]; !ok {
+					poolPairs[pool.ID] = make(map[denomPair]struct{})
+				}
+				poolPairs[pool.ID][pair] = struct{}{}
+			}
+		}
+	}
+
+	c.routes = routes
+	c.poolPairs = poolPairs
+
+	domain.SQSRouterCandidateRouteIndexPairsGauge.Set(float64(len(routes)))
+}
+
+// getPairsToRecompute returns the pairs that are not indexed, have the token in or token out
+// denom updated or have routes through an updated pool.
+func (c *candidateRouteIndexWorker) getPairsToRecompute(pairs []denomPair, pending blockUpdate) map[denomPair]struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pairsToRecompute := make(map[denomPair]struct{})
+
+	for _, pair := range pairs {
+		_, isIndexed := c.routes[pair]
+		_, isTokenInUpdated := pending.updatedDenoms[pair.tokenInDenom]
+		_, isTokenOutUpdated := pending.updatedDenoms[pair.tokenOutDenom]
+
+		if !isIndexed || isTokenInUpdated || isTokenOutUpdated {
+			pairsToRecompute[pair] = struct{}{}
+		}
+	}
+
+	for poolID := range pending.poolIDs {
+		for pair := range c.poolPairs[poolID] {
+			pairsToRecompute[pair] = struct{}{}
+		}
+	}
+
+	return pairsToRecompute
+}
+
+// getTopDenoms returns up to numTopDenoms denoms with the highest total liquidity capitalization.
+// Denoms without liquidity capitalization are skipped.
+func (c *candidateRouteIndexWorker) getTopDenoms() []string {
+	poolDenomMetadata := c.tokensUsecase.GetFullPoolDenomMetadata()
+
+	denoms := make([]string, 0, len(poolDenomMetadata))
+	for denom, metadata := range poolDenomMetadata {
+		if metadata.TotalLiquidityCap.IsNil() || !metadata.TotalLiquidityCap.IsPositive() {
+			continue
+		}
+		denoms = append(denoms, denom)
+	}
+
+	sort.Slice(denoms, func(i, j int) bool {
+		liquidityCapI := poolDenomMetadata[denoms[i]].TotalLiquidityCap
+		liquidityCapJ := poolDenomMetadata[denoms[j]].TotalLiquidityCap
+		if !liquidityCapI.Equal(liquidityCapJ) {
+			return liquidityCapI.GT(liquidityCapJ)
+		}
+		return denoms[i] < denoms[j]
+	})
+
+	if len(denoms) > c.numTopDenoms {
+		denoms = denoms[:c.numTopDenoms]
+	}
+
+	return denoms
+}
@@ -0,0 +1,137 @@
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/router/usecase/worker"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+type CandidateRouteIndexWorkerTestSuite struct {
+	suite.Suite
+}
+
+const (
+	denomA = "denomA"
+	denomB = "denomB"
+	denomC = "denomC"
+)
+
+// poolIDs are the IDs of the single pool route returned for each pair.
+var poolIDs = map[string]uint64{
+	denomA + denomB: 1,
+	denomB + denomA: 2,
+	denomA + denomC: 3,
+	denomC + denomA: 4,
+}
+
+func TestCandidateRouteIndexWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(CandidateRouteIndexWorkerTestSuite))
+}
+
+// Validates that the index computes the pairings of the top denoms and
+// incrementally recomputes only the pairs touched by the updated denoms and pools.
+func (s *CandidateRouteIndexWorkerTestSuite) TestUpdate() {
+	var (
+		liquidityCaps = map[string]int64{
+			denomA: 300,
+			denomB: 200,
+			denomC: 100,
+		}
+
+		recomputedPairs = map[string]struct{}{}
+
+		failingPair = ""
+	)
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetFullPoolDenomMetadataFunc: func() domain.PoolDenomMetaDataMap {
+			metadata := domain.PoolDenomMetaDataMap{}
+			for denom, liquidityCap := range liquidityCaps {
+				metadata[denom] = domain.PoolDenomMetaData{TotalLiquidityCap: osmomath.NewInt(liquidityCap)}
+			}
+			return metadata
+		},
+	}
+
+	routerUsecase := &mocks.RouterUsecaseMock{
+		GetCandidateRoutesFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (sqsdomain.CandidateRoutes, error) {
+			pair := tokenIn.Denom + tokenOutDenom
+			recomputedPairs[pair] = struct{}{}
+
+			if pair == failingPair {
+				return sqsdomain.CandidateRoutes{}, fmt.Errorf("failed to compute routes")
+			}
+
+			return sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{Pools: []sqsdomain.CandidatePool{{ID: poolIDs[pair], TokenOutDenom: tokenOutDenom}}},
+				},
+			}, nil
+		},
+	}
+
+	indexWorker := worker.NewCandidateRouteIndexWorker(routerUsecase, tokensUsecase, 2, &log.NoOpLogger{})
+
+	update := func(updatedDenoms map[string]struct{}, poolIDs map[uint64]struct{}) {
+		recomputedPairs = map[string]struct{}{}
+		indexWorker.UpdateSync(context.Background(), domain.BlockPoolMetadata{
+			UpdatedDenoms: updatedDenoms,
+			PoolIDs:       poolIDs,
+		})
+	}
+
+	requireIndexed := func(tokenInDenom, tokenOutDenom string, expectedIndexed bool) {
+		routes, ok := indexWorker.GetIndexedCandidateRoutes(tokenInDenom, tokenOutDenom)
+		s.Require().Equal(expectedIndexed, ok)
+		if expectedIndexed {
+			s.Require().Equal(poolIDs[tokenInDenom+tokenOutDenom], routes.Routes[0].Pools[0].ID)
+		}
+	}
+
+	// Initial update computes all pairings of the top 2 denoms.
+	update(nil, nil)
+	s.Require().Equal(map[string]struct{}{denomA + denomB: {}, denomB + denomA: {}}, recomputedPairs)
+	requireIndexed(denomA, denomB, true)
+	requireIndexed(denomB, denomA, true)
+	requireIndexed(denomA, denomC, false)
+
+	// No updates, nothing is recomputed.
+	update(nil, nil)
+	s.Require().Empty(recomputedPairs)
+
+	// Pool 1 is only in the A -> B route.
+	update(nil, map[uint64]struct{}{1: {}})
+	s.Require().Equal(map[string]struct{}{denomA + denomB: {}}, recomputedPairs)
+
+	// Denom B is in both pairs.
+	update(map[string]struct{}{denomB: {}}, nil)
+	s.Require().Equal(map[string]struct{}{denomA + denomB: {}, denomB + denomA: {}}, recomputedPairs)
+
+	// Denom C is not in the top denoms.
+	update(map[string]struct{}{denomC: {}}, nil)
+	s.Require().Empty(recomputedPairs)
+
+	// C becomes a top denom, replacing B.
+	liquidityCaps[denomC] = 1000
+	update(nil, nil)
+	s.Require().Equal(map[string]struct{}{denomA + denomC: {}, denomC + denomA: {}}, recomputedPairs)
+	requireIndexed(denomA, denomC, true)
+	requireIndexed(denomC, denomA, true)
+	requireIndexed(denomA, denomB, false)
+
+	// Failure to recompute drops the pair from the index.
+	failingPair = denomA + denomC
+	update(map[string]struct{}{denomC: {}}, nil)
+	requireIndexed(denomA, denomC, false)
+	requireIndexed(denomC, denomA, true)
+}
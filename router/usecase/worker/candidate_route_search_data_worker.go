@@ -58,7 +58,7 @@ func (c *candidateRouteSearchDataWorker) ComputeSearchDataSync(ctx context.Conte
 
 	// Notify listeners
 	for _, listener := range c.listeners {
-		_ = listener.OnSearchDataUpdate(ctx, height)
+		_ = listener.OnSearchDataUpdate(ctx, height, blockPoolMetaData)
 	}
 
 	return nil
@@ -0,0 +1,15 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// UpdateSync applies the given block pool metadata to the candidate route index synchronously.
+func (c *candidateRouteIndexWorker) UpdateSync(ctx context.Context, blockPoolMetaData domain.BlockPoolMetadata) {
+	c.update(ctx, blockUpdate{
+		updatedDenoms: blockPoolMetaData.UpdatedDenoms,
+		poolIDs:       blockPoolMetaData.PoolIDs,
+	})
+}
@@ -0,0 +1,38 @@
+package sqsdomain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+const (
+	// IngestProtocolVersionFull is the ingest protocol version where all pools
+	// updated in a block are sent in full.
+	IngestProtocolVersionFull uint32 = 1
+	// IngestProtocolVersionDelta is the ingest protocol version where the pools
+	// ingested previously are sent as deltas.
+	IngestProtocolVersionDelta uint32 = 2
+
+	// LatestIngestProtocolVersion is the latest supported ingest protocol version.
+	LatestIngestProtocolVersion = IngestProtocolVersionDelta
+)
+
+// PoolDataChecksum returns the checksum of the encoded pool data.
+// The chain model is expected to be encoded with the codec's interface JSON encoding
+// and the SQS and tick models with the sqsdomain/json package. The tick model is empty
+// for non-concentrated pools.
+//
+// The checksum is the SHA-256 of the length-prefixed concatenation of the models.
+// Used to verify that the pool data obtained by applying a delta matches the node's.
+func PoolDataChecksum(chainModel, sqsModel, tickModel []byte) []byte {
+	hasher := sha256.New()
+
+	lengthBz := make([]byte, 8)
+	for _, model := range [][]byte{chainModel, sqsModel, tickModel} {
+		binary.BigEndian.PutUint64(lengthBz, uint64(len(model)))
+		hasher.Write(lengthBz)
+		hasher.Write(model)
+	}
+
+	return hasher.Sum(nil)
+}
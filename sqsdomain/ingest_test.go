@@ -0,0 +1,52 @@
+package sqsdomain_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/sqsdomain"
+)
+
+// Tests that the pool data checksum encoding is stable.
+// The node computes the checksums with its own sqsdomain version, so any change
+// to the encoding breaks the delta ingest protocol with nodes on older versions.
+func TestPoolDataChecksum(t *testing.T) {
+	tests := []struct {
+		name string
+
+		chainModel []byte
+		sqsModel   []byte
+		tickModel  []byte
+
+		expectedChecksum string
+	}{
+		{
+			name:             "non-concentrated pool",
+			chainModel:       []byte(`{"id":"1"}`),
+			sqsModel:         []byte(`{"balances":[]}`),
+			expectedChecksum: "8325da51d55950f138c805f0a211bb04a21863a0b40f89e02882acfa020b256a",
+		},
+		{
+			name:             "models are length prefixed",
+			chainModel:       []byte("ab"),
+			sqsModel:         []byte("c"),
+			expectedChecksum: "528e931465bb50b57f335f8f47b78a4e3a2ee8b14454d7d3586105668d33c410",
+		},
+		{
+			name:             "same concatenation with different model boundaries",
+			chainModel:       []byte("a"),
+			sqsModel:         []byte("bc"),
+			expectedChecksum: "74f2dff90c16bd75e74ea8ab93e0f683754b4ae5a7e2b6c960734cf5c656341a",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			checksum := sqsdomain.PoolDataChecksum(tc.chainModel, tc.sqsModel, tc.tickModel)
+			require.Equal(t, tc.expectedChecksum, hex.EncodeToString(checksum))
+		})
+	}
+}
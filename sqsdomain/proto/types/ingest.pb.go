@@ -88,6 +88,211 @@ func (x *PoolData) GetTickModel() []byte {
 	return nil
 }
 
+// PoolDelta represents the changes of a previously ingested pool within a block.
+// Fields left empty are unchanged since the previous block.
+// Only sent with ingest protocol version 2 or above.
+type PoolDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pool_id is the ID of the changed pool.
+	PoolId uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	// chain_model is the updated chain representation model of the pool.
+	ChainModel []byte `protobuf:"bytes,2,opt,name=chain_model,json=chainModel,proto3" json:"chain_model,omitempty"`
+	// balances are the JSON-encoded updated balances of the sidecar query server model.
+	Balances []byte `protobuf:"bytes,3,opt,name=balances,proto3" json:"balances,omitempty"`
+	// pool_liquidity_cap is the updated liquidity capitalization of the pool.
+	PoolLiquidityCap string `protobuf:"bytes,4,opt,name=pool_liquidity_cap,json=poolLiquidityCap,proto3" json:"pool_liquidity_cap,omitempty"`
+	// pool_liquidity_cap_error is the updated liquidity capitalization error of the pool.
+	// Only applied together with pool_liquidity_cap.
+	PoolLiquidityCapError string `protobuf:"bytes,5,opt,name=pool_liquidity_cap_error,json=poolLiquidityCapError,proto3" json:"pool_liquidity_cap_error,omitempty"`
+	// tick_updates are the updated tick ranges of a concentrated liquidity pool.
+	TickUpdates []*TickRangeUpdate `protobuf:"bytes,6,rep,name=tick_updates,json=tickUpdates,proto3" json:"tick_updates,omitempty"`
+	// current_tick_index is the updated current tick index of a concentrated liquidity pool.
+	// Only valid if has_tick_state is set.
+	CurrentTickIndex int64 `protobuf:"varint,7,opt,name=current_tick_index,json=currentTickIndex,proto3" json:"current_tick_index,omitempty"`
+	// has_no_liquidity is true if the concentrated liquidity pool has no liquidity.
+	// Only valid if has_tick_state is set.
+	HasNoLiquidity bool `protobuf:"varint,8,opt,name=has_no_liquidity,json=hasNoLiquidity,proto3" json:"has_no_liquidity,omitempty"`
+	// has_tick_state is true if current_tick_index and has_no_liquidity are set.
+	HasTickState bool `protobuf:"varint,9,opt,name=has_tick_state,json=hasTickState,proto3" json:"has_tick_state,omitempty"`
+	// checksum is the checksum of the pool data after applying the delta.
+	// See sqsdomain.PoolDataChecksum for details.
+	Checksum []byte `protobuf:"bytes,10,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *PoolDelta) Reset() {
+	*x = PoolDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ingest_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolDelta) ProtoMessage() {}
+
+func (x *PoolDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_ingest_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolDelta.ProtoReflect.Descriptor instead.
+func (*PoolDelta) Descriptor() ([]byte, []int) {
+	return file_ingest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PoolDelta) GetPoolId() uint64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *PoolDelta) GetChainModel() []byte {
+	if x != nil {
+		return x.ChainModel
+	}
+	return nil
+}
+
+func (x *PoolDelta) GetBalances() []byte {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *PoolDelta) GetPoolLiquidityCap() string {
+	if x != nil {
+		return x.PoolLiquidityCap
+	}
+	return ""
+}
+
+func (x *PoolDelta) GetPoolLiquidityCapError() string {
+	if x != nil {
+		return x.PoolLiquidityCapError
+	}
+	return ""
+}
+
+func (x *PoolDelta) GetTickUpdates() []*TickRangeUpdate {
+	if x != nil {
+		return x.TickUpdates
+	}
+	return nil
+}
+
+func (x *PoolDelta) GetCurrentTickIndex() int64 {
+	if x != nil {
+		return x.CurrentTickIndex
+	}
+	return 0
+}
+
+func (x *PoolDelta) GetHasNoLiquidity() bool {
+	if x != nil {
+		return x.HasNoLiquidity
+	}
+	return false
+}
+
+func (x *PoolDelta) GetHasTickState() bool {
+	if x != nil {
+		return x.HasTickState
+	}
+	return false
+}
+
+func (x *PoolDelta) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// TickRangeUpdate represents the updated liquidity of a tick range
+// of a concentrated liquidity pool.
+type TickRangeUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// lower_tick is the lower tick of the range.
+	LowerTick int64 `protobuf:"varint,1,opt,name=lower_tick,json=lowerTick,proto3" json:"lower_tick,omitempty"`
+	// upper_tick is the upper tick of the range.
+	UpperTick int64 `protobuf:"varint,2,opt,name=upper_tick,json=upperTick,proto3" json:"upper_tick,omitempty"`
+	// liquidity_amount is the updated decimal liquidity of the range.
+	// Empty if the range is removed.
+	LiquidityAmount string `protobuf:"bytes,3,opt,name=liquidity_amount,json=liquidityAmount,proto3" json:"liquidity_amount,omitempty"`
+}
+
+func (x *TickRangeUpdate) Reset() {
+	*x = TickRangeUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ingest_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TickRangeUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickRangeUpdate) ProtoMessage() {}
+
+func (x *TickRangeUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_ingest_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickRangeUpdate.ProtoReflect.Descriptor instead.
+func (*TickRangeUpdate) Descriptor() ([]byte, []int) {
+	return file_ingest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TickRangeUpdate) GetLowerTick() int64 {
+	if x != nil {
+		return x.LowerTick
+	}
+	return 0
+}
+
+func (x *TickRangeUpdate) GetUpperTick() int64 {
+	if x != nil {
+		return x.UpperTick
+	}
+	return 0
+}
+
+func (x *TickRangeUpdate) GetLiquidityAmount() string {
+	if x != nil {
+		return x.LiquidityAmount
+	}
+	return ""
+}
+
 // The block process request.
 // Sends taker fees, block height and pools.
 type ProcessBlockRequest struct {
@@ -100,13 +305,24 @@ type ProcessBlockRequest struct {
 	// taker_fees_map is the map of taker fees for the block.
 	TakerFeesMap []byte `protobuf:"bytes,2,opt,name=taker_fees_map,json=takerFeesMap,proto3" json:"taker_fees_map,omitempty"`
 	// pools in the block.
+	// With ingest protocol version 2 or above, only contains the pools
+	// created in the block or, on full reingest, all pools.
 	Pools []*PoolData `protobuf:"bytes,3,rep,name=pools,proto3" json:"pools,omitempty"`
+	// pool_deltas are the changes of the previously ingested pools in the block.
+	// Only set with ingest protocol version 2 or above.
+	PoolDeltas []*PoolDelta `protobuf:"bytes,4,rep,name=pool_deltas,json=poolDeltas,proto3" json:"pool_deltas,omitempty"`
+	// removed_pool_ids are the IDs of the pools removed in the block.
+	// Only set with ingest protocol version 2 or above.
+	RemovedPoolIds []uint64 `protobuf:"varint,5,rep,packed,name=removed_pool_ids,json=removedPoolIds,proto3" json:"removed_pool_ids,omitempty"`
+	// version is the ingest protocol version. Zero is treated as version 1,
+	// where all the pools updated in the block are sent in full.
+	Version uint32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *ProcessBlockRequest) Reset() {
 	*x = ProcessBlockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ingest_proto_msgTypes[1]
+		mi := &file_ingest_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -119,7 +335,7 @@ func (x *ProcessBlockRequest) String() string {
 func (*ProcessBlockRequest) ProtoMessage() {}
 
 func (x *ProcessBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ingest_proto_msgTypes[1]
+	mi := &file_ingest_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,7 +348,7 @@ func (x *ProcessBlockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessBlockRequest.ProtoReflect.Descriptor instead.
 func (*ProcessBlockRequest) Descriptor() ([]byte, []int) {
-	return file_ingest_proto_rawDescGZIP(), []int{1}
+	return file_ingest_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ProcessBlockRequest) GetBlockHeight() uint64 {
@@ -156,6 +372,27 @@ func (x *ProcessBlockRequest) GetPools() []*PoolData {
 	return nil
 }
 
+func (x *ProcessBlockRequest) GetPoolDeltas() []*PoolDelta {
+	if x != nil {
+		return x.PoolDeltas
+	}
+	return nil
+}
+
+func (x *ProcessBlockRequest) GetRemovedPoolIds() []uint64 {
+	if x != nil {
+		return x.RemovedPoolIds
+	}
+	return nil
+}
+
+func (x *ProcessBlockRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // The response after completing the block processing.
 type ProcessBlockReply struct {
 	state         protoimpl.MessageState
@@ -166,7 +403,7 @@ type ProcessBlockReply struct {
 func (x *ProcessBlockReply) Reset() {
 	*x = ProcessBlockReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ingest_proto_msgTypes[2]
+		mi := &file_ingest_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -179,7 +416,7 @@ func (x *ProcessBlockReply) String() string {
 func (*ProcessBlockReply) ProtoMessage() {}
 
 func (x *ProcessBlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_ingest_proto_msgTypes[2]
+	mi := &file_ingest_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,7 +429,7 @@ func (x *ProcessBlockReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessBlockReply.ProtoReflect.Descriptor instead.
 func (*ProcessBlockReply) Descriptor() ([]byte, []int) {
-	return file_ingest_proto_rawDescGZIP(), []int{2}
+	return file_ingest_proto_rawDescGZIP(), []int{4}
 }
 
 var File_ingest_proto protoreflect.FileDescriptor
@@ -206,27 +443,70 @@ var file_ingest_proto_rawDesc = []byte{
 	0x1b, 0x0a, 0x09, 0x73, 0x71, 0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0c, 0x52, 0x08, 0x73, 0x71, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
 	0x74, 0x69, 0x63, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x22, 0x92, 0x01, 0x0a, 0x13,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x5f,
-	0x66, 0x65, 0x65, 0x73, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
-	0x74, 0x61, 0x6b, 0x65, 0x72, 0x46, 0x65, 0x65, 0x73, 0x4d, 0x61, 0x70, 0x12, 0x32, 0x0a, 0x05,
-	0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x71,
-	0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31,
-	0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73,
-	0x22, 0x13, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0x6f, 0x0a, 0x0b, 0x53, 0x51, 0x53, 0x49, 0x6e, 0x67, 0x65,
-	0x73, 0x74, 0x65, 0x72, 0x12, 0x60, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x27, 0x2e, 0x73, 0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73,
-	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
-	0x73, 0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74,
-	0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x73, 0x71, 0x73, 0x64, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x22, 0xaa, 0x03, 0x0a, 0x09,
+	0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x6f, 0x6f, 0x6c,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x2c, 0x0a, 0x12, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x63, 0x61, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x6f, 0x6f,
+	0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x43, 0x61, 0x70, 0x12, 0x37, 0x0a,
+	0x18, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f,
+	0x63, 0x61, 0x70, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x15, 0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x43, 0x61,
+	0x70, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x46, 0x0a, 0x0c, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73,
+	0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61,
+	0x31, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x0b, 0x74, 0x69, 0x63, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2c,
+	0x0a, 0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x10,
+	0x68, 0x61, 0x73, 0x5f, 0x6e, 0x6f, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x4e, 0x6f, 0x4c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x24, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x68, 0x61, 0x73, 0x54, 0x69, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x7a, 0x0a, 0x0f, 0x54, 0x69, 0x63, 0x6b,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x96, 0x02, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x24, 0x0a, 0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x5f, 0x6d, 0x61,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x46, 0x65,
+	0x65, 0x73, 0x4d, 0x61, 0x70, 0x12, 0x32, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x70, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x0a, 0x70,
+	0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50, 0x6f, 0x6f, 0x6c,
+	0x49, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x13, 0x0a,
+	0x11, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x32, 0x6f, 0x0a, 0x0b, 0x53, 0x51, 0x53, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65,
+	0x72, 0x12, 0x60, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x27, 0x2e, 0x73, 0x71, 0x73, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x73, 0x71, 0x73,
+	0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x73, 0x71, 0x73, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -241,21 +521,25 @@ func file_ingest_proto_rawDescGZIP() []byte {
 	return file_ingest_proto_rawDescData
 }
 
-var file_ingest_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ingest_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_ingest_proto_goTypes = []interface{}{
 	(*PoolData)(nil),            // 0: sqs.ingest.v1beta1.PoolData
-	(*ProcessBlockRequest)(nil), // 1: sqs.ingest.v1beta1.ProcessBlockRequest
-	(*ProcessBlockReply)(nil),   // 2: sqs.ingest.v1beta1.ProcessBlockReply
+	(*PoolDelta)(nil),           // 1: sqs.ingest.v1beta1.PoolDelta
+	(*TickRangeUpdate)(nil),     // 2: sqs.ingest.v1beta1.TickRangeUpdate
+	(*ProcessBlockRequest)(nil), // 3: sqs.ingest.v1beta1.ProcessBlockRequest
+	(*ProcessBlockReply)(nil),   // 4: sqs.ingest.v1beta1.ProcessBlockReply
 }
 var file_ingest_proto_depIdxs = []int32{
-	0, // 0: sqs.ingest.v1beta1.ProcessBlockRequest.pools:type_name -> sqs.ingest.v1beta1.PoolData
-	1, // 1: sqs.ingest.v1beta1.SQSIngester.ProcessBlock:input_type -> sqs.ingest.v1beta1.ProcessBlockRequest
-	2, // 2: sqs.ingest.v1beta1.SQSIngester.ProcessBlock:output_type -> sqs.ingest.v1beta1.ProcessBlockReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: sqs.ingest.v1beta1.PoolDelta.tick_updates:type_name -> sqs.ingest.v1beta1.TickRangeUpdate
+	0, // 1: sqs.ingest.v1beta1.ProcessBlockRequest.pools:type_name -> sqs.ingest.v1beta1.PoolData
+	1, // 2: sqs.ingest.v1beta1.ProcessBlockRequest.pool_deltas:type_name -> sqs.ingest.v1beta1.PoolDelta
+	3, // 3: sqs.ingest.v1beta1.SQSIngester.ProcessBlock:input_type -> sqs.ingest.v1beta1.ProcessBlockRequest
+	4, // 4: sqs.ingest.v1beta1.SQSIngester.ProcessBlock:output_type -> sqs.ingest.v1beta1.ProcessBlockReply
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_ingest_proto_init() }
@@ -277,7 +561,7 @@ func file_ingest_proto_init() {
 			}
 		}
 		file_ingest_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessBlockRequest); i {
+			switch v := v.(*PoolDelta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -289,6 +573,30 @@ func file_ingest_proto_init() {
 			}
 		}
 		file_ingest_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TickRangeUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ingest_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ingest_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProcessBlockReply); i {
 			case 0:
 				return &v.state
@@ -307,7 +615,7 @@ func file_ingest_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ingest_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
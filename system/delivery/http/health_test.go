@@ -0,0 +1,273 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
+	systemhttpdelivery "github.com/osmosis-labs/sqs/system/delivery/http"
+)
+
+// staticChainStatusGetter is a chain status getter returning a fixed chain status.
+type staticChainStatusGetter domain.ChainStatusReport
+
+// GetChainStatus implements domain.ChainStatusGetter.
+func (g staticChainStatusGetter) GetChainStatus() domain.ChainStatusReport {
+	return domain.ChainStatusReport(g)
+}
+
+// This test validates the readiness component report, read from the node status
+// polled by the chain status monitor, and the liveness probe.
+func TestReadiness(t *testing.T) {
+	syncedNode := domain.ChainStatusReport{NodeHeight: 110}
+
+	tests := []struct {
+		name string
+
+		chainStatus         domain.ChainStatusReport
+		heightMetadata      domain.HeightMetadata
+		priceUpdatesErr     error
+		routeCacheStats     domain.RouteCacheStats
+		minWarmCacheEntries int
+
+		expectedStatusCode         int
+		expectedNotReadyComponents []string
+	}{
+		{
+			name:                "ready",
+			chainStatus:         syncedNode,
+			heightMetadata:      domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+			routeCacheStats:     domain.RouteCacheStats{CandidateRoutes: 5},
+			minWarmCacheEntries: 5,
+
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:           "node unavailable",
+			chainStatus:    domain.ChainStatusReport{NodeError: "connection refused"},
+			heightMetadata: domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.NodeReadinessComponent, domain.IngestLagReadinessComponent},
+		},
+		{
+			name:           "node status not checked yet",
+			heightMetadata: domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.NodeReadinessComponent, domain.IngestLagReadinessComponent},
+		},
+		{
+			name:           "node catching up",
+			chainStatus:    domain.ChainStatusReport{NodeHeight: 110, NodeCatchingUp: true},
+			heightMetadata: domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.NodeReadinessComponent},
+		},
+		{
+			name:           "ingest lagging behind the node",
+			chainStatus:    syncedNode,
+			heightMetadata: domain.HeightMetadata{IngestHeight: 99, SnapshotAgeMs: 1000},
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.IngestLagReadinessComponent},
+		},
+		{
+			name:           "ingest stalled",
+			chainStatus:    domain.ChainStatusReport{NodeHeight: 110, Status: domain.ChainStatusIngestStall, Message: "no block ingested"},
+			heightMetadata: domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 31000},
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.IngestStallReadinessComponent},
+		},
+		{
+			name:           "chain halted",
+			chainStatus:    domain.ChainStatusReport{NodeHeight: 100, Status: domain.ChainStatusChainHalt, Message: "no block produced"},
+			heightMetadata: domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 600000},
+
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "no block ingested",
+			chainStatus: syncedNode,
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.IngestLagReadinessComponent},
+		},
+		{
+			name:            "price updates invalid",
+			chainStatus:     syncedNode,
+			heightMetadata:  domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+			priceUpdatesErr: errors.New("price updates are stale"),
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.PriceUpdatesReadinessComponent},
+		},
+		{
+			name:                "cache cold",
+			chainStatus:         syncedNode,
+			heightMetadata:      domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000},
+			routeCacheStats:     domain.RouteCacheStats{CandidateRoutes: 4},
+			minWarmCacheEntries: 5,
+
+			expectedStatusCode:         http.StatusServiceUnavailable,
+			expectedNotReadyComponents: []string{domain.CacheWarmnessReadinessComponent},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			chainInfoUsecase := &mocks.ChainInfoUsecaseMock{
+				GetHeightMetadataFunc: func(ctx context.Context) domain.HeightMetadata {
+					return tc.heightMetadata
+				},
+				ValidatePriceUpdatesFunc: func() error {
+					return tc.priceUpdatesErr
+				},
+			}
+
+			routerUsecase := &mocks.RouterUsecaseMock{
+				GetRouteCacheStatsFunc: func() domain.RouteCacheStats {
+					return tc.routeCacheStats
+				},
+			}
+
+			config := domain.Config{
+				LoggerIsProduction: true,
+				Router:             &domain.RouterConfig{RouteCacheEnabled: true},
+				Health: &domain.HealthConfig{
+					MaxIngestLagBlocks:                10,
+					MinWarmCandidateRouteCacheEntries: tc.minWarmCacheEntries,
+				},
+			}
+
+			e := echo.New()
+			systemhttpdelivery.NewSystemHandler(e, config, &log.NoOpLogger{}, chainInfoUsecase, routerUsecase, staticChainStatusGetter(tc.chainStatus))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+
+			var report domain.ReadinessReport
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			require.Len(t, report.Components, 7)
+
+			notReadyComponents := []string{}
+			for name, component := range report.Components {
+				if !component.Ready {
+					notReadyComponents = append(notReadyComponents, name)
+				}
+			}
+			require.ElementsMatch(t, tc.expectedNotReadyComponents, notReadyComponents)
+
+			expectedStatus := domain.ReadinessStatusReady
+			if len(tc.expectedNotReadyComponents) > 0 {
+				expectedStatus = domain.ReadinessStatusNotReady
+			}
+			require.Equal(t, expectedStatus, report.Status)
+
+			// Liveness does not depend on the readiness components.
+			rec = httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+// This test validates that the legacy /healthcheck endpoint keeps its legacy checks,
+// ignoring ingest stalls and cache warmness, and its legacy response body.
+func TestHealthcheck(t *testing.T) {
+	tests := []struct {
+		name string
+
+		chainStatus domain.ChainStatusReport
+
+		expectedStatusCode int
+		expectedBody       map[string]string
+	}{
+		{
+			name:        "ready",
+			chainStatus: domain.ChainStatusReport{NodeHeight: 105},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				"grpc_gateway_status": "running",
+				"chain_latest_height": "105",
+				"store_latest_height": "100",
+			},
+		},
+		{
+			name:        "ingest stalled",
+			chainStatus: domain.ChainStatusReport{NodeHeight: 105, Status: domain.ChainStatusIngestStall},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				"grpc_gateway_status": "running",
+				"chain_latest_height": "105",
+				"store_latest_height": "100",
+			},
+		},
+		{
+			name:        "node catching up",
+			chainStatus: domain.ChainStatusReport{NodeHeight: 105, NodeCatchingUp: true},
+
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody: map[string]string{
+				"message": "node is still catching up",
+			},
+		},
+		{
+			name:        "ingest lagging behind the node",
+			chainStatus: domain.ChainStatusReport{NodeHeight: 111},
+
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody: map[string]string{
+				"message": "node is not synced, chain height (111), store height (100), tolerance (10)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			chainInfoUsecase := &mocks.ChainInfoUsecaseMock{
+				GetHeightMetadataFunc: func(ctx context.Context) domain.HeightMetadata {
+					return domain.HeightMetadata{IngestHeight: 100, SnapshotAgeMs: 1000}
+				},
+			}
+
+			config := domain.Config{
+				LoggerIsProduction: true,
+				Router:             &domain.RouterConfig{RouteCacheEnabled: true},
+				Health: &domain.HealthConfig{
+					MaxIngestLagBlocks:                10,
+					MinWarmCandidateRouteCacheEntries: 5,
+				},
+			}
+
+			e := echo.New()
+			systemhttpdelivery.NewSystemHandler(e, config, &log.NoOpLogger{}, chainInfoUsecase, &mocks.RouterUsecaseMock{}, staticChainStatusGetter(tc.chainStatus))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
@@ -1,43 +1,32 @@
 package http
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"net/http/pprof"
 	"runtime"
 	"runtime/debug"
-	"strconv"
 	"strings"
 
-	"go.uber.org/zap"
-
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	echoSwagger "github.com/swaggo/echo-swagger"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/log"
-	"github.com/osmosis-labs/sqs/sqsdomain/json"
 
 	"github.com/labstack/echo/v4"
 )
 
 type SystemHandler struct {
-	logger      log.Logger
-	grpcAddress string
-	CIUsecase   mvc.ChainInfoUsecase
-	config      domain.Config
-}
+	logger        log.Logger
+	CIUsecase     mvc.ChainInfoUsecase
+	routerUsecase mvc.RouterUsecase
+	config        domain.Config
 
-// Parse the response from the GRPC Gateway status endpoint
-type JsonResponse struct {
-	Result struct {
-		SyncInfo struct {
-			LatestBlockHeight string `json:"latest_block_height"`
-			CatchingUp        bool   `json:"catching_up"`
-		} `json:"sync_info"`
-	} `json:"result"`
+	// chainStatusGetter provides the node status polled by the chain status monitor.
+	chainStatusGetter domain.ChainStatusGetter
 }
 
 // ConfigPrivateResponse defines the response for the /config-private endpoint
@@ -46,18 +35,40 @@ type ConfigPrivateResponse struct {
 }
 
 const (
-	heightTolerance       = 10
 	versionPlaceholder    = "version="
 	whiteSpacePlaceholder = " "
 )
 
+// readinessComponents are the components checked by /health/ready in order.
+var readinessComponents = []string{
+	domain.NodeReadinessComponent,
+	domain.IngestLagReadinessComponent,
+	domain.IngestStallReadinessComponent,
+	domain.PriceUpdatesReadinessComponent,
+	domain.PoolLiquidityUpdatesReadinessComponent,
+	domain.CandidateRouteSearchDataUpdatesReadinessComponent,
+	domain.CacheWarmnessReadinessComponent,
+}
+
+// legacyHealthcheckComponents are the components checked by the legacy /healthcheck in order.
+// These are the checks it always had, excluding the ingest stall and cache warmness.
+var legacyHealthcheckComponents = []string{
+	domain.NodeReadinessComponent,
+	domain.IngestLagReadinessComponent,
+	domain.PriceUpdatesReadinessComponent,
+	domain.PoolLiquidityUpdatesReadinessComponent,
+	domain.CandidateRouteSearchDataUpdatesReadinessComponent,
+}
+
 // NewSystemHandler will initialize the /debug/ppof resources endpoint
-func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us mvc.ChainInfoUsecase) {
+// The readiness probe reads the node status from the given chain status getter rather than polling the node.
+func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us mvc.ChainInfoUsecase, routerUsecase mvc.RouterUsecase, chainStatusGetter domain.ChainStatusGetter) {
 	handler := &SystemHandler{
-		logger:      logger,
-		grpcAddress: config.ChainTendermintRPCEndpoint,
-		CIUsecase:   us,
-		config:      config,
+		logger:            logger,
+		CIUsecase:         us,
+		routerUsecase:     routerUsecase,
+		config:            config,
+		chainStatusGetter: chainStatusGetter,
 	}
 
 	// if debug mod, enable additional profiles that are too intensive
@@ -73,6 +84,9 @@ func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us
 	e.GET("/debug/pprof/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
 	e.GET("/debug/pprof/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace)))
 
+	e.GET("/health/live", handler.GetLiveness)
+	e.GET("/health/ready", handler.GetReadiness)
+	// Kept for backwards compatibility with the legacy checks and response body.
 	e.GET("/healthcheck", handler.GetHealthStatus)
 	e.GET("/config", handler.GetConfig)
 	e.GET("/config-private", handler.GetConfigPrivate)
@@ -139,78 +153,175 @@ func extractVersion(ldGlagsValueStr string) (string, error) {
 	return substring[:index], nil
 }
 
-// GetHealthStatus handles health check requests for GRPC gateway
+// GetLiveness handles liveness probes. The service is live as long as it serves requests.
+func (h *SystemHandler) GetLiveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "live",
+	})
+}
+
+// GetReadiness handles readiness probes. It aggregates the readiness of the node, ingest lag and stall,
+// price, pool liquidity and candidate route search data updates and route cache warmness
+// into a component report. Returns 503 if any of the components is not ready.
+// The node and ingest stall statuses are the ones last detected by the chain status monitor.
+func (h *SystemHandler) GetReadiness(c echo.Context) error {
+	report, _ := h.getReadinessReport(readinessComponents)
+
+	if report.Status != domain.ReadinessStatusReady {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetHealthStatus handles the legacy /healthcheck endpoint. It keeps the legacy checks of the node, ingest lag,
+// price, pool liquidity and candidate route search data updates, and the legacy response body: the chain and store
+// heights if ready, or 503 with the message of the first component that is not ready otherwise.
 func (h *SystemHandler) GetHealthStatus(c echo.Context) error {
-	// Check GRPC Gateway status
-	url := h.grpcAddress + "/status"
-	resp, err := http.Get(url)
-	if err != nil || resp == nil || resp.StatusCode != http.StatusOK {
-		h.logger.Error("Error checking GRPC gateway status", zap.Error(err))
-		return echo.NewHTTPError(http.StatusServiceUnavailable, "Error connecting to the Osmosis chain via GRPC gateway")
-	} else {
-		if resp.Body != nil {
-			defer resp.Body.Close()
+	report, chainStatus := h.getReadinessReport(legacyHealthcheckComponents)
+
+	if report.Status != domain.ReadinessStatusReady {
+		for _, name := range legacyHealthcheckComponents {
+			if component := report.Components[name]; !component.Ready {
+				return echo.NewHTTPError(http.StatusServiceUnavailable, component.Message)
+			}
 		}
 	}
 
-	var statusResponse JsonResponse
+	// Use the latest ingested state rather than the snapshot pinned to the probe request.
+	heightMetadata := h.CIUsecase.GetHeightMetadata(context.Background())
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"grpc_gateway_status": "running",
+		"chain_latest_height": fmt.Sprint(chainStatus.NodeHeight),
+		"store_latest_height": fmt.Sprint(heightMetadata.IngestHeight),
+	})
+}
 
-	if resp == nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get response from GRPC gateway")
+// getReadinessReport returns the readiness report of the given components
+// and the chain status that the node and ingest readiness were computed from.
+func (h *SystemHandler) getReadinessReport(componentNames []string) (domain.ReadinessReport, domain.ChainStatusReport) {
+	components := make(map[string]domain.ReadinessComponentReport, len(componentNames))
+
+	chainStatus := h.chainStatusGetter.GetChainStatus()
+	for _, name := range componentNames {
+		switch name {
+		case domain.NodeReadinessComponent:
+			components[name] = getNodeReadiness(chainStatus)
+		case domain.IngestLagReadinessComponent:
+			components[name] = h.getIngestLagReadiness(chainStatus)
+		case domain.IngestStallReadinessComponent:
+			components[name] = getIngestStallReadiness(chainStatus)
+		case domain.PriceUpdatesReadinessComponent:
+			components[name] = validationReadiness(h.CIUsecase.ValidatePriceUpdates())
+		case domain.PoolLiquidityUpdatesReadinessComponent:
+			components[name] = validationReadiness(h.CIUsecase.ValidatePoolLiquidityUpdates())
+		case domain.CandidateRouteSearchDataUpdatesReadinessComponent:
+			components[name] = validationReadiness(h.CIUsecase.ValidateCandidateRouteSearchDataUpdates())
+		case domain.CacheWarmnessReadinessComponent:
+			components[name] = h.getCacheWarmnessReadiness()
+		}
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to read response body")
+	report := domain.ReadinessReport{
+		Status:     domain.ReadinessStatusReady,
+		Components: components,
 	}
 
-	err = json.Unmarshal(bodyBytes, &statusResponse)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse JSON response")
+	for _, component := range components {
+		if !component.Ready {
+			report.Status = domain.ReadinessStatusNotReady
+			break
+		}
 	}
 
-	// allow 10 blocks of difference before claiming node is not synced
-	latestChainHeight, err := strconv.ParseUint(statusResponse.Result.SyncInfo.LatestBlockHeight, 10, 64)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse latest block height from GRPC gateway")
+	return report, chainStatus
+}
+
+// getNodeReadiness returns the readiness of the node, not ready if it is unreachable or catching up.
+func getNodeReadiness(chainStatus domain.ChainStatusReport) domain.ReadinessComponentReport {
+	switch {
+	case !chainStatus.IsNodeStatusObserved():
+		return notReady("node status has not been checked yet")
+	case chainStatus.NodeError != "":
+		return notReady(fmt.Sprintf("error connecting to the Osmosis chain: %s", chainStatus.NodeError))
+	case chainStatus.NodeCatchingUp:
+		return notReady("node is still catching up")
+	default:
+		return ready(fmt.Sprintf("chain height (%d)", chainStatus.NodeHeight))
+	}
+}
+
+// getIngestLagReadiness returns the readiness of ingest, not ready if the latest ingested height lags behind
+// the node height.
+func (h *SystemHandler) getIngestLagReadiness(chainStatus domain.ChainStatusReport) domain.ReadinessComponentReport {
+	// Use the latest ingested state rather than the snapshot pinned to the probe request.
+	heightMetadata := h.CIUsecase.GetHeightMetadata(context.Background())
+
+	if heightMetadata.IngestHeight == 0 {
+		return notReady("no block has been ingested yet")
 	}
 
-	// Check the latest height from chain info use case
-	// Errors if the height has not beein updated for more than 30 seconds
-	latestStoreHeight, err := h.CIUsecase.GetLatestHeight()
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get latest height from sqs store: %s", err))
+	// The lag cannot be computed without the node height. The node component reports the error.
+	if !chainStatus.IsNodeStatusObserved() || chainStatus.NodeError != "" {
+		return notReady(fmt.Sprintf("store height (%d), chain height unknown", heightMetadata.IngestHeight))
 	}
 
-	// Check if the node is catching up. Error if so.
-	if statusResponse.Result.SyncInfo.CatchingUp {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, "Node is still catching up")
+	nodeHeight := chainStatus.NodeHeight
+	maxIngestLagBlocks := h.config.Health.MaxIngestLagBlocks
+	if nodeHeight > heightMetadata.IngestHeight && nodeHeight-heightMetadata.IngestHeight > maxIngestLagBlocks {
+		return notReady(fmt.Sprintf("node is not synced, chain height (%d), store height (%d), tolerance (%d)", nodeHeight, heightMetadata.IngestHeight, maxIngestLagBlocks))
 	}
 
-	// If the node is not synced, return HTTP 503
-	if latestChainHeight-latestStoreHeight > heightTolerance {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("Node is not synced, chain height (%d), store height (%d), tolerance (%d)", latestChainHeight, latestStoreHeight, heightTolerance))
+	return ready(fmt.Sprintf("chain height (%d), store height (%d)", nodeHeight, heightMetadata.IngestHeight))
+}
+
+// getIngestStallReadiness returns the readiness of ingest progress, not ready if the chain status monitor
+// detected that blocks are produced but not ingested. A halted chain leaves ingest behind on every replica,
+// so it is left to the degraded mode rather than failing readiness.
+func getIngestStallReadiness(chainStatus domain.ChainStatusReport) domain.ReadinessComponentReport {
+	switch chainStatus.Status {
+	case domain.ChainStatusIngestStall:
+		return notReady(chainStatus.Message)
+	case domain.ChainStatusChainHalt:
+		return ready(fmt.Sprintf("chain is halted: %s", chainStatus.Message))
+	default:
+		return ready("")
 	}
+}
 
-	// Validate price pre-compuation updates
-	if err := h.CIUsecase.ValidatePriceUpdates(); err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+// getCacheWarmnessReadiness returns the readiness of the route caches, not ready if the candidate route cache
+// has fewer entries than configured. Always ready if the route cache is disabled.
+func (h *SystemHandler) getCacheWarmnessReadiness() domain.ReadinessComponentReport {
+	if !h.config.Router.RouteCacheEnabled {
+		return ready("route cache is disabled")
 	}
 
-	// Validate pool liquidity updates
-	if err := h.CIUsecase.ValidatePoolLiquidityUpdates(); err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	routeCacheStats := h.routerUsecase.GetRouteCacheStats()
+
+	minEntries := h.config.Health.MinWarmCandidateRouteCacheEntries
+	if routeCacheStats.CandidateRoutes < minEntries {
+		return notReady(fmt.Sprintf("candidate route cache entries (%d), min (%d)", routeCacheStats.CandidateRoutes, minEntries))
 	}
 
-	// Validate candidate route search data updates
-	if err := h.CIUsecase.ValidateCandidateRouteSearchDataUpdates(); err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	return ready(fmt.Sprintf("candidate route cache entries (%d), ranked route cache entries (%d)", routeCacheStats.CandidateRoutes, routeCacheStats.RankedRoutes))
+}
+
+// validationReadiness returns the readiness of a component from the error of its validation.
+func validationReadiness(err error) domain.ReadinessComponentReport {
+	if err != nil {
+		return notReady(err.Error())
 	}
 
-	// Return combined status
-	return c.JSON(http.StatusOK, map[string]string{
-		"grpc_gateway_status": "running",
-		"chain_latest_height": fmt.Sprint(latestChainHeight),
-		"store_latest_height": fmt.Sprint(latestStoreHeight),
-	})
+	return ready("")
+}
+
+// ready returns a ready component report with the given message.
+func ready(message string) domain.ReadinessComponentReport {
+	return domain.ReadinessComponentReport{Ready: true, Message: message}
+}
+
+// notReady returns a not ready component report with the given message.
+func notReady(message string) domain.ReadinessComponentReport {
+	return domain.ReadinessComponentReport{Ready: false, Message: message}
 }
@@ -1,5 +1,7 @@
 package usecase
 
+import "github.com/osmosis-labs/sqs/domain"
+
 // PutArbitraryTypeTokenMetadata is a test helper to put arbitrary types to token metadata
 func (t *tokensUseCase) SetTokenMetadataByChainDenom(key string, value any) {
 	t.tokenMetadataByChainDenom.Store(key, value)
@@ -29,3 +31,8 @@ func (f *ChainRegistryHTTPFetcher) SetLastFetchHash(value string) {
 func (f *ChainRegistryHTTPFetcher) GetLastFetchHash() string {
 	return f.lastFetchHash
 }
+
+// FormatPricesCoalescingKey is a test helper to format the prices coalescing key.
+func FormatPricesCoalescingKey(baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts []domain.PricingOption, height uint64) string {
+	return formatPricesCoalescingKey(baseDenoms, quoteDenoms, pricingSourceType, opts, height)
+}
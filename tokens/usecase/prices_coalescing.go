@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// pricesCoalescingKeySeparator separates the components of the prices coalescing key.
+const pricesCoalescingKeySeparator = "|"
+
+// formatPricesCoalescingKey formats the key of a price request from the base and quote denoms,
+// pricing source, pricing options and height. The denoms are sorted since the result does not depend on their order.
+func formatPricesCoalescingKey(baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts []domain.PricingOption, height uint64) string {
+	options := domain.PricingOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return fmt.Sprintf("%s%s%s%s%d%s%t%s%t%s%d%s%d",
+		formatSortedDenoms(baseDenoms), pricesCoalescingKeySeparator,
+		formatSortedDenoms(quoteDenoms), pricesCoalescingKeySeparator,
+		pricingSourceType, pricesCoalescingKeySeparator,
+		options.RecomputePrices, pricesCoalescingKeySeparator,
+		options.RecomputePricesIsSpotPriceComputeMethod, pricesCoalescingKeySeparator,
+		options.MinPoolLiquidityCap, pricesCoalescingKeySeparator,
+		height,
+	)
+}
+
+// formatSortedDenoms formats the given denoms to a string in sorted order without mutating them.
+func formatSortedDenoms(denoms []string) string {
+	sortedDenoms := make([]string, len(denoms))
+	copy(sortedDenoms, denoms)
+	sort.Strings(sortedDenoms)
+
+	return strings.Join(sortedDenoms, ",")
+}
+
+// copyPrices returns a copy of the prices maps so that callers sharing coalesced prices
+// can modify their result independently. The prices themselves are not copied.
+func copyPrices(prices domain.PricesResult) domain.PricesResult {
+	if prices == nil {
+		return nil
+	}
+
+	pricesCopy := make(domain.PricesResult, len(prices))
+	for baseDenom, quotePrices := range prices {
+		quotePricesCopy := make(map[string]osmomath.BigDec, len(quotePrices))
+		for quoteDenom, price := range quotePrices {
+			quotePricesCopy[quoteDenom] = price
+		}
+		pricesCopy[baseDenom] = quotePricesCopy
+	}
+
+	return pricesCopy
+}
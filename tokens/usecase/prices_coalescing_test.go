@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+	tokensusecase "github.com/osmosis-labs/sqs/tokens/usecase"
+)
+
+// staticHeightGetter is a latest height getter returning a fixed height.
+type staticHeightGetter uint64
+
+// GetLatestHeight implements domain.LatestHeightGetter.
+func (h staticHeightGetter) GetLatestHeight() uint64 {
+	return uint64(h)
+}
+
+// blockingPricingSource is a pricing source that counts its price computations
+// and blocks them until released.
+type blockingPricingSource struct {
+	numCalls atomic.Int32
+	release  chan struct{}
+}
+
+var _ domain.PricingSource = &blockingPricingSource{}
+
+// GetPrice implements domain.PricingSource.
+func (p *blockingPricingSource) GetPrice(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+	p.numCalls.Add(1)
+	<-p.release
+	return osmomath.OneBigDec(), nil
+}
+
+// InitializeCache implements domain.PricingSource.
+func (p *blockingPricingSource) InitializeCache(*cache.Cache) {}
+
+// GetFallbackStrategy implements domain.PricingSource.
+func (p *blockingPricingSource) GetFallbackStrategy(quoteDenom string) domain.PricingSourceType {
+	return domain.NoneSourceType
+}
+
+// This test validates that identical concurrent price requests are computed once,
+// with every request receiving its own copy of the prices.
+func (s *TokensUseCaseTestSuite) TestGetPrices_RequestCoalescing() {
+	const numRequests = 5
+
+	tokensUsecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+		UOSMO: {HumanDenom: "osmo", Precision: defaultCosmosExponent},
+	}, 0, noOpLogger)
+
+	pricingSource := &blockingPricingSource{release: make(chan struct{})}
+	tokensUsecase.RegisterPricingStrategy(domain.ChainPricingSourceType, pricingSource)
+	tokensUsecase.EnableRequestCoalescing(staticHeightGetter(1))
+
+	var wg sync.WaitGroup
+	results := make([]domain.PricesResult, numRequests)
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			prices, err := tokensUsecase.GetPrices(context.Background(), []string{UOSMO}, []string{USDC}, domain.ChainPricingSourceType)
+			s.Require().NoError(err)
+			results[i] = prices
+		}(i)
+	}
+
+	// Wait for the first computation to start and give the rest of the requests time to join it.
+	s.Require().Eventually(func() bool { return pricingSource.numCalls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(pricingSource.release)
+	wg.Wait()
+
+	s.Require().Equal(int32(1), pricingSource.numCalls.Load())
+
+	// Modifying the result of one request does not affect the others.
+	results[0][UOSMO][USDC] = osmomath.ZeroBigDec()
+	for _, prices := range results[1:] {
+		s.Require().Equal(osmomath.OneBigDec(), prices[UOSMO][USDC])
+	}
+}
+
+// This test validates that the prices coalescing key is independent of the order of the denoms
+// while distinguishing pricing options and heights.
+func (s *TokensUseCaseTestSuite) TestFormatPricesCoalescingKey() {
+	const height = uint64(100)
+
+	key := tokensusecase.FormatPricesCoalescingKey([]string{UOSMO, ATOM}, []string{USDC}, domain.ChainPricingSourceType, nil, height)
+
+	s.Require().Equal(key, tokensusecase.FormatPricesCoalescingKey([]string{ATOM, UOSMO}, []string{USDC}, domain.ChainPricingSourceType, nil, height))
+
+	s.Require().NotEqual(key, tokensusecase.FormatPricesCoalescingKey([]string{UOSMO, ATOM}, []string{USDT}, domain.ChainPricingSourceType, nil, height))
+	s.Require().NotEqual(key, tokensusecase.FormatPricesCoalescingKey([]string{UOSMO, ATOM}, []string{USDC}, domain.CoinGeckoPricingSourceType, nil, height))
+	s.Require().NotEqual(key, tokensusecase.FormatPricesCoalescingKey([]string{UOSMO, ATOM}, []string{USDC}, domain.ChainPricingSourceType, []domain.PricingOption{domain.WithRecomputePrices()}, height))
+	s.Require().NotEqual(key, tokensusecase.FormatPricesCoalescingKey([]string{UOSMO, ATOM}, []string{USDC}, domain.ChainPricingSourceType, nil, height+1))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/osmosis-labs/sqs/domain/workerpool"
 	"github.com/osmosis-labs/sqs/log"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 )
@@ -44,6 +45,12 @@ type tokensUseCase struct {
 	// TokenRegistryLoader fetches tokens from the chain registry into the tokens use case
 	tokenLoader domain.TokenRegistryLoader
 
+	// latestHeightGetter keys coalesced price requests by height.
+	// Nil if request coalescing is disabled.
+	latestHeightGetter domain.LatestHeightGetter
+	// pricesGroup coalesces identical concurrent price requests.
+	pricesGroup singleflight.Group
+
 	// Logger instance
 	logger log.Logger
 }
@@ -259,7 +266,33 @@ func (t *tokensUseCase) GetChainScalingFactorByDenomMut(denom string) (osmomath.
 }
 
 // GetPrices implements pricing.PricingStrategy.
+// Identical concurrent price requests within the same block are coalesced if enabled. See EnableRequestCoalescing.
 func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+	getPrices := func(ctx context.Context) (domain.PricesResult, error) {
+		return t.getPrices(ctx, baseDenoms, quoteDenoms, pricingSourceType, opts...)
+	}
+
+	if t.latestHeightGetter == nil {
+		return getPrices(ctx)
+	}
+
+	key := formatPricesCoalescingKey(baseDenoms, quoteDenoms, pricingSourceType, opts, domain.GetRequestHeight(ctx, t.latestHeightGetter))
+
+	prices, err := domain.CoalesceRequest(ctx, &t.pricesGroup, key, domain.CoalescedPricesRequest, getPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyPrices(prices), nil
+}
+
+// EnableRequestCoalescing implements mvc.TokensUsecase.
+func (t *tokensUseCase) EnableRequestCoalescing(latestHeightGetter domain.LatestHeightGetter) {
+	t.latestHeightGetter = latestHeightGetter
+}
+
+// getPrices returns the prices of the base denoms in the quote denoms. See GetPrices.
+func (t *tokensUseCase) getPrices(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
 	byBaseDenomResult := make(map[string]map[string]osmomath.BigDec, len(baseDenoms))
 
 	numWorkers := len(baseDenoms)
@@ -371,7 +404,12 @@ func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom str
 }
 
 // UpdateAssetsAtHeightIntervalSync updates assets at configured height interval.
+// No-op if the token registry loader is not set.
 func (t *tokensUseCase) UpdateAssetsAtHeightIntervalSync(height uint64) error {
+	if t.tokenLoader == nil {
+		return nil
+	}
+
 	if height%uint64(t.updateAssetsHeightInterval) == 0 {
 		if err := t.tokenLoader.FetchAndUpdateTokens(); err != nil {
 			return err